@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+)
+
+var (
+	cancelBackupName string
+	cancelRestoreID  string
+	cancelCleanup    bool
+	cancelServer     string
+)
+
+// backup and restore tasks only run inside the process executing them, so cancel is sent to the backup server
+var cancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "cancel subcommand cancel a running backup or restore task in backup server.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var api string
+		params := url.Values{}
+		params.Set("cleanup", strconv.FormatBool(cancelCleanup))
+		if cancelBackupName != "" {
+			api = core.CANCEL_BACKUP_API
+			params.Set("backup_name", cancelBackupName)
+		} else if cancelRestoreID != "" {
+			api = core.CANCEL_RESTORE_API
+			params.Set("id", cancelRestoreID)
+		} else {
+			fmt.Println("either backup name or restore id is needed")
+			return
+		}
+
+		address := "http://" + cancelServer + core.API_V1_PREFIX + api + "?" + params.Encode()
+		req, err := http.NewRequest(http.MethodDelete, address, nil)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			fmt.Println(fmt.Sprintf("fail to request backup server %s: %s", cancelServer, err.Error()))
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		fmt.Println(string(body))
+	},
+}
+
+func init() {
+	cancelCmd.Flags().StringVarP(&cancelBackupName, "name", "n", "", "name of the backup to cancel")
+	cancelCmd.Flags().StringVarP(&cancelRestoreID, "restore_id", "r", "", "id of the restore task to cancel")
+	cancelCmd.Flags().BoolVarP(&cancelCleanup, "cleanup", "", false, "remove the partial backup or drop the collections created by the restore")
+	cancelCmd.Flags().StringVarP(&cancelServer, "server", "s", "localhost:"+DefaultServerPort, "address of the backup server")
+
+	rootCmd.AddCommand(cancelCmd)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	copyBackupName   string
+	copyTarget       string
+	copyTargetBucket string
+	copyTargetPath   string
+	copyChecksum     bool
+)
+
+var copyBackupCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "copy subcommand copy a backup into another bucket, path or storage.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		resp := backupContext.CopyBackup(context, &backuppb.CopyBackupRequest{
+			BackupName:       copyBackupName,
+			Target:           copyTarget,
+			TargetBucketName: copyTargetBucket,
+			TargetPath:       copyTargetPath,
+			Checksum:         copyChecksum,
+		})
+		if len(resp.GetData().GetSkippedBackups()) > 0 {
+			fmt.Println(fmt.Sprintf("referenced backups already exist in the target, skipped: %v", resp.GetData().GetSkippedBackups()))
+		}
+		fmt.Println(resp.GetMsg())
+	},
+}
+
+func init() {
+	copyBackupCmd.Flags().StringVarP(&copyBackupName, "name", "n", "", "backup name to copy")
+	copyBackupCmd.Flags().StringVarP(&copyTarget, "target", "t", "", "name of the copy target in backup.copyTargets of config, copy within the backup storage if not set")
+	copyBackupCmd.Flags().StringVarP(&copyTargetBucket, "target_bucket", "", "", "bucket to copy into, override the bucket of the target")
+	copyBackupCmd.Flags().StringVarP(&copyTargetPath, "target_path", "", "", "root path to copy into, override the root path of the target")
+	copyBackupCmd.Flags().BoolVarP(&copyChecksum, "checksum", "c", false, "read the copied files to compare their checksums in the verification, only sizes are compared by default")
+
+	rootCmd.AddCommand(copyBackupCmd)
+}
@@ -21,6 +21,7 @@ var (
 	dbCollections   string
 	force           bool
 	metaOnly        bool
+	parentBackup    string
 )
 
 var createBackupCmd = &cobra.Command{
@@ -63,6 +64,7 @@ var createBackupCmd = &cobra.Command{
 			DbCollections:   utils.WrapDBCollections(dbCollections),
 			Force:           force,
 			MetaOnly:        metaOnly,
+			ParentBackup:    parentBackup,
 		})
 
 		fmt.Println(resp.GetMsg())
@@ -75,9 +77,10 @@ func init() {
 	createBackupCmd.Flags().StringVarP(&backupName, "name", "n", "", "backup name, if unset will generate a name automatically")
 	createBackupCmd.Flags().StringVarP(&collectionNames, "colls", "c", "", "collectionNames to backup, use ',' to connect multiple collections")
 	createBackupCmd.Flags().StringVarP(&databases, "databases", "d", "", "databases to backup")
-	createBackupCmd.Flags().StringVarP(&dbCollections, "database_collections", "a", "", "databases and collections to backup, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}")
+	createBackupCmd.Flags().StringVarP(&dbCollections, "database_collections", "a", "", "databases and collections to backup, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}, select partitions by {\"db1\":{\"c1\":[\"p1\", \"p2\"]}}")
 	createBackupCmd.Flags().BoolVarP(&force, "force", "f", false, "force backup, will skip flush, should make sure data has been stored into disk when using it")
 	createBackupCmd.Flags().BoolVarP(&metaOnly, "meta_only", "", false, "only backup collection meta instead of data")
+	createBackupCmd.Flags().StringVarP(&parentBackup, "parent", "", "", "parent backup name, if set will create an incremental backup that only copies segments new or changed since the parent")
 
 	createBackupCmd.Flags().SortFlags = false
 
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	exportBackupName  string
+	exportOutput      string
+	exportCompression string
+)
+
+var exportBackupCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export subcommand export a backup into a single tar archive file.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		// when the archive is written to stdout, print the logs and messages to stderr instead
+		archiveOut := os.Stdout
+		if exportOutput == "-" {
+			os.Stdout = os.Stderr
+		}
+
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		request := &backuppb.ExportBackupRequest{
+			BackupName:  exportBackupName,
+			ArchivePath: exportOutput,
+			Compression: exportCompression,
+		}
+		var resp *backuppb.BackupArchiveResponse
+		if exportOutput == "-" {
+			resp = backupContext.WriteBackupArchive(context, request, archiveOut)
+		} else {
+			resp = backupContext.ExportBackupFile(context, request, exportOutput)
+		}
+		fmt.Println(resp.GetMsg())
+		if resp.GetCode() == backuppb.ResponseCode_Success && len(resp.GetData().GetBackups()) > 1 {
+			fmt.Println(fmt.Sprintf("backups referenced by %s are also exported: %v", resp.GetData().GetBackupName(), resp.GetData().GetBackups()[1:]))
+		}
+	},
+}
+
+func init() {
+	exportBackupCmd.Flags().StringVarP(&exportBackupName, "name", "n", "", "backup name to export")
+	exportBackupCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "path of the archive file, - for stdout")
+	exportBackupCmd.Flags().StringVarP(&exportCompression, "compression", "z", "", "compression codec of the archive, support none, gzip, zstd")
+
+	rootCmd.AddCommand(exportBackupCmd)
+}
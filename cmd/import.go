@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var importInput string
+
+var importBackupCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import subcommand import a backup from an archive created by the export subcommand.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		request := &backuppb.ImportBackupRequest{
+			ArchivePath: importInput,
+		}
+		var resp *backuppb.BackupArchiveResponse
+		if importInput == "-" {
+			resp = backupContext.ReadBackupArchive(context, request, os.Stdin)
+		} else {
+			resp = backupContext.ImportBackupFile(context, request, importInput)
+		}
+		fmt.Println(resp.GetMsg())
+		if len(resp.GetData().GetSkippedBackups()) > 0 {
+			fmt.Println(fmt.Sprintf("referenced backups already exist, skipped: %v", resp.GetData().GetSkippedBackups()))
+		}
+	},
+}
+
+func init() {
+	importBackupCmd.Flags().StringVarP(&importInput, "input", "i", "", "path of the archive file, - for stdin")
+
+	rootCmd.AddCommand(importBackupCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	pruneDryRun bool
+	prunePolicy string
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "prune subcommand delete the backups not kept by the retention policies.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		resp := backupContext.PruneBackups(context, &backuppb.PruneBackupsRequest{
+			DryRun: pruneDryRun,
+			Policy: prunePolicy,
+		})
+		if resp.GetCode() != backuppb.ResponseCode_Success && len(resp.GetData()) == 0 {
+			fmt.Println(resp.GetMsg())
+			return
+		}
+
+		action := "prune"
+		if pruneDryRun {
+			action = "would prune"
+		}
+		for _, decision := range resp.GetData() {
+			if decision.GetKeep() {
+				fmt.Println(fmt.Sprintf("keep %s: %s", decision.GetBackupName(), decision.GetReason()))
+			} else {
+				fmt.Println(fmt.Sprintf("%s %s: %s", action, decision.GetBackupName(), decision.GetReason()))
+			}
+		}
+		fmt.Println(resp.GetMsg())
+	},
+}
+
+func init() {
+	pruneCmd.Flags().BoolVarP(&pruneDryRun, "dry-run", "", false, "only show the backups to prune without deleting them")
+	pruneCmd.Flags().StringVarP(&prunePolicy, "policy", "p", "", "only apply the retention policy with this name")
+
+	rootCmd.AddCommand(pruneCmd)
+}
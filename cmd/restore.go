@@ -30,6 +30,16 @@ var (
 	restoreDropExistCollection  bool
 	restoreDropExistIndex       bool
 	restoreSkipCreateCollection bool
+	restoreToTimestamp          uint64
+	restoreToTime               string
+	restoreDryRun               bool
+	restoreVerify               bool
+	restoreStrictVerify         bool
+	restoreLoadState            bool
+	restoreLoadReplicaNumber    int32
+	restoreAliases              bool
+	restoreSwapAliases          bool
+	resumeRestoreID             string
 )
 
 var restoreBackupCmd = &cobra.Command{
@@ -44,6 +54,11 @@ var restoreBackupCmd = &cobra.Command{
 		context := context.Background()
 		backupContext := core.CreateBackupContext(context, params)
 		log.Info("restore cmd input args", zap.Strings("args", args))
+		// restore tasks are kept in the task store, so that a failed restore is able to be resumed by its id
+		if err := backupContext.InitTaskStore(); err != nil {
+			fmt.Println(fmt.Sprintf("fail to init task store: %s", err.Error()))
+			return
+		}
 		start := time.Now().Unix()
 		var collectionNameArr []string
 		if restoreCollectionNames == "" {
@@ -92,9 +107,30 @@ var restoreBackupCmd = &cobra.Command{
 			DropExistCollection:  restoreDropExistCollection,
 			DropExistIndex:       restoreDropExistIndex,
 			SkipCreateCollection: restoreSkipCreateCollection,
+			RestoreToTimestamp:   restoreToTimestamp,
+			RestoreToTime:        restoreToTime,
+			DryRun:               restoreDryRun,
+			Verify:               restoreVerify,
+			StrictVerify:         restoreStrictVerify,
+			RestoreLoadState:     restoreLoadState,
+			LoadReplicaNumber:    restoreLoadReplicaNumber,
+			RestoreAliases:       restoreAliases,
+			SwapAliases:          restoreSwapAliases,
+			ResumeRestoreId:      resumeRestoreID,
 		})
 
+		if restoreDryRun && resp.GetPlan() != nil {
+			printRestorePlan(resp.GetPlan())
+		}
+		for _, collectionTask := range resp.GetData().GetCollectionRestoreTasks() {
+			if collectionTask.GetVerifyResult() != nil {
+				printRestoreVerifyResult(collectionTask)
+			}
+		}
 		fmt.Println(resp.GetMsg())
+		if resp.GetData().GetStateCode() == backuppb.RestoreTaskStateCode_FAIL {
+			fmt.Println(fmt.Sprintf("restore task id: %s, resume it by --resume_restore_id", resp.GetData().GetId()))
+		}
 		duration := time.Now().Unix() - start
 		fmt.Println(fmt.Sprintf("duration:%d s", duration))
 	},
@@ -106,7 +142,7 @@ func init() {
 	restoreBackupCmd.Flags().StringVarP(&renameSuffix, "suffix", "s", "", "add a suffix to collection name to restore")
 	restoreBackupCmd.Flags().StringVarP(&renameCollectionNames, "rename", "r", "", "rename collections to new names, format: db1.collection1:db2.collection1_new,db1.collection2:db2.collection2_new")
 	restoreBackupCmd.Flags().StringVarP(&restoreDatabases, "databases", "d", "", "databases to restore, if not set, restore all databases")
-	restoreBackupCmd.Flags().StringVarP(&restoreDatabaseCollections, "database_collections", "a", "", "databases and collections to restore, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}")
+	restoreBackupCmd.Flags().StringVarP(&restoreDatabaseCollections, "database_collections", "a", "", "databases and collections to restore, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}, select partitions by {\"db1\":{\"c1\":[\"p1\", \"p2\"]}}")
 
 	restoreBackupCmd.Flags().BoolVarP(&restoreMetaOnly, "meta_only", "", false, "if true, restore meta only")
 	restoreBackupCmd.Flags().BoolVarP(&restoreRestoreIndex, "restore_index", "", false, "if true, restore index")
@@ -114,9 +150,81 @@ func init() {
 	restoreBackupCmd.Flags().BoolVarP(&restoreDropExistCollection, "drop_exist_collection", "", false, "if true, drop existing target collection before create")
 	restoreBackupCmd.Flags().BoolVarP(&restoreDropExistIndex, "drop_exist_index", "", false, "if true, drop existing index of target collection before create")
 	restoreBackupCmd.Flags().BoolVarP(&restoreSkipCreateCollection, "skip_create_collection", "", false, "if true, will skip collection, use when collection exist, restore index or data")
+	restoreBackupCmd.Flags().Uint64VarP(&restoreToTimestamp, "restore_to_timestamp", "", 0, "restore the data to the timestamp, should not be later than the backup timestamp")
+	restoreBackupCmd.Flags().StringVarP(&restoreToTime, "restore_to_time", "", "", "restore the data to the time in RFC3339 format, such as 2024-03-01T14:04:59Z")
+	restoreBackupCmd.Flags().BoolVarP(&restoreDryRun, "dry-run", "", false, "only show the plan of the restore without creating, dropping or copying anything")
+	restoreBackupCmd.Flags().BoolVarP(&restoreVerify, "verify", "", false, "if true, verify the row counts and sample the primary keys of the restored collections")
+	restoreBackupCmd.Flags().BoolVarP(&restoreStrictVerify, "strict_verify", "", false, "if true, verify the restored collections and fail the restore on mismatch")
+	restoreBackupCmd.Flags().BoolVarP(&restoreLoadState, "restore_load_state", "", false, "if true, load the collections and partitions which were loaded at backup time after the indexes are built")
+	restoreBackupCmd.Flags().Int32VarP(&restoreLoadReplicaNumber, "load_replica_number", "", 0, "replica number to load the collections by restore_load_state, 0 means the default of milvus")
+	restoreBackupCmd.Flags().BoolVarP(&restoreAliases, "restore_aliases", "", false, "if true, recreate the aliases of the collections, renamed by rename and suffix as collections")
+	restoreBackupCmd.Flags().BoolVarP(&restoreSwapAliases, "swap_aliases", "", false, "if true, restore the aliases without the suffix and move the ones existing on other collections onto the restored collections")
+	restoreBackupCmd.Flags().StringVarP(&resumeRestoreID, "resume_restore_id", "", "", "id of a failed restore task to resume, only the data not restored yet is restored, the backup name should be the same")
 
 	// won't print flags in character order
 	restoreBackupCmd.Flags().SortFlags = false
 
 	rootCmd.AddCommand(restoreBackupCmd)
 }
+
+func printRestorePlan(plan *backuppb.RestorePlan) {
+	for _, db := range plan.GetCreateDatabases() {
+		fmt.Println(fmt.Sprintf("create database %s", db))
+	}
+	for _, coll := range plan.GetCollections() {
+		actions := make([]string, 0)
+		if coll.GetDrop() {
+			actions = append(actions, "drop")
+		}
+		if coll.GetCreate() {
+			actions = append(actions, "create")
+		}
+		if coll.GetDropIndex() {
+			actions = append(actions, "drop index")
+		}
+		fmt.Println(fmt.Sprintf("restore %s.%s into %s.%s, exist: %t, actions: [%s], size: %d",
+			coll.GetSourceDbName(), coll.GetSourceCollectionName(), coll.GetTargetDbName(), coll.GetTargetCollectionName(),
+			coll.GetExist(), strings.Join(actions, ", "), coll.GetSize()))
+		for _, index := range coll.GetIndexes() {
+			fmt.Println(fmt.Sprintf("  create index %s on %s, type: %s, params: %v", index.GetIndexName(), index.GetFieldName(), index.GetIndexType(), index.GetParams()))
+		}
+		for _, partition := range coll.GetPartitions() {
+			fmt.Println(fmt.Sprintf("  partition %s, create: %t, groups: %d, l0 segments: %d, size: %d",
+				partition.GetPartitionName(), partition.GetCreate(), len(partition.GetGroups()), len(partition.GetL0SegmentIds()), partition.GetSize()))
+			for _, group := range partition.GetGroups() {
+				fmt.Println(fmt.Sprintf("    group %d, segments: %v, size: %d", group.GetGroupId(), group.GetSegmentIds(), group.GetSize()))
+			}
+		}
+		if len(coll.GetL0SegmentIds()) > 0 {
+			fmt.Println(fmt.Sprintf("  collection l0 segments: %v", coll.GetL0SegmentIds()))
+		}
+		if coll.GetLoad() && len(coll.GetLoadPartitions()) == 0 {
+			fmt.Println("  load collection")
+		} else if coll.GetLoad() {
+			fmt.Println(fmt.Sprintf("  load partitions: %v", coll.GetLoadPartitions()))
+		}
+		if len(coll.GetAliases()) > 0 {
+			fmt.Println(fmt.Sprintf("  aliases: %v", coll.GetAliases()))
+		}
+	}
+	for _, conflict := range plan.GetConflicts() {
+		fmt.Println(fmt.Sprintf("conflict: %s", conflict))
+	}
+	fmt.Println(fmt.Sprintf("total size: %d", plan.GetSize()))
+}
+
+func printRestoreVerifyResult(task *backuppb.RestoreCollectionTask) {
+	result := task.GetVerifyResult()
+	fmt.Println(fmt.Sprintf("verify %s.%s, passed: %t", task.GetTargetDbName(), task.GetTargetCollectionName(), result.GetPassed()))
+	for _, partition := range result.GetPartitions() {
+		fmt.Println(fmt.Sprintf("  partition %s, rows: %d, expected: %d to %d, sampled keys: %d, mismatched keys: %d",
+			partition.GetPartitionName(), partition.GetActualRows(), partition.GetMinRows(), partition.GetMaxRows(),
+			partition.GetSampledKeys(), partition.GetMismatchedKeys()))
+	}
+	if result.GetCollectionRows() >= 0 {
+		fmt.Println(fmt.Sprintf("  collection rows: %d", result.GetCollectionRows()))
+	}
+	for _, mismatch := range result.GetMismatches() {
+		fmt.Println(fmt.Sprintf("  mismatch: %s", mismatch))
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	resumeBackupName string
+)
+
+var resumeBackupCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "resume subcommand resume a failed or interrupted backup.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		fmt.Println("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		start := time.Now().Unix()
+		resp := backupContext.ResumeBackup(context, &backuppb.ResumeBackupRequest{
+			BackupName: resumeBackupName,
+		})
+
+		fmt.Println(resp.GetMsg())
+		duration := time.Now().Unix() - start
+		fmt.Println(fmt.Sprintf("duration:%d s", duration))
+	},
+}
+
+func init() {
+	resumeBackupCmd.Flags().StringVarP(&resumeBackupName, "name", "n", "", "name of the backup to resume")
+
+	rootCmd.AddCommand(resumeBackupCmd)
+}
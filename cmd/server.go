@@ -12,12 +12,14 @@ import (
 )
 
 const (
-	DefaultServerPort = "8080"
-	ServerPortEnvKey  = "SERVER_PORT"
+	DefaultServerPort    = "8080"
+	ServerPortEnvKey     = "SERVER_PORT"
+	GrpcServerPortEnvKey = "GRPC_SERVER_PORT"
 )
 
 var (
-	port string
+	port     string
+	grpcPort string
 )
 
 var serverCmd = &cobra.Command{
@@ -31,7 +33,7 @@ var serverCmd = &cobra.Command{
 		params.Init()
 
 		context := context.Background()
-		server, err := core.NewServer(context, params, core.Port(port))
+		server, err := core.NewServer(context, params, core.Port(port), core.GrpcPort(grpcPort))
 		if err != nil {
 			fmt.Errorf("fail to create backup server, %s", err.Error())
 		}
@@ -48,5 +50,12 @@ func init() {
 	}
 	serverCmd.Flags().StringVarP(&port, "port", "p", serverPort, "Port to listen")
 
+	grpcServerPort := os.Getenv(GrpcServerPortEnvKey)
+	_, err = strconv.Atoi(grpcServerPort)
+	if err != nil {
+		grpcServerPort = ""
+	}
+	serverCmd.Flags().StringVarP(&grpcPort, "grpc-port", "", grpcServerPort, "Port to serve the MilvusBackupService grpc api, disabled if unset")
+
 	rootCmd.AddCommand(serverCmd)
 }
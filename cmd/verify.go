@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	verifyBackupName string
+	verifyChecksum   bool
+)
+
+var verifyBackupCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verify subcommand check the files of a backup for missing, extra, truncated or mismatched ones.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		resp := backupContext.VerifyBackup(context, &backuppb.VerifyBackupRequest{
+			BackupName: verifyBackupName,
+			Checksum:   verifyChecksum,
+		})
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			fmt.Println(resp.GetMsg())
+			return
+		}
+
+		for _, problem := range resp.GetData().GetProblems() {
+			if problem.GetState() == backuppb.FileVerifyState_FILE_EXTRA {
+				fmt.Println(fmt.Sprintf("%s %s size: %d", problem.GetState().String(), problem.GetPath(), problem.GetActualSize()))
+				continue
+			}
+			fmt.Println(fmt.Sprintf("%s %s collection: %s(%d) partition: %d segment: %d expected size: %d actual size: %d",
+				problem.GetState().String(), problem.GetPath(),
+				problem.GetCollectionName(), problem.GetCollectionId(), problem.GetPartitionId(), problem.GetSegmentId(),
+				problem.GetExpectedSize(), problem.GetActualSize()))
+		}
+		if resp.GetData().GetUnrecordedFiles() > 0 {
+			fmt.Println(fmt.Sprintf("%d files have no recorded checksum, only their existence is checked", resp.GetData().GetUnrecordedFiles()))
+		}
+		fmt.Println(resp.GetMsg())
+	},
+}
+
+func init() {
+	verifyBackupCmd.Flags().StringVarP(&verifyBackupName, "name", "n", "", "backup name to verify")
+	verifyBackupCmd.Flags().BoolVarP(&verifyChecksum, "checksum", "c", false, "read the files to compare their checksums, only sizes are compared by default")
+
+	rootCmd.AddCommand(verifyBackupCmd)
+}
@@ -19,6 +19,30 @@ type Backup interface {
 	RestoreBackup(context.Context, *backuppb.RestoreBackupRequest) *backuppb.RestoreBackupResponse
 	// Get restore state by given id
 	GetRestore(context.Context, *backuppb.GetRestoreStateRequest) *backuppb.RestoreBackupResponse
-	// Copy backuppb between buckets
-	//CopyBackup(context.Context, *backuppb.CopyBackupRequest) (*backuppb.CopyBackupResponse, error)
+	// Resume a failed or interrupted backup from its checkpoint
+	ResumeBackup(context.Context, *backuppb.ResumeBackupRequest) *backuppb.BackupInfoResponse
+	// Cancel a running backup
+	CancelBackup(context.Context, *backuppb.CancelBackupRequest) *backuppb.BackupInfoResponse
+	// Cancel a running restore
+	CancelRestore(context.Context, *backuppb.CancelRestoreRequest) *backuppb.RestoreBackupResponse
+	// Create a backup schedule run by the server
+	CreateSchedule(context.Context, *backuppb.CreateScheduleRequest) *backuppb.ScheduleResponse
+	// List backup schedules with their run history
+	ListSchedules(context.Context, *backuppb.ListSchedulesRequest) *backuppb.ListSchedulesResponse
+	// Delete a backup schedule created by CreateSchedule
+	DeleteSchedule(context.Context, *backuppb.DeleteScheduleRequest) *backuppb.ScheduleResponse
+	// Prune the backups by the retention policies
+	PruneBackups(context.Context, *backuppb.PruneBackupsRequest) *backuppb.PruneBackupsResponse
+	// Verify the files of a backup against its checksum manifest and segment meta
+	VerifyBackup(context.Context, *backuppb.VerifyBackupRequest) *backuppb.VerifyBackupResponse
+	// Export a backup into a single tar archive
+	ExportBackup(context.Context, *backuppb.ExportBackupRequest) *backuppb.BackupArchiveResponse
+	// Import a backup from an archive created by ExportBackup
+	ImportBackup(context.Context, *backuppb.ImportBackupRequest) *backuppb.BackupArchiveResponse
+	// Copy a backup into another bucket, path or storage
+	CopyBackup(context.Context, *backuppb.CopyBackupRequest) *backuppb.CopyBackupResponse
+	// Get the throttle of the data copies
+	GetThrottle(context.Context, *backuppb.GetThrottleRequest) *backuppb.ThrottleResponse
+	// Update the throttle of the data copies at runtime
+	UpdateThrottle(context.Context, *backuppb.UpdateThrottleRequest) *backuppb.ThrottleResponse
 }
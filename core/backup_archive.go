@@ -0,0 +1,555 @@
+package core
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+// An exported archive is a tar of the backup directories, the entry names are relative to backupRootPath:
+//
+//	backup_name/meta/backup_meta.json
+//	backup_name/meta/...
+//	backup_name/binlogs/...
+//	ref_backup_name/meta/backup_meta.json
+//	...
+//	.blobs/ab/abcdef...
+//
+// The backup meta is the first entry of every backup. The exported backup comes first, followed by the backups
+// referenced by its segments, and the blobs of deduplicated backups come last.
+// The archive is optionally compressed as a whole by gzip or zstd.
+
+// ExportBackup exports a backup into an archive file under the archive dir of the local disk,
+// the archive path of the request is relative to the archive dir
+func (b *BackupContext) ExportBackup(ctx context.Context, request *backuppb.ExportBackupRequest) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	filePath, err := b.archiveFilePath(request.GetArchivePath())
+	if err != nil {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Parameter_Error,
+			Msg:       err.Error(),
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Fail,
+			Msg:       err.Error(),
+		}
+	}
+	resp := b.ExportBackupFile(ctx, request, filePath)
+	if resp.GetCode() == backuppb.ResponseCode_Success {
+		resp.Data.ArchivePath = request.GetArchivePath()
+	}
+	return resp
+}
+
+// ExportBackupFile exports a backup into the archive file of any local path, only for the export command
+func (b *BackupContext) ExportBackupFile(ctx context.Context, request *backuppb.ExportBackupRequest, filePath string) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	if filePath == "" {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Parameter_Error,
+			Msg:       "empty archive path",
+		}
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Fail,
+			Msg:       err.Error(),
+		}
+	}
+	resp := b.WriteBackupArchive(ctx, request, file)
+	if err := file.Close(); err != nil && resp.GetCode() == backuppb.ResponseCode_Success {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+	}
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		// don't leave an incomplete archive
+		os.Remove(filePath)
+		return resp
+	}
+	resp.Data.ArchivePath = filePath
+	return resp
+}
+
+// archiveFilePath resolves the archive path of a request into the archive dir,
+// absolute paths and paths escaping the archive dir are rejected
+func (b *BackupContext) archiveFilePath(archivePath string) (string, error) {
+	if archivePath == "" {
+		return "", errors.New("empty archive path")
+	}
+	if filepath.IsAbs(archivePath) || strings.HasPrefix(archivePath, "/") {
+		return "", fmt.Errorf("archive path %s should be relative to the archive dir", archivePath)
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(archivePath), "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("archive path %s should not contain ..", archivePath)
+		}
+	}
+	return filepath.Join(b.params.BackupCfg.ArchiveDir, archivePath), nil
+}
+
+// WriteBackupArchive exports a backup as an archive into the writer, the archive path of the request is ignored
+func (b *BackupContext) WriteBackupArchive(ctx context.Context, request *backuppb.ExportBackupRequest, writer io.Writer) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive ExportBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.String("bucketName", request.GetBucketName()),
+		zap.String("path", request.GetPath()),
+		zap.String("archivePath", request.GetArchivePath()),
+		zap.String("compression", request.GetCompression()))
+
+	resp := &backuppb.BackupArchiveResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+	codec, err := compression.Validate(request.GetCompression())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	var backupBucketName string
+	var backupPath string
+	if request.GetBucketName() == "" || request.GetPath() == "" {
+		backupBucketName = b.backupBucketName
+		backupPath = b.backupRootPath + SEPERATOR + request.GetBackupName()
+	} else {
+		backupBucketName = request.GetBucketName()
+		backupPath = request.GetPath() + SEPERATOR + request.GetBackupName()
+	}
+	backup, err := b.readBackup(ctx, backupBucketName, backupPath)
+	if err != nil {
+		log.Error("fail to read backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if backup == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = "not found backup " + request.GetBackupName()
+		return resp
+	}
+	if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("backup %s is not complete, state: %s", request.GetBackupName(), backup.GetStateCode().String())
+		return resp
+	}
+
+	result, err := b.exportBackup(ctx, backupBucketName, backupPath, backup, codec, writer)
+	if err != nil {
+		log.Error("fail to export backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = fmt.Sprintf("export backup %s, %d files, %d bytes", request.GetBackupName(), result.GetFiles(), result.GetSize())
+	resp.Data = result
+	log.Info("finish ExportBackup", zap.String("requestId", request.GetRequestId()), zap.String("msg", resp.Msg))
+	return resp
+}
+
+// ImportBackup imports a backup from an archive file under the archive dir of the local disk,
+// the archive path of the request is relative to the archive dir
+func (b *BackupContext) ImportBackup(ctx context.Context, request *backuppb.ImportBackupRequest) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	filePath, err := b.archiveFilePath(request.GetArchivePath())
+	if err != nil {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Parameter_Error,
+			Msg:       err.Error(),
+		}
+	}
+	resp := b.ImportBackupFile(ctx, request, filePath)
+	if resp.GetCode() == backuppb.ResponseCode_Success {
+		resp.Data.ArchivePath = request.GetArchivePath()
+	}
+	return resp
+}
+
+// ImportBackupFile imports a backup from the archive file of any local path, only for the import command
+func (b *BackupContext) ImportBackupFile(ctx context.Context, request *backuppb.ImportBackupRequest, filePath string) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	if filePath == "" {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Parameter_Error,
+			Msg:       "empty archive path",
+		}
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return &backuppb.BackupArchiveResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Fail,
+			Msg:       err.Error(),
+		}
+	}
+	defer file.Close()
+	resp := b.ReadBackupArchive(ctx, request, file)
+	if resp.GetCode() == backuppb.ResponseCode_Success {
+		resp.Data.ArchivePath = filePath
+	}
+	return resp
+}
+
+// ReadBackupArchive imports a backup from the archive read from the reader, the archive path of the request is ignored
+func (b *BackupContext) ReadBackupArchive(ctx context.Context, request *backuppb.ImportBackupRequest, reader io.Reader) *backuppb.BackupArchiveResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive ImportBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("archivePath", request.GetArchivePath()),
+		zap.String("bucketName", request.GetBucketName()),
+		zap.String("path", request.GetPath()))
+
+	resp := &backuppb.BackupArchiveResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	var backupBucketName string
+	var backupRootPath string
+	if request.GetBucketName() == "" || request.GetPath() == "" {
+		backupBucketName = b.backupBucketName
+		backupRootPath = b.backupRootPath
+	} else {
+		backupBucketName = request.GetBucketName()
+		backupRootPath = request.GetPath()
+	}
+
+	result, err := b.importBackup(ctx, backupBucketName, backupRootPath, reader)
+	if err != nil {
+		log.Error("fail to import backup", zap.String("archivePath", request.GetArchivePath()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = fmt.Sprintf("import backup %s, %d files, %d bytes", result.GetBackupName(), result.GetFiles(), result.GetSize())
+	resp.Data = result
+	log.Info("finish ImportBackup", zap.String("requestId", request.GetRequestId()), zap.String("msg", resp.Msg))
+	return resp
+}
+
+// exportBackup writes the meta and binlogs of the backup and the backups referenced by it into a tar archive,
+// binlogs of deduplicated backups are exported with their blobs
+func (b *BackupContext) exportBackup(ctx context.Context, bucketName, backupPath string, backup *backuppb.BackupInfo, codec string, writer io.Writer) (*backuppb.BackupArchiveResult, error) {
+	backupRootPath := backupPath[:strings.LastIndex(backupPath, SEPERATOR)]
+	backupName := backupPath[strings.LastIndex(backupPath, SEPERATOR)+1:]
+	result := &backuppb.BackupArchiveResult{
+		BackupName:     backupName,
+		Backups:        []string{backupName},
+		SkippedBackups: make([]string, 0),
+	}
+	for _, refBackup := range collectRefBackups(backup) {
+		if refBackup != backupName {
+			result.Backups = append(result.Backups, refBackup)
+		}
+	}
+
+	var compressWriter io.WriteCloser
+	if codec != compression.None {
+		var err error
+		compressWriter, err = compression.NewWriter(codec, writer)
+		if err != nil {
+			return nil, err
+		}
+		writer = compressWriter
+	}
+	tarWriter := tar.NewWriter(writer)
+	modTime := time.Now()
+	addFile := func(filePath string, size int64) error {
+		reader, err := b.getStorageClient(storage.BackupStorage).Reader(ctx, bucketName, filePath)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		err = tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.TrimPrefix(filePath, backupRootPath+SEPERATOR),
+			Size:     size,
+			Mode:     0644,
+			ModTime:  modTime,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.CopyN(tarWriter, reader, size); err != nil {
+			return fmt.Errorf("fail to read %s: %w", filePath, err)
+		}
+		result.Files++
+		result.Size += size
+		return nil
+	}
+
+	blobSizes := make(map[string]int64, 0)
+	for _, name := range result.GetBackups() {
+		metaKeys, metaSizes, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, bucketName, BackupMetaDirPath(backupRootPath, name)+SEPERATOR, true)
+		if err != nil {
+			return nil, err
+		}
+		backupMetaPath := BackupMetaPath(backupRootPath, name)
+		metaIndex := -1
+		for i, key := range metaKeys {
+			if key == backupMetaPath {
+				metaIndex = i
+			}
+		}
+		if metaIndex < 0 {
+			return nil, fmt.Errorf("backup meta of %s not found", name)
+		}
+		if err := addFile(backupMetaPath, metaSizes[metaIndex]); err != nil {
+			return nil, err
+		}
+		for i, key := range metaKeys {
+			if i == metaIndex {
+				continue
+			}
+			if err := addFile(key, metaSizes[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		binlogKeys, binlogSizes, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, bucketName, BackupBinlogDirPath(backupRootPath, name)+SEPERATOR, true)
+		if err != nil {
+			return nil, err
+		}
+		for i, key := range binlogKeys {
+			if err := addFile(key, binlogSizes[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		manifest, err := b.readBlobManifest(ctx, bucketName, backupRootPath+SEPERATOR+name)
+		if err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			for _, ref := range manifest.Files {
+				blobSizes[ref.Hash] = ref.Size
+			}
+		}
+	}
+
+	hashes := make([]string, 0, len(blobSizes))
+	for hash := range blobSizes {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		if err := addFile(BlobPath(backupRootPath, hash), blobSizes[hash]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if compressWriter != nil {
+		if err := compressWriter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// importBackup unpacks an archive created by exportBackup into backupRootPath, the compression of the archive is detected.
+// The import fails if the exported backup already exists, referenced backups already existing are skipped.
+// The imported backups are removed if the import fails, blobs are kept as they may be shared with other backups.
+func (b *BackupContext) importBackup(ctx context.Context, bucketName, backupRootPath string, reader io.Reader) (*backuppb.BackupArchiveResult, error) {
+	bufReader := bufio.NewReader(reader)
+	magic, err := bufReader.Peek(4)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	decompressReader, err := compression.NewReader(compression.Detect(magic), bufReader)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressReader.Close()
+	tarReader := tar.NewReader(decompressReader)
+
+	result := &backuppb.BackupArchiveResult{
+		Backups:        make([]string, 0),
+		SkippedBackups: make([]string, 0),
+	}
+	// backups found in the archive, true if the backup is skipped
+	skipped := make(map[string]bool, 0)
+	success := false
+	defer func() {
+		if success {
+			return
+		}
+		for _, name := range result.GetBackups() {
+			if skipped[name] {
+				continue
+			}
+			err := b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(ctx, bucketName, BackupDirPath(backupRootPath, name))
+			if err != nil {
+				log.Error("fail to remove the backup partially imported", zap.String("backupName", name), zap.Error(err))
+			}
+		}
+	}()
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		name, isBlob, err := parseArchiveEntry(header)
+		if err != nil {
+			return nil, err
+		}
+		targetPath := backupRootPath + SEPERATOR + header.Name
+
+		if isBlob {
+			exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, targetPath)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				log.Debug("blob already exist, skip import", zap.String("blob", targetPath))
+				continue
+			}
+		} else {
+			if _, ok := skipped[name]; !ok {
+				backupMetaPath := BackupMetaPath(backupRootPath, name)
+				if targetPath != backupMetaPath {
+					return nil, fmt.Errorf("invalid archive, the first entry of backup %s is %s instead of its backup meta", name, header.Name)
+				}
+				exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, backupMetaPath)
+				if err != nil {
+					return nil, err
+				}
+				if exist && len(result.GetBackups()) == 0 {
+					return nil, fmt.Errorf("backup %s already exists", name)
+				}
+				if len(result.GetBackups()) == 0 {
+					result.BackupName = name
+				}
+				result.Backups = append(result.Backups, name)
+				skipped[name] = exist
+				if exist {
+					log.Info("referenced backup already exists, skip import", zap.String("backupName", name))
+					result.SkippedBackups = append(result.SkippedBackups, name)
+				}
+			}
+			if skipped[name] {
+				continue
+			}
+		}
+
+		err = b.getStorageClient(storage.BackupStorage).WriteFrom(ctx, bucketName, targetPath, tarReader, header.Size)
+		if err != nil {
+			return nil, err
+		}
+		result.Files++
+		result.Size += header.Size
+	}
+
+	if result.GetBackupName() == "" {
+		return nil, errors.New("invalid archive, no backup found")
+	}
+	backup, err := b.readBackup(ctx, bucketName, backupRootPath+SEPERATOR+result.GetBackupName())
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the imported backup %s: %w", result.GetBackupName(), err)
+	}
+	for _, refBackup := range collectRefBackups(backup) {
+		if _, ok := skipped[refBackup]; !ok {
+			return nil, fmt.Errorf("invalid archive, backup %s referenced by %s not found", refBackup, result.GetBackupName())
+		}
+	}
+	success = true
+	return result, nil
+}
+
+// parseArchiveEntry checks the entry is a file of the backup layout, returns the name of the backup it belongs to,
+// or whether it is a blob
+func parseArchiveEntry(header *tar.Header) (string, bool, error) {
+	if header.Typeflag != tar.TypeReg {
+		return "", false, fmt.Errorf("invalid archive, %s is not a regular file", header.Name)
+	}
+	if path.IsAbs(header.Name) || path.Clean(header.Name) != header.Name || strings.HasPrefix(header.Name, "..") {
+		return "", false, fmt.Errorf("invalid archive, illegal path %s", header.Name)
+	}
+	parts := strings.Split(header.Name, SEPERATOR)
+	if parts[0] == BLOB_DIR {
+		if len(parts) != 3 || len(parts[1]) != 2 || !strings.HasPrefix(parts[2], parts[1]) {
+			return "", false, fmt.Errorf("invalid archive, illegal blob path %s", header.Name)
+		}
+		return "", true, nil
+	}
+	switch {
+	case len(parts) == 3 && parts[1] == META_PREFIX:
+	case len(parts) >= 3 && parts[1] == BINGLOG_DIR:
+	default:
+		return "", false, fmt.Errorf("invalid archive, %s is neither a backup meta nor a binlog", header.Name)
+	}
+	return parts[0], false, nil
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+// writeTestBackupMeta writes the meta files of the backup into backupRootPath
+func writeTestBackupMeta(t *testing.T, b *BackupContext, backupInfo *backuppb.BackupInfo) {
+	ctx := context.Background()
+	output, err := serialize(backupInfo)
+	assert.NoError(t, err)
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, BackupMetaPath(b.backupRootPath, backupInfo.GetName()), output.BackupMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, CollectionMetaPath(b.backupRootPath, backupInfo.GetName()), output.CollectionMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, PartitionMetaPath(b.backupRootPath, backupInfo.GetName()), output.PartitionMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, SegmentMetaPath(b.backupRootPath, backupInfo.GetName()), output.SegmentMetaBytes))
+}
+
+func TestExportImportBackup(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+
+	newBackup := func(name string, segments ...*backuppb.SegmentBackupInfo) *backuppb.BackupInfo {
+		return &backuppb.BackupInfo{
+			Name:      name,
+			StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+			CollectionBackups: []*backuppb.CollectionBackupInfo{{
+				CollectionId:   1,
+				CollectionName: "coll",
+				PartitionBackups: []*backuppb.PartitionBackupInfo{{
+					CollectionId:   1,
+					PartitionId:    2,
+					SegmentBackups: segments,
+				}},
+			}},
+		}
+	}
+	write := func(filePath string, content string) {
+		assert.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, filePath, []byte(content)))
+	}
+
+	// full has a binlog, incr has a deduplicated binlog and a segment referencing full
+	writeTestBackupMeta(t, b, newBackup("full", &backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3}))
+	write(BackupBinlogDirPath(b.backupRootPath, "full")+"/insert_log/1/2/3/100/1", "full binlog")
+	writeTestBackupMeta(t, b, newBackup("incr",
+		&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3, RefBackup: "full"},
+		&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 4}))
+	hash := strings.Repeat("ab", 32)
+	manifest, err := json.Marshal(&BlobManifest{Files: map[string]BlobRef{
+		"binlogs/insert_log/1/2/4/100/1": {Hash: hash, Size: int64(len("incr binlog"))},
+	}})
+	assert.NoError(t, err)
+	write(BlobManifestPath(b.backupRootPath, "incr"), string(manifest))
+	write(BlobPath(b.backupRootPath, hash), "incr binlog")
+
+	listFiles := func(rootPath string) map[string]string {
+		keys, _, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, rootPath+SEPERATOR, true)
+		assert.NoError(t, err)
+		files := make(map[string]string, len(keys))
+		for _, key := range keys {
+			content, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, key)
+			assert.NoError(t, err)
+			files[strings.TrimPrefix(key, rootPath+SEPERATOR)] = string(content)
+		}
+		return files
+	}
+
+	var archive bytes.Buffer
+	backupPath := b.backupRootPath + SEPERATOR + "incr"
+	backup, err := b.readBackup(ctx, b.backupBucketName, backupPath)
+	assert.NoError(t, err)
+	result, err := b.exportBackup(ctx, b.backupBucketName, backupPath, backup, compression.Zstd, &archive)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"incr", "full"}, result.GetBackups())
+	assert.Equal(t, compression.Zstd, compression.Detect(archive.Bytes()))
+
+	// import into an empty root, all files are the same as the original ones
+	importRootPath := b.backupRootPath + "-imported"
+	result, err = b.importBackup(ctx, b.backupBucketName, importRootPath, bytes.NewReader(archive.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, "incr", result.GetBackupName())
+	assert.Empty(t, result.GetSkippedBackups())
+	expected := listFiles(b.backupRootPath)
+	assert.Equal(t, int64(len(expected)), result.GetFiles())
+	assert.Equal(t, expected, listFiles(importRootPath))
+
+	_, err = b.importBackup(ctx, b.backupBucketName, importRootPath, bytes.NewReader(archive.Bytes()))
+	assert.ErrorContains(t, err, "backup incr already exists")
+
+	// referenced backup already exists
+	assert.NoError(t, b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(importRootPath, "incr")))
+	result, err = b.importBackup(ctx, b.backupBucketName, importRootPath, bytes.NewReader(archive.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full"}, result.GetSkippedBackups())
+	assert.Equal(t, expected, listFiles(importRootPath))
+
+	// invalid entries are rejected and the backup partially imported is removed
+	var invalid bytes.Buffer
+	tarWriter := tar.NewWriter(&invalid)
+	for _, name := range []string{"other/meta/backup_meta.json", "other/../../evil"} {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: 2, Mode: 0644}))
+		_, err = tarWriter.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	_, err = b.importBackup(ctx, b.backupBucketName, importRootPath, &invalid)
+	assert.ErrorContains(t, err, "illegal path")
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, BackupMetaPath(importRootPath, "other"))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	_, _, err = parseArchiveEntry(&tar.Header{Typeflag: tar.TypeReg, Name: "other/collections/1"})
+	assert.ErrorContains(t, err, "neither a backup meta nor a binlog")
+	_, _, err = parseArchiveEntry(&tar.Header{Typeflag: tar.TypeSymlink, Name: "other/meta/backup_meta.json"})
+	assert.ErrorContains(t, err, "not a regular file")
+}
+
+func TestArchiveFilePath(t *testing.T) {
+	b := newEncryptionTestContext(t)
+	b.params.BackupCfg.ArchiveDir = "data/archives"
+
+	filePath, err := b.archiveFilePath("nightly/mybackup.tar.zst")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("data/archives", "nightly/mybackup.tar.zst"), filePath)
+
+	for _, archivePath := range []string{"", "/etc/passwd", "../mybackup.tar", "nightly/../../mybackup.tar"} {
+		_, err = b.archiveFilePath(archivePath)
+		assert.Error(t, err, archivePath)
+	}
+	resp := b.ExportBackup(context.Background(), &backuppb.ExportBackupRequest{BackupName: "full", ArchivePath: "/tmp/mybackup.tar"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = b.ImportBackup(context.Background(), &backuppb.ImportBackupRequest{ArchivePath: "../mybackup.tar"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+}
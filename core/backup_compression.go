@@ -0,0 +1,186 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/encryption"
+)
+
+// copyBinlogEncoded copies a binlog of milvus into backup, the binlog is compressed by the codec,
+// then encrypted by the data key if it is not nil, on the fly. The checksum of the encoded binlog is returned.
+func (b *BackupContext) copyBinlogEncoded(ctx context.Context, dataKey []byte, codec string, fromPath, targetPath string) (FileChecksum, error) {
+	storageClient := b.getThrottledStorageClient(storage.MilvusStorage)
+	size, err := storageClient.Size(ctx, b.milvusBucketName, fromPath)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	reader, err := storageClient.Reader(ctx, b.milvusBucketName, fromPath)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	defer reader.Close()
+	compressReader, err := compression.NewCompressReader(codec, reader)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	defer compressReader.Close()
+	var encodedReader io.Reader = compressReader
+	if codec != compression.None {
+		// the size of compressed data is unknown until it is written,
+		// the storage uploads it in parts of bounded size, see ChunkManager.WriteFrom
+		size = -1
+	}
+	if dataKey != nil {
+		encodedReader, err = encryption.NewEncryptReader(dataKey, compressReader)
+		if err != nil {
+			return FileChecksum{}, err
+		}
+		if size >= 0 {
+			size = encryption.EncryptedSize(size)
+		}
+	}
+	checksumReader := newChecksumReader(encodedReader)
+	// the bytes are throttled by the source only
+	err = b.getStorageClient(storage.BackupStorage).WriteFrom(ctx, b.backupBucketName, targetPath, checksumReader, size)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	return checksumReader.checksum(), nil
+}
+
+// copyDecoded copies the files under fromPath of backup into toPath of milvus bucket like ChunkManager.Copy,
+// files are decrypted by the data key if it is not nil, then decompressed by their codecs keyed by file path
+func (b *BackupContext) copyDecoded(ctx context.Context, backupBucketName string, dataKey []byte, codecs map[string]string, fromPath, toPath string) error {
+	storageClient := b.getThrottledStorageClient(storage.BackupStorage)
+	keys, sizes, err := storageClient.ListWithPrefix(ctx, backupBucketName, fromPath, true)
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		// the dir of segment 1 is a prefix of the dir of segment 12
+		if !isUnderDir(key, fromPath) {
+			continue
+		}
+		targetKey := strings.Replace(key, fromPath, toPath, 1)
+		codec := codecs[key]
+		size := sizes[i]
+		if dataKey != nil {
+			size, err = encryption.DecryptedSize(size)
+			if err != nil {
+				return fmt.Errorf("fail to decrypt %s: %w", key, err)
+			}
+		}
+		if codec != compression.None {
+			// the decompressed size is not recorded, the storage uploads it in parts of bounded size
+			size = -1
+		}
+		reader, err := storageClient.Reader(ctx, backupBucketName, key)
+		if err != nil {
+			return err
+		}
+		err = func() error {
+			defer reader.Close()
+			var decryptReader io.Reader = reader
+			if dataKey != nil {
+				plainReader, err := encryption.NewDecryptReader(dataKey, reader)
+				if err != nil {
+					return err
+				}
+				decryptReader = plainReader
+			}
+			decompressReader, err := compression.NewReader(codec, decryptReader)
+			if err != nil {
+				return err
+			}
+			defer decompressReader.Close()
+			return b.getStorageClient(storage.MilvusStorage).WriteFrom(ctx, b.milvusBucketName, targetKey, decompressReader, size)
+		}()
+		if err != nil {
+			log.Error("fail to decode file", zap.String("from", key), zap.String("to", targetKey), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// binlogCompressions returns the compression codecs of the compressed insert logs and delta logs of the collection backup,
+// keyed by the path of binlog in backup
+func binlogCompressions(backupPath string, collectionBackup *backuppb.CollectionBackupInfo) map[string]string {
+	codecs := make(map[string]string, 0)
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			segmentBackupPath := RefBackupPath(backupPath, segment.GetRefBackup())
+			for _, fieldBinlogs := range [][]*backuppb.FieldBinlog{segment.GetBinlogs(), segment.GetDeltalogs()} {
+				for _, fieldBinlog := range fieldBinlogs {
+					for _, binlog := range fieldBinlog.GetBinlogs() {
+						if binlog.GetCompression() == compression.None {
+							continue
+						}
+						binlogPath := segmentBackupPath + SEPERATOR + backupBinlogRelativePath(binlog.GetLogPath(), segment.GetGroupId())
+						codecs[binlogPath] = binlog.GetCompression()
+					}
+				}
+			}
+		}
+	}
+	return codecs
+}
+
+// hasCompressedBinlogs returns true if any binlog under the dir is compressed
+func hasCompressedBinlogs(codecs map[string]string, dir string) bool {
+	for binlogPath := range codecs {
+		if isUnderDir(binlogPath, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionMeta records the compression codec of the meta files of a backup,
+// so that they are decompressed by the recorded codec rather than by the codec detected from the content
+type compressionMeta struct {
+	MetaCompression string `json:"meta_compression"`
+}
+
+// writeCompressionMeta saves the compression codec of the meta files, nothing is written if they are not compressed
+func (b *BackupContext) writeCompressionMeta(ctx context.Context, backupInfo *backuppb.BackupInfo) error {
+	if backupInfo.GetMetaCompression() == compression.None {
+		return nil
+	}
+	bytes, err := json.Marshal(&compressionMeta{MetaCompression: backupInfo.GetMetaCompression()})
+	if err != nil {
+		return err
+	}
+	return b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, CompressionMetaPath(b.backupRootPath, backupInfo.GetName()), bytes)
+}
+
+// readCompressionMeta reads the compression codec of the meta files of the backup, none if they are not compressed
+func (b *BackupContext) readCompressionMeta(ctx context.Context, bucketName, backupPath string) (string, error) {
+	compressionMetaPath := backupPath + SEPERATOR + META_PREFIX + SEPERATOR + COMPRESSION_META_FILE
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, compressionMetaPath)
+	if err != nil {
+		return compression.None, err
+	}
+	if !exist {
+		return compression.None, nil
+	}
+	bytes, err := b.getStorageClient(storage.BackupStorage).Read(ctx, bucketName, compressionMetaPath)
+	if err != nil {
+		return compression.None, err
+	}
+	meta := &compressionMeta{}
+	if err := json.Unmarshal(bytes, meta); err != nil {
+		return compression.None, err
+	}
+	return compression.Validate(meta.MetaCompression)
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+func TestCompressedBackupMeta(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+
+	for _, codec := range []string{compression.Gzip, compression.Zstd} {
+		backupInfo := &backuppb.BackupInfo{
+			Name:            "compressed_" + codec,
+			MetaCompression: codec,
+			CollectionBackups: []*backuppb.CollectionBackupInfo{
+				{CollectionId: 1, CollectionName: "coll"},
+			},
+		}
+		output, err := serialize(backupInfo)
+		assert.NoError(t, err)
+		assert.NoError(t, b.writeCompressionMeta(ctx, backupInfo))
+		assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, BackupMetaPath(b.backupRootPath, backupInfo.GetName()), output.BackupMetaBytes))
+		assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, CollectionMetaPath(b.backupRootPath, backupInfo.GetName()), output.CollectionMetaBytes))
+		assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, PartitionMetaPath(b.backupRootPath, backupInfo.GetName()), output.PartitionMetaBytes))
+		assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, SegmentMetaPath(b.backupRootPath, backupInfo.GetName()), output.SegmentMetaBytes))
+
+		raw, err := os.ReadFile(CollectionMetaPath(b.backupRootPath, backupInfo.GetName()))
+		assert.NoError(t, err)
+		assert.Equal(t, codec, compression.Detect(raw))
+
+		readInfo, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupInfo.GetName())
+		assert.NoError(t, err)
+		assert.Equal(t, "coll", readInfo.GetCollectionBackups()[0].GetCollectionName())
+		assert.Equal(t, codec, readInfo.GetMetaCompression())
+
+		// the meta files are decompressed by the recorded codec only
+		assert.NoError(t, os.Remove(CompressionMetaPath(b.backupRootPath, backupInfo.GetName())))
+		_, err = b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupInfo.GetName())
+		assert.Error(t, err)
+	}
+}
+
+func TestCompressedBinlogCopy(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+
+	encryptionInfo, err := b.newBackupEncryption()
+	assert.NoError(t, err)
+	dataKey, err := b.backupDataKey(encryptionInfo)
+	assert.NoError(t, err)
+
+	content := bytes.Repeat([]byte("milvus binlog "), 10000)
+	binlogPath := b.milvusRootPath + "/insert_log/1/2/3/4/5"
+	assert.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, content))
+
+	backupPath := b.backupRootPath + "/compressed"
+	collectionBackup := &backuppb.CollectionBackupInfo{
+		PartitionBackups: []*backuppb.PartitionBackupInfo{{
+			SegmentBackups: []*backuppb.SegmentBackupInfo{{
+				GroupId: 3,
+				Binlogs: []*backuppb.FieldBinlog{{
+					Binlogs: []*backuppb.Binlog{{LogPath: binlogPath, Compression: compression.Zstd}},
+				}},
+			}},
+		}},
+	}
+	codecs := binlogCompressions(backupPath, collectionBackup)
+	backupBinlogPath := backupPath + "/binlogs/insert_log/1/2/3/3/4/5"
+	assert.Equal(t, map[string]string{backupBinlogPath: compression.Zstd}, codecs)
+
+	backupDir := backupPath + "/binlogs/insert_log/1/2/3/"
+	assert.True(t, hasCompressedBinlogs(codecs, backupDir))
+	assert.False(t, hasCompressedBinlogs(codecs, backupPath+"/binlogs/delta_log/1/2/3/"))
+	// the dir of segment 1 is a prefix of the dir of segment 12
+	assert.False(t, hasCompressedBinlogs(map[string]string{backupPath + "/binlogs/delta_log/1/2/12/12/5": compression.Zstd}, backupPath+"/binlogs/delta_log/1/2/1"))
+
+	tempDir := b.milvusRootPath + "/restore-temp/"
+	for _, key := range [][]byte{nil, dataKey} {
+		checksum, err := b.copyBinlogEncoded(ctx, key, compression.Zstd, binlogPath, backupBinlogPath)
+		assert.NoError(t, err)
+		raw, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, backupBinlogPath)
+		assert.NoError(t, err)
+		assert.Less(t, len(raw), len(content))
+		// the checksum computed while copying is of the stored binlog
+		sum := sha256.Sum256(raw)
+		assert.Equal(t, FileChecksum{Size: int64(len(raw)), Sha256: hex.EncodeToString(sum[:])}, checksum)
+
+		assert.NoError(t, b.copyDecoded(ctx, b.backupBucketName, key, codecs, backupDir, tempDir+backupDir))
+		decoded, err := b.getStorageClient(storage.MilvusStorage).Read(ctx, b.milvusBucketName, tempDir+backupBinlogPath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, decoded)
+	}
+}
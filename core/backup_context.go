@@ -16,15 +16,20 @@ import (
 	"github.com/zilliztech/milvus-backup/core/storage"
 	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/kv"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/encryption"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
 )
 
 const (
 	BULKINSERT_TIMEOUT            = 60 * 60
 	BULKINSERT_SLEEP_INTERVAL     = 5
+	LOAD_TIMEOUT                  = 60 * 60
+	LOAD_SLEEP_INTERVAL           = 5
 	BACKUP_NAME                   = "BACKUP_NAME"
 	COLLECTION_RENAME_SUFFIX      = "COLLECTION_RENAME_SUFFIX"
-	RPS                           = 1000
+	RPS                           = 1000 // rate of the jobs submitted to the collection worker pools
 	BackupSegmentGroupMaxSizeInMB = 256
 
 	GC_Warn_Message = "This warn won't fail the backup process. Pause GC can protect data not to be GCed during backup, it is necessary to backup very large data(cost more than a hour)."
@@ -43,8 +48,11 @@ type BackupContext struct {
 	// milvus client
 	milvusClient *MilvusClient
 
-	// data storage client
-	storageClient    *storage.ChunkManager
+	// data storage client of milvus, it serves the backups as well unless crossStorage is enabled
+	storageClient *storage.ChunkManager
+	// data storage client of the backups if crossStorage is enabled
+	backupStorageClient *storage.ChunkManager
+
 	milvusBucketName string
 	backupBucketName string
 	milvusRootPath   string
@@ -55,6 +63,39 @@ type BackupContext struct {
 	backupCollectionWorkerPool *common.WorkerPool
 	backupCopyDataWorkerPool   *common.WorkerPool
 	bulkinsertWorkerPools      map[string]*common.WorkerPool
+
+	// running backup and restore tasks which are able to be cancelled, task id -> canceler
+	taskCancelers map[string]*taskCanceler
+	cancelMu      sync.Mutex
+
+	// persists the task states and backup schedules in server mode, nil otherwise
+	taskStore kv.BaseKV
+	// runs the backup schedules in server mode, nil otherwise
+	scheduler *BackupScheduler
+	// serializes the prunes of backups
+	pruneMu sync.Mutex
+
+	// master key to wrap the data keys of encrypted backups, loaded on first use
+	masterKey   *encryption.MasterKey
+	masterKeyMu sync.Mutex
+
+	// throttle of the data copies, created from the config on first use and updated at runtime
+	throttle        *storage.Throttle
+	throttleConfig  *backuppb.ThrottleConfig
+	throttleProfile string
+	throttleMu      sync.Mutex
+
+	// sleep between the attempts of the storage operations, 2s if it is 0
+	storageRetrySleep time.Duration
+}
+
+// storageRetryOptions returns the retry options of the storage operations of backup and restore
+func (b *BackupContext) storageRetryOptions() []retry.Option {
+	sleep := b.storageRetrySleep
+	if sleep == 0 {
+		sleep = 2 * time.Second
+	}
+	return []retry.Option{retry.Sleep(sleep), retry.Attempts(5)}
 }
 
 func CreateMilvusClient(ctx context.Context, params paramtable.BackupParams) (gomilvus.Client, error) {
@@ -81,6 +122,7 @@ func CreateMilvusClient(ctx context.Context, params paramtable.BackupParams) (go
 	return c, nil
 }
 
+// CreateStorageClient creates the storage client of milvus, it serves the backups as well unless crossStorage is enabled
 func CreateStorageClient(ctx context.Context, params paramtable.BackupParams) (storage.ChunkManager, error) {
 	minioEndPoint := params.MinioCfg.Address + ":" + params.MinioCfg.Port
 	log.Debug("Start minio client",
@@ -98,6 +140,18 @@ func (b *BackupContext) Start() error {
 	return nil
 }
 
+// InitTaskStore reloads the task history and persists the task states from now on. It is used by the server,
+// and by the restore command so that a failed restore is able to be resumed by a later command.
+func (b *BackupContext) InitTaskStore() error {
+	store, err := newTaskStore(b.params.BackupCfg)
+	if err != nil {
+		log.Error("fail to create task store", zap.String("type", b.params.BackupCfg.TaskStoreType), zap.Error(err))
+		return err
+	}
+	b.taskStore = store
+	return b.meta.InitTaskStore(store, b.params.BackupCfg.TaskStoreHistorySize)
+}
+
 func (b *BackupContext) Close() error {
 	b.started = false
 	if b.milvusClient != nil {
@@ -117,6 +171,7 @@ func CreateBackupContext(ctx context.Context, params paramtable.BackupParams) *B
 		backupRootPath:        params.MinioCfg.BackupRootPath,
 		bulkinsertWorkerPools: make(map[string]*common.WorkerPool),
 		meta:                  newMetaManager(),
+		taskCancelers:         make(map[string]*taskCanceler),
 	}
 }
 
@@ -134,7 +189,19 @@ func (b *BackupContext) getMilvusClient() *MilvusClient {
 	return b.milvusClient
 }
 
-func (b *BackupContext) getStorageClient() storage.ChunkManager {
+// getStorageClient returns the storage client of the role, both roles share the client unless crossStorage is enabled
+func (b *BackupContext) getStorageClient(role storage.StorageRole) storage.ChunkManager {
+	if role == storage.BackupStorage && b.params.MinioCfg.CrossStorage {
+		if b.backupStorageClient == nil {
+			storageClient, err := storage.NewChunkManagerOfRole(b.ctx, b.params, storage.BackupStorage)
+			if err != nil {
+				log.Error("failed to initial backup storage client", zap.Error(err))
+				panic(err)
+			}
+			b.backupStorageClient = &storageClient
+		}
+		return *b.backupStorageClient
+	}
 	if b.storageClient == nil {
 		storageClient, err := CreateStorageClient(b.ctx, b.params)
 		if err != nil {
@@ -146,6 +213,18 @@ func (b *BackupContext) getStorageClient() storage.ChunkManager {
 	return *b.storageClient
 }
 
+// copyBetweenStorages copies the files under fromPath of the storage of the from role to toPath of the storage of the to role,
+// by the server side copy if both roles share a storage, or by streaming the files otherwise.
+// The copy is throttled by the source, the streamed bytes are not counted again by the target.
+func (b *BackupContext) copyBetweenStorages(ctx context.Context, from storage.StorageRole, fromBucketName, fromPath string,
+	to storage.StorageRole, toBucketName, toPath string) error {
+	if b.getStorageClient(from) == b.getStorageClient(to) {
+		return b.getThrottledStorageClient(from).Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+	}
+	return storage.CopyBetweenStorages(ctx, b.getThrottledStorageClient(from), fromBucketName, fromPath,
+		b.getStorageClient(to), toBucketName, toPath)
+}
+
 func (b *BackupContext) getBackupCollectionWorkerPool() *common.WorkerPool {
 	if b.backupCollectionWorkerPool == nil {
 		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.BackupCollectionParallelism, RPS)
@@ -161,7 +240,8 @@ func (b *BackupContext) getBackupCollectionWorkerPool() *common.WorkerPool {
 
 func (b *BackupContext) getCopyDataWorkerPool() *common.WorkerPool {
 	if b.backupCopyDataWorkerPool == nil {
-		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.BackupCopyDataParallelism, RPS)
+		// the copies are not limited by the pool, the storage requests and bytes of them are limited by the throttle
+		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.BackupCopyDataParallelism, 0)
 		if err != nil {
 			log.Error("failed to initial copy data worker pool", zap.Error(err))
 			panic(err)
@@ -221,6 +301,9 @@ func (b *BackupContext) GetBackup(ctx context.Context, request *backuppb.GetBack
 		resp.Msg = "empty backup name and backup id, please set a backup name or id"
 	} else if request.GetBackupId() != "" {
 		backupInfo := b.meta.GetFullMeta(request.GetBackupId())
+		if backupInfo == nil {
+			backupInfo = b.meta.GetBackupHistory(request.GetBackupId())
+		}
 		resp.Code = backuppb.ResponseCode_Success
 		resp.Msg = "success"
 		resp.Data = backupInfo
@@ -251,6 +334,10 @@ func (b *BackupContext) GetBackup(ctx context.Context, request *backuppb.GetBack
 				resp.Msg = err.Error()
 			}
 
+			// the backup is not in storage if it failed, the task reloaded from the task store tells why
+			if backup == nil && err == nil {
+				backup = b.meta.GetBackupHistoryByName(request.GetBackupName())
+			}
 			resp.Data = backup
 			if backup == nil {
 				resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
@@ -307,7 +394,7 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 	}
 
 	// 1, trigger inner sync to get the newest backup list in the milvus cluster
-	backupPaths, _, err := b.getStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
+	backupPaths, _, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
 	if err != nil {
 		log.Error("Fail to list backup directory", zap.Error(err))
 		resp.Code = backuppb.ResponseCode_Fail
@@ -319,6 +406,10 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 	backupInfos := make([]*backuppb.BackupInfo, 0)
 	backupNames := make([]string, 0)
 	for _, backupPath := range backupPaths {
+		// shared blob area of deduplicated backups, not a backup
+		if BackupPathToName(b.backupRootPath, backupPath) == BLOB_DIR {
+			continue
+		}
 		backupResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
 			BackupName: BackupPathToName(b.backupRootPath, backupPath),
 		})
@@ -388,12 +479,47 @@ func (b *BackupContext) DeleteBackup(ctx context.Context, request *backuppb.Dele
 		resp.Msg = "empty backup name"
 		return resp
 	}
+	if request.GetBackupName() == BLOB_DIR {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "invalid backup name: " + BLOB_DIR
+		return resp
+	}
 
 	getResp := b.GetBackup(b.ctx, &backuppb.GetBackupRequest{
 		BackupName: request.GetBackupName(),
 	})
+	// incremental backups may reference the data of this backup, deleting it will break them
+	if getResp.GetData() != nil {
+		dependents, err := b.getDependentBackups(ctx, request.GetBackupName())
+		if err != nil {
+			log.Error("fail to check dependent backups", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		if len(dependents) > 0 {
+			errMsg := fmt.Sprintf("backup %s is referenced by incremental backups %v, delete them first", request.GetBackupName(), dependents)
+			log.Warn(errMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errMsg
+			return resp
+		}
+	}
+	// deduplicated backup, remove the blobs no other backup uses before the manifest is deleted.
+	// A failed backup has no backup meta but the blob manifest of its checkpoint, its blobs are released as well.
+	err := b.removeUnreferencedBlobs(ctx, request.GetBackupName())
+	if err != nil {
+		log.Error("fail to remove unreferenced blobs", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
 	// always trigger a remove to make sure it is deleted
-	err := b.getStorageClient().RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, request.GetBackupName()))
+	err = b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, request.GetBackupName()))
+	// the task of a deleted backup should not be found by name any more
+	if err == nil {
+		b.meta.RemoveBackupsByName(request.GetBackupName())
+	}
 
 	if getResp.GetCode() == backuppb.ResponseCode_Request_Object_Not_Found {
 		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
@@ -427,6 +553,27 @@ func (b *BackupContext) DeleteBackup(ctx context.Context, request *backuppb.Dele
 	return resp
 }
 
+// getDependentBackups returns the names of backups which reference the data of the given backup
+func (b *BackupContext) getDependentBackups(ctx context.Context, backupName string) ([]string, error) {
+	listResp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{})
+	if listResp.GetCode() != backuppb.ResponseCode_Success {
+		return nil, errors.New(listResp.GetMsg())
+	}
+	dependents := make([]string, 0)
+	for _, backup := range listResp.GetData() {
+		if backup.GetName() == backupName {
+			continue
+		}
+		for _, refBackup := range collectRefBackups(backup) {
+			if refBackup == backupName {
+				dependents = append(dependents, backup.GetName())
+				break
+			}
+		}
+	}
+	return dependents, nil
+}
+
 func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backupPath string) (*backuppb.BackupInfo, error) {
 	backupMetaDirPath := backupPath + SEPERATOR + META_PREFIX
 	backupMetaPath := backupMetaDirPath + SEPERATOR + BACKUP_META_FILE
@@ -434,7 +581,7 @@ func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backu
 	partitionMetaPath := backupMetaDirPath + SEPERATOR + PARTITION_META_FILE
 	segmentMetaPath := backupMetaDirPath + SEPERATOR + SEGMENT_META_FILE
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, backupMetaPath)
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, backupMetaPath)
 	if err != nil {
 		log.Error("check backup meta file failed", zap.String("path", backupMetaPath), zap.Error(err))
 		return nil, err
@@ -444,22 +591,38 @@ func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backu
 		return nil, err
 	}
 
-	backupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, backupMetaPath)
+	encryptionInfo, err := b.readEncryptionMeta(ctx, bucketName, backupPath)
+	if err != nil {
+		log.Error("Read backup encryption meta failed", zap.String("backupPath", backupPath), zap.Error(err))
+		return nil, err
+	}
+	dataKey, err := b.backupDataKey(encryptionInfo)
+	if err != nil {
+		log.Error("Fail to get the data key of backup", zap.String("backupPath", backupPath), zap.Error(err))
+		return nil, err
+	}
+	metaCompression, err := b.readCompressionMeta(ctx, bucketName, backupPath)
+	if err != nil {
+		log.Error("Read backup compression meta failed", zap.String("backupPath", backupPath), zap.Error(err))
+		return nil, err
+	}
+
+	backupMetaBytes, err := b.readBackupMetaFile(ctx, bucketName, backupMetaPath, dataKey, metaCompression)
 	if err != nil {
 		log.Error("Read backup meta failed", zap.String("path", backupMetaPath), zap.Error(err))
 		return nil, err
 	}
-	collectionBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, collectionMetaPath)
+	collectionBackupMetaBytes, err := b.readBackupMetaFile(ctx, bucketName, collectionMetaPath, dataKey, metaCompression)
 	if err != nil {
 		log.Error("Read collection meta failed", zap.String("path", collectionMetaPath), zap.Error(err))
 		return nil, err
 	}
-	partitionBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, partitionMetaPath)
+	partitionBackupMetaBytes, err := b.readBackupMetaFile(ctx, bucketName, partitionMetaPath, dataKey, metaCompression)
 	if err != nil {
 		log.Error("Read partition meta failed", zap.String("path", partitionMetaPath), zap.Error(err))
 		return nil, err
 	}
-	segmentBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, segmentMetaPath)
+	segmentBackupMetaBytes, err := b.readBackupMetaFile(ctx, bucketName, segmentMetaPath, dataKey, metaCompression)
 	if err != nil {
 		log.Error("Read segment meta failed", zap.String("path", segmentMetaPath), zap.Error(err))
 		return nil, err
@@ -541,8 +704,12 @@ func (b *BackupContext) Check(ctx context.Context) string {
 			"backup-bucket: %s\n"+
 			"backup-rootpath: %s\n",
 		version, b.milvusBucketName, b.milvusRootPath, b.backupBucketName, b.backupRootPath)
+	if b.params.MinioCfg.CrossStorage {
+		info += fmt.Sprintf("backup-storage: %s %s:%s\n",
+			b.params.MinioCfg.BackupStorageType, b.params.MinioCfg.BackupAddress, b.params.MinioCfg.BackupPort)
+	}
 
-	paths, _, err := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR, false)
+	paths, _, err := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR, false)
 	if err != nil {
 		return "Failed to connect to storage milvus path\n" + info + err.Error()
 	}
@@ -551,27 +718,28 @@ func (b *BackupContext) Check(ctx context.Context) string {
 		return "Milvus storage is empty. Please verify whether your cluster is really empty. If not, the configs(minio address, port, bucket, rootPath) may be wrong\n" + info
 	}
 
-	paths, _, err = b.getStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
+	paths, _, err = b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
 	if err != nil {
 		return "Failed to connect to storage backup path " + info + err.Error()
 	}
 
 	CHECK_PATH := "milvus_backup_check_" + time.Now().String()
 
-	err = b.getStorageClient().Write(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH, []byte{1})
+	err = b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH, []byte{1})
 	if err != nil {
 		return "Failed to connect to storage milvus path\n" + info + err.Error()
 	}
 	defer func() {
-		b.getStorageClient().Remove(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH)
+		b.getStorageClient(storage.MilvusStorage).Remove(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH)
 	}()
 
-	err = b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH, b.backupRootPath+SEPERATOR+CHECK_PATH)
+	err = b.copyBetweenStorages(ctx, storage.MilvusStorage, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH,
+		storage.BackupStorage, b.backupBucketName, b.backupRootPath+SEPERATOR+CHECK_PATH)
 	if err != nil {
 		return "Failed to copy file from milvus storage to backup storage\n" + info + err.Error()
 	}
 	defer func() {
-		b.getStorageClient().Remove(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+CHECK_PATH)
+		b.getStorageClient(storage.BackupStorage).Remove(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+CHECK_PATH)
 	}()
 
 	return "Succeed to connect to milvus and storage.\n" + info
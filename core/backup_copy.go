@@ -0,0 +1,327 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+// backupCopyTarget is the storage a backup is copied into
+type backupCopyTarget struct {
+	storageClient storage.ChunkManager
+	bucketName    string
+	rootPath      string
+}
+
+func (b *BackupContext) CopyBackup(ctx context.Context, request *backuppb.CopyBackupRequest) *backuppb.CopyBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive CopyBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.String("bucketName", request.GetBucketName()),
+		zap.String("path", request.GetPath()),
+		zap.String("target", request.GetTarget()),
+		zap.String("targetBucketName", request.GetTargetBucketName()),
+		zap.String("targetPath", request.GetTargetPath()),
+		zap.Bool("checksum", request.GetChecksum()))
+
+	resp := &backuppb.CopyBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	var backupBucketName string
+	var backupRootPath string
+	if request.GetBucketName() == "" || request.GetPath() == "" {
+		backupBucketName = b.backupBucketName
+		backupRootPath = b.backupRootPath
+	} else {
+		backupBucketName = request.GetBucketName()
+		backupRootPath = request.GetPath()
+	}
+
+	target := &backupCopyTarget{}
+	if request.GetTarget() == "" {
+		target.storageClient = b.getStorageClient(storage.BackupStorage)
+		target.bucketName = b.backupBucketName
+		target.rootPath = b.backupRootPath
+	} else {
+		found := false
+		for _, targetConfig := range b.params.BackupCfg.CopyTargets {
+			if targetConfig.Name != request.GetTarget() {
+				continue
+			}
+			found = true
+			storageClient, err := storage.NewCopyTargetChunkManager(ctx, targetConfig)
+			if err != nil {
+				log.Error("fail to create the storage client of copy target", zap.String("target", targetConfig.Name), zap.Error(err))
+				resp.Code = backuppb.ResponseCode_Fail
+				resp.Msg = err.Error()
+				return resp
+			}
+			target.storageClient = storageClient
+			target.bucketName = targetConfig.BucketName
+			target.rootPath = targetConfig.RootPath
+		}
+		if !found {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = "copy target not found in config: " + request.GetTarget()
+			return resp
+		}
+	}
+	if request.GetTargetBucketName() != "" && request.GetTargetPath() != "" {
+		target.bucketName = request.GetTargetBucketName()
+		target.rootPath = request.GetTargetPath()
+	}
+	if request.GetTarget() == "" && target.bucketName == backupBucketName && target.rootPath == backupRootPath {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "the target of copy is the same as the source, set target or target_bucket_name and target_path"
+		return resp
+	}
+
+	backupPath := backupRootPath + SEPERATOR + request.GetBackupName()
+	backup, err := b.readBackup(ctx, backupBucketName, backupPath)
+	if err != nil {
+		log.Error("fail to read backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if backup == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = "not found backup " + request.GetBackupName()
+		return resp
+	}
+	if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("backup %s is not complete, state: %s", request.GetBackupName(), backup.GetStateCode().String())
+		return resp
+	}
+
+	result, err := b.copyBackup(ctx, backupBucketName, backupPath, backup, target, request.GetChecksum())
+	if err != nil {
+		log.Error("fail to copy backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		resp.Data = result
+		return resp
+	}
+	result.Target = request.GetTarget()
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = fmt.Sprintf("copy backup %s to %s/%s, %d files copied, %d files already exist, %d files verified",
+		request.GetBackupName(), target.bucketName, target.rootPath, result.GetCopiedFiles(), result.GetSkippedFiles(), result.GetVerifiedFiles())
+	resp.Data = result
+	log.Info("finish CopyBackup", zap.String("requestId", request.GetRequestId()), zap.String("msg", resp.Msg))
+	return resp
+}
+
+// copyBackup copies the backup into the target, together with the backups referenced by its segments which are not in the target yet.
+// Files already in the target with the same size are skipped, so that an interrupted copy is resumed by copying again.
+func (b *BackupContext) copyBackup(ctx context.Context, bucketName, backupPath string, backup *backuppb.BackupInfo, target *backupCopyTarget, checksum bool) (*backuppb.CopyBackupResult, error) {
+	backupRootPath := backupPath[:strings.LastIndex(backupPath, SEPERATOR)]
+	backupName := backupPath[strings.LastIndex(backupPath, SEPERATOR)+1:]
+	result := &backuppb.CopyBackupResult{
+		BackupName:       backupName,
+		TargetBucketName: target.bucketName,
+		TargetPath:       target.rootPath,
+		Backups:          make([]string, 0),
+		SkippedBackups:   make([]string, 0),
+	}
+
+	exist, err := target.storageClient.Exist(ctx, target.bucketName, BackupMetaPath(target.rootPath, backupName))
+	if err != nil {
+		return result, err
+	}
+	if exist {
+		return result, fmt.Errorf("backup %s already exists in the target", backupName)
+	}
+
+	visited := make(map[string]bool, 0)
+	var copyWithRefs func(name string, backup *backuppb.BackupInfo) error
+	copyWithRefs = func(name string, backup *backuppb.BackupInfo) error {
+		visited[name] = true
+		for _, refBackup := range collectRefBackups(backup) {
+			if visited[refBackup] {
+				continue
+			}
+			exist, err := target.storageClient.Exist(ctx, target.bucketName, BackupMetaPath(target.rootPath, refBackup))
+			if err != nil {
+				return err
+			}
+			if exist {
+				log.Info("referenced backup already exists in the target, skip copy", zap.String("backupName", refBackup))
+				visited[refBackup] = true
+				result.SkippedBackups = append(result.SkippedBackups, refBackup)
+				continue
+			}
+			refBackupInfo, err := b.readBackup(ctx, bucketName, backupRootPath+SEPERATOR+refBackup)
+			if err != nil {
+				return err
+			}
+			if refBackupInfo == nil {
+				return fmt.Errorf("backup %s referenced by %s not found", refBackup, name)
+			}
+			if err := copyWithRefs(refBackup, refBackupInfo); err != nil {
+				return err
+			}
+		}
+		if err := b.copyBackupFiles(ctx, bucketName, backupRootPath, name, backup, target, checksum, result); err != nil {
+			return err
+		}
+		result.Backups = append(result.Backups, name)
+		return nil
+	}
+	return result, copyWithRefs(backupName, backup)
+}
+
+// copyBackupFiles copies the meta, binlogs and blobs of a backup into the target by the copy data worker pool, then verifies
+// the copied files. The backup meta is copied at last, the backup doesn't exist in the target until it is verified.
+// Files failing the verification are removed from the target, so that they are copied again on retry.
+func (b *BackupContext) copyBackupFiles(ctx context.Context, bucketName, backupRootPath, backupName string, backup *backuppb.BackupInfo, target *backupCopyTarget, checksum bool, result *backuppb.CopyBackupResult) error {
+	source := b.getStorageClient(storage.BackupStorage)
+	copyFile := func(ctx context.Context, fromPath string, size int64, toPath string) error {
+		return retry.Do(ctx, func() error {
+			throttledSource := b.getThrottledStorageClient(storage.BackupStorage)
+			if source == target.storageClient {
+				return throttledSource.Copy(ctx, bucketName, target.bucketName, fromPath, toPath)
+			}
+			return storage.CopyObjectBetweenStorages(ctx, throttledSource, bucketName, fromPath, size, target.storageClient, target.bucketName, toPath)
+		}, b.storageRetryOptions()...)
+	}
+	targetPathOf := func(sourcePath string) string {
+		return target.rootPath + SEPERATOR + strings.TrimPrefix(sourcePath, backupRootPath+SEPERATOR)
+	}
+
+	sourcePaths := make([]string, 0)
+	sourceSizes := make([]int64, 0)
+	for _, prefix := range []string{BackupMetaDirPath(backupRootPath, backupName), BackupBinlogDirPath(backupRootPath, backupName)} {
+		keys, sizes, err := source.ListWithPrefix(ctx, bucketName, prefix+SEPERATOR, true)
+		if err != nil {
+			return err
+		}
+		sourcePaths = append(sourcePaths, keys...)
+		sourceSizes = append(sourceSizes, sizes...)
+	}
+	manifest, err := b.readBlobManifest(ctx, bucketName, backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return err
+	}
+	if manifest != nil {
+		blobs := make(map[string]bool, 0)
+		for _, ref := range manifest.Files {
+			if !blobs[ref.Hash] {
+				blobs[ref.Hash] = true
+				sourcePaths = append(sourcePaths, BlobPath(backupRootPath, ref.Hash))
+				sourceSizes = append(sourceSizes, ref.Size)
+			}
+		}
+	}
+
+	targetSizes := make(map[string]int64, 0)
+	targetPrefixes := []string{BackupDirPath(target.rootPath, backupName)}
+	if manifest != nil {
+		targetPrefixes = append(targetPrefixes, BlobDirPath(target.rootPath)+SEPERATOR)
+	}
+	for _, prefix := range targetPrefixes {
+		keys, sizes, err := target.storageClient.ListWithPrefix(ctx, target.bucketName, prefix, true)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			targetSizes[key] = sizes[i]
+		}
+	}
+
+	backupMetaPath := BackupMetaPath(backupRootPath, backupName)
+	backupMetaSize := int64(-1)
+	jobIds := make([]int64, 0)
+	for i, sourcePath := range sourcePaths {
+		if sourcePath == backupMetaPath {
+			backupMetaSize = sourceSizes[i]
+			continue
+		}
+		targetPath := targetPathOf(sourcePath)
+		if size, ok := targetSizes[targetPath]; ok && size == sourceSizes[i] {
+			log.Debug("file already exists in the target, skip copy", zap.String("path", targetPath))
+			result.SkippedFiles++
+			continue
+		}
+		fromPath, size := sourcePath, sourceSizes[i]
+		job := func(ctx context.Context) error {
+			return copyFile(ctx, fromPath, size, targetPath)
+		}
+		jobIds = append(jobIds, b.getCopyDataWorkerPool().SubmitWithId(cancelableJob(ctx, job)))
+		result.CopiedFiles++
+		result.CopiedSize += size
+	}
+	if backupMetaSize < 0 {
+		return fmt.Errorf("backup meta of %s not found", backupName)
+	}
+	if err := b.getCopyDataWorkerPool().WaitJobs(ctx, jobIds); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	verifier := &BackupContext{
+		ctx:     b.ctx,
+		params:  b.params,
+		started: true,
+		// the target is verified as the backup storage of the verifier
+		storageClient:       &target.storageClient,
+		backupStorageClient: &target.storageClient,
+		backupBucketName:    target.bucketName,
+		backupRootPath:      target.rootPath,
+		meta:                newMetaManager(),
+	}
+	verification, err := verifier.verifyBackup(ctx, target.bucketName, target.rootPath+SEPERATOR+backupName, backup, checksum)
+	if err != nil {
+		return err
+	}
+	result.VerifiedFiles += verification.GetCheckedFiles()
+	if len(verification.GetProblems()) > 0 {
+		for _, problem := range verification.GetProblems() {
+			log.Warn("copied file failed the verification", zap.String("path", problem.GetPath()), zap.String("state", problem.GetState().String()))
+			if problem.GetState() == backuppb.FileVerifyState_FILE_MISSING {
+				continue
+			}
+			if err := target.storageClient.Remove(ctx, target.bucketName, problem.GetPath()); err != nil {
+				log.Error("fail to remove the file failed the verification", zap.String("path", problem.GetPath()), zap.Error(err))
+			}
+		}
+		return fmt.Errorf("%d of %d files of backup %s failed the verification after copy, copy again to retry them",
+			len(verification.GetProblems()), verification.GetCheckedFiles(), backupName)
+	}
+
+	if err := copyFile(ctx, backupMetaPath, backupMetaSize, targetPathOf(backupMetaPath)); err != nil {
+		return err
+	}
+	result.CopiedFiles++
+	result.CopiedSize += backupMetaSize
+	return nil
+}
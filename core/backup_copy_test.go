@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func TestCopyBackup(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+	b.params.BackupCfg.BackupCopyDataParallelism = 4
+
+	newSegment := func(segmentID int64, refBackup string) *backuppb.SegmentBackupInfo {
+		return &backuppb.SegmentBackupInfo{
+			CollectionId: 1,
+			PartitionId:  2,
+			SegmentId:    segmentID,
+			GroupId:      segmentID,
+			RefBackup:    refBackup,
+			Binlogs: []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{
+				{LogPath: fmt.Sprintf("%s/insert_log/1/2/%d/100/1", b.milvusRootPath, segmentID)},
+			}}},
+		}
+	}
+	newBackup := func(name string, segments ...*backuppb.SegmentBackupInfo) *backuppb.BackupInfo {
+		return &backuppb.BackupInfo{
+			Name:      name,
+			StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+			CollectionBackups: []*backuppb.CollectionBackupInfo{{
+				CollectionId:   1,
+				CollectionName: "coll",
+				PartitionBackups: []*backuppb.PartitionBackupInfo{{
+					CollectionId:   1,
+					PartitionId:    2,
+					SegmentBackups: segments,
+				}},
+			}},
+		}
+	}
+	// writeBinlog writes the binlog of the segment into backup and records its checksum
+	writeBinlog := func(backupName string, segmentID int64, content string) {
+		relativePath := backupBinlogRelativePath(newSegment(segmentID, "").GetBinlogs()[0].GetBinlogs()[0].GetLogPath(), segmentID)
+		filePath := b.backupRootPath + SEPERATOR + backupName + SEPERATOR + relativePath
+		assert.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, filePath, []byte(content)))
+		checksum, err := b.fileChecksum(ctx, storage.BackupStorage, b.backupBucketName, filePath)
+		assert.NoError(t, err)
+		manifest, err := json.Marshal(&ChecksumManifest{Files: map[string]FileChecksum{relativePath: checksum}})
+		assert.NoError(t, err)
+		assert.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, ChecksumManifestPath(b.backupRootPath, backupName), manifest))
+	}
+
+	// incr references the segment 3 of full
+	writeTestBackupMeta(t, b, newBackup("full", newSegment(3, "")))
+	writeBinlog("full", 3, "binlog of segment 3")
+	writeTestBackupMeta(t, b, newBackup("incr", newSegment(3, "full"), newSegment(4, "")))
+	writeBinlog("incr", 4, "binlog of segment 4")
+
+	// copy into another storage client so that files are streamed between storages
+	targetClient, err := storage.NewChunkManager(ctx, b.params)
+	assert.NoError(t, err)
+	target := &backupCopyTarget{
+		storageClient: targetClient,
+		bucketName:    b.backupBucketName,
+		rootPath:      b.backupRootPath + "-copy",
+	}
+	listFiles := func(rootPath string) map[string]string {
+		keys, _, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, rootPath+SEPERATOR, true)
+		assert.NoError(t, err)
+		files := make(map[string]string, len(keys))
+		for _, key := range keys {
+			content, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, key)
+			assert.NoError(t, err)
+			files[strings.TrimPrefix(key, rootPath+SEPERATOR)] = string(content)
+		}
+		return files
+	}
+
+	backupPath := b.backupRootPath + SEPERATOR + "incr"
+	backup, err := b.readBackup(ctx, b.backupBucketName, backupPath)
+	assert.NoError(t, err)
+	result, err := b.copyBackup(ctx, b.backupBucketName, backupPath, backup, target, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full", "incr"}, result.GetBackups())
+	// the binlog of full is verified again as a binlog referenced by incr
+	assert.Equal(t, int64(3), result.GetVerifiedFiles())
+	expected := listFiles(b.backupRootPath)
+	assert.Equal(t, int64(len(expected)), result.GetCopiedFiles())
+	assert.Equal(t, expected, listFiles(target.rootPath))
+
+	_, err = b.copyBackup(ctx, b.backupBucketName, backupPath, backup, target, true)
+	assert.ErrorContains(t, err, "backup incr already exists")
+
+	// interrupted copy with a missing and a corrupted file, the corrupted one is found by the verification and removed
+	targetBinlogPath := target.rootPath + "/incr/binlogs/insert_log/1/2/4/4/100/1"
+	assert.NoError(t, targetClient.Remove(ctx, target.bucketName, BackupMetaPath(target.rootPath, "incr")))
+	assert.NoError(t, targetClient.Remove(ctx, target.bucketName, CollectionMetaPath(target.rootPath, "incr")))
+	assert.NoError(t, targetClient.Write(ctx, target.bucketName, targetBinlogPath, []byte("binlog of segment x")))
+	_, err = b.copyBackup(ctx, b.backupBucketName, backupPath, backup, target, true)
+	assert.ErrorContains(t, err, "failed the verification")
+	exist, err := targetClient.Exist(ctx, target.bucketName, targetBinlogPath)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// copy again to resume
+	result, err = b.copyBackup(ctx, b.backupBucketName, backupPath, backup, target, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full"}, result.GetSkippedBackups())
+	assert.Equal(t, []string{"incr"}, result.GetBackups())
+	// the binlog removed by the verification and the backup meta
+	assert.Equal(t, int64(2), result.GetCopiedFiles())
+	assert.Less(t, int64(0), result.GetSkippedFiles())
+	assert.Equal(t, expected, listFiles(target.rootPath))
+}
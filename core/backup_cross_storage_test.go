@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+// newCrossStorageTestContext returns a context whose milvus storage and backup storage are different memory storages,
+// the buckets of both storages have the same name
+func newCrossStorageTestContext(t *testing.T) *BackupContext {
+	ctx := context.Background()
+	var params paramtable.BackupParams
+	params.MinioCfg.CrossStorage = true
+	milvusStorage, err := storage.NewMemoryChunkManager(ctx, nil)
+	require.NoError(t, err)
+	backupStorage, err := storage.NewMemoryChunkManager(ctx, nil)
+	require.NoError(t, err)
+	var milvusClient, backupClient storage.ChunkManager = milvusStorage, backupStorage
+	return &BackupContext{
+		ctx:                 ctx,
+		params:              params,
+		storageClient:       &milvusClient,
+		backupStorageClient: &backupClient,
+		milvusBucketName:    "bucket",
+		backupBucketName:    "bucket",
+		milvusRootPath:      "files",
+		backupRootPath:      "backup",
+		meta:                newMetaManager(),
+	}
+}
+
+func storageExist(t *testing.T, b *BackupContext, role storage.StorageRole, bucketName, filePath string) bool {
+	exist, err := b.getStorageClient(role).Exist(context.Background(), bucketName, filePath)
+	require.NoError(t, err)
+	return exist
+}
+
+func TestCrossStorageCopyBinlog(t *testing.T) {
+	ctx := context.Background()
+	b := newCrossStorageTestContext(t)
+	addFaultTestBackup(b, "b1")
+
+	for logID, codec := range []string{compression.None, compression.Gzip} {
+		binlogPath := fmt.Sprintf("files/insert_log/1/2/3/100/%d", logID)
+		targetPath := fmt.Sprintf("backup/b1/binlogs/insert_log/1/2/3/3/100/%d", logID)
+		require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, []byte("binlog")))
+		require.NoError(t, b.copyBinlog(ctx, "b1", binlogPath, targetPath, codec))
+
+		// the binlog is written into the backup storage although the bucket names are the same
+		assert.True(t, storageExist(t, b, storage.BackupStorage, b.backupBucketName, targetPath))
+		assert.False(t, storageExist(t, b, storage.MilvusStorage, b.milvusBucketName, targetPath))
+		assert.False(t, storageExist(t, b, storage.BackupStorage, b.backupBucketName, binlogPath))
+	}
+}
+
+func TestCrossStorageCopyToMilvus(t *testing.T) {
+	ctx := context.Background()
+	b := newCrossStorageTestContext(t)
+
+	// a bucket given by the request is in the backup storage as well
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, "other", "backup/b1/binlogs/1", []byte("binlog")))
+	err := b.copyBetweenStorages(ctx, storage.BackupStorage, "other", "backup/b1/binlogs/", storage.MilvusStorage, b.milvusBucketName, "files/restore_temp/")
+	require.NoError(t, err)
+
+	data, err := b.getStorageClient(storage.MilvusStorage).Read(ctx, b.milvusBucketName, "files/restore_temp/1")
+	require.NoError(t, err)
+	assert.Equal(t, "binlog", string(data))
+	assert.False(t, storageExist(t, b, storage.BackupStorage, b.backupBucketName, "files/restore_temp/1"))
+}
+
+func TestSharedStorageCopy(t *testing.T) {
+	ctx := context.Background()
+	b := newCrossStorageTestContext(t)
+	b.params.MinioCfg.CrossStorage = false
+
+	// both roles are served by the milvus storage unless crossStorage is enabled
+	assert.Equal(t, b.getStorageClient(storage.MilvusStorage), b.getStorageClient(storage.BackupStorage))
+	require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, "files/insert_log/1", []byte("binlog")))
+	err := b.copyBetweenStorages(ctx, storage.MilvusStorage, b.milvusBucketName, "files/insert_log/", storage.BackupStorage, b.backupBucketName, "backup/b1/binlogs/")
+	require.NoError(t, err)
+	assert.True(t, storageExist(t, b, storage.MilvusStorage, b.milvusBucketName, "backup/b1/binlogs/1"))
+}
+
+func TestCopyBinlogChecksum(t *testing.T) {
+	ctx := context.Background()
+	b := newCrossStorageTestContext(t)
+	addFaultTestBackup(b, "b1")
+	binlogPath := "files/insert_log/1/2/3/100/1"
+	require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, []byte("binlog")))
+	sum := sha256.Sum256([]byte("binlog"))
+	expected := FileChecksum{Size: 6, Sha256: hex.EncodeToString(sum[:])}
+	checksumOf := func(targetPath string) FileChecksum {
+		_, relativePath := splitBackupBinlogPath(targetPath)
+		return b.meta.GetChecksumManifest("b1").Files[relativePath]
+	}
+
+	// the checksum is computed while streaming the binlog into the backup storage
+	targetPath := "backup/b1/binlogs/insert_log/1/2/3/3/100/1"
+	require.NoError(t, b.copyBinlog(ctx, "b1", binlogPath, targetPath, compression.None))
+	assert.Equal(t, expected, checksumOf(targetPath))
+
+	// only the size is known for the server side copy, unless the binlog is read back
+	b.params.MinioCfg.CrossStorage = false
+	targetPath = "backup/b1/binlogs/insert_log/1/2/3/3/100/2"
+	require.NoError(t, b.copyBinlog(ctx, "b1", binlogPath, targetPath, compression.None))
+	assert.Equal(t, FileChecksum{Size: 6}, checksumOf(targetPath))
+
+	b.params.BackupCfg.ChecksumReadBack = true
+	targetPath = "backup/b1/binlogs/insert_log/1/2/3/3/100/3"
+	require.NoError(t, b.copyBinlog(ctx, "b1", binlogPath, targetPath, compression.None))
+	assert.Equal(t, expected, checksumOf(targetPath))
+}
@@ -0,0 +1,332 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+const (
+	// BLOB_DIR is the shared blob area under backupRootPath, binlogs of deduplicated backups are stored here by content hash.
+	// It starts with '.' so that it never conflicts with a backup name.
+	BLOB_DIR           = ".blobs"
+	BLOB_MANIFEST_FILE = "blob_manifest.json"
+)
+
+// BlobManifest records the binlogs of a deduplicated backup.
+// The key of Files is the path relative to the backup directory, e.g. binlogs/insert_log/1/2/3/4/100/5
+type BlobManifest struct {
+	Files map[string]BlobRef `json:"files"`
+}
+
+type BlobRef struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+func BlobDirPath(backupRootPath string) string {
+	return backupRootPath + SEPERATOR + BLOB_DIR
+}
+
+// BlobPath returns the path of a blob, blobs are spread into sub directories by the first two characters of hash
+func BlobPath(backupRootPath, hash string) string {
+	return BlobDirPath(backupRootPath) + SEPERATOR + hash[:2] + SEPERATOR + hash
+}
+
+func BlobManifestPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + BLOB_MANIFEST_FILE
+}
+
+// isUnderDir checks whether the path is the dir or a path under it, matching whole path segments
+// so that the dir of segment 1 doesn't contain the binlogs of segment 12
+func isUnderDir(path, dir string) bool {
+	dir = strings.TrimSuffix(dir, SEPERATOR)
+	return path == dir || strings.HasPrefix(path, dir+SEPERATOR)
+}
+
+// splitBackupBinlogPath splits a binlog path of backup into the backup path and the path relative to the backup,
+// backup_root/backup_name/binlogs/insert_log/... => backup_root/backup_name, binlogs/insert_log/...
+func splitBackupBinlogPath(binlogPath string) (string, string) {
+	index := strings.Index(binlogPath, SEPERATOR+BINGLOG_DIR+SEPERATOR)
+	if index < 0 {
+		return "", binlogPath
+	}
+	return binlogPath[:index], binlogPath[index+1:]
+}
+
+// checkDedupSupported returns the error if dedup is enabled for a backup whose binlogs are encrypted or compressed.
+// Encoded binlogs are copied in full, so the combination is rejected on creating and resuming a backup rather than dropping dedup.
+func (b *BackupContext) checkDedupSupported(encrypted bool, binlogCompression string) error {
+	if !b.params.BackupCfg.DedupEnable {
+		return nil
+	}
+	if encrypted {
+		return errors.New("encryption is not supported together with dedup")
+	}
+	if binlogCompression != compression.None {
+		return errors.New("binlog compression is not supported together with dedup")
+	}
+	return nil
+}
+
+// copyBinlog copies a binlog of milvus into backup, binlog is compressed by the codec and encrypted if the backup is encrypted,
+// or stored into the shared blob area if dedup is enabled. Dedup of the encoded binlogs is rejected by checkDedupSupported.
+// The checksum of the copied binlog is recorded, the blob ref is the checksum of a deduplicated binlog.
+func (b *BackupContext) copyBinlog(ctx context.Context, backupID string, fromPath, targetPath string, codec string) error {
+	if encryptionInfo := b.meta.GetBackup(backupID).GetEncryption(); encryptionInfo != nil || codec != compression.None {
+		dataKey, err := b.backupDataKey(encryptionInfo)
+		if err != nil {
+			return err
+		}
+		var checksum FileChecksum
+		err = retry.Do(ctx, func() error {
+			var err error
+			checksum, err = b.copyBinlogEncoded(ctx, dataKey, codec, fromPath, targetPath)
+			return err
+		}, b.storageRetryOptions()...)
+		if err != nil {
+			return err
+		}
+		return b.recordFileChecksum(ctx, backupID, targetPath, checksum)
+	}
+
+	if !b.params.BackupCfg.DedupEnable {
+		var checksum FileChecksum
+		err := retry.Do(ctx, func() error {
+			var err error
+			checksum, err = b.copyBinlogPlain(ctx, fromPath, targetPath)
+			return err
+		}, b.storageRetryOptions()...)
+		if err != nil {
+			return err
+		}
+		return b.recordFileChecksum(ctx, backupID, targetPath, checksum)
+	}
+
+	_, relativePath := splitBackupBinlogPath(targetPath)
+	return retry.Do(ctx, func() error {
+		// the binlog is hashed as a stream, it is copied into the blob area only if the blob doesn't exist
+		checksum, err := b.fileChecksum(ctx, storage.MilvusStorage, b.milvusBucketName, fromPath)
+		if err != nil {
+			return err
+		}
+		blobPath := BlobPath(b.backupRootPath, checksum.Sha256)
+		exist, err := b.getThrottledStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, blobPath)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			err = b.copyBetweenStorages(ctx, storage.MilvusStorage, b.milvusBucketName, fromPath, storage.BackupStorage, b.backupBucketName, blobPath)
+			if err != nil {
+				return err
+			}
+		} else {
+			log.Debug("blob already exist, skip write", zap.String("from", fromPath), zap.String("blob", blobPath))
+		}
+		b.meta.AddBlobRef(backupID, relativePath, BlobRef{Hash: checksum.Sha256, Size: checksum.Size})
+		return nil
+	}, b.storageRetryOptions()...)
+}
+
+// copyBinlogPlain copies a binlog of milvus into backup as it is, the checksum is computed from the streamed content.
+// The content doesn't pass through backup if both storages are the same and the binlog is copied by the server side copy,
+// only the size of the binlog is recorded then.
+func (b *BackupContext) copyBinlogPlain(ctx context.Context, fromPath, targetPath string) (FileChecksum, error) {
+	storageClient := b.getThrottledStorageClient(storage.MilvusStorage)
+	size, err := storageClient.Size(ctx, b.milvusBucketName, fromPath)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	if b.getStorageClient(storage.MilvusStorage) == b.getStorageClient(storage.BackupStorage) {
+		err = storageClient.Copy(ctx, b.milvusBucketName, b.backupBucketName, fromPath, targetPath)
+		return FileChecksum{Size: size}, err
+	}
+	reader, err := storageClient.Reader(ctx, b.milvusBucketName, fromPath)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	defer reader.Close()
+	checksumReader := newChecksumReader(reader)
+	err = b.getStorageClient(storage.BackupStorage).WriteFrom(ctx, b.backupBucketName, targetPath, checksumReader, size)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	return checksumReader.checksum(), nil
+}
+
+// readBlobManifest reads the blob manifest of the backup, return nil if the backup is not deduplicated
+func (b *BackupContext) readBlobManifest(ctx context.Context, bucketName, backupPath string) (*BlobManifest, error) {
+	manifestPath := backupPath + SEPERATOR + META_PREFIX + SEPERATOR + BLOB_MANIFEST_FILE
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	bytes, err := b.getStorageClient(storage.BackupStorage).Read(ctx, bucketName, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BlobManifest{}
+	err = json.Unmarshal(bytes, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// countBlobRefs returns the reference count of every blob among all the backups except the excluded one,
+// including the backups being created which only have the manifest in memory
+func (b *BackupContext) countBlobRefs(ctx context.Context, excludeBackup string) (map[string]int, error) {
+	refs := make(map[string]int, 0)
+	backupPaths, _, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, backupPath := range backupPaths {
+		backupName := BackupPathToName(b.backupRootPath, backupPath)
+		if backupName == BLOB_DIR || backupName == excludeBackup {
+			continue
+		}
+		manifest, err := b.readBlobManifest(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+		if err != nil {
+			return nil, err
+		}
+		if manifest == nil {
+			continue
+		}
+		for _, ref := range manifest.Files {
+			refs[ref.Hash]++
+		}
+	}
+	for backupID, manifest := range b.meta.GetBlobManifests() {
+		backup := b.meta.GetBackup(backupID)
+		// finished backups have been counted from storage
+		if backup == nil || backup.GetName() == excludeBackup ||
+			(backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_INITIAL && backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_EXECUTING) {
+			continue
+		}
+		for _, ref := range manifest.Files {
+			refs[ref.Hash]++
+		}
+	}
+	return refs, nil
+}
+
+// removeUnreferencedBlobs removes the blobs of the backup which are not referenced by any other backup
+func (b *BackupContext) removeUnreferencedBlobs(ctx context.Context, backupName string) error {
+	manifest, err := b.readBlobManifest(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return nil
+	}
+	refs, err := b.countBlobRefs(ctx, backupName)
+	if err != nil {
+		return err
+	}
+	removed := make(map[string]bool, 0)
+	for _, ref := range manifest.Files {
+		if refs[ref.Hash] > 0 || removed[ref.Hash] {
+			continue
+		}
+		err := b.getStorageClient(storage.BackupStorage).Remove(ctx, b.backupBucketName, BlobPath(b.backupRootPath, ref.Hash))
+		if err != nil {
+			return err
+		}
+		removed[ref.Hash] = true
+	}
+	log.Info("remove unreferenced blobs",
+		zap.String("backupName", backupName),
+		zap.Int("fileNum", len(manifest.Files)),
+		zap.Int("removedBlobNum", len(removed)))
+	return nil
+}
+
+// binlogDirExist checks whether a binlog directory of backup exists,
+// binlogs of deduplicated backups are only recorded in the blob manifest
+func (b *BackupContext) binlogDirExist(ctx context.Context, bucketName, binlogDir string) (bool, error) {
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, binlogDir)
+	if err != nil || exist {
+		return exist, err
+	}
+	backupPath, relativeDir := splitBackupBinlogPath(binlogDir)
+	if backupPath == "" {
+		return false, nil
+	}
+	manifest, err := b.readBlobManifest(ctx, bucketName, backupPath)
+	if err != nil || manifest == nil {
+		return false, err
+	}
+	for relativePath := range manifest.Files {
+		if isUnderDir(relativePath, relativeDir) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// blobManifestCache caches the blob manifests read during a restore, keyed by backup path
+type blobManifestCache struct {
+	mu        sync.Mutex
+	manifests map[string]*BlobManifest
+}
+
+func newBlobManifestCache() *blobManifestCache {
+	return &blobManifestCache{
+		manifests: make(map[string]*BlobManifest, 0),
+	}
+}
+
+func (b *BackupContext) getBlobManifest(ctx context.Context, cache *blobManifestCache, bucketName, backupPath string) (*BlobManifest, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if manifest, ok := cache.manifests[backupPath]; ok {
+		return manifest, nil
+	}
+	var manifest *BlobManifest
+	err := retry.Do(ctx, func() error {
+		var err error
+		manifest, err = b.readBlobManifest(ctx, bucketName, backupPath)
+		return err
+	}, b.storageRetryOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	cache.manifests[backupPath] = manifest
+	return manifest, nil
+}
+
+// copyBlobsToDir rebuilds the binlog directory of a deduplicated backup from the blob area into the target bucket,
+// backup_path/binlogs/insert_log/... will be copied to tempDir + backup_path/binlogs/insert_log/...
+func (b *BackupContext) copyBlobsToDir(ctx context.Context, backupBucketName string, manifest *BlobManifest, binlogDir string, tempDir string) error {
+	backupPath, relativeDir := splitBackupBinlogPath(binlogDir)
+	backupRootPath := backupPath[:strings.LastIndex(backupPath, SEPERATOR)]
+	for relativePath, ref := range manifest.Files {
+		if !isUnderDir(relativePath, relativeDir) {
+			continue
+		}
+		blobPath := BlobPath(backupRootPath, ref.Hash)
+		targetPath := tempDir + backupPath + SEPERATOR + relativePath
+		err := retry.Do(ctx, func() error {
+			return b.copyBetweenStorages(ctx, storage.BackupStorage, backupBucketName, blobPath, storage.MilvusStorage, b.milvusBucketName, targetPath)
+		}, b.storageRetryOptions()...)
+		if err != nil {
+			log.Error("fail to copy blob", zap.String("blob", blobPath), zap.String("to", targetPath), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+func newDedupTestContext(t *testing.T) *BackupContext {
+	b := newEncryptionTestContext(t)
+	b.params.BackupCfg.DedupEnable = true
+	return b
+}
+
+func blobHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// copyDedupBinlogs copies the binlogs of milvus into the backup, keyed by the binlog path relative to the milvus root
+func copyDedupBinlogs(t *testing.T, b *BackupContext, backupName string, binlogs map[string]string) {
+	ctx := context.Background()
+	for relativePath, content := range binlogs {
+		binlogPath := b.milvusRootPath + "/insert_log/" + relativePath
+		require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, []byte(content)))
+		backupBinlogPath := b.backupRootPath + "/" + backupName + "/binlogs/insert_log/" + relativePath
+		require.NoError(t, b.copyBinlog(ctx, backupName, binlogPath, backupBinlogPath, compression.None))
+	}
+}
+
+func TestCopyBinlogDedup(t *testing.T) {
+	ctx := context.Background()
+	b := newDedupTestContext(t)
+	addFaultTestBackup(b, "b1")
+	addFaultTestBackup(b, "b2")
+
+	copyDedupBinlogs(t, b, "b1", map[string]string{"1/2/3/4/100/1": "shared", "1/2/3/4/101/1": "only b1"})
+	copyDedupBinlogs(t, b, "b2", map[string]string{"1/2/3/4/100/1": "shared", "1/2/3/4/102/1": "shared"})
+
+	// binlogs of the same content are stored once in the blob area, not in the backup directory
+	blobs, _, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, b.backupBucketName, BlobDirPath(b.backupRootPath)+SEPERATOR, true)
+	assert.NoError(t, err)
+	assert.Len(t, blobs, 2)
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, b.backupRootPath+"/b1/binlogs/insert_log/1/2/3/4/100/1")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	data, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, BlobPath(b.backupRootPath, blobHash("shared")))
+	assert.NoError(t, err)
+	assert.Equal(t, "shared", string(data))
+
+	manifest := b.meta.GetBlobManifest("b2")
+	require.NotNil(t, manifest)
+	assert.Equal(t, BlobRef{Hash: blobHash("shared"), Size: 6}, manifest.Files["binlogs/insert_log/1/2/3/4/100/1"])
+	assert.Equal(t, BlobRef{Hash: blobHash("shared"), Size: 6}, manifest.Files["binlogs/insert_log/1/2/3/4/102/1"])
+}
+
+func TestCountBlobRefs(t *testing.T) {
+	ctx := context.Background()
+	b := newDedupTestContext(t)
+	addFaultTestBackup(b, "b1")
+	executing := addFaultTestBackup(b, "b2")
+	executing.StateCode = backuppb.BackupTaskStateCode_BACKUP_EXECUTING
+	b.meta.AddBackup(executing)
+
+	copyDedupBinlogs(t, b, "b1", map[string]string{"1/2/3/4/100/1": "shared", "1/2/3/4/101/1": "only b1"})
+	copyDedupBinlogs(t, b, "b2", map[string]string{"1/2/3/4/100/1": "shared"})
+	// the manifest of the finished backup is counted from storage, the executing one from memory
+	b.writeBackupCheckpoint(ctx, "b1")
+
+	refs, err := b.countBlobRefs(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{blobHash("shared"): 2, blobHash("only b1"): 1}, refs)
+
+	refs, err = b.countBlobRefs(ctx, "b1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{blobHash("shared"): 1}, refs)
+}
+
+func TestDedupL0SegmentDirs(t *testing.T) {
+	ctx := context.Background()
+	b := newDedupTestContext(t)
+	addFaultTestBackup(b, "b1")
+	// the collection level l0 segments 1 and 12 share the prefix of their dirs
+	manifest := &BlobManifest{Files: map[string]BlobRef{
+		"binlogs/delta_log/1/-1/12/100/1": {Hash: blobHash("segment 12"), Size: 10},
+	}}
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, BlobPath(b.backupRootPath, blobHash("segment 12")), []byte("segment 12")))
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, BlobManifestPath(b.backupRootPath, "b1"), manifestBytes))
+
+	backupPath := b.backupRootPath + "/b1"
+	exist, err := b.binlogDirExist(ctx, b.backupBucketName, backupPath+"/binlogs/delta_log/1/-1/1")
+	require.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = b.binlogDirExist(ctx, b.backupBucketName, backupPath+"/binlogs/delta_log/1/-1/12")
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	// only the blobs of segment 12 are copied for its dir
+	tempDir := b.milvusRootPath + "/restore-temp"
+	require.NoError(t, b.copyBlobsToDir(ctx, b.backupBucketName, manifest, backupPath+"/binlogs/delta_log/1/-1/1", tempDir))
+	copied, _, err := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, tempDir, true)
+	require.NoError(t, err)
+	assert.Empty(t, copied)
+	require.NoError(t, b.copyBlobsToDir(ctx, b.backupBucketName, manifest, backupPath+"/binlogs/delta_log/1/-1/12", tempDir))
+	copied, _, err = b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, tempDir, true)
+	require.NoError(t, err)
+	require.Len(t, copied, 1)
+	assert.True(t, strings.HasSuffix(copied[0], "/b1/binlogs/delta_log/1/-1/12/100/1"))
+
+	assert.True(t, isUnderDir("a/1/2", "a/1"))
+	assert.True(t, isUnderDir("a/1", "a/1/"))
+	assert.False(t, isUnderDir("a/12/2", "a/1"))
+}
+
+func TestCheckDedupSupported(t *testing.T) {
+	b := newDedupTestContext(t)
+	assert.NoError(t, b.checkDedupSupported(false, compression.None))
+	assert.EqualError(t, b.checkDedupSupported(true, compression.None), "encryption is not supported together with dedup")
+	assert.EqualError(t, b.checkDedupSupported(false, compression.Zstd), "binlog compression is not supported together with dedup")
+
+	b.params.BackupCfg.DedupEnable = false
+	assert.NoError(t, b.checkDedupSupported(true, compression.Zstd))
+}
+
+func TestRemoveUnreferencedBlobs(t *testing.T) {
+	ctx := context.Background()
+	b := newDedupTestContext(t)
+	addFaultTestBackup(b, "b1")
+	addFaultTestBackup(b, "b2")
+
+	copyDedupBinlogs(t, b, "b1", map[string]string{"1/2/3/4/100/1": "shared", "1/2/3/4/101/1": "only b1"})
+	copyDedupBinlogs(t, b, "b2", map[string]string{"1/2/3/4/100/1": "shared", "1/2/3/4/102/1": "only b2"})
+	// b2 failed, its blob refs are only flushed into the checkpoint
+	b.meta.UpdateBackup("b2", setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL))
+	b.writeBackupCheckpoint(ctx, "b1")
+	b.writeBackupCheckpoint(ctx, "b2")
+
+	blobExist := func(content string) bool {
+		exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, BlobPath(b.backupRootPath, blobHash(content)))
+		assert.NoError(t, err)
+		return exist
+	}
+
+	assert.NoError(t, b.removeUnreferencedBlobs(ctx, "b2"))
+	assert.True(t, blobExist("shared"))
+	assert.True(t, blobExist("only b1"))
+	assert.False(t, blobExist("only b2"))
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, "b2")))
+
+	assert.NoError(t, b.removeUnreferencedBlobs(ctx, "b1"))
+	assert.False(t, blobExist("shared"))
+	assert.False(t, blobExist("only b1"))
+
+	// a backup without blob manifest removes nothing
+	assert.NoError(t, b.removeUnreferencedBlobs(ctx, "not_exist"))
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/encryption"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+// getMasterKey loads the master key from the configured key file or environment variable
+func (b *BackupContext) getMasterKey() (*encryption.MasterKey, error) {
+	b.masterKeyMu.Lock()
+	defer b.masterKeyMu.Unlock()
+	if b.masterKey != nil {
+		return b.masterKey, nil
+	}
+	masterKey, err := encryption.LoadMasterKey(b.params.BackupCfg.EncryptionKeyFile, b.params.BackupCfg.EncryptionKeyEnv)
+	if err != nil {
+		return nil, err
+	}
+	b.masterKey = masterKey
+	return masterKey, nil
+}
+
+// newBackupEncryption generates the data key of a new backup, wrapped by the master key
+func (b *BackupContext) newBackupEncryption() (*backuppb.EncryptionInfo, error) {
+	masterKey, err := b.getMasterKey()
+	if err != nil {
+		return nil, err
+	}
+	_, wrappedDataKey, err := masterKey.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+	return &backuppb.EncryptionInfo{
+		Algorithm:      encryption.Algorithm,
+		MasterKeyId:    masterKey.ID(),
+		WrappedDataKey: wrappedDataKey,
+	}, nil
+}
+
+// backupDataKey unwraps the data key of a backup, returns nil if the backup is not encrypted
+func (b *BackupContext) backupDataKey(info *backuppb.EncryptionInfo) ([]byte, error) {
+	if info == nil {
+		return nil, nil
+	}
+	if info.GetAlgorithm() != encryption.Algorithm {
+		return nil, fmt.Errorf("unsupported backup encryption algorithm: %s", info.GetAlgorithm())
+	}
+	masterKey, err := b.getMasterKey()
+	if err != nil {
+		return nil, fmt.Errorf("backup is encrypted by master key %s: %w", info.GetMasterKeyId(), err)
+	}
+	if masterKey.ID() != info.GetMasterKeyId() {
+		return nil, fmt.Errorf("backup is encrypted by master key %s, but the configured master key is %s", info.GetMasterKeyId(), masterKey.ID())
+	}
+	return masterKey.UnwrapDataKey(info.GetWrappedDataKey())
+}
+
+// writeEncryptionMeta saves the wrapped data key of the backup, nothing is written if the backup is not encrypted
+func (b *BackupContext) writeEncryptionMeta(ctx context.Context, backupInfo *backuppb.BackupInfo) error {
+	if backupInfo.GetEncryption() == nil {
+		return nil
+	}
+	bytes, err := json.Marshal(backupInfo.GetEncryption())
+	if err != nil {
+		return err
+	}
+	return b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, EncryptionMetaPath(b.backupRootPath, backupInfo.GetName()), bytes)
+}
+
+// readEncryptionMeta reads the encryption of the backup, return nil if the backup is not encrypted
+func (b *BackupContext) readEncryptionMeta(ctx context.Context, bucketName, backupPath string) (*backuppb.EncryptionInfo, error) {
+	encryptionMetaPath := backupPath + SEPERATOR + META_PREFIX + SEPERATOR + ENCRYPTION_META_FILE
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, encryptionMetaPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	bytes, err := b.getStorageClient(storage.BackupStorage).Read(ctx, bucketName, encryptionMetaPath)
+	if err != nil {
+		return nil, err
+	}
+	info := &backuppb.EncryptionInfo{}
+	err = json.Unmarshal(bytes, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// writeBackupMetaFile writes a meta file into the backup bucket,
+// the content is compressed by the meta compression codec of the backup, then encrypted if the backup is encrypted
+func (b *BackupContext) writeBackupMetaFile(ctx context.Context, backupInfo *backuppb.BackupInfo, filePath string, content []byte) error {
+	dataKey, err := b.backupDataKey(backupInfo.GetEncryption())
+	if err != nil {
+		return err
+	}
+	if backupInfo.GetMetaCompression() != compression.None {
+		content, err = compression.Compress(backupInfo.GetMetaCompression(), content)
+		if err != nil {
+			return err
+		}
+	}
+	if dataKey != nil {
+		content, err = encryption.Encrypt(dataKey, content)
+		if err != nil {
+			return err
+		}
+	}
+	return b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, filePath, content)
+}
+
+// readBackupMetaFile reads a meta file of backup, the content is decrypted if the data key is not nil,
+// then decompressed by the meta compression codec of the backup. Meta files of old backups are neither compressed nor encrypted.
+func (b *BackupContext) readBackupMetaFile(ctx context.Context, bucketName, filePath string, dataKey []byte, codec string) ([]byte, error) {
+	content, err := b.getStorageClient(storage.BackupStorage).Read(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if dataKey != nil {
+		content, err = encryption.Decrypt(dataKey, content)
+		if err != nil {
+			return nil, fmt.Errorf("fail to decrypt %s: %w", filePath, err)
+		}
+	}
+	if codec != compression.None {
+		content, err = compression.Decompress(codec, content)
+		if err != nil {
+			return nil, fmt.Errorf("fail to decompress %s: %w", filePath, err)
+		}
+	}
+	return content, nil
+}
+
+// backupDataKeyCache caches the data keys of the backups read during a restore, keyed by backup path.
+// The data key is nil if the backup is not encrypted.
+type backupDataKeyCache struct {
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+func newBackupDataKeyCache() *backupDataKeyCache {
+	return &backupDataKeyCache{
+		keys: make(map[string][]byte, 0),
+	}
+}
+
+func (b *BackupContext) getBackupDataKey(ctx context.Context, cache *backupDataKeyCache, bucketName, backupPath string) ([]byte, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if dataKey, ok := cache.keys[backupPath]; ok {
+		return dataKey, nil
+	}
+	var info *backuppb.EncryptionInfo
+	err := retry.Do(ctx, func() error {
+		var err error
+		info, err = b.readEncryptionMeta(ctx, bucketName, backupPath)
+		return err
+	}, b.storageRetryOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := b.backupDataKey(info)
+	if err != nil {
+		return nil, err
+	}
+	cache.keys[backupPath] = dataKey
+	return dataKey, nil
+}
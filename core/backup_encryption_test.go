@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/encryption"
+)
+
+func newEncryptionTestContext(t *testing.T) *BackupContext {
+	ctx := context.Background()
+	key := make([]byte, encryption.KeySize)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+	t.Setenv("TEST_BACKUP_MASTER_KEY", base64.StdEncoding.EncodeToString(key))
+
+	var params paramtable.BackupParams
+	params.MinioCfg.StorageType = paramtable.Local
+	params.BackupCfg.EncryptionEnable = true
+	params.BackupCfg.EncryptionKeyEnv = "TEST_BACKUP_MASTER_KEY"
+	storageClient, err := storage.NewChunkManager(ctx, params)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	return &BackupContext{
+		ctx:            ctx,
+		params:         params,
+		storageClient:  &storageClient,
+		milvusRootPath: path.Join(dir, "files"),
+		backupRootPath: path.Join(dir, "backup"),
+		meta:           newMetaManager(),
+	}
+}
+
+func TestEncryptedBackupMeta(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+
+	encryptionInfo, err := b.newBackupEncryption()
+	assert.NoError(t, err)
+	assert.Equal(t, encryption.Algorithm, encryptionInfo.GetAlgorithm())
+	backupInfo := &backuppb.BackupInfo{
+		Name:       "encrypted",
+		Encryption: encryptionInfo,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{CollectionId: 1, CollectionName: "coll"},
+		},
+	}
+	output, err := serialize(backupInfo)
+	assert.NoError(t, err)
+	assert.NoError(t, b.writeEncryptionMeta(ctx, backupInfo))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, BackupMetaPath(b.backupRootPath, "encrypted"), output.BackupMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, CollectionMetaPath(b.backupRootPath, "encrypted"), output.CollectionMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, PartitionMetaPath(b.backupRootPath, "encrypted"), output.PartitionMetaBytes))
+	assert.NoError(t, b.writeBackupMetaFile(ctx, backupInfo, SegmentMetaPath(b.backupRootPath, "encrypted"), output.SegmentMetaBytes))
+
+	raw, err := os.ReadFile(CollectionMetaPath(b.backupRootPath, "encrypted"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "coll")
+
+	readInfo, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+"encrypted")
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", readInfo.GetCollectionBackups()[0].GetCollectionName())
+	assert.Equal(t, encryptionInfo.GetMasterKeyId(), readInfo.GetEncryption().GetMasterKeyId())
+
+	// another master key
+	otherKey := make([]byte, encryption.KeySize)
+	_, err = rand.Read(otherKey)
+	assert.NoError(t, err)
+	b.masterKey, err = encryption.NewMasterKey(otherKey)
+	assert.NoError(t, err)
+	_, err = b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+"encrypted")
+	assert.Error(t, err)
+}
+
+func TestEncryptedBinlogCopy(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+
+	encryptionInfo, err := b.newBackupEncryption()
+	assert.NoError(t, err)
+	dataKey, err := b.backupDataKey(encryptionInfo)
+	assert.NoError(t, err)
+
+	content := make([]byte, 3*encryption.ChunkSize+7)
+	_, err = rand.Read(content)
+	assert.NoError(t, err)
+	binlogPath := b.milvusRootPath + "/insert_log/1/2/3/4/5"
+	assert.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, content))
+
+	backupBinlogPath := b.backupRootPath + "/encrypted/binlogs/insert_log/1/2/3/3/4/5"
+	_, err = b.copyBinlogEncoded(ctx, dataKey, compression.None, binlogPath, backupBinlogPath)
+	assert.NoError(t, err)
+	raw, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, backupBinlogPath)
+	assert.NoError(t, err)
+	assert.Equal(t, encryption.EncryptedSize(int64(len(content))), int64(len(raw)))
+
+	backupDir := b.backupRootPath + "/encrypted/binlogs/insert_log/1/2/3/"
+	tempDir := b.milvusRootPath + "/restore-temp/"
+	assert.NoError(t, b.copyDecoded(ctx, b.backupBucketName, dataKey, nil, backupDir, tempDir+backupDir))
+	decrypted, err := b.getStorageClient(storage.MilvusStorage).Read(ctx, b.milvusBucketName, tempDir+backupBinlogPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, decrypted)
+
+	dataKeys := newBackupDataKeyCache()
+	cachedKey, err := b.getBackupDataKey(ctx, dataKeys, b.backupBucketName, b.backupRootPath+"/encrypted")
+	assert.NoError(t, err)
+	// no encryption meta
+	assert.Nil(t, cachedKey)
+}
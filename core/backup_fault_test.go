@@ -0,0 +1,184 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+// newFaultTestContext returns a context whose storage injects the faults by the rules
+func newFaultTestContext(t *testing.T, rules ...storage.FaultRule) *BackupContext {
+	b := newEncryptionTestContext(t)
+	fcm, err := storage.NewFaultInjectingChunkManager(b.getStorageClient(storage.MilvusStorage), rules)
+	require.NoError(t, err)
+	var storageClient storage.ChunkManager = fcm
+	b.storageClient = &storageClient
+	b.storageRetrySleep = time.Millisecond
+	return b
+}
+
+func addFaultTestBackup(b *BackupContext, name string) *backuppb.BackupInfo {
+	backupInfo := &backuppb.BackupInfo{
+		Id:        name,
+		Name:      name,
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+	}
+	b.meta.AddBackup(backupInfo)
+	b.meta.AddCollection(&backuppb.CollectionBackupInfo{Id: name, CollectionId: 1, CollectionName: "coll"})
+	return backupInfo
+}
+
+func TestWriteBackupInfoMetaRetry(t *testing.T) {
+	ctx := context.Background()
+	b := newFaultTestContext(t,
+		storage.FaultRule{Name: "backup meta", Operations: []string{"Write"}, PathPattern: BACKUP_META_FILE + "$", ErrorRate: 1, Times: 1},
+		storage.FaultRule{Name: "segment meta", Operations: []string{"Write"}, PathPattern: SEGMENT_META_FILE + "$", PartialWriteRate: 1, Times: 1},
+	)
+	addFaultTestBackup(b, "transient")
+
+	assert.NoError(t, b.writeBackupInfoMeta(ctx, "transient"))
+	backupInfo, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+"transient")
+	assert.NoError(t, err)
+	require.NotNil(t, backupInfo)
+	assert.Equal(t, "coll", backupInfo.GetCollectionBackups()[0].GetCollectionName())
+}
+
+func TestWriteBackupInfoMetaFail(t *testing.T) {
+	b := newFaultTestContext(t,
+		storage.FaultRule{Name: "collection meta", Operations: []string{"Write"}, PathPattern: COLLECTION_META_FILE + "$", ErrorRate: 1},
+	)
+	addFaultTestBackup(b, "persistent")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, b.writeBackupInfoMeta(ctx, "persistent"))
+
+	// the backup meta is written at last, the incomplete backup is not visible
+	backupInfo, err := b.readBackup(context.Background(), b.backupBucketName, b.backupRootPath+SEPERATOR+"persistent")
+	assert.NoError(t, err)
+	assert.Nil(t, backupInfo)
+}
+
+func TestCopyBinlogRetry(t *testing.T) {
+	ctx := context.Background()
+	b := newFaultTestContext(t,
+		storage.FaultRule{Name: "copy", Operations: []string{"Copy"}, ErrorRate: 1, Times: 2},
+	)
+	addFaultTestBackup(b, "copy")
+
+	binlogPath := b.milvusRootPath + "/insert_log/1/2/3/4/5"
+	require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, []byte("binlog")))
+	backupBinlogPath := b.backupRootPath + "/copy/binlogs/insert_log/1/2/3/3/4/5"
+	assert.NoError(t, b.copyBinlog(ctx, "copy", binlogPath, backupBinlogPath, compression.None))
+	data, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, backupBinlogPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "binlog", string(data))
+}
+
+func TestCopySegmentExistFault(t *testing.T) {
+	ctx := context.Background()
+	b := newFaultTestContext(t,
+		storage.FaultRule{Name: "exist", Operations: []string{"Exist"}, PathPattern: "insert_log/1/449949/449950/", ErrorRate: 1, Times: 2},
+		storage.FaultRule{Name: "missing", Operations: []string{"Exist"}, PathPattern: "insert_log/1/449949/449951/", MissingRate: 1},
+	)
+	addFaultTestBackup(b, "exist")
+
+	for _, segmentID := range []int64{449950, 449951} {
+		binlogPath := fmt.Sprintf("%s/insert_log/1/449949/%d/100/1", b.milvusRootPath, segmentID)
+		require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, []byte("binlog")))
+		b.meta.AddSegment(&backuppb.SegmentBackupInfo{
+			CollectionId: 1,
+			PartitionId:  449949,
+			SegmentId:    segmentID,
+			Binlogs: []*backuppb.FieldBinlog{{
+				FieldID: 100,
+				Binlogs: []*backuppb.Binlog{{LogPath: binlogPath}},
+			}},
+		})
+	}
+	backupBinlogPath := b.backupRootPath + "/exist/binlogs"
+
+	// the failed existence checks are retried
+	assert.NoError(t, b.copySegment(ctx, backupBinlogPath, b.meta.GetSegment(449950)))
+	assert.True(t, storageExist(t, b, storage.BackupStorage, b.backupBucketName, backupBinlogPath+"/insert_log/1/449949/449950/449950/100/1"))
+
+	// a missing binlog fails the backup rather than being skipped
+	assert.Error(t, b.copySegment(ctx, backupBinlogPath, b.meta.GetSegment(449951)))
+	assert.False(t, storageExist(t, b, storage.BackupStorage, b.backupBucketName, backupBinlogPath+"/insert_log/1/449949/449951/449951/100/1"))
+}
+
+// newRestoreFaultTestContext returns a cross storage context whose milvus storage and backup storage inject the faults by the rules
+func newRestoreFaultTestContext(t *testing.T, milvusRules []storage.FaultRule, backupRules []storage.FaultRule) *BackupContext {
+	b := newCrossStorageTestContext(t)
+	b.storageRetrySleep = time.Millisecond
+	milvusStorage, err := storage.NewFaultInjectingChunkManager(b.getStorageClient(storage.MilvusStorage), milvusRules)
+	require.NoError(t, err)
+	backupStorage, err := storage.NewFaultInjectingChunkManager(b.getStorageClient(storage.BackupStorage), backupRules)
+	require.NoError(t, err)
+	var milvusClient, backupClient storage.ChunkManager = milvusStorage, backupStorage
+	b.storageClient = &milvusClient
+	b.backupStorageClient = &backupClient
+	return b
+}
+
+func newRestoreTempFiles(b *BackupContext) *restoreTempFiles {
+	return &restoreTempFiles{
+		dir:              "restore-temp-fault/",
+		backupBucketName: b.backupBucketName,
+		blobManifests:    newBlobManifestCache(),
+		dataKeys:         newBackupDataKeyCache(),
+		binlogCodecs:     make(map[string]string, 0),
+	}
+}
+
+func TestCopyRestoreFilesRetry(t *testing.T) {
+	ctx := context.Background()
+	b := newRestoreFaultTestContext(t,
+		[]storage.FaultRule{
+			{Name: "write", Operations: []string{"WriteFrom"}, PartialWriteRate: 1, Times: 1},
+		},
+		[]storage.FaultRule{
+			{Name: "exist", Operations: []string{"Exist"}, PathPattern: "meta/", ErrorRate: 1, Times: 2},
+			{Name: "reader", Operations: []string{"Reader"}, ErrorRate: 1, Times: 1},
+		},
+	)
+	backupDir := b.backupRootPath + "/restore/binlogs/insert_log/1/2/3/"
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, backupDir+"3/100/1", []byte("binlog")))
+
+	temp := newRestoreTempFiles(b)
+	realFiles, err := b.copyRestoreFiles(ctx, temp, []string{backupDir, ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{temp.dir + backupDir, ""}, realFiles)
+	data, err := b.getStorageClient(storage.MilvusStorage).Read(ctx, b.milvusBucketName, temp.dir+backupDir+"3/100/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "binlog", string(data))
+}
+
+func TestCopyRestoreFilesFail(t *testing.T) {
+	backupDir := "backup/restore/binlogs/insert_log/1/2/3/"
+	for _, c := range []struct {
+		name        string
+		milvusRules []storage.FaultRule
+		backupRules []storage.FaultRule
+	}{
+		{name: "write", milvusRules: []storage.FaultRule{{Name: "write", Operations: []string{"WriteFrom"}, ErrorRate: 1}}},
+		{name: "exist", backupRules: []storage.FaultRule{{Name: "exist", Operations: []string{"Exist"}, PathPattern: "meta/", ErrorRate: 1}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			b := newRestoreFaultTestContext(t, c.milvusRules, c.backupRules)
+			require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(context.Background(), b.backupBucketName, backupDir+"3/100/1", []byte("binlog")))
+
+			_, err := b.copyRestoreFiles(context.Background(), newRestoreTempFiles(b), []string{backupDir})
+			assert.Error(t, err)
+			assert.False(t, storageExist(t, b, storage.MilvusStorage, b.milvusBucketName, "restore-temp-fault/"+backupDir+"3/100/1"))
+		})
+	}
+}
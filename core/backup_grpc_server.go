@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// GrpcHandlers serves the MilvusBackupService on top of BackupContext
+type GrpcHandlers struct {
+	backupContext *BackupContext
+}
+
+var _ backuppb.MilvusBackupServiceServer = (*GrpcHandlers)(nil)
+
+// NewGrpcHandlers creates a new GrpcHandlers
+func NewGrpcHandlers(backupContext *BackupContext) *GrpcHandlers {
+	return &GrpcHandlers{
+		backupContext: backupContext,
+	}
+}
+
+// RegisterTo registers the MilvusBackupService to given grpc server
+func (h *GrpcHandlers) RegisterTo(server *grpc.Server) {
+	backuppb.RegisterMilvusBackupServiceServer(server, h)
+}
+
+func (h *GrpcHandlers) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.CreateBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.GetBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) ListBackups(ctx context.Context, request *backuppb.ListBackupsRequest) (*backuppb.ListBackupsResponse, error) {
+	return h.backupContext.ListBackups(ctx, request), nil
+}
+
+func (h *GrpcHandlers) DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) (*backuppb.DeleteBackupResponse, error) {
+	return h.backupContext.DeleteBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) (*backuppb.RestoreBackupResponse, error) {
+	return h.backupContext.RestoreBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) GetRestore(ctx context.Context, request *backuppb.GetRestoreStateRequest) (*backuppb.RestoreBackupResponse, error) {
+	return h.backupContext.GetRestore(ctx, request), nil
+}
+
+func (h *GrpcHandlers) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.ResumeBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) CancelBackup(ctx context.Context, request *backuppb.CancelBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.CancelBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) CancelRestore(ctx context.Context, request *backuppb.CancelRestoreRequest) (*backuppb.RestoreBackupResponse, error) {
+	return h.backupContext.CancelRestore(ctx, request), nil
+}
+
+func (h *GrpcHandlers) CreateSchedule(ctx context.Context, request *backuppb.CreateScheduleRequest) (*backuppb.ScheduleResponse, error) {
+	return h.backupContext.CreateSchedule(ctx, request), nil
+}
+
+func (h *GrpcHandlers) ListSchedules(ctx context.Context, request *backuppb.ListSchedulesRequest) (*backuppb.ListSchedulesResponse, error) {
+	return h.backupContext.ListSchedules(ctx, request), nil
+}
+
+func (h *GrpcHandlers) DeleteSchedule(ctx context.Context, request *backuppb.DeleteScheduleRequest) (*backuppb.ScheduleResponse, error) {
+	return h.backupContext.DeleteSchedule(ctx, request), nil
+}
+
+func (h *GrpcHandlers) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) (*backuppb.PruneBackupsResponse, error) {
+	return h.backupContext.PruneBackups(ctx, request), nil
+}
+
+func (h *GrpcHandlers) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) (*backuppb.VerifyBackupResponse, error) {
+	return h.backupContext.VerifyBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) ExportBackup(ctx context.Context, request *backuppb.ExportBackupRequest) (*backuppb.BackupArchiveResponse, error) {
+	return h.backupContext.ExportBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) ImportBackup(ctx context.Context, request *backuppb.ImportBackupRequest) (*backuppb.BackupArchiveResponse, error) {
+	return h.backupContext.ImportBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) CopyBackup(ctx context.Context, request *backuppb.CopyBackupRequest) (*backuppb.CopyBackupResponse, error) {
+	return h.backupContext.CopyBackup(ctx, request), nil
+}
+
+func (h *GrpcHandlers) GetThrottle(ctx context.Context, request *backuppb.GetThrottleRequest) (*backuppb.ThrottleResponse, error) {
+	return h.backupContext.GetThrottle(ctx, request), nil
+}
+
+func (h *GrpcHandlers) UpdateThrottle(ctx context.Context, request *backuppb.UpdateThrottleRequest) (*backuppb.ThrottleResponse, error) {
+	return h.backupContext.UpdateThrottle(ctx, request), nil
+}
+
+func (h *GrpcHandlers) Check(ctx context.Context, request *backuppb.CheckRequest) (*backuppb.CheckResponse, error) {
+	msg := h.backupContext.Check(ctx)
+	resp := &backuppb.CheckResponse{
+		Code: backuppb.ResponseCode_Success,
+		Msg:  msg,
+	}
+	if !strings.HasPrefix(msg, "Succeed") {
+		resp.Code = backuppb.ResponseCode_Fail
+	}
+	return resp, nil
+}
+
+// registerGrpcServer register the grpc server
+func (s *Server) registerGrpcServer() {
+	s.grpcServer = grpc.NewServer()
+	NewGrpcHandlers(s.backupContext).RegisterTo(s.grpcServer)
+}
+
+// startGrpcServer listens on the grpc port and serves in background, panic when failed to listen
+func (s *Server) startGrpcServer() {
+	lis, err := net.Listen("tcp", s.config.grpcPort)
+	if err != nil {
+		log.Error("Failed to listen grpc port", zap.String("port", s.config.grpcPort), zap.Error(err))
+		panic(err)
+	}
+	go func() {
+		log.Info("Start backup grpc server", zap.String("port", s.config.grpcPort))
+		if err := s.grpcServer.Serve(lis); err != nil {
+			log.Error("Failed to serve grpc", zap.Error(err))
+		}
+	}()
+}
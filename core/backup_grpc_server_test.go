@@ -0,0 +1,64 @@
+package core
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// newGrpcTestClient serves the handlers of the context by an in-process grpc server and returns a client connected to it
+func newGrpcTestClient(t *testing.T, b *BackupContext) backuppb.MilvusBackupServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	NewGrpcHandlers(b).RegisterTo(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return backuppb.NewMilvusBackupServiceClient(conn)
+}
+
+func TestGrpcRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+	client := newGrpcTestClient(t, b)
+
+	updateResp, err := client.UpdateThrottle(ctx, &backuppb.UpdateThrottleRequest{
+		RequestId: "update",
+		Throttle: &backuppb.ThrottleConfig{
+			BytesPerSecond: 2048,
+			Profiles:       []*backuppb.ThrottleProfile{{Name: "night", Start: "22:00", End: "06:00", RequestsPerSecond: 10}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "update", updateResp.GetRequestId())
+	assert.Equal(t, backuppb.ResponseCode_Success, updateResp.GetCode())
+
+	getResp, err := client.GetThrottle(ctx, &backuppb.GetThrottleRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, backuppb.ResponseCode_Success, getResp.GetCode())
+	assert.NotEmpty(t, getResp.GetRequestId())
+	assert.Equal(t, int64(2048), getResp.GetData().GetConfig().GetBytesPerSecond())
+	require.Len(t, getResp.GetData().GetConfig().GetProfiles(), 1)
+	assert.Equal(t, "night", getResp.GetData().GetConfig().GetProfiles()[0].GetName())
+
+	// errors of the request are returned in the response code instead of the grpc status
+	deleteResp, err := client.DeleteBackup(ctx, &backuppb.DeleteBackupRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, deleteResp.GetCode())
+	assert.Equal(t, "empty backup name", deleteResp.GetMsg())
+}
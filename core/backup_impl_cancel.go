@@ -0,0 +1,239 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const CANCELLED_TASK_MESSAGE = "task is cancelled"
+
+// taskCanceler holds the cancel function of a running backup or restore task
+type taskCanceler struct {
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+	cleanup   atomic.Bool
+}
+
+// registerTask creates the context of a backup or restore task which is able to be cancelled by the task id.
+// The context is derived from the backup context rather than the request, so that async tasks outlive their requests.
+func (b *BackupContext) registerTask(id string) context.Context {
+	ctx, cancel := context.WithCancel(b.ctx)
+	b.cancelMu.Lock()
+	defer b.cancelMu.Unlock()
+	b.taskCancelers[id] = &taskCanceler{cancel: cancel}
+	return ctx
+}
+
+// unregisterTask releases the context of the task, returns whether the task is cancelled and should be cleaned up
+func (b *BackupContext) unregisterTask(id string) (cancelled bool, cleanup bool) {
+	b.cancelMu.Lock()
+	defer b.cancelMu.Unlock()
+	canceler, exist := b.taskCancelers[id]
+	if !exist {
+		return false, false
+	}
+	canceler.cancel()
+	delete(b.taskCancelers, id)
+	return canceler.cancelled.Load(), canceler.cleanup.Load()
+}
+
+// cancelTask cancels the context of the task, return false if the task is not running in this backup context
+func (b *BackupContext) cancelTask(id string, cleanup bool) bool {
+	b.cancelMu.Lock()
+	defer b.cancelMu.Unlock()
+	canceler, exist := b.taskCancelers[id]
+	if !exist {
+		return false
+	}
+	canceler.cleanup.Store(cleanup)
+	canceler.cancelled.Store(true)
+	canceler.cancel()
+	return true
+}
+
+func (b *BackupContext) isTaskCancelled(id string) bool {
+	b.cancelMu.Lock()
+	defer b.cancelMu.Unlock()
+	canceler, exist := b.taskCancelers[id]
+	return exist && canceler.cancelled.Load()
+}
+
+// cancelableJob binds the job to the context of its task. Jobs of a cancelled task return without error,
+// so that the shared worker pools are not broken, the task should check its context after waiting the jobs.
+func cancelableJob(taskCtx context.Context, job common.Job) common.Job {
+	return func(ctx context.Context) error {
+		if taskCtx.Err() != nil {
+			return nil
+		}
+		err := job(taskCtx)
+		if taskCtx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+}
+
+// failBackup marks the backup as failed, or cancelled if the failure is caused by cancellation
+func (b *BackupContext) failBackup(id string, err error) {
+	if b.isTaskCancelled(id) {
+		b.meta.UpdateBackup(id,
+			setStateCode(backuppb.BackupTaskStateCode_BACKUP_CANCELLED),
+			setErrorMessage(CANCELLED_TASK_MESSAGE),
+			setEndTime(time.Now().UnixNano()/int64(time.Millisecond)))
+		return
+	}
+	b.meta.UpdateBackup(id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL), setErrorMessage(err.Error()))
+}
+
+// finishBackupTask unregisters the backup task, the partial backup is removed if it is cancelled with cleanup
+func (b *BackupContext) finishBackupTask(id string) {
+	cancelled, cleanup := b.unregisterTask(id)
+	backup := b.meta.GetBackup(id)
+	if !cancelled || !cleanup || backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_CANCELLED {
+		return
+	}
+	log.Info("clean up cancelled backup", zap.String("backupName", backup.GetName()))
+	// flush the blob refs, so that the blobs only copied by this backup are able to be removed
+	b.writeBackupCheckpoint(b.ctx, id)
+	err := b.removeUnreferencedBlobs(b.ctx, backup.GetName())
+	if err != nil {
+		log.Warn("fail to remove blobs of cancelled backup", zap.String("backupName", backup.GetName()), zap.Error(err))
+		return
+	}
+	err = b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(b.ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backup.GetName()+SEPERATOR)
+	if err != nil {
+		log.Warn("fail to remove cancelled backup", zap.String("backupName", backup.GetName()), zap.Error(err))
+	}
+}
+
+// failRestore marks the restore task as failed, or cancelled if the failure is caused by cancellation
+func (b *BackupContext) failRestore(id string, err error) {
+	if b.isTaskCancelled(id) {
+		b.meta.UpdateRestoreTask(id,
+			setRestoreStateCode(backuppb.RestoreTaskStateCode_CANCELLED),
+			setRestoreErrorMessage(CANCELLED_TASK_MESSAGE),
+			setRestoreEndTime(time.Now().Unix()))
+		return
+	}
+	b.meta.UpdateRestoreTask(id, setRestoreStateCode(backuppb.RestoreTaskStateCode_FAIL), setRestoreErrorMessage(err.Error()))
+}
+
+// finishRestoreTask unregisters the restore task, the target collections created by it are dropped if it is cancelled with cleanup
+func (b *BackupContext) finishRestoreTask(id string) {
+	cancelled, cleanup := b.unregisterTask(id)
+	task := b.meta.GetRestoreTask(id)
+	if !cancelled || !cleanup || task.GetStateCode() != backuppb.RestoreTaskStateCode_CANCELLED {
+		return
+	}
+	for _, collectionTask := range task.GetCollectionRestoreTasks() {
+		// the collection exists before the restore
+		if collectionTask.GetSkipCreateCollection() && !collectionTask.GetDropExistCollection() {
+			continue
+		}
+		log.Info("drop collection of cancelled restore",
+			zap.String("target_db_name", collectionTask.GetTargetDbName()),
+			zap.String("target_collection_name", collectionTask.GetTargetCollectionName()))
+		exist, err := b.getMilvusClient().HasCollection(b.ctx, collectionTask.GetTargetDbName(), collectionTask.GetTargetCollectionName())
+		if err == nil && exist {
+			err = b.getMilvusClient().DropCollection(b.ctx, collectionTask.GetTargetDbName(), collectionTask.GetTargetCollectionName())
+		}
+		if err != nil {
+			log.Warn("fail to drop collection of cancelled restore",
+				zap.String("target_db_name", collectionTask.GetTargetDbName()),
+				zap.String("target_collection_name", collectionTask.GetTargetCollectionName()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (b *BackupContext) CancelBackup(ctx context.Context, request *backuppb.CancelBackupRequest) *backuppb.BackupInfoResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive CancelBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.Bool("cleanup", request.GetCleanup()))
+
+	resp := &backuppb.BackupInfoResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	backup := b.meta.GetBackupByName(request.GetBackupName())
+	if backup == nil {
+		errMsg := fmt.Sprintf("backup task doesn't exist: %s", request.GetBackupName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+	if !isTaskInterrupted(backup.GetStateCode()) || !b.cancelTask(backup.GetId(), request.GetCleanup()) {
+		errMsg := fmt.Sprintf("backup is not running: %s, state: %s", request.GetBackupName(), backup.GetStateCode().String())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "backup is being cancelled"
+	resp.Data = b.meta.GetBackup(backup.GetId())
+	return resp
+}
+
+func (b *BackupContext) CancelRestore(ctx context.Context, request *backuppb.CancelRestoreRequest) *backuppb.RestoreBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive CancelRestoreRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("id", request.GetId()),
+		zap.Bool("cleanup", request.GetCleanup()))
+
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if request.GetId() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty restore id"
+		return resp
+	}
+
+	task := b.meta.GetRestoreTask(request.GetId())
+	if task == nil {
+		errMsg := fmt.Sprintf("restore task doesn't exist: %s", request.GetId())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+	if !isRestoreTaskInterrupted(task.GetStateCode()) || !b.cancelTask(task.GetId(), request.GetCleanup()) {
+		errMsg := fmt.Sprintf("restore task is not running: %s, state: %s", request.GetId(), task.GetStateCode().String())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "restore is being cancelled"
+	resp.Data = b.meta.GetRestoreTask(task.GetId())
+	return resp
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestCancelTask(t *testing.T) {
+	b := CreateBackupContext(context.Background(), paramtable.BackupParams{})
+
+	assert.False(t, b.cancelTask("not_exist", false))
+
+	ctx := b.registerTask("t1")
+	assert.False(t, b.isTaskCancelled("t1"))
+	assert.True(t, b.cancelTask("t1", true))
+	assert.True(t, b.isTaskCancelled("t1"))
+	assert.Error(t, ctx.Err())
+
+	cancelled, cleanup := b.unregisterTask("t1")
+	assert.True(t, cancelled)
+	assert.True(t, cleanup)
+	assert.False(t, b.isTaskCancelled("t1"))
+
+	// finished without cancel
+	ctx = b.registerTask("t2")
+	cancelled, cleanup = b.unregisterTask("t2")
+	assert.False(t, cancelled)
+	assert.False(t, cleanup)
+	assert.Error(t, ctx.Err())
+}
+
+func TestCancelableJob(t *testing.T) {
+	taskCtx, cancel := context.WithCancel(context.Background())
+	jobErr := errors.New("job failed")
+	executed := 0
+	job := cancelableJob(taskCtx, func(ctx context.Context) error {
+		executed++
+		return jobErr
+	})
+
+	assert.ErrorIs(t, job(context.Background()), jobErr)
+	assert.Equal(t, 1, executed)
+
+	cancel()
+	// jobs of a cancelled task are skipped without breaking the pool
+	assert.NoError(t, job(context.Background()))
+	assert.Equal(t, 1, executed)
+}
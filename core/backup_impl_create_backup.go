@@ -13,14 +13,16 @@ import (
 	"strings"
 	"time"
 
-	jsoniter "github.com/json-iterator/go"
+	"github.com/golang/protobuf/proto"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
 	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
 	"github.com/zilliztech/milvus-backup/internal/util/retry"
 )
 
@@ -35,7 +37,8 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		zap.String("databaseCollections", utils.GetCreateDBCollections(request)),
 		zap.Bool("async", request.GetAsync()),
 		zap.Bool("force", request.GetForce()),
-		zap.Bool("metaOnly", request.GetMetaOnly()))
+		zap.Bool("metaOnly", request.GetMetaOnly()),
+		zap.String("parentBackup", request.GetParentBackup()))
 
 	resp := &backuppb.BackupInfoResponse{
 		RequestId: request.GetRequestId(),
@@ -55,7 +58,7 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		request.BackupName = "backup_" + fmt.Sprint(time.Now().UTC().Format("2006_01_02_15_04_05_")) + fmt.Sprint(time.Now().Nanosecond())
 	}
 	if request.GetBackupName() != "" {
-		exist, err := b.getStorageClient().Exist(b.ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
+		exist, err := b.getStorageClient(storage.BackupStorage).Exist(b.ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
 		if err != nil {
 			errMsg := fmt.Sprintf("fail to check whether exist backup with name: %s", request.GetBackupName())
 			log.Error(errMsg, zap.Error(err))
@@ -79,6 +82,32 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		return resp
 	}
 
+	// parent backup validate
+	if request.GetParentBackup() != "" {
+		if request.GetParentBackup() == request.GetBackupName() {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = "parent backup can not be the backup itself"
+			return resp
+		}
+		parentResp := b.GetBackup(b.ctx, &backuppb.GetBackupRequest{
+			BackupName: request.GetParentBackup(),
+		})
+		if parentResp.GetCode() != backuppb.ResponseCode_Success || parentResp.GetData() == nil {
+			errMsg := fmt.Sprintf("parent backup does not exist: %s", request.GetParentBackup())
+			log.Error(errMsg, zap.String("msg", parentResp.GetMsg()))
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errMsg
+			return resp
+		}
+		if parentResp.GetData().GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS {
+			errMsg := fmt.Sprintf("parent backup is not completed: %s, state: %s", request.GetParentBackup(), parentResp.GetData().GetStateCode().String())
+			log.Error(errMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errMsg
+			return resp
+		}
+	}
+
 	milvusVersion, err := b.getMilvusClient().GetVersion(b.ctx)
 	if err != nil {
 		log.Error("fail to get milvus version", zap.Error(err))
@@ -87,20 +116,53 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		return resp
 	}
 
+	metaCompression, err := compression.Validate(b.params.BackupCfg.CompressionCodec)
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	binlogCompression := compression.None
+	if b.params.BackupCfg.CompressBinlog {
+		binlogCompression = metaCompression
+	}
+	if err := b.checkDedupSupported(b.params.BackupCfg.EncryptionEnable, binlogCompression); err != nil {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	// encrypt the backup by its own data key
+	var encryptionInfo *backuppb.EncryptionInfo
+	if b.params.BackupCfg.EncryptionEnable {
+		encryptionInfo, err = b.newBackupEncryption()
+		if err != nil {
+			log.Error("fail to generate the data key of backup", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = "fail to generate the data key of backup: " + err.Error()
+			return resp
+		}
+	}
+
 	backup := &backuppb.BackupInfo{
-		Id:            request.GetRequestId(),
-		StateCode:     backuppb.BackupTaskStateCode_BACKUP_INITIAL,
-		StartTime:     time.Now().UnixNano() / int64(time.Millisecond),
-		Name:          request.BackupName,
-		MilvusVersion: milvusVersion,
+		Id:                request.GetRequestId(),
+		StateCode:         backuppb.BackupTaskStateCode_BACKUP_INITIAL,
+		StartTime:         time.Now().UnixNano() / int64(time.Millisecond),
+		Name:              request.BackupName,
+		MilvusVersion:     milvusVersion,
+		ParentBackup:      request.GetParentBackup(),
+		Encryption:        encryptionInfo,
+		MetaCompression:   metaCompression,
+		BinlogCompression: binlogCompression,
 	}
 	b.meta.AddBackup(backup)
 	//levelBackupInfo := NewLeveledBackupInfo(backup)
 	//b.backupTasksCache.Store(request.GetRequestId(), levelBackupInfo)
 	//b.backupNameIdDict.Store(name, request.GetRequestId())
+	taskCtx := b.registerTask(backup.GetId())
 
 	if request.Async {
-		go b.executeCreateBackup(ctx, request, backup)
+		go b.executeCreateBackup(taskCtx, request, backup)
 		asyncResp := &backuppb.BackupInfoResponse{
 			RequestId: request.GetRequestId(),
 			Code:      backuppb.ResponseCode_Success,
@@ -109,7 +171,7 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		}
 		return asyncResp
 	} else {
-		err := b.executeCreateBackup(ctx, request, backup)
+		err := b.executeCreateBackup(taskCtx, request, backup)
 		resp.Data = b.meta.GetBackup(backup.GetId())
 		if err != nil {
 			resp.Code = backuppb.ResponseCode_Fail
@@ -125,6 +187,8 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 type collectionStruct struct {
 	db             string
 	collectionName string
+	// partitions to backup, empty means all
+	partitions []string
 }
 
 // parse collections to backup
@@ -142,8 +206,7 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 	dbCollectionsStr := utils.GetCreateDBCollections(request)
 	// first priority: dbCollections
 	if dbCollectionsStr != "" {
-		var dbCollections DbCollections
-		err := jsoniter.UnmarshalFromString(dbCollectionsStr, &dbCollections)
+		dbCollections, dbPartitions, err := parseDbCollections(dbCollectionsStr)
 		if err != nil {
 			log.Error("fail in unmarshal dbCollections in CreateBackupRequest", zap.String("dbCollections", dbCollectionsStr), zap.Error(err))
 			return nil, err
@@ -157,11 +220,11 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 				}
 				for _, coll := range collections {
 					log.Debug("Add collection to toBackupCollections", zap.String("db", db), zap.String("collection", coll.Name))
-					toBackupCollections = append(toBackupCollections, collectionStruct{db, coll.Name})
+					toBackupCollections = append(toBackupCollections, collectionStruct{db, coll.Name, nil})
 				}
 			} else {
 				for _, coll := range collections {
-					toBackupCollections = append(toBackupCollections, collectionStruct{db, coll})
+					toBackupCollections = append(toBackupCollections, collectionStruct{db, coll, dbPartitions[db][coll]})
 				}
 			}
 		}
@@ -182,7 +245,7 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 				return nil, err
 			}
 			for _, coll := range collections {
-				toBackupCollections = append(toBackupCollections, collectionStruct{db.Name, coll.Name})
+				toBackupCollections = append(toBackupCollections, collectionStruct{db.Name, coll.Name, nil})
 			}
 		}
 		log.Debug(fmt.Sprintf("List %v collections", len(toBackupCollections)))
@@ -205,7 +268,7 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 				log.Error(errMsg)
 				return nil, errors.New(errMsg)
 			}
-			toBackupCollections = append(toBackupCollections, collectionStruct{dbName, collectionName})
+			toBackupCollections = append(toBackupCollections, collectionStruct{dbName, collectionName, nil})
 		}
 	}
 
@@ -286,6 +349,15 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		}
 	}
 
+	// aliases are not essential to the backup, they are left empty if the milvus doesn't list them
+	aliases, err := b.getMilvusClient().ListAliases(b.ctx, collection.db, completeCollection.Name)
+	if err != nil {
+		log.Warn("fail to list aliases, backup the collection without aliases", zap.String("collection_name", completeCollection.Name), zap.Error(err))
+	}
+	log.Info("collection aliases",
+		zap.String("collection_name", completeCollection.Name),
+		zap.Strings("aliases", aliases))
+
 	collectionBackup := &backuppb.CollectionBackupInfo{
 		Id:               backupInfo.Id,
 		StateCode:        backuppb.BackupTaskStateCode_BACKUP_INITIAL,
@@ -298,6 +370,7 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		ConsistencyLevel: backuppb.ConsistencyLevel(completeCollection.ConsistencyLevel),
 		HasIndex:         len(indexInfos) > 0,
 		IndexInfos:       indexInfos,
+		Aliases:          aliases,
 	}
 	b.meta.AddCollection(collectionBackup)
 
@@ -306,7 +379,13 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		log.Error("fail to ShowPartitions", zap.Error(err))
 		return err
 	}
-
+	if len(collection.partitions) > 0 {
+		partitions, err = filterPartitions(partitions, collection.partitions)
+		if err != nil {
+			log.Error("fail to select partitions", zap.String("collection", collection.collectionName), zap.Error(err))
+			return err
+		}
+	}
 	// use GetLoadingProgress currently, GetLoadState is a new interface @20230104  milvus pr#21515
 	collectionLoadProgress, err := b.getMilvusClient().GetLoadingProgress(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName(), []string{})
 	if err != nil {
@@ -461,9 +540,27 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		zap.String("collectionName", collectionBackup.GetCollectionName()),
 		zap.Int64s("segments", newSegIDs))
 
+	b.addCollectionPartitions(collectionBackup, partitions, unfilledSegments, partitionLoadStates, collectionLoadState)
+	return nil
+}
+
+// addCollectionPartitions adds the partitions of the collection and their segments into meta.
+// Segments of the partitions not selected are not backuped, l0 segments of the collection apply to all partitions.
+func (b *BackupContext) addCollectionPartitions(collectionBackup *backuppb.CollectionBackupInfo, partitions []*entity.Partition,
+	segments []*entity.Segment, partitionLoadStates map[string]string, collectionLoadState string) {
+	partitionIDs := lo.SliceToMap(partitions, func(partition *entity.Partition) (int64, bool) {
+		return partition.ID, true
+	})
+	partitionIDs[-1] = true
+
+	// partitions and segments left by an earlier backup of the collection are not part of this backup
+	b.meta.ResetCollectionPartitions(collectionBackup.GetCollectionId())
 	partSegInfoMap := make(map[int64][]*backuppb.SegmentBackupInfo)
-	for _, v := range unfilledSegments {
+	for _, v := range segments {
 		segment := v
+		if !partitionIDs[segment.ParititionID] {
+			continue
+		}
 		segmentInfo := &backuppb.SegmentBackupInfo{
 			SegmentId:    segment.ID,
 			CollectionId: segment.CollectionID,
@@ -508,23 +605,42 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 	}
 
 	b.meta.UpdateCollection(collectionBackup.Id, collectionBackup.CollectionId, setCollectionLoadState(collectionLoadState), setCollectionSize(collectionBackupSize))
-	return nil
 }
 
-func (b *BackupContext) backupCollectionExecute(ctx context.Context, collectionBackup *backuppb.CollectionBackupInfo) error {
+// filterPartitions returns the partitions with the names, all of them should exist
+func filterPartitions(partitions []*entity.Partition, names []string) ([]*entity.Partition, error) {
+	selected := make([]*entity.Partition, 0, len(names))
+	for _, name := range names {
+		partition, ok := lo.Find(partitions, func(partition *entity.Partition) bool {
+			return partition.Name == name
+		})
+		if !ok {
+			return nil, fmt.Errorf("partition does not exist: %s", name)
+		}
+		selected = append(selected, partition)
+	}
+	return selected, nil
+}
+
+func (b *BackupContext) backupCollectionExecute(ctx context.Context, collectionBackup *backuppb.CollectionBackupInfo, parentSegments map[int64]*backuppb.SegmentBackupInfo) error {
 	log.Info("backupCollectionExecute", zap.Any("collectionMeta", collectionBackup.String()))
 	backupInfo := b.meta.GetBackupByCollectionID(collectionBackup.GetCollectionId())
 	backupBinlogPath := BackupBinlogDirPath(b.backupRootPath, backupInfo.GetName())
 	for _, partition := range b.meta.GetPartitions(collectionBackup.CollectionId) {
-		segmentBackupInfos := make([]*backuppb.SegmentBackupInfo, 0)
 		var currentSize int64 = 0
 		var groupID int64 = 1
 		// currently not group l0 segments
 		//var currentL0Size int64 = 0
 		//var l0GroupID int64 = 1
+		// segments are copied by groups, groupSegmentIDs is keyed by the group id
+		groupSegmentIDs := make(map[int64][]int64)
 		segments := b.meta.GetSegments(partition.GetPartitionId())
 		for _, v := range segments {
 			segment := v
+			// copied before the backup is resumed, keep its binlogs and group
+			if segment.GetBackuped() {
+				continue
+			}
 			err := b.fillSegmentBackupInfo(ctx, segment)
 			if err != nil {
 				log.Error("Fail to fill segment backup info", zap.Error(err))
@@ -537,6 +653,7 @@ func (b *BackupContext) backupCollectionExecute(ctx context.Context, collectionB
 				}
 				currentSize = currentSize + segment.GetSize()
 				b.meta.UpdateSegment(segment.GetPartitionId(), segment.GetSegmentId(), setGroupID(groupID))
+				groupSegmentIDs[groupID] = append(groupSegmentIDs[groupID], segment.GetSegmentId())
 			} else {
 				//if currentSize > BackupSegmentGroupMaxSizeInMB*1024*1024 { // 256MB
 				//	l0GroupID++
@@ -544,8 +661,8 @@ func (b *BackupContext) backupCollectionExecute(ctx context.Context, collectionB
 				//}
 				//currentL0Size = currentL0Size + segment.GetSize()
 				b.meta.UpdateSegment(segment.GetPartitionId(), segment.GetSegmentId(), setGroupID(segment.GetSegmentId()))
+				groupSegmentIDs[segment.GetSegmentId()] = append(groupSegmentIDs[segment.GetSegmentId()], segment.GetSegmentId())
 			}
-			segmentBackupInfos = append(segmentBackupInfos, segment)
 		}
 		log.Info("Begin copy data",
 			zap.String("dbName", collectionBackup.GetDbName()),
@@ -553,37 +670,41 @@ func (b *BackupContext) backupCollectionExecute(ctx context.Context, collectionB
 			zap.Int64("collectionID", partition.GetCollectionId()),
 			zap.Int64("partitionID", partition.GetPartitionId()))
 
-		sort.SliceStable(segmentBackupInfos, func(i, j int) bool {
-			return segmentBackupInfos[i].Size < segmentBackupInfos[j].Size
+		groupIDs := lo.Keys(groupSegmentIDs)
+		sort.Slice(groupIDs, func(i, j int) bool {
+			return groupIDs[i] < groupIDs[j]
 		})
-
-		segmentIDs := lo.Map(segmentBackupInfos, func(segment *backuppb.SegmentBackupInfo, _ int) int64 {
-			return segment.GetSegmentId()
+		groups := lo.Map(groupIDs, func(groupID int64, _ int) []int64 {
+			return groupSegmentIDs[groupID]
 		})
-		err := b.copySegments(ctx, backupBinlogPath, segmentIDs)
+		err := b.copySegmentGroups(ctx, backupInfo.GetId(), backupBinlogPath, groups, parentSegments)
 		if err != nil {
 			return err
 		}
 	}
 
 	l0Segments := collectionBackup.GetL0Segments()
-	segmentBackupInfos := make([]*backuppb.SegmentBackupInfo, 0)
 	segmentIDs := make([]int64, 0)
 	for _, v := range l0Segments {
 		segment := v
-		err := b.fillSegmentBackupInfo(ctx, segment)
-		if err != nil {
-			log.Error("Fail to fill segment backup info", zap.Error(err))
-			return err
+		if !b.meta.GetSegment(segment.GetSegmentId()).GetBackuped() {
+			err := b.fillSegmentBackupInfo(ctx, segment)
+			if err != nil {
+				log.Error("Fail to fill segment backup info", zap.Error(err))
+				return err
+			}
 		}
 		segmentIDs = append(segmentIDs, segment.GetSegmentId())
-		segmentBackupInfos = append(segmentBackupInfos, b.meta.GetSegment(segment.GetSegmentId()))
 	}
-	err := b.copySegments(ctx, backupBinlogPath, segmentIDs)
+	err := b.copySegments(ctx, backupBinlogPath, segmentIDs, parentSegments)
 	if err != nil {
 		log.Error("Fail to fill segment backup info", zap.Error(err))
 		return err
 	}
+	// collect after copy, so that group id and parent reference are included
+	segmentBackupInfos := lo.Map(segmentIDs, func(segmentID int64, _ int) *backuppb.SegmentBackupInfo {
+		return b.meta.GetSegment(segmentID)
+	})
 	b.meta.UpdateCollection(collectionBackup.Id, collectionBackup.CollectionId, setL0Segments(segmentBackupInfos))
 
 	b.meta.UpdateCollection(collectionBackup.Id, collectionBackup.CollectionId, setCollectionEndTime(time.Now().Unix()))
@@ -631,33 +752,44 @@ func (b *BackupContext) resumeMilvusGC(ctx context.Context, gcAddress string) {
 	log.Info("Resume Milvus GC response", zap.String("response", string(body)), zap.String("address", gcAddress))
 }
 
+// pauseMilvusGCIfEnabled pauses milvus GC if it is enabled by request or config, returns the function to resume GC
+func (b *BackupContext) pauseMilvusGCIfEnabled(ctx context.Context, enable bool, seconds int32, address string) func() {
+	if !enable && !b.params.BackupCfg.GcPauseEnable {
+		return func() {}
+	}
+	var pause = 0
+	if seconds == 0 {
+		pause = b.params.BackupCfg.GcPauseSeconds
+	} else {
+		pause = int(seconds)
+	}
+	var gcAddress string = ""
+	if address == "" {
+		gcAddress = b.params.BackupCfg.GcPauseAddress
+	} else {
+		gcAddress = address
+	}
+	b.pauseMilvusGC(ctx, gcAddress, pause)
+	return func() {
+		// resume GC even if the task is cancelled
+		b.resumeMilvusGC(b.ctx, gcAddress)
+	}
+}
+
 func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest, backupInfo *backuppb.BackupInfo) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	defer b.finishBackupTask(backupInfo.GetId())
 
 	// pause GC
-	if request.GetGcPauseEnable() || b.params.BackupCfg.GcPauseEnable {
-		var pause = 0
-		if request.GetGcPauseSeconds() == 0 {
-			pause = b.params.BackupCfg.GcPauseSeconds
-		} else {
-			pause = int(request.GetGcPauseSeconds())
-		}
-		var gcAddress string = ""
-		if request.GetGcPauseAddress() == "" {
-			gcAddress = b.params.BackupCfg.GcPauseAddress
-		} else {
-			gcAddress = request.GetGcPauseAddress()
-		}
-		b.pauseMilvusGC(ctx, gcAddress, pause)
-		defer b.resumeMilvusGC(ctx, gcAddress)
-	}
+	resumeGC := b.pauseMilvusGCIfEnabled(ctx, request.GetGcPauseEnable(), request.GetGcPauseSeconds(), request.GetGcPauseAddress())
+	defer resumeGC()
 
 	// 1, get collection level meta
 	toBackupCollections, err := b.parseBackupCollections(request)
 	if err != nil {
 		log.Error("parse backup collections from request failed", zap.Error(err))
-		b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL), setErrorMessage(err.Error()))
+		b.failBackup(backupInfo.GetId(), err)
 		return err
 	}
 	collectionNames := make([]string, len(toBackupCollections))
@@ -675,31 +807,27 @@ func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backup
 			}, retry.Sleep(120*time.Second), retry.Attempts(128))
 			return err
 		}
-		jobId := b.getBackupCollectionWorkerPool().SubmitWithId(job)
+		jobId := b.getBackupCollectionWorkerPool().SubmitWithId(cancelableJob(ctx, job))
 		jobIds = append(jobIds, jobId)
 	}
-	err = b.getBackupCollectionWorkerPool().WaitJobs(jobIds)
+	err = b.getBackupCollectionWorkerPool().WaitJobs(ctx, jobIds)
+	if err == nil {
+		err = ctx.Err()
+	}
 	if err != nil {
-		b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL), setErrorMessage(err.Error()))
+		b.failBackup(backupInfo.GetId(), err)
 		return err
 	}
 	log.Info("Finish prepare all collections meta")
 
 	if !request.GetMetaOnly() {
-		for collectionID, collection := range b.meta.GetCollections(backupInfo.GetId()) {
-			collectionClone := collection
-			log.Info("before backupCollectionExecute", zap.Int64("collectionID", collectionID), zap.String("collection", collection.CollectionName))
-			job := func(ctx context.Context) error {
-				err := b.backupCollectionExecute(ctx, collectionClone)
-				return err
-			}
-			jobId := b.getBackupCollectionWorkerPool().SubmitWithId(job)
-			jobIds = append(jobIds, jobId)
-		}
-
-		err = b.getBackupCollectionWorkerPool().WaitJobs(jobIds)
+		// checkpoint the collections meta, so that the backup is able to be resumed from now on
+		b.writeBackupCheckpoint(ctx, backupInfo.GetId())
+		err = b.backupCollectionsData(ctx, backupInfo.GetId(), request.GetParentBackup())
 		if err != nil {
-			b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL), setErrorMessage(err.Error()))
+			b.failBackup(backupInfo.GetId(), err)
+			// the task context may be cancelled
+			b.writeBackupCheckpoint(b.ctx, backupInfo.GetId())
 			return err
 		}
 	} else {
@@ -711,10 +839,11 @@ func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backup
 	// 7, write meta data
 	err = b.writeBackupInfoMeta(ctx, backupInfo.GetId())
 	if err != nil {
-		backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
-		backupInfo.ErrorMessage = err.Error()
+		// the checkpoint is kept, the backup is able to be resumed
+		b.failBackup(backupInfo.GetId(), err)
 		return err
 	}
+	b.removeBackupCheckpoint(ctx, backupInfo.GetName())
 	log.Info("finish executeCreateBackup",
 		zap.String("requestId", request.GetRequestId()),
 		zap.String("backupName", request.GetBackupName()),
@@ -723,10 +852,87 @@ func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backup
 	return nil
 }
 
+// backupCollectionsData copies the data of all the collections in the backup, segments already backuped are skipped
+func (b *BackupContext) backupCollectionsData(ctx context.Context, backupID string, parentBackup string) error {
+	parentSegments, err := b.getParentSegments(ctx, parentBackup)
+	if err != nil {
+		log.Error("fail to read parent backup", zap.String("parentBackup", parentBackup), zap.Error(err))
+		return err
+	}
+	jobIds := make([]int64, 0)
+	for collectionID, collection := range b.meta.GetCollections(backupID) {
+		collectionClone := collection
+		log.Info("before backupCollectionExecute", zap.Int64("collectionID", collectionID), zap.String("collection", collection.CollectionName))
+		job := func(ctx context.Context) error {
+			err := b.backupCollectionExecute(ctx, collectionClone, parentSegments)
+			return err
+		}
+		jobId := b.getBackupCollectionWorkerPool().SubmitWithId(cancelableJob(ctx, job))
+		jobIds = append(jobIds, jobId)
+	}
+	err = b.getBackupCollectionWorkerPool().WaitJobs(ctx, jobIds)
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// writeBackupCheckpoint saves the progress of the backup into its meta directory and the task store,
+// failure only logs a warning as it doesn't break the backup itself
+func (b *BackupContext) writeBackupCheckpoint(ctx context.Context, id string) {
+	b.meta.persistBackupTask(id)
+	backupInfo := b.meta.GetFullMeta(id)
+	if backupInfo == nil {
+		return
+	}
+	err := b.writeEncryptionMeta(ctx, backupInfo)
+	if err == nil {
+		err = b.writeCompressionMeta(ctx, backupInfo)
+	}
+	var checkpointBytes []byte
+	if err == nil {
+		checkpointBytes, err = json.Marshal(backupInfo)
+	}
+	if err == nil {
+		err = b.writeBackupMetaFile(ctx, backupInfo, CheckpointMetaPath(b.backupRootPath, backupInfo.GetName()), checkpointBytes)
+	}
+	// blob refs of the copied binlogs are needed to resume a deduplicated backup
+	manifest := b.meta.GetBlobManifest(id)
+	if err == nil && manifest != nil {
+		var manifestBytes []byte
+		manifestBytes, err = json.Marshal(manifest)
+		if err == nil {
+			err = b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, BlobManifestPath(b.backupRootPath, backupInfo.GetName()), manifestBytes)
+		}
+	}
+	// checksums of the binlogs copied before are kept by a resumed backup
+	checksumManifest := b.meta.GetChecksumManifest(id)
+	if err == nil && checksumManifest != nil {
+		var manifestBytes []byte
+		manifestBytes, err = json.Marshal(checksumManifest)
+		if err == nil {
+			err = b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, ChecksumManifestPath(b.backupRootPath, backupInfo.GetName()), manifestBytes)
+		}
+	}
+	if err != nil {
+		log.Warn("fail to write backup checkpoint", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+	}
+}
+
+func (b *BackupContext) removeBackupCheckpoint(ctx context.Context, backupName string) {
+	err := b.getStorageClient(storage.BackupStorage).Remove(ctx, b.backupBucketName, CheckpointMetaPath(b.backupRootPath, backupName))
+	if err != nil {
+		log.Warn("fail to remove backup checkpoint", zap.String("backupName", backupName), zap.Error(err))
+	}
+}
+
 func (b *BackupContext) writeBackupInfoMeta(ctx context.Context, id string) error {
 	backupInfo := b.meta.GetFullMeta(id)
 	log.Info("Final backupInfo", zap.String("backupInfo", backupInfo.String()))
-	output, _ := serialize(backupInfo)
+	output, err := serialize(backupInfo)
+	if err != nil {
+		return err
+	}
 	log.Debug("backup meta", zap.String("value", string(output.BackupMetaBytes)))
 	log.Info("collection meta", zap.String("value", string(output.CollectionMetaBytes)))
 	log.Debug("partition meta", zap.String("value", string(output.PartitionMetaBytes)))
@@ -751,12 +957,71 @@ func (b *BackupContext) writeBackupInfoMeta(ctx context.Context, id string) erro
 	}
 	log.Debug("channel cp meta", zap.String("value", string(channelCPsBytes)))
 
-	b.getStorageClient().Write(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, backupInfo.GetName()), output.BackupMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, CollectionMetaPath(b.backupRootPath, backupInfo.GetName()), output.CollectionMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, PartitionMetaPath(b.backupRootPath, backupInfo.GetName()), output.PartitionMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, SegmentMetaPath(b.backupRootPath, backupInfo.GetName()), output.SegmentMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, FullMetaPath(b.backupRootPath, backupInfo.GetName()), output.FullMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, ChannelCPMetaPath(b.backupRootPath, backupInfo.GetName()), channelCPsBytes)
+	// retry each write as a whole, so that a partial write is overwritten
+	writeWithRetry := func(filePath string, write func() error) error {
+		err := retry.Do(ctx, write, b.storageRetryOptions()...)
+		if err != nil {
+			log.Error("fail to write backup meta after retry", zap.String("path", filePath), zap.Error(err))
+		}
+		return err
+	}
+	err = writeWithRetry(EncryptionMetaPath(b.backupRootPath, backupInfo.GetName()), func() error {
+		return b.writeEncryptionMeta(ctx, backupInfo)
+	})
+	if err != nil {
+		return err
+	}
+	err = writeWithRetry(CompressionMetaPath(b.backupRootPath, backupInfo.GetName()), func() error {
+		return b.writeCompressionMeta(ctx, backupInfo)
+	})
+	if err != nil {
+		return err
+	}
+	if manifest := b.meta.GetBlobManifest(id); manifest != nil {
+		manifestBytes, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		err = writeWithRetry(BlobManifestPath(b.backupRootPath, backupInfo.GetName()), func() error {
+			return b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, BlobManifestPath(b.backupRootPath, backupInfo.GetName()), manifestBytes)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if manifest := b.meta.GetChecksumManifest(id); manifest != nil {
+		manifestBytes, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		err = writeWithRetry(ChecksumManifestPath(b.backupRootPath, backupInfo.GetName()), func() error {
+			return b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, ChecksumManifestPath(b.backupRootPath, backupInfo.GetName()), manifestBytes)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// the backup meta is written at last, the backup is not visible until all the other meta files are written
+	metaFiles := []struct {
+		path    string
+		content []byte
+	}{
+		{CollectionMetaPath(b.backupRootPath, backupInfo.GetName()), output.CollectionMetaBytes},
+		{PartitionMetaPath(b.backupRootPath, backupInfo.GetName()), output.PartitionMetaBytes},
+		{SegmentMetaPath(b.backupRootPath, backupInfo.GetName()), output.SegmentMetaBytes},
+		{FullMetaPath(b.backupRootPath, backupInfo.GetName()), output.FullMetaBytes},
+		{ChannelCPMetaPath(b.backupRootPath, backupInfo.GetName()), channelCPsBytes},
+		{BackupMetaPath(b.backupRootPath, backupInfo.GetName()), output.BackupMetaBytes},
+	}
+	for _, metaFile := range metaFiles {
+		metaFile := metaFile
+		err = writeWithRetry(metaFile.path, func() error {
+			return b.writeBackupMetaFile(ctx, backupInfo, metaFile.path, metaFile.content)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	log.Info("finish writeBackupInfoMeta",
 		zap.String("path", BackupDirPath(b.backupRootPath, backupInfo.GetName())),
@@ -765,20 +1030,160 @@ func (b *BackupContext) writeBackupInfoMeta(ctx context.Context, id string) erro
 	return nil
 }
 
-func (b *BackupContext) copySegments(ctx context.Context, backupBinlogPath string, segmentIDs []int64) error {
+// getParentSegments reads the parent backup and returns its segments by segment id.
+// The ref_backup of every returned segment points to the backup which physically holds the binlogs,
+// so that a chain of incremental backups always references the data directly.
+func (b *BackupContext) getParentSegments(ctx context.Context, parentBackup string) (map[int64]*backuppb.SegmentBackupInfo, error) {
+	parentSegments := make(map[int64]*backuppb.SegmentBackupInfo, 0)
+	if parentBackup == "" {
+		return parentSegments, nil
+	}
+	parent, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+parentBackup)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errors.New("parent backup does not exist: " + parentBackup)
+	}
+	for _, collection := range parent.GetCollectionBackups() {
+		segments := collection.GetL0Segments()
+		for _, partition := range collection.GetPartitionBackups() {
+			segments = append(segments, partition.GetSegmentBackups()...)
+		}
+		for _, segment := range segments {
+			// segments of old backups are not stored in their own group directory, can not be referenced
+			if !segment.GetBackuped() || segment.GetGroupId() == 0 {
+				continue
+			}
+			parentSegment := proto.Clone(segment).(*backuppb.SegmentBackupInfo)
+			if parentSegment.GetRefBackup() == "" {
+				parentSegment.RefBackup = parentBackup
+			}
+			parentSegments[segment.GetSegmentId()] = parentSegment
+		}
+	}
+	log.Info("read parent backup segments", zap.String("parentBackup", parentBackup), zap.Int("segmentNum", len(parentSegments)))
+	return parentSegments, nil
+}
+
+// isSameSegmentBinlogs returns true if the two segments have exactly the same insert logs and delta logs
+func isSameSegmentBinlogs(segment, other *backuppb.SegmentBackupInfo) bool {
+	binlogsDict := func(fieldBinlogs []*backuppb.FieldBinlog) map[string]int64 {
+		dict := make(map[string]int64, 0)
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				dict[binlog.GetLogPath()] = binlog.GetLogSize()
+			}
+		}
+		return dict
+	}
+	isSame := func(a, b map[string]int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for path, size := range a {
+			if otherSize, ok := b[path]; !ok || otherSize != size {
+				return false
+			}
+		}
+		return true
+	}
+	return segment.GetIsL0() == other.GetIsL0() &&
+		isSame(binlogsDict(segment.GetBinlogs()), binlogsDict(other.GetBinlogs())) &&
+		isSame(binlogsDict(segment.GetDeltalogs()), binlogsDict(other.GetDeltalogs()))
+}
+
+func (b *BackupContext) copySegments(ctx context.Context, backupBinlogPath string, segmentIDs []int64, parentSegments map[int64]*backuppb.SegmentBackupInfo) error {
+	jobIds := b.submitCopySegments(ctx, backupBinlogPath, segmentIDs, parentSegments)
+	return b.waitCopySegments(ctx, jobIds)
+}
+
+// copySegmentGroups copies the groups of segments at the same time, the backup is checkpointed once a group is copied,
+// so that a resumed backup doesn't copy the finished groups again
+func (b *BackupContext) copySegmentGroups(ctx context.Context, backupID string, backupBinlogPath string, groups [][]int64, parentSegments map[int64]*backuppb.SegmentBackupInfo) error {
+	groupJobIds := lo.Map(groups, func(segmentIDs []int64, _ int) []int64 {
+		return b.submitCopySegments(ctx, backupBinlogPath, segmentIDs, parentSegments)
+	})
+	for _, jobIds := range groupJobIds {
+		err := b.waitCopySegments(ctx, jobIds)
+		// the segments copied before the failure are kept as well
+		b.writeBackupCheckpoint(ctx, backupID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// submitCopySegments submits the copy jobs of the segments which are not backuped yet, returns the ids of the jobs
+func (b *BackupContext) submitCopySegments(ctx context.Context, backupBinlogPath string, segmentIDs []int64, parentSegments map[int64]*backuppb.SegmentBackupInfo) []int64 {
 	jobIds := make([]int64, 0)
 	for _, v := range segmentIDs {
 		segmentID := v
 		segment := b.meta.GetSegment(segmentID)
+		if segment.GetBackuped() {
+			log.Debug("segment has been backuped, skip copy", zap.Int64("segment_id", segmentID))
+			continue
+		}
+		// unchanged since parent backup, only record the reference
+		if parentSegment, ok := parentSegments[segmentID]; ok && isSameSegmentBinlogs(segment, parentSegment) {
+			log.Debug("segment is unchanged since parent backup, skip copy",
+				zap.Int64("segment_id", segmentID),
+				zap.String("ref_backup", parentSegment.GetRefBackup()))
+			b.meta.UpdateSegment(segment.GetPartitionId(), segmentID,
+				setSegmentGroupId(parentSegment.GetGroupId()),
+				setSegmentRefBackup(parentSegment.GetRefBackup()),
+				// keep the compression codecs of the binlogs in the referenced backup
+				setSegmentBinlogs(parentSegment.GetBinlogs()),
+				setSegmentDeltaBinlogs(parentSegment.GetDeltalogs()),
+				setSegmentBackuped(true))
+			continue
+		}
 		job := func(ctx context.Context) error {
 			return b.copySegment(ctx, backupBinlogPath, segment)
 		}
-		jobId := b.getCopyDataWorkerPool().SubmitWithId(job)
+		jobId := b.getCopyDataWorkerPool().SubmitWithId(cancelableJob(ctx, job))
 		jobIds = append(jobIds, jobId)
 	}
+	return jobIds
+}
+
+func (b *BackupContext) waitCopySegments(ctx context.Context, jobIds []int64) error {
+	err := b.getCopyDataWorkerPool().WaitJobs(ctx, jobIds)
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// binlogBackupPath generates the target path of a binlog in backup
+// milvus_rootpath/insert_log/collection_id/partition_id/segment_id/ =>
+// backup_rootpath/backup_name/binlog/insert_log/collection_id/partition_id/group_id/segment_id
+func binlogBackupPath(binlogPath, milvusRootPath, backupBinlogPath string, partitionID, groupID int64) string {
+	var targetPath string
+	if milvusRootPath == "" {
+		targetPath = backupBinlogPath + SEPERATOR + binlogPath
+	} else {
+		targetPath = strings.Replace(binlogPath, milvusRootPath, backupBinlogPath, 1)
+	}
+	if groupID != 0 {
+		targetPath = strings.Replace(targetPath,
+			strconv.FormatInt(partitionID, 10),
+			strconv.FormatInt(partitionID, 10)+"/"+strconv.FormatInt(groupID, 10),
+			1)
+	}
+	return targetPath
+}
 
-	err := b.getCopyDataWorkerPool().WaitJobs(jobIds)
-	return err
+// binlogExist checks whether the binlog exists in milvus bucket, failed checks are retried
+func (b *BackupContext) binlogExist(ctx context.Context, binlogPath string) (bool, error) {
+	var exist bool
+	err := retry.Do(ctx, func() error {
+		var err error
+		exist, err = b.getThrottledStorageClient(storage.MilvusStorage).Exist(ctx, b.milvusBucketName, binlogPath)
+		return err
+	}, b.storageRetryOptions()...)
+	return exist, err
 }
 
 func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string, segment *backuppb.SegmentBackupInfo) error {
@@ -787,34 +1192,22 @@ func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string
 		zap.Int64("segment_id", segment.GetSegmentId()),
 		zap.Int64("group_id", segment.GetGroupId()))
 	log.Info("copy segment", zap.String("backupBinlogPath", backupBinlogPath))
-	// generate target path
-	// milvus_rootpath/insert_log/collection_id/partition_id/segment_id/ =>
-	// backup_rootpath/backup_name/binlog/insert_log/collection_id/partition_id/group_id/segment_id
-	backupPathFunc := func(binlogPath, rootPath, backupBinlogPath string) string {
-		if rootPath == "" {
-			return backupBinlogPath + SEPERATOR + binlogPath
-		} else {
-			return strings.Replace(binlogPath, rootPath, backupBinlogPath, 1)
-		}
-	}
+	backup := b.meta.GetBackupByCollectionID(segment.GetCollectionId())
+	backupID := backup.GetId()
+	// the codec recorded on creating the backup, so that a resumed backup compresses the binlogs in the same way
+	codec := backup.GetBinlogCompression()
 	// insert log
 	for _, binlogs := range segment.GetBinlogs() {
 		for _, binlog := range binlogs.GetBinlogs() {
-			targetPath := backupPathFunc(binlog.GetLogPath(), b.milvusRootPath, backupBinlogPath)
 			// use segmentID as group id
 			segment.GroupId = segment.SegmentId
-			if segment.GetGroupId() != 0 {
-				targetPath = strings.Replace(targetPath,
-					strconv.FormatInt(segment.GetPartitionId(), 10),
-					strconv.FormatInt(segment.GetPartitionId(), 10)+"/"+strconv.FormatInt(segment.GetGroupId(), 10),
-					1)
-			}
+			targetPath := binlogBackupPath(binlog.GetLogPath(), b.milvusRootPath, backupBinlogPath, segment.GetPartitionId(), segment.GetGroupId())
 			if targetPath == binlog.GetLogPath() {
 				return errors.New(fmt.Sprintf("copy src path and dst path can not be the same, src: %s dst: %s", binlog.GetLogPath(), targetPath))
 			}
 
 			//binlog := binlog
-			exist, err := b.getStorageClient().Exist(ctx, b.milvusBucketName, binlog.GetLogPath())
+			exist, err := b.binlogExist(ctx, binlog.GetLogPath())
 			if err != nil {
 				log.Info("Fail to check file exist",
 					zap.Error(err),
@@ -825,12 +1218,10 @@ func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string
 				log.Error("Binlog file not exist",
 					zap.Error(err),
 					zap.String("file", binlog.GetLogPath()))
-				return err
+				return errors.New("Binlog file not exist " + binlog.GetLogPath())
 			}
 
-			err = retry.Do(ctx, func() error {
-				return b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, binlog.GetLogPath(), targetPath)
-			}, retry.Sleep(2*time.Second), retry.Attempts(5))
+			err = b.copyBinlog(ctx, backupID, binlog.GetLogPath(), targetPath, codec)
 			if err != nil {
 				log.Info("Fail to copy file after retry",
 					zap.Error(err),
@@ -847,19 +1238,13 @@ func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string
 	// delta log
 	for _, binlogs := range segment.GetDeltalogs() {
 		for _, binlog := range binlogs.GetBinlogs() {
-			targetPath := backupPathFunc(binlog.GetLogPath(), b.milvusRootPath, backupBinlogPath)
-			if segment.GetGroupId() != 0 {
-				targetPath = strings.Replace(targetPath,
-					strconv.FormatInt(segment.GetPartitionId(), 10),
-					strconv.FormatInt(segment.GetPartitionId(), 10)+"/"+strconv.FormatInt(segment.GetGroupId(), 10),
-					1)
-			}
+			targetPath := binlogBackupPath(binlog.GetLogPath(), b.milvusRootPath, backupBinlogPath, segment.GetPartitionId(), segment.GetGroupId())
 			if targetPath == binlog.GetLogPath() {
 				return errors.New(fmt.Sprintf("copy src path and dst path can not be the same, src: %s dst: %s", binlog.GetLogPath(), targetPath))
 			}
 
 			//binlog := binlog
-			exist, err := b.getStorageClient().Exist(ctx, b.milvusBucketName, binlog.GetLogPath())
+			exist, err := b.binlogExist(ctx, binlog.GetLogPath())
 			if err != nil {
 				log.Info("Fail to check file exist",
 					zap.Error(err),
@@ -872,9 +1257,7 @@ func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string
 					zap.String("file", binlog.GetLogPath()))
 				return errors.New("Binlog file not exist " + binlog.GetLogPath())
 			}
-			err = retry.Do(ctx, func() error {
-				return b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, binlog.GetLogPath(), targetPath)
-			}, retry.Sleep(2*time.Second), retry.Attempts(5))
+			err = b.copyBinlog(ctx, backupID, binlog.GetLogPath(), targetPath, codec)
 			if err != nil {
 				log.Info("Fail to copy file after retry",
 					zap.Error(err),
@@ -888,7 +1271,9 @@ func (b *BackupContext) copySegment(ctx context.Context, backupBinlogPath string
 			}
 		}
 	}
-	b.meta.UpdateSegment(segment.GetPartitionId(), segment.GetSegmentId(), setSegmentBackuped(true))
+	b.meta.UpdateSegment(segment.GetPartitionId(), segment.GetSegmentId(),
+		setSegmentBinlogCompression(codec),
+		setSegmentBackuped(true))
 	return nil
 }
 
@@ -904,7 +1289,7 @@ func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackup
 
 	insertPath := fmt.Sprintf("%s%s/%v/%v/%v/", rootPath, "insert_log", segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId())
 	log.Debug("insertPath", zap.String("bucket", b.milvusBucketName), zap.String("insertPath", insertPath))
-	fieldsLogDir, _, err := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, insertPath, false)
+	fieldsLogDir, _, err := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, insertPath, false)
 	// handle segment level
 	isL0 := false
 	if len(fieldsLogDir) == 0 {
@@ -917,7 +1302,7 @@ func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackup
 	log.Debug("fieldsLogDir", zap.String("bucket", b.milvusBucketName), zap.Any("fieldsLogDir", fieldsLogDir))
 	insertLogs := make([]*backuppb.FieldBinlog, 0)
 	for _, fieldLogDir := range fieldsLogDir {
-		binlogPaths, sizes, _ := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, fieldLogDir, false)
+		binlogPaths, sizes, _ := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, fieldLogDir, false)
 		fieldIdStr := strings.Replace(strings.Replace(fieldLogDir, insertPath, "", 1), SEPERATOR, "", -1)
 		fieldId, _ := strconv.ParseInt(fieldIdStr, 10, 64)
 		binlogs := make([]*backuppb.Binlog, 0)
@@ -935,10 +1320,10 @@ func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackup
 	}
 
 	deltaLogPath := fmt.Sprintf("%s%s/%v/%v/%v/", rootPath, "delta_log", segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId())
-	deltaFieldsLogDir, _, _ := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, deltaLogPath, false)
+	deltaFieldsLogDir, _, _ := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, deltaLogPath, false)
 	deltaLogs := make([]*backuppb.FieldBinlog, 0)
 	for _, deltaFieldLogDir := range deltaFieldsLogDir {
-		binlogPaths, sizes, _ := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, deltaFieldLogDir, false)
+		binlogPaths, sizes, _ := b.getStorageClient(storage.MilvusStorage).ListWithPrefix(ctx, b.milvusBucketName, deltaFieldLogDir, false)
 		fieldIdStr := strings.Replace(strings.Replace(deltaFieldLogDir, deltaLogPath, "", 1), SEPERATOR, "", -1)
 		fieldId, _ := strconv.ParseInt(fieldIdStr, 10, 64)
 		binlogs := make([]*backuppb.Binlog, 0)
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+// ids are large so that they don't appear in the temporary directories of the tests
+const (
+	testCollectionID int64 = 449000000001
+	testPartitionID  int64 = 449000000002
+)
+
+// newCreateTestContext returns a context whose milvus root path is under the local storage,
+// faults of the rules are injected into the storage
+func newCreateTestContext(t *testing.T, rules ...storage.FaultRule) *BackupContext {
+	b := newFaultTestContext(t, rules...)
+	b.params.MinioCfg.RootPath = b.milvusRootPath
+	b.params.BackupCfg.BackupCollectionParallelism = 1
+	b.params.BackupCfg.BackupCopyDataParallelism = 4
+	b.taskCancelers = make(map[string]*taskCanceler)
+	b.started = true
+	return b
+}
+
+// writeTestSegment writes an insert binlog of the segment into milvus storage
+func writeTestSegment(t *testing.T, b *BackupContext, segmentID int64) {
+	binlogPath := fmt.Sprintf("%s/insert_log/%d/%d/%d/100/1", b.milvusRootPath, testCollectionID, testPartitionID, segmentID)
+	require.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(context.Background(), b.milvusBucketName, binlogPath, []byte(fmt.Sprintf("segment %d", segmentID))))
+}
+
+// prepareTestBackup adds a backup of the test collection with the segments into meta, as backupCollectionPrepare does
+func prepareTestBackup(b *BackupContext, name string, segmentIDs ...int64) {
+	b.meta.AddBackup(&backuppb.BackupInfo{Id: name, Name: name, StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING})
+	collectionBackup := &backuppb.CollectionBackupInfo{Id: name, CollectionId: testCollectionID, CollectionName: "coll"}
+	b.meta.AddCollection(collectionBackup)
+	segments := make([]*entity.Segment, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		segments = append(segments, &entity.Segment{ID: segmentID, CollectionID: testCollectionID, ParititionID: testPartitionID, NumRows: 1})
+	}
+	partitions := []*entity.Partition{{ID: testPartitionID, Name: "_default"}}
+	b.addCollectionPartitions(collectionBackup, partitions, segments, map[string]string{}, LoadState_NotLoad)
+}
+
+func backupSegmentIDs(backup *backuppb.BackupInfo) []int64 {
+	ids := make([]int64, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				ids = append(ids, segment.GetSegmentId())
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func backupSegmentExist(t *testing.T, b *BackupContext, backupName string, segmentID int64) bool {
+	binlogPath := fmt.Sprintf("%s/%s/binlogs/insert_log/%d/%d/%d/%d/100/1", b.backupRootPath, backupName, testCollectionID, testPartitionID, segmentID, segmentID)
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(context.Background(), b.backupBucketName, binlogPath)
+	require.NoError(t, err)
+	return exist
+}
+
+func TestConsecutiveBackupsOfCollection(t *testing.T) {
+	ctx := context.Background()
+	b := newCreateTestContext(t)
+	for _, segmentID := range []int64{10, 11, 12} {
+		writeTestSegment(t, b, segmentID)
+	}
+
+	prepareTestBackup(b, "first", 10, 11)
+	require.NoError(t, b.backupCollectionsData(ctx, "first", ""))
+	assert.Equal(t, []int64{10, 11}, backupSegmentIDs(b.meta.GetFullMeta("first")))
+
+	// segment 10 is compacted into segment 12 before the second backup
+	prepareTestBackup(b, "second", 11, 12)
+	require.NoError(t, b.backupCollectionsData(ctx, "second", ""))
+	second := b.meta.GetFullMeta("second")
+	assert.Equal(t, []int64{11, 12}, backupSegmentIDs(second))
+	assert.Equal(t, int32(100), second.GetProgress())
+	assert.False(t, backupSegmentExist(t, b, "second", 10))
+	assert.True(t, backupSegmentExist(t, b, "second", 11))
+	assert.True(t, backupSegmentExist(t, b, "second", 12))
+}
+
+func TestCopySegmentGroupsCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	b := newCreateTestContext(t, storage.FaultRule{
+		Name:        "source of segment 11",
+		Operations:  []string{"Exist"},
+		PathPattern: fmt.Sprintf("insert_log/%d/%d/11/", testCollectionID, testPartitionID),
+		ErrorRate:   1,
+	})
+	for _, segmentID := range []int64{10, 11} {
+		writeTestSegment(t, b, segmentID)
+	}
+	prepareTestBackup(b, "groups", 10, 11)
+	for _, segmentID := range []int64{10, 11} {
+		require.NoError(t, b.fillSegmentBackupInfo(ctx, b.meta.GetSegment(segmentID)))
+	}
+
+	backupBinlogPath := BackupBinlogDirPath(b.backupRootPath, "groups")
+	err := b.copySegmentGroups(ctx, "groups", backupBinlogPath, [][]int64{{10}, {11}}, nil)
+	assert.Error(t, err)
+
+	// the group copied before the failure is checkpointed
+	checkpoint, err := b.readBackupCheckpoint(ctx, "groups")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	backuped := make(map[int64]bool)
+	for _, partition := range checkpoint.GetCollectionBackups()[0].GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			backuped[segment.GetSegmentId()] = segment.GetBackuped()
+		}
+	}
+	assert.Equal(t, map[int64]bool{10: true, 11: false}, backuped)
+}
+
+func TestCopySegmentRecordedCompression(t *testing.T) {
+	ctx := context.Background()
+	b := newCreateTestContext(t)
+	writeTestSegment(t, b, 10)
+	prepareTestBackup(b, "zstd", 10)
+	b.meta.GetBackup("zstd").BinlogCompression = compression.Zstd
+
+	// the binlogs are compressed by the codec recorded on the backup rather than the config
+	b.params.BackupCfg.CompressBinlog = false
+	require.NoError(t, b.backupCollectionsData(ctx, "zstd", ""))
+	binlogPath := fmt.Sprintf("%s/zstd/binlogs/insert_log/%d/%d/10/10/100/1", b.backupRootPath, testCollectionID, testPartitionID)
+	raw, err := b.getStorageClient(storage.BackupStorage).Read(ctx, b.backupBucketName, binlogPath)
+	require.NoError(t, err)
+	assert.Equal(t, compression.Zstd, compression.Detect(raw))
+	assert.Equal(t, compression.Zstd, b.meta.GetSegment(10).GetBinlogs()[0].GetBinlogs()[0].GetCompression())
+}
+
+func TestRebuildBackupFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	b := newCreateTestContext(t)
+	for _, segmentID := range []int64{10, 11} {
+		writeTestSegment(t, b, segmentID)
+	}
+	prepareTestBackup(b, "interrupted", 10, 11)
+	require.NoError(t, b.backupCollectionsData(ctx, "interrupted", ""))
+	checkpoint, err := b.readBackupCheckpoint(ctx, "interrupted")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+
+	// binlogs of segment 11 are lost, it is copied again by the resumed backup
+	require.NoError(t, b.getStorageClient(storage.BackupStorage).RemoveWithPrefix(ctx, b.backupBucketName,
+		fmt.Sprintf("%s/interrupted/binlogs/insert_log/%d/%d/11/", b.backupRootPath, testCollectionID, testPartitionID)))
+	backup, err := b.rebuildBackupFromCheckpoint(ctx, "resumed", checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "interrupted", backup.GetName())
+	assert.Nil(t, b.meta.GetBackup("interrupted"))
+	assert.True(t, b.meta.GetSegment(10).GetBackuped())
+	assert.False(t, b.meta.GetSegment(11).GetBackuped())
+
+	require.NoError(t, b.backupCollectionsData(ctx, "resumed", ""))
+	assert.True(t, backupSegmentExist(t, b, "interrupted", 11))
+	assert.Equal(t, []int64{10, 11}, backupSegmentIDs(b.meta.GetFullMeta("resumed")))
+}
+
+func TestResumeBackup(t *testing.T) {
+	ctx := context.Background()
+	b := newCreateTestContext(t)
+	for _, segmentID := range []int64{10, 11} {
+		writeTestSegment(t, b, segmentID)
+	}
+
+	resp := b.ResumeBackup(ctx, &backuppb.ResumeBackupRequest{BackupName: "interrupted"})
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, resp.GetCode())
+
+	// the backup is interrupted after the collection meta is checkpointed
+	prepareTestBackup(b, "interrupted", 10, 11)
+	b.writeBackupCheckpoint(ctx, "interrupted")
+	b.meta.UpdateBackup("interrupted", setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL))
+
+	resp = b.ResumeBackup(ctx, &backuppb.ResumeBackupRequest{BackupName: "interrupted", RequestId: "resumed"})
+	require.Equal(t, backuppb.ResponseCode_Success, resp.GetCode(), resp.GetMsg())
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_SUCCESS, resp.GetData().GetStateCode())
+	assert.True(t, backupSegmentExist(t, b, "interrupted", 10))
+	assert.True(t, backupSegmentExist(t, b, "interrupted", 11))
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+"interrupted")
+	require.NoError(t, err)
+	require.NotNil(t, backup)
+	assert.Equal(t, []int64{10, 11}, backupSegmentIDs(backup))
+
+	// the checkpoint is removed once the backup is completed
+	resp = b.ResumeBackup(ctx, &backuppb.ResumeBackupRequest{BackupName: "interrupted"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+}
+
+func TestFilterPartitions(t *testing.T) {
+	partitions := []*entity.Partition{{ID: 1, Name: "_default"}, {ID: 2, Name: "p1"}, {ID: 3, Name: "p2"}}
+	selected, err := filterPartitions(partitions, []string{"p2", "_default"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Partition{{ID: 3, Name: "p2"}, {ID: 1, Name: "_default"}}, selected)
+
+	_, err = filterPartitions(partitions, []string{"p1", "p3"})
+	assert.Error(t, err)
+}
+
+func TestAddSelectedCollectionPartitions(t *testing.T) {
+	b := newCreateTestContext(t)
+	b.meta.AddBackup(&backuppb.BackupInfo{Id: "selected", Name: "selected", StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING})
+	collectionBackup := &backuppb.CollectionBackupInfo{Id: "selected", CollectionId: testCollectionID, CollectionName: "coll"}
+	b.meta.AddCollection(collectionBackup)
+
+	partitions, err := filterPartitions([]*entity.Partition{{ID: testPartitionID, Name: "p1"}, {ID: testPartitionID + 1, Name: "p2"}}, []string{"p1"})
+	require.NoError(t, err)
+	segments := []*entity.Segment{
+		{ID: 10, CollectionID: testCollectionID, ParititionID: testPartitionID, NumRows: 1},
+		{ID: 11, CollectionID: testCollectionID, ParititionID: testPartitionID + 1, NumRows: 1},
+		// l0 segment of the collection
+		{ID: 12, CollectionID: testCollectionID, ParititionID: -1},
+	}
+	b.addCollectionPartitions(collectionBackup, partitions, segments, map[string]string{"p1": LoadState_Loaded}, LoadState_Loaded)
+
+	// the segments of the partitions not selected are not backuped, the l0 segments of the collection are
+	backupPartitions := b.meta.GetPartitions(testCollectionID)
+	require.Len(t, backupPartitions, 1)
+	partition := backupPartitions[testPartitionID]
+	require.NotNil(t, partition)
+	assert.Equal(t, "p1", partition.GetPartitionName())
+	assert.Equal(t, LoadState_Loaded, partition.GetLoadState())
+	assert.Equal(t, []int64{10}, lo.Map(partition.GetSegmentBackups(), func(segment *backuppb.SegmentBackupInfo, _ int) int64 {
+		return segment.GetSegmentId()
+	}))
+	assert.NotNil(t, b.meta.GetSegment(10))
+	assert.Nil(t, b.meta.GetSegment(11))
+	assert.NotNil(t, b.meta.GetSegment(12))
+	collection := b.meta.GetFullMeta("selected").GetCollectionBackups()[0]
+	assert.Equal(t, []int64{12}, lo.Map(collection.GetL0Segments(), func(segment *backuppb.SegmentBackupInfo, _ int) int64 {
+		return segment.GetSegmentId()
+	}))
+	assert.Equal(t, LoadState_Loaded, collection.GetLoadState())
+}
+
+func TestBackupCollectionPrepareWithoutAliases(t *testing.T) {
+	client := newFakeMilvusClient()
+	b := newRestoreTestContext(t, client)
+	client.collections["coll"] = &entity.Collection{
+		ID:   testCollectionID,
+		Name: "coll",
+		Schema: &entity.Schema{
+			CollectionName: "coll",
+			Fields:         []*entity.Field{{ID: 100, Name: "id", PrimaryKey: true, DataType: entity.FieldTypeInt64}},
+		},
+	}
+	backupInfo := &backuppb.BackupInfo{Id: "backup"}
+
+	// the fake milvus doesn't list aliases, the collection is backed up without them
+	err := b.backupCollectionPrepare(context.Background(), backupInfo, collectionStruct{db: "default", collectionName: "coll"}, false)
+	assert.ErrorContains(t, err, "show partitions")
+	collection := b.meta.GetCollections("backup")[testCollectionID]
+	require.NotNil(t, collection)
+	assert.Empty(t, collection.GetAliases())
+	assert.Equal(t, "id", collection.GetSchema().GetFields()[0].GetName())
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// restoredAliases renames the aliases of the collection backup in the same way as the collection, by the full name
+// in collectionRenames or by the suffix. An alias belongs to the database of its collection, the database of a renamed alias is ignored.
+// The suffix is not applied if swap is set, so that the original aliases are swapped onto the restored collection.
+func restoredAliases(collection *backuppb.CollectionBackupInfo, collectionRenames map[string]string, suffix string, swap bool) []string {
+	if swap {
+		suffix = ""
+	}
+	aliases := make([]string, 0, len(collection.GetAliases()))
+	for _, alias := range collection.GetAliases() {
+		if newName := collectionRenames[collection.GetDbName()+"."+alias]; newName != "" {
+			aliases = append(aliases, newName[strings.Index(newName, ".")+1:])
+		} else {
+			aliases = append(aliases, alias+suffix)
+		}
+	}
+	return aliases
+}
+
+// aliasConflicts returns the conflicts of the aliases to restore which fail the restore, an alias of another collection
+// conflicts unless swap_aliases is set, and an alias named after an existing collection always conflicts
+func (b *BackupContext) aliasConflicts(ctx context.Context, task *backuppb.RestoreCollectionTask) ([]string, error) {
+	targetDBName := task.GetTargetDbName()
+	conflicts := make([]string, 0)
+	for _, alias := range task.GetAliases() {
+		// an alias is resolved to its collection by milvus
+		exist, err := b.getMilvusClient().HasCollection(ctx, targetDBName, alias)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			continue
+		}
+		collection, err := b.getMilvusClient().DescribeCollection(ctx, targetDBName, alias)
+		if err != nil {
+			return nil, err
+		}
+		switch collection.Name {
+		case task.GetTargetCollectionName():
+		case alias:
+			conflicts = append(conflicts, fmt.Sprintf("alias %s.%s is the name of an existing collection", targetDBName, alias))
+		default:
+			if !task.GetSwapAliases() {
+				conflicts = append(conflicts, fmt.Sprintf("alias %s.%s already exists on collection %s, set swap_aliases to move it", targetDBName, alias, collection.Name))
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// restoreAliases creates the aliases of the restored collection, the aliases which already point to it are skipped.
+// An alias of another collection fails the task, unless swap_aliases is set to alter it onto the restored collection in one step.
+func (b *BackupContext) restoreAliases(ctx context.Context, task *backuppb.RestoreCollectionTask) error {
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	log := log.With(
+		zap.String("target_db_name", targetDBName),
+		zap.String("target_collection_name", targetCollectionName))
+
+	existAliases, err := b.getMilvusClient().ListAliases(ctx, targetDBName, targetCollectionName)
+	if err != nil {
+		log.Error("fail to list aliases", zap.Error(err))
+		return err
+	}
+	for _, alias := range task.GetAliases() {
+		if lo.Contains(existAliases, alias) {
+			log.Info("alias already exists", zap.String("alias", alias))
+			continue
+		}
+		err := b.getMilvusClient().CreateAlias(ctx, targetDBName, targetCollectionName, alias)
+		if err == nil {
+			log.Info("create alias", zap.String("alias", alias))
+			continue
+		}
+		if !task.GetSwapAliases() {
+			log.Error("fail to create alias", zap.String("alias", alias), zap.Error(err))
+			return fmt.Errorf("fail to create alias %s of collection %s.%s, set swap_aliases to move an existing alias: %w",
+				alias, targetDBName, targetCollectionName, err)
+		}
+		log.Info("fail to create alias, try to swap it onto the restored collection", zap.String("alias", alias), zap.Error(err))
+		err = b.getMilvusClient().AlterAlias(ctx, targetDBName, targetCollectionName, alias)
+		if err != nil {
+			log.Error("fail to swap alias", zap.String("alias", alias), zap.Error(err))
+			return err
+		}
+		log.Info("swap alias", zap.String("alias", alias))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestRestoredAliases(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{
+		DbName:         "db1",
+		CollectionName: "coll",
+		Aliases:        []string{"prod", "search"},
+	}
+	assert.Equal(t, []string{"prod", "search"}, restoredAliases(collection, map[string]string{}, "", false))
+	assert.Equal(t, []string{"prod_bak", "search_bak"}, restoredAliases(collection, map[string]string{}, "_bak", false))
+
+	// the renamed alias keeps its name, the others are renamed by the suffix
+	renames := map[string]string{"db1.coll": "db2.coll_new", "db1.prod": "db2.prod"}
+	assert.Equal(t, []string{"prod", "search_bak"}, restoredAliases(collection, renames, "_bak", false))
+
+	// the original aliases are swapped onto the restored collection, only the renames apply
+	assert.Equal(t, []string{"prod", "search"}, restoredAliases(collection, map[string]string{}, "_bak", true))
+	assert.Equal(t, []string{"prod_v2", "search"}, restoredAliases(collection, map[string]string{"db1.prod": "db1.prod_v2"}, "_bak", true))
+
+	assert.Empty(t, restoredAliases(&backuppb.CollectionBackupInfo{DbName: "db1"}, renames, "", false))
+}
+
+func TestAliasConflicts(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeMilvusClient()
+	b := newRestoreTestContext(t, client)
+	client.collections["coll"] = &entity.Collection{Name: "coll"}
+	client.collections["coll_bak"] = &entity.Collection{Name: "coll_bak"}
+	client.collections["search"] = client.collections["coll"]
+	client.collections["prod"] = client.collections["coll_bak"]
+
+	// search is on another collection, prod is on the restored collection already, coll is a collection
+	task := &backuppb.RestoreCollectionTask{
+		TargetDbName:         "default",
+		TargetCollectionName: "coll_bak",
+		Aliases:              []string{"search", "prod", "new", "coll"},
+	}
+	conflicts, err := b.aliasConflicts(ctx, task)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alias default.search already exists on collection coll, set swap_aliases to move it",
+		"alias default.coll is the name of an existing collection",
+	}, conflicts)
+
+	task.SwapAliases = true
+	conflicts, err = b.aliasConflicts(ctx, task)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alias default.coll is the name of an existing collection"}, conflicts)
+
+	// the dry run reports the conflicts of aliases
+	task = &backuppb.RestoreCollectionTask{
+		CollBackup:           &backuppb.CollectionBackupInfo{DbName: "default", CollectionName: "coll"},
+		TargetDbName:         "default",
+		TargetCollectionName: "coll_new",
+		Aliases:              []string{"search"},
+	}
+	resp := b.dryRunRestore(ctx, &backuppb.RestoreBackupRequest{}, &backuppb.RestoreBackupTask{CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{task}}, &backuppb.RestorePlan{})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, []string{"alias default.search already exists on collection coll, set swap_aliases to move it"}, resp.GetPlan().GetConflicts())
+	assert.Equal(t, []string{"search"}, resp.GetPlan().GetCollections()[0].GetAliases())
+
+	// aliases of a new database are not checked
+	resp = b.dryRunRestore(ctx, &backuppb.RestoreBackupRequest{}, &backuppb.RestoreBackupTask{CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{task}}, &backuppb.RestorePlan{CreateDatabases: []string{"default"}})
+	assert.Empty(t, resp.GetPlan().GetConflicts())
+}
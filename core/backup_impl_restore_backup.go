@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
-	jsoniter "github.com/json-iterator/go"
+	"github.com/golang/protobuf/proto"
 	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/samber/lo"
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
 	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/common"
 	"github.com/zilliztech/milvus-backup/internal/log"
@@ -40,7 +41,11 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		zap.Bool("async", request.GetAsync()),
 		zap.String("bucketName", request.GetBucketName()),
 		zap.String("path", request.GetPath()),
-		zap.String("databaseCollections", utils.GetRestoreDBCollections(request)))
+		zap.String("databaseCollections", utils.GetRestoreDBCollections(request)),
+		zap.String("resumeRestoreId", request.GetResumeRestoreId()),
+		zap.Uint64("restoreToTimestamp", request.GetRestoreToTimestamp()),
+		zap.String("restoreToTime", request.GetRestoreToTime()),
+		zap.Bool("dryRun", request.GetDryRun()))
 
 	resp := &backuppb.RestoreBackupResponse{
 		RequestId: request.GetRequestId(),
@@ -66,6 +71,14 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		}
 	}
 
+	restoreToTs, err := parseRestoreToTimestamp(request)
+	if err != nil {
+		log.Error("illegal restore to timestamp", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
 	getResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
 		BackupName: request.GetBackupName(),
 		BucketName: request.GetBucketName(),
@@ -99,6 +112,67 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 
 	backup := getResp.GetData()
 
+	// incremental backup, make sure all the referenced backups in the chain still exist
+	for _, refBackup := range collectRefBackups(backup) {
+		exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, backupBucketName, RefBackupPath(backupPath, refBackup)+SEPERATOR+META_PREFIX+SEPERATOR+BACKUP_META_FILE)
+		if err != nil {
+			log.Error("fail to check referenced backup", zap.String("refBackup", refBackup), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		if !exist {
+			errorMsg := fmt.Sprintf("backup %s references data of backup %s which does not exist", backup.GetName(), refBackup)
+			log.Error(errorMsg)
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = errorMsg
+			return resp
+		}
+	}
+
+	if request.GetResumeRestoreId() != "" {
+		oldTask := b.meta.GetRestoreTask(request.GetResumeRestoreId())
+		if oldTask == nil {
+			errorMsg := fmt.Sprintf("restore task doesn't exist: %s", request.GetResumeRestoreId())
+			log.Error(errorMsg)
+			resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+			resp.Msg = errorMsg
+			return resp
+		}
+		if isRestoreTaskInterrupted(oldTask.GetStateCode()) || oldTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			errorMsg := fmt.Sprintf("only failed restore task can be resumed, id: %s, state: %s", oldTask.GetId(), oldTask.GetStateCode().String())
+			log.Error(errorMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errorMsg
+			return resp
+		}
+		for _, collectionTask := range oldTask.GetCollectionRestoreTasks() {
+			if collectionTask.GetCollBackup().GetId() != backup.GetId() {
+				errorMsg := fmt.Sprintf("restore task %s is not restored from backup %s", oldTask.GetId(), backup.GetName())
+				log.Error(errorMsg)
+				resp.Code = backuppb.ResponseCode_Parameter_Error
+				resp.Msg = errorMsg
+				return resp
+			}
+		}
+		task, err := b.prepareResumeRestoreTask(ctx, oldTask)
+		if err != nil {
+			log.Error("fail to prepare resume restore task", zap.String("id", oldTask.GetId()), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		if request.GetDryRun() {
+			return b.dryRunRestore(ctx, request, task, &backuppb.RestorePlan{})
+		}
+		defer func() {
+			b.cleanRestoreWorkerPool(task.GetId())
+		}()
+		b.meta.AddRestoreTask(task)
+		taskCtx := b.registerTask(task.GetId())
+		return b.runRestoreTask(taskCtx, request, backupBucketName, backupPath, backup, task)
+	}
+
 	var taskID string
 	if request.GetId() != "" {
 		taskID = request.GetId()
@@ -120,10 +194,12 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 	// 2, initial restoreCollectionTasks
 	toRestoreCollectionBackups := make([]*backuppb.CollectionBackupInfo, 0)
 
+	// partitions selected by db_collections
+	var dbPartitions DbCollectionPartitions
 	dbCollectionsStr := utils.GetRestoreDBCollections(request)
 	if dbCollectionsStr != "" {
 		var dbCollections DbCollections
-		err := jsoniter.UnmarshalFromString(dbCollectionsStr, &dbCollections)
+		dbCollections, dbPartitions, err = parseDbCollections(dbCollectionsStr)
 		if err != nil {
 			log.Error("fail in unmarshal dbCollections in RestoreBackupRequest", zap.String("dbCollections", dbCollectionsStr), zap.Error(err))
 			errorMsg := fmt.Sprintf("fail in unmarshal dbCollections in RestoreBackupRequest， dbCollections: %s, err: %s", request.GetDbCollections(), err)
@@ -183,6 +259,20 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			}
 		}
 	}
+	for i, collectionBackup := range toRestoreCollectionBackups {
+		partitionNames := dbPartitions[collectionBackup.GetDbName()][collectionBackup.GetCollectionName()]
+		if len(partitionNames) == 0 {
+			continue
+		}
+		selected, err := selectPartitionBackups(collectionBackup, partitionNames)
+		if err != nil {
+			log.Error("fail to select partitions to restore", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = err.Error()
+			return resp
+		}
+		toRestoreCollectionBackups[i] = selected
+	}
 	log.Info("Collections to restore", zap.Int("collection_num", len(toRestoreCollectionBackups)))
 
 	// add default db in collection_renames if not set
@@ -208,6 +298,8 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		collectionRenames[fullCollectionName] = fullCollectionNewName
 	}
 
+	// a dry run records the databases to create and the conflicts into the plan instead of creating or failing
+	plan := &backuppb.RestorePlan{}
 	restoreCollectionTasks := make([]*backuppb.RestoreCollectionTask, 0)
 	for _, restoreCollection := range toRestoreCollectionBackups {
 		backupDBCollectionName := restoreCollection.DbName + "." + restoreCollection.GetSchema().GetName()
@@ -225,6 +317,17 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		}
 		targetDBCollectionName := targetDBName + "." + targetCollectionName
 
+		if restoreToTs > restoreCollection.GetBackupTimestamp() {
+			restoreToTime, _ := utils.ParseTS(restoreToTs)
+			backupTime, _ := utils.ParseTS(restoreCollection.GetBackupTimestamp())
+			errorMsg := fmt.Sprintf("can not restore collection %s to %s which is later than the backup time %s",
+				backupDBCollectionName, restoreToTime.UTC().Format(time.RFC3339), backupTime.UTC().Format(time.RFC3339))
+			log.Error(errorMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errorMsg
+			return resp
+		}
+
 		// check if the database exist, if not, create it first
 		dbs, err := b.getMilvusClient().ListDatabases(ctx)
 		if err != nil {
@@ -241,7 +344,11 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 				break
 			}
 		}
-		if !hasDatabase {
+		if !hasDatabase && request.GetDryRun() {
+			if !lo.Contains(plan.GetCreateDatabases(), targetDBName) {
+				plan.CreateDatabases = append(plan.CreateDatabases, targetDBName)
+			}
+		} else if !hasDatabase {
 			err := b.getMilvusClient().CreateDatabase(ctx, targetDBName)
 			if err != nil {
 				errorMsg := fmt.Sprintf("fail to create database %s, err: %s", targetDBName, err)
@@ -254,7 +361,7 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		}
 
 		// check if the collection exist, if exist, will not restore
-		if !request.GetSkipCreateCollection() {
+		if !request.GetSkipCreateCollection() && !lo.Contains(plan.GetCreateDatabases(), targetDBName) {
 			exist, err := b.getMilvusClient().HasCollection(ctx, targetDBName, targetCollectionName)
 			if err != nil {
 				errorMsg := fmt.Sprintf("fail to check whether the collection is exist, collection_name: %s, err: %s", targetDBCollectionName, err)
@@ -266,9 +373,13 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			if exist {
 				errorMsg := fmt.Sprintf("The collection to restore already exists, backupCollectName: %s, targetCollectionName: %s", backupDBCollectionName, targetDBCollectionName)
 				log.Error(errorMsg)
-				resp.Code = backuppb.ResponseCode_Fail
-				resp.Msg = errorMsg
-				return resp
+				if request.GetDryRun() {
+					plan.Conflicts = append(plan.Conflicts, errorMsg)
+				} else {
+					resp.Code = backuppb.ResponseCode_Fail
+					resp.Msg = errorMsg
+					return resp
+				}
 			}
 		} else {
 			log.Info("skip check collection exist")
@@ -297,13 +408,65 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			DropExistCollection:   request.GetDropExistCollection(),
 			DropExistIndex:        request.GetDropExistIndex(),
 			SkipCreateCollection:  request.GetSkipCreateCollection(),
+			RestoreToTimestamp:    restoreToTs,
+			Verify:                request.GetVerify() || request.GetStrictVerify(),
+			StrictVerify:          request.GetStrictVerify(),
+			RestoreLoadState:      request.GetRestoreLoadState(),
+			LoadReplicaNumber:     request.GetLoadReplicaNumber(),
+			SwapAliases:           request.GetSwapAliases(),
+			SelectedPartitions:    dbPartitions[restoreCollection.GetDbName()][restoreCollection.GetCollectionName()],
+		}
+		if request.GetRestoreAliases() || request.GetSwapAliases() {
+			restoreCollectionTask.Aliases = restoredAliases(restoreCollection, collectionRenames, request.GetCollectionSuffix(), request.GetSwapAliases())
 		}
 		restoreCollectionTasks = append(restoreCollectionTasks, restoreCollectionTask)
 		task.CollectionRestoreTasks = restoreCollectionTasks
 		task.ToRestoreSize = task.GetToRestoreSize() + toRestoreSize
 	}
+	if request.GetDryRun() {
+		return b.dryRunRestore(ctx, request, task, plan)
+	}
 	b.meta.AddRestoreTask(task)
+	taskCtx := b.registerTask(task.GetId())
+
+	return b.runRestoreTask(taskCtx, request, backupBucketName, backupPath, backup, task)
+}
 
+// prepareResumeRestoreTask builds the task to resume a failed restore, finished collections, groups and l0 segments are kept.
+// Target collections which have been created are re-entered instead of created again.
+func (b *BackupContext) prepareResumeRestoreTask(ctx context.Context, oldTask *backuppb.RestoreBackupTask) (*backuppb.RestoreBackupTask, error) {
+	task := proto.Clone(oldTask).(*backuppb.RestoreBackupTask)
+	task.StateCode = backuppb.RestoreTaskStateCode_INITIAL
+	task.ErrorMessage = ""
+	task.EndTime = 0
+	for _, collectionTask := range task.GetCollectionRestoreTasks() {
+		if collectionTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			continue
+		}
+		collectionTask.StateCode = backuppb.RestoreTaskStateCode_INITIAL
+		collectionTask.ErrorMessage = ""
+		exist, err := b.getMilvusClient().HasCollection(ctx, collectionTask.GetTargetDbName(), collectionTask.GetTargetCollectionName())
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			collectionTask.SkipCreateCollection = true
+			collectionTask.DropExistCollection = false
+			collectionTask.DropExistIndex = false
+		}
+		log.Info("resume restore collection",
+			zap.String("target_db_name", collectionTask.GetTargetDbName()),
+			zap.String("target_collection_name", collectionTask.GetTargetCollectionName()),
+			zap.Bool("collectionExist", exist),
+			zap.Int64("restoredSize", collectionTask.GetRestoredSize()))
+	}
+	return task, nil
+}
+
+func (b *BackupContext) runRestoreTask(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) *backuppb.RestoreBackupResponse {
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
 	if request.Async {
 		go b.executeRestoreBackupTask(ctx, backupBucketName, backupPath, backup, task)
 		asyncResp := &backuppb.RestoreBackupResponse{
@@ -331,9 +494,11 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) (*backuppb.RestoreBackupTask, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	defer b.finishRestoreTask(task.GetId())
 
 	wp, err := common.NewWorkerPool(ctx, b.params.BackupCfg.RestoreParallelism, RPS)
 	if err != nil {
+		b.failRestore(task.GetId(), err)
 		return task, err
 	}
 	wp.Start()
@@ -351,6 +516,10 @@ func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, backupBuck
 	// 3, execute restoreCollectionTasks
 	for _, restoreCollectionTask := range restoreCollectionTasks {
 		restoreCollectionTaskClone := restoreCollectionTask
+		// finished in the restore task which is resumed
+		if restoreCollectionTaskClone.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			continue
+		}
 		job := func(ctx context.Context) error {
 			endTask, err := b.executeRestoreCollectionTask(ctx, backupBucketName, backupPath, restoreCollectionTaskClone, id)
 			if err != nil {
@@ -361,6 +530,7 @@ func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, backupBuck
 				return err
 			}
 			restoreCollectionTaskClone.StateCode = backuppb.RestoreTaskStateCode_SUCCESS
+			b.meta.UpdateRestoreTask(id, setCollectionRestoreStateCode(restoreCollectionTaskClone.GetId(), backuppb.RestoreTaskStateCode_SUCCESS))
 			log.Info("finish restore collection",
 				zap.String("db_name", restoreCollectionTaskClone.GetTargetDbName()),
 				zap.String("collection_name", restoreCollectionTaskClone.GetTargetCollectionName()),
@@ -370,7 +540,12 @@ func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, backupBuck
 		wp.Submit(job)
 	}
 	wp.Done()
-	if err := wp.Wait(); err != nil {
+	err = wp.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		b.failRestore(id, err)
 		return task, err
 	}
 
@@ -506,38 +681,18 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 	}
 
 	if task.GetRestoreIndex() {
-		vectorFields := make(map[string]bool, 0)
-		for _, field := range collectionSchema.Fields {
-			if strings.HasSuffix(strings.ToLower(field.DataType.Name()), "vector") {
-				vectorFields[field.Name] = true
-			}
-		}
+		vectorFields := vectorFieldsOfSchema(task.GetCollBackup().GetSchema())
 		indexes := task.GetCollBackup().GetIndexInfos()
 		for _, index := range indexes {
-			var idx entity.Index
 			log.Info("source index",
 				zap.String("indexName", index.GetIndexName()),
 				zap.String("indexType", index.GetIndexType()),
 				zap.Any("params", index.GetParams()))
-			if _, ok := vectorFields[index.GetFieldName()]; ok && task.GetUseAutoIndex() {
-				log.Info("use auto index")
-				params := make(map[string]string, 0)
-				// auto index only support index_type and metric_type in params
-				params["index_type"] = "AUTOINDEX"
-				params["metric_type"] = index.GetParams()["metric_type"]
-				idx = entity.NewGenericIndex(index.GetIndexName(), entity.AUTOINDEX, params)
-			} else {
-				log.Info("not auto index")
-				indexType := index.GetIndexType()
-				if indexType == "marisa-trie" {
-					indexType = "Trie"
-				}
-				params := index.GetParams()
-				if params["index_type"] == "marisa-trie" {
-					params["index_type"] = "Trie"
-				}
-				idx = entity.NewGenericIndex(index.GetIndexName(), entity.IndexType(indexType), index.GetParams())
-			}
+			restored := restoredIndexInfo(index, vectorFields[index.GetFieldName()] && task.GetUseAutoIndex())
+			log.Info("restore index",
+				zap.String("indexName", restored.GetIndexName()),
+				zap.String("indexType", restored.GetIndexType()))
+			idx := entity.NewGenericIndex(restored.GetIndexName(), entity.IndexType(restored.GetIndexType()), restored.GetParams())
 			err := b.getMilvusClient().CreateIndex(ctx, targetDBName, targetCollectionName, index.GetFieldName(), idx, true)
 			if err != nil {
 				log.Warn("Fail to restore index", zap.Error(err))
@@ -546,13 +701,27 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 		}
 	}
 
-	tempDir := fmt.Sprintf("restore-temp-%s-%s-%s%s", parentTaskID, task.TargetDbName, task.TargetCollectionName, SEPERATOR)
-	isSameBucket := b.milvusBucketName == backupBucketName
+	tempFiles := &restoreTempFiles{
+		dir:              fmt.Sprintf("restore-temp-%s-%s-%s%s", parentTaskID, task.TargetDbName, task.TargetCollectionName, SEPERATOR),
+		backupBucketName: backupBucketName,
+		// the buckets of different storages are never the same, even if they have the same name
+		isSameBucket:  b.getStorageClient(storage.MilvusStorage) == b.getStorageClient(storage.BackupStorage) && b.milvusBucketName == backupBucketName,
+		blobManifests: newBlobManifestCache(),
+		dataKeys:      newBackupDataKeyCache(),
+		binlogCodecs:  binlogCompressions(backupPath, task.GetCollBackup()),
+	}
+	tempDir := tempFiles.dir
+	// the data to restore is bounded by the timestamp
+	endTs := task.GetCollBackup().GetBackupTimestamp()
+	if task.GetRestoreToTimestamp() != 0 {
+		endTs = task.GetRestoreToTimestamp()
+	}
 	// clean the temporary file
 	defer func() {
-		if !isSameBucket && !b.params.BackupCfg.KeepTempFiles {
+		if (!tempFiles.isSameBucket || tempFiles.used.Load()) && !b.params.BackupCfg.KeepTempFiles {
 			log.Info("Delete temporary file", zap.String("dir", tempDir))
-			err := b.getStorageClient().RemoveWithPrefix(ctx, b.milvusBucketName, tempDir)
+			// the task context may be cancelled
+			err := b.getStorageClient(storage.MilvusStorage).RemoveWithPrefix(b.ctx, b.milvusBucketName, tempDir)
 			if err != nil {
 				log.Warn("Delete temporary file failed", zap.Error(err))
 			}
@@ -561,31 +730,35 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 
 	// bulk insert
 	copyAndBulkInsert := func(dbName, collectionName, partitionName string, files []string, isL0 bool) error {
-		realFiles := make([]string, len(files))
-		// if milvus bucket and backup bucket are not the same, should copy the data first
-		if !isSameBucket {
-			log.Info("milvus bucket and backup bucket are not the same, copy the data first", zap.Strings("files", files))
-			for i, file := range files {
-				// empty delta file, no need to copy
-				if file == "" {
-					realFiles[i] = file
-				} else {
-					log.Debug("Copy temporary restore file", zap.String("from", file), zap.String("to", tempDir+file))
-					err := retry.Do(ctx, func() error {
-						return b.getStorageClient().Copy(ctx, backupBucketName, b.milvusBucketName, file, tempDir+file)
-					}, retry.Sleep(2*time.Second), retry.Attempts(5))
-					if err != nil {
-						log.Error("fail to copy backup date from backup bucket to restore target milvus bucket after retry", zap.Error(err))
-						return err
-					}
-					realFiles[i] = tempDir + file
-				}
-			}
-		} else {
-			realFiles = files
+		realFiles, err := b.copyRestoreFiles(ctx, tempFiles, files)
+		if err != nil {
+			return err
+		}
+
+		err = b.executeBulkInsert(ctx, dbName, collectionName, partitionName, realFiles, int64(endTs), isL0)
+		if err != nil {
+			log.Error("fail to bulk insert to partition",
+				zap.String("partition", partitionName),
+				zap.Error(err))
+			return err
 		}
+		return nil
+	}
 
-		err := b.executeBulkInsert(ctx, dbName, collectionName, partitionName, realFiles, int64(task.GetCollBackup().BackupTimestamp), isL0)
+	// point in time restore, only the picked binlogs of the backup dirs are copied into the temporary dir to bulk insert
+	copyPickedAndBulkInsert := func(partitionName string, pickBackupPath string, dirs []string, relativeFiles []string, isL0 bool) error {
+		err := b.copyBinlogsToDir(ctx, backupBucketName, tempFiles.blobManifests, tempFiles.dataKeys, tempFiles.binlogCodecs, pickBackupPath, relativeFiles, tempDir)
+		if err != nil {
+			return err
+		}
+		tempFiles.used.Store(true)
+		files := make([]string, len(dirs))
+		for i, dir := range dirs {
+			if dir != "" {
+				files[i] = tempDir + dir
+			}
+		}
+		err = b.executeBulkInsert(ctx, targetDBName, targetCollectionName, partitionName, files, int64(endTs), isL0)
 		if err != nil {
 			log.Error("fail to bulk insert to partition",
 				zap.String("partition", partitionName),
@@ -596,13 +769,17 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 	}
 
 	jobIds := make([]int64, 0)
-	restoredSize := atomic.Int64{}
+	// a resumed task starts from the size restored before
+	restoredSize := atomic.NewInt64(task.GetRestoredSize())
 
 	type partitionL0Segment struct {
 		collectionID  int64
 		partitionName string
 		partitionID   int64
 		segmentID     int64
+		refBackup     string
+		// deltalogs picked by point in time restore, nil means all
+		deltaFiles []string
 	}
 	partitionL0Segments := make([]partitionL0Segment, 0)
 	for _, v := range task.GetCollBackup().GetPartitionBackups() {
@@ -626,8 +803,12 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 		}
 
 		type restoreGroup struct {
-			files []string
-			size  int64
+			groupId int64
+			files   []string
+			size    int64
+			// binlogs picked by point in time restore, nil means all the binlogs in files
+			backupPath    string
+			relativeFiles []string
 		}
 		restoreFileGroups := make([]restoreGroup, 0)
 
@@ -638,38 +819,87 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 			return !segment.IsL0
 		})
 		groupIds := collectGroupIdsFromSegments(notl0Segments)
+		// point in time restore, pick the binlogs if some of the group are later than the timestamp
+		pickGroupBinlogs := func(groupBackupPath string, groupId int64) (restoreGroup, bool) {
+			if task.GetRestoreToTimestamp() == 0 {
+				return restoreGroup{}, false
+			}
+			insertFiles, deltaFiles, size, filtered := restoreBinlogsOfGroup(notl0Segments, groupId, task.GetRestoreToTimestamp())
+			if !filtered {
+				return restoreGroup{}, false
+			}
+			log.Info("pick binlogs for point in time restore",
+				zap.String("partition", partitionBackup.GetPartitionName()),
+				zap.Int64("groupId", groupId),
+				zap.Int("insertFileNum", len(insertFiles)),
+				zap.Int("deltaFileNum", len(deltaFiles)))
+			return restoreGroup{
+				groupId:       groupId,
+				files:         backupGroupDirs(groupBackupPath, partitionBackup, groupId, len(deltaFiles) > 0),
+				size:          size,
+				backupPath:    groupBackupPath,
+				relativeFiles: append(insertFiles, deltaFiles...),
+			}, true
+		}
 		if len(groupIds) == 1 && groupIds[0] == 0 {
 			// backward compatible old backup without group id
-			files, size, err := b.getBackupPartitionPaths(ctx, backupBucketName, backupPath, partitionBackup)
-			if err != nil {
-				log.Error("fail to get partition backup binlog files",
-					zap.Error(err),
-					zap.String("partition", partitionBackup.GetPartitionName()))
-				return task, err
+			if isRestoreGroupFinished(task, partitionBackup.GetPartitionId(), 0) {
+				log.Info("skip restored partition", zap.String("partition", partitionBackup.GetPartitionName()))
+			} else if group, picked := pickGroupBinlogs(backupPath, 0); picked {
+				if len(group.relativeFiles) > 0 {
+					restoreFileGroups = append(restoreFileGroups, group)
+				}
+			} else {
+				files, size, err := b.getBackupPartitionPaths(ctx, backupBucketName, backupPath, partitionBackup)
+				if err != nil {
+					log.Error("fail to get partition backup binlog files",
+						zap.Error(err),
+						zap.String("partition", partitionBackup.GetPartitionName()))
+					return task, err
+				}
+				restoreFileGroups = append(restoreFileGroups, restoreGroup{groupId: 0, files: files, size: size})
 			}
-			restoreFileGroups = append(restoreFileGroups, restoreGroup{files: files, size: size})
 		} else {
 			// bulk insert by segment groups
 			for _, groupId := range groupIds {
-				files, size, err := b.getBackupPartitionPathsWithGroupID(ctx, backupBucketName, backupPath, partitionBackup, groupId)
+				if isRestoreGroupFinished(task, partitionBackup.GetPartitionId(), groupId) {
+					log.Info("skip restored group", zap.String("partition", partitionBackup.GetPartitionName()), zap.Int64("groupId", groupId))
+					continue
+				}
+				// segments referenced from a parent backup are stored in the parent's directory
+				groupBackupPath := RefBackupPath(backupPath, refBackupOfGroup(notl0Segments, groupId))
+				if group, picked := pickGroupBinlogs(groupBackupPath, groupId); picked {
+					if len(group.relativeFiles) > 0 {
+						restoreFileGroups = append(restoreFileGroups, group)
+					}
+					continue
+				}
+				files, size, err := b.getBackupPartitionPathsWithGroupID(ctx, backupBucketName, groupBackupPath, partitionBackup, groupId)
 				if err != nil {
 					log.Error("fail to get partition backup binlog files",
 						zap.Error(err),
 						zap.String("partition", partitionBackup.GetPartitionName()))
 					return task, err
 				}
-				restoreFileGroups = append(restoreFileGroups, restoreGroup{files: files, size: size})
+				restoreFileGroups = append(restoreFileGroups, restoreGroup{groupId: groupId, files: files, size: size})
 			}
 		}
 
 		for _, value := range restoreFileGroups {
 			group := value
 			job := func(ctx context.Context) error {
-				err := copyAndBulkInsert(targetDBName, targetCollectionName, partitionBackup.GetPartitionName(), group.files, false)
+				var err error
+				if group.relativeFiles != nil {
+					err = copyPickedAndBulkInsert(partitionBackup.GetPartitionName(), group.backupPath, group.files, group.relativeFiles, false)
+				} else {
+					err = copyAndBulkInsert(targetDBName, targetCollectionName, partitionBackup.GetPartitionName(), group.files, false)
+				}
 				if err != nil {
 					return err
 				} else {
-					b.meta.UpdateRestoreTask(parentTaskID, addCollectionRestoredSize(task.GetCollBackup().GetCollectionId(), group.size))
+					b.meta.UpdateRestoreTask(parentTaskID,
+						addCollectionRestoredSize(task.GetCollBackup().GetCollectionId(), group.size),
+						addFinishedRestoreGroup(task.GetId(), partitionBackup.GetPartitionId(), partitionBackup.GetPartitionName(), group.groupId))
 					restoredSize.Add(group.size)
 					task.RestoredSize = restoredSize.Load()
 					return nil
@@ -681,17 +911,31 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 
 		if len(l0Segments) > 0 {
 			for _, segment := range l0Segments {
-				partitionL0Segments = append(partitionL0Segments, partitionL0Segment{
+				if isRestoreL0SegmentFinished(task, segment.GetPartitionId(), segment.GetSegmentId()) {
+					continue
+				}
+				l0Segment := partitionL0Segment{
 					collectionID:  segment.CollectionId,
 					partitionName: partitionBackup.GetPartitionName(),
 					partitionID:   segment.GetPartitionId(),
 					segmentID:     segment.GetSegmentId(),
-				})
+					refBackup:     segment.GetRefBackup(),
+				}
+				if task.GetRestoreToTimestamp() != 0 {
+					deltaFiles, filtered := restoreDeltalogsOfL0Segment(segment, task.GetRestoreToTimestamp())
+					if filtered && len(deltaFiles) == 0 {
+						continue
+					}
+					if filtered {
+						l0Segment.deltaFiles = deltaFiles
+					}
+				}
+				partitionL0Segments = append(partitionL0Segments, l0Segment)
 			}
 		}
 	}
 
-	err := b.getRestoreWorkerPool(parentTaskID).WaitJobs(jobIds)
+	err := b.getRestoreWorkerPool(parentTaskID).WaitJobs(ctx, jobIds)
 	if err != nil {
 		return task, err
 	}
@@ -702,31 +946,286 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 	for _, v := range partitionL0Segments {
 		segmentBackup := v
 		job := func(ctx context.Context) error {
-			l0Files := fmt.Sprintf("%s/%s/%s/%d/%d/%d", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, segmentBackup.collectionID, segmentBackup.partitionID, segmentBackup.segmentID)
+			l0BackupPath := RefBackupPath(backupPath, segmentBackup.refBackup)
+			l0Files := fmt.Sprintf("%s/%s/%s/%d/%d/%d", l0BackupPath, BINGLOG_DIR, DELTA_LOG_DIR, segmentBackup.collectionID, segmentBackup.partitionID, segmentBackup.segmentID)
 			log.Info("restore l0 segment ", zap.String("files", l0Files))
-			return copyAndBulkInsert(targetDBName, targetCollectionName, segmentBackup.partitionName, []string{l0Files}, true)
+			var err error
+			if segmentBackup.deltaFiles != nil {
+				err = copyPickedAndBulkInsert(segmentBackup.partitionName, l0BackupPath, []string{l0Files}, segmentBackup.deltaFiles, true)
+			} else {
+				err = copyAndBulkInsert(targetDBName, targetCollectionName, segmentBackup.partitionName, []string{l0Files}, true)
+			}
+			if err != nil {
+				return err
+			}
+			b.meta.UpdateRestoreTask(parentTaskID, addFinishedRestoreL0Segment(task.GetId(), segmentBackup.partitionID, segmentBackup.partitionName, segmentBackup.segmentID))
+			return nil
 		}
 		jobId := b.getRestoreWorkerPool(parentTaskID).SubmitWithId(job)
 		l0JobIds = append(l0JobIds, jobId)
 	}
 
-	if len(task.GetCollBackup().GetL0Segments()) > 0 {
-		for _, v := range task.GetCollBackup().GetL0Segments() {
-			segment := v
+	for _, v := range task.GetCollBackup().GetL0Segments() {
+		segment := v
+		var deltaFiles []string
+		if task.GetRestoreToTimestamp() != 0 {
+			picked, filtered := restoreDeltalogsOfL0Segment(segment, task.GetRestoreToTimestamp())
+			if filtered && len(picked) == 0 {
+				continue
+			}
+			if filtered {
+				deltaFiles = picked
+			}
+		}
+		for _, p := range collectionL0Partitions(task) {
+			partitionID, partitionName := p.GetPartitionId(), p.GetPartitionName()
+			if isRestoreL0SegmentFinished(task, partitionID, segment.GetSegmentId()) {
+				continue
+			}
 			job := func(ctx context.Context) error {
-				l0Files := fmt.Sprintf("%s/%s/%s/%d/%d/%d", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, task.CollBackup.CollectionId, -1, segment.GetSegmentId())
-				log.Info("restore l0 segment ", zap.String("files", l0Files))
-				return copyAndBulkInsert(targetDBName, targetCollectionName, "", []string{l0Files}, true)
+				l0BackupPath := RefBackupPath(backupPath, segment.GetRefBackup())
+				l0Files := fmt.Sprintf("%s/%s/%s/%d/%d/%d", l0BackupPath, BINGLOG_DIR, DELTA_LOG_DIR, task.CollBackup.CollectionId, -1, segment.GetSegmentId())
+				log.Info("restore l0 segment ", zap.String("files", l0Files), zap.String("partition", partitionName))
+				var err error
+				if deltaFiles != nil {
+					err = copyPickedAndBulkInsert(partitionName, l0BackupPath, []string{l0Files}, deltaFiles, true)
+				} else {
+					err = copyAndBulkInsert(targetDBName, targetCollectionName, partitionName, []string{l0Files}, true)
+				}
+				if err != nil {
+					return err
+				}
+				b.meta.UpdateRestoreTask(parentTaskID, addFinishedRestoreL0Segment(task.GetId(), partitionID, partitionName, segment.GetSegmentId()))
+				return nil
 			}
 			jobId := b.getRestoreWorkerPool(parentTaskID).SubmitWithId(job)
 			l0JobIds = append(l0JobIds, jobId)
 		}
 	}
-	err = b.getRestoreWorkerPool(parentTaskID).WaitJobs(l0JobIds)
+	err = b.getRestoreWorkerPool(parentTaskID).WaitJobs(ctx, l0JobIds)
+	if err != nil {
+		return task, err
+	}
 
+	if task.GetRestoreLoadState() {
+		err = b.restoreLoadState(ctx, task, parentTaskID)
+		if err != nil {
+			return task, err
+		}
+	}
+	if task.GetVerify() {
+		err = b.verifyRestoredCollection(ctx, backupPath, tempFiles, task, parentTaskID)
+		if err != nil {
+			return task, err
+		}
+	}
+	if len(task.GetAliases()) > 0 {
+		err = b.restoreAliases(ctx, task)
+	}
 	return task, err
 }
 
+// restoreTempFiles is the temporary dir of milvus bucket of a restore collection task, the files to bulk insert are copied into it
+// if they are not readable by bulk insert as they are
+type restoreTempFiles struct {
+	dir              string
+	backupBucketName string
+	isSameBucket     bool
+	// binlogs of deduplicated backups and binlogs picked by point in time restore
+	// are copied into the temporary dir even in the same bucket
+	blobManifests *blobManifestCache
+	// binlogs of encrypted backups are decrypted into the temporary dir
+	dataKeys *backupDataKeyCache
+	// compressed binlogs are decompressed into the temporary dir
+	binlogCodecs map[string]string
+	// whether any file is copied into the dir
+	used atomic.Bool
+}
+
+// copyRestoreFiles returns the files to bulk insert, the files of backup are copied into the temporary dir first
+// if they are deduplicated, encrypted, compressed, or in another bucket
+func (b *BackupContext) copyRestoreFiles(ctx context.Context, temp *restoreTempFiles, files []string) ([]string, error) {
+	realFiles := make([]string, len(files))
+	// deduplicated backup, rebuild the binlog files from blobs
+	for i, file := range files {
+		if file == "" {
+			continue
+		}
+		backupPathOfFile, _ := splitBackupBinlogPath(file)
+		manifest, err := b.getBlobManifest(ctx, temp.blobManifests, temp.backupBucketName, backupPathOfFile)
+		if err != nil {
+			log.Error("fail to read blob manifest", zap.String("backupPath", backupPathOfFile), zap.Error(err))
+			return nil, err
+		}
+		if manifest != nil {
+			log.Debug("Copy blobs to temporary restore dir", zap.String("dir", file), zap.String("to", temp.dir+file))
+			err = b.copyBlobsToDir(ctx, temp.backupBucketName, manifest, file, temp.dir)
+			if err != nil {
+				return nil, err
+			}
+			temp.used.Store(true)
+			realFiles[i] = temp.dir + file
+			continue
+		}
+		// encrypted or compressed binlogs, decode the binlog files into the temporary dir
+		dataKey, err := b.getBackupDataKey(ctx, temp.dataKeys, temp.backupBucketName, backupPathOfFile)
+		if err != nil {
+			log.Error("fail to get the data key of backup", zap.String("backupPath", backupPathOfFile), zap.Error(err))
+			return nil, err
+		}
+		if dataKey != nil || hasCompressedBinlogs(temp.binlogCodecs, file) {
+			log.Debug("Decode files to temporary restore dir", zap.String("dir", file), zap.String("to", temp.dir+file))
+			err = retry.Do(ctx, func() error {
+				return b.copyDecoded(ctx, temp.backupBucketName, dataKey, temp.binlogCodecs, file, temp.dir+file)
+			}, b.storageRetryOptions()...)
+			if err != nil {
+				log.Error("fail to decode backup data into restore target milvus bucket after retry", zap.Error(err))
+				return nil, err
+			}
+			temp.used.Store(true)
+			realFiles[i] = temp.dir + file
+		}
+	}
+	// if milvus bucket and backup bucket are not the same, should copy the data first
+	if !temp.isSameBucket {
+		log.Info("milvus bucket and backup bucket are not the same, copy the data first", zap.Strings("files", files))
+		for i, file := range files {
+			// empty delta file, no need to copy
+			if file == "" {
+				realFiles[i] = file
+			} else if realFiles[i] != "" {
+				// already rebuilt from blobs
+				continue
+			} else {
+				log.Debug("Copy temporary restore file", zap.String("from", file), zap.String("to", temp.dir+file))
+				err := retry.Do(ctx, func() error {
+					return b.copyBetweenStorages(ctx, storage.BackupStorage, temp.backupBucketName, file, storage.MilvusStorage, b.milvusBucketName, temp.dir+file)
+				}, b.storageRetryOptions()...)
+				if err != nil {
+					log.Error("fail to copy backup date from backup bucket to restore target milvus bucket after retry", zap.Error(err))
+					return nil, err
+				}
+				realFiles[i] = temp.dir + file
+			}
+		}
+	} else {
+		for i, file := range files {
+			if realFiles[i] == "" {
+				realFiles[i] = file
+			}
+		}
+	}
+
+	return realFiles, nil
+}
+
+// selectPartitionBackups returns a copy of the collection backup with the partitions of the names only,
+// the l0 segments of the collection are kept as they apply to all partitions
+func selectPartitionBackups(collection *backuppb.CollectionBackupInfo, names []string) (*backuppb.CollectionBackupInfo, error) {
+	selected := proto.Clone(collection).(*backuppb.CollectionBackupInfo)
+	partitions := selected.GetPartitionBackups()
+	selected.PartitionBackups = make([]*backuppb.PartitionBackupInfo, 0, len(names))
+	selected.Size = 0
+	for _, name := range names {
+		partition, ok := lo.Find(partitions, func(partition *backuppb.PartitionBackupInfo) bool {
+			return partition.GetPartitionName() == name
+		})
+		if !ok {
+			return nil, fmt.Errorf("partition %s of collection %s.%s does not exist in the backup", name, collection.GetDbName(), collection.GetCollectionName())
+		}
+		selected.PartitionBackups = append(selected.PartitionBackups, partition)
+		selected.Size += partition.GetSize()
+	}
+	return selected, nil
+}
+
+// vectorFieldsOfSchema returns the names of the vector fields of the schema
+func vectorFieldsOfSchema(schema *backuppb.CollectionSchema) map[string]bool {
+	vectorFields := make(map[string]bool, 0)
+	for _, field := range schema.GetFields() {
+		if strings.HasSuffix(strings.ToLower(entity.FieldType(field.GetDataType()).Name()), "vector") {
+			vectorFields[field.GetName()] = true
+		}
+	}
+	return vectorFields
+}
+
+// restoredIndexInfo returns the index to create for the index in backup, the index is replaced by autoindex if useAutoIndex
+func restoredIndexInfo(index *backuppb.IndexInfo, useAutoIndex bool) *backuppb.IndexInfo {
+	if useAutoIndex {
+		return &backuppb.IndexInfo{
+			FieldName: index.GetFieldName(),
+			IndexName: index.GetIndexName(),
+			IndexType: string(entity.AUTOINDEX),
+			// auto index only support index_type and metric_type in params
+			Params: map[string]string{
+				"index_type":  "AUTOINDEX",
+				"metric_type": index.GetParams()["metric_type"],
+			},
+		}
+	}
+	indexType := index.GetIndexType()
+	if indexType == "marisa-trie" {
+		indexType = "Trie"
+	}
+	params := make(map[string]string, len(index.GetParams()))
+	for key, value := range index.GetParams() {
+		params[key] = value
+	}
+	if params["index_type"] == "marisa-trie" {
+		params["index_type"] = "Trie"
+	}
+	return &backuppb.IndexInfo{
+		FieldName: index.GetFieldName(),
+		IndexName: index.GetIndexName(),
+		IndexType: indexType,
+		Params:    params,
+	}
+}
+
+// isRestoreGroupFinished checks whether the segment group has been bulk inserted by the restore task resumed
+func isRestoreGroupFinished(task *backuppb.RestoreCollectionTask, partitionID int64, groupID int64) bool {
+	for _, part := range task.GetPartitionRestoreTasks() {
+		if part.GetPartitionId() == partitionID {
+			return lo.Contains(part.GetFinishedGroupIds(), groupID)
+		}
+	}
+	return false
+}
+
+// collectionL0Partitions returns the partitions to bulk insert the l0 segments of the collection into,
+// the selected partitions if partitions are selected, otherwise the whole collection as partition -1
+func collectionL0Partitions(task *backuppb.RestoreCollectionTask) []*backuppb.PartitionBackupInfo {
+	if len(task.GetSelectedPartitions()) > 0 {
+		return task.GetCollBackup().GetPartitionBackups()
+	}
+	return []*backuppb.PartitionBackupInfo{{PartitionId: -1}}
+}
+
+// isRestoreL0SegmentFinished checks whether the l0 segment has been bulk inserted by the restore task resumed,
+// partitionID -1 means a collection level l0 segment
+func isRestoreL0SegmentFinished(task *backuppb.RestoreCollectionTask, partitionID int64, segmentID int64) bool {
+	if partitionID == -1 {
+		return lo.Contains(task.GetFinishedL0SegmentIds(), segmentID)
+	}
+	for _, part := range task.GetPartitionRestoreTasks() {
+		if part.GetPartitionId() == partitionID {
+			return lo.Contains(part.GetFinishedL0SegmentIds(), segmentID)
+		}
+	}
+	return false
+}
+
+// refBackupOfGroup returns the backup which holds the binlogs of the group, empty means the current backup
+func refBackupOfGroup(segments []*backuppb.SegmentBackupInfo, groupId int64) string {
+	for _, seg := range segments {
+		if seg.GetGroupId() == groupId {
+			return seg.GetRefBackup()
+		}
+	}
+	return ""
+}
+
 func collectGroupIdsFromSegments(segments []*backuppb.SegmentBackupInfo) []int64 {
 	dict := make(map[int64]bool)
 	res := make([]int64, 0)
@@ -815,7 +1314,11 @@ func (b *BackupContext) watchBulkInsertState(ctx context.Context, taskId int64,
 				log.Warn(fmt.Sprintf("bulkinsert task state progress hang for more than %d s", timeout))
 				return errors.New("import task timeout")
 			}
-			time.Sleep(time.Second * time.Duration(sleepSeconds))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second * time.Duration(sleepSeconds)):
+			}
 			continue
 		}
 	}
@@ -831,7 +1334,7 @@ func (b *BackupContext) getBackupPartitionPaths(ctx context.Context, bucketName
 	insertPath := fmt.Sprintf("%s/%s/%s/%v/%v/", backupPath, BINGLOG_DIR, INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
 	deltaPath := fmt.Sprintf("%s/%s/%s/%v/%v/", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, deltaPath)
+	exist, err := b.binlogDirExist(ctx, bucketName, deltaPath)
 	if err != nil {
 		log.Warn("check binlog exist fail", zap.Error(err))
 		return []string{}, 0, err
@@ -863,7 +1366,7 @@ func (b *BackupContext) getBackupPartitionPathsWithGroupID(ctx context.Context,
 		}
 	}
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, deltaPath)
+	exist, err := b.binlogDirExist(ctx, bucketName, deltaPath)
 	if err != nil {
 		log.Warn("check binlog exist fail", zap.Error(err))
 		return []string{}, 0, err
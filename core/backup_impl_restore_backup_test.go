@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/common"
+)
+
+// fakeMilvusClient serves the milvus calls of the tests, the calls not overridden panic
+type fakeMilvusClient struct {
+	gomilvus.Client
+	mu sync.Mutex
+	// partition name -> files of the bulk inserts into it
+	bulkInserts map[string][]string
+	loadState   entity.LoadState
+	// partition name -> primary keys of the rows in it, queried by the verification
+	rows map[string][]int64
+	// collection or alias name -> the collection
+	collections map[string]*entity.Collection
+}
+
+func newFakeMilvusClient() *fakeMilvusClient {
+	return &fakeMilvusClient{
+		bulkInserts: make(map[string][]string),
+		rows:        make(map[string][]int64),
+		collections: make(map[string]*entity.Collection),
+	}
+}
+
+func (c *fakeMilvusClient) HasCollection(ctx context.Context, collName string) (bool, error) {
+	_, ok := c.collections[collName]
+	return ok, nil
+}
+
+func (c *fakeMilvusClient) DescribeCollection(ctx context.Context, collName string) (*entity.Collection, error) {
+	collection, ok := c.collections[collName]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", collName)
+	}
+	return collection, nil
+}
+
+func (c *fakeMilvusClient) UsingDatabase(ctx context.Context, dbName string) error {
+	return nil
+}
+
+func (c *fakeMilvusClient) DescribeIndex(ctx context.Context, collName string, fieldName string, opts ...gomilvus.IndexOption) ([]entity.Index, error) {
+	return nil, fmt.Errorf("index not found")
+}
+
+func (c *fakeMilvusClient) ShowPartitions(ctx context.Context, collName string) ([]*entity.Partition, error) {
+	return nil, fmt.Errorf("show partitions is not supported by the fake milvus")
+}
+
+func (c *fakeMilvusClient) HasPartition(ctx context.Context, collName string, partitionName string) (bool, error) {
+	return true, nil
+}
+
+func (c *fakeMilvusClient) BulkInsert(ctx context.Context, collName string, partitionName string, files []string, opts ...gomilvus.BulkInsertOption) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, file := range files {
+		if file != "" {
+			c.bulkInserts[partitionName] = append(c.bulkInserts[partitionName], file)
+		}
+	}
+	return 1, nil
+}
+
+func (c *fakeMilvusClient) GetBulkInsertState(ctx context.Context, taskID int64) (*entity.BulkInsertTaskState, error) {
+	return &entity.BulkInsertTaskState{ID: taskID, State: entity.BulkInsertCompleted}, nil
+}
+
+func (c *fakeMilvusClient) GetLoadState(ctx context.Context, collName string, partitionNames []string) (entity.LoadState, error) {
+	return c.loadState, nil
+}
+
+func (c *fakeMilvusClient) GetCollectionStatistics(ctx context.Context, collName string) (map[string]string, error) {
+	var rows int
+	for _, keys := range c.rows {
+		rows += len(keys)
+	}
+	return map[string]string{"row_count": strconv.Itoa(rows)}, nil
+}
+
+// Query counts the rows of the partitions, only count(*) is supported
+func (c *fakeMilvusClient) Query(ctx context.Context, collName string, partitionNames []string, expr string, outputFields []string, opts ...gomilvus.SearchQueryOptionFunc) (gomilvus.ResultSet, error) {
+	var rows int64
+	for _, partitionName := range partitionNames {
+		rows += int64(len(c.rows[partitionName]))
+	}
+	return gomilvus.ResultSet{entity.NewColumnInt64("count(*)", []int64{rows})}, nil
+}
+
+func (c *fakeMilvusClient) QueryByPks(ctx context.Context, collName string, partitionNames []string, ids entity.Column, outputFields []string, opts ...gomilvus.SearchQueryOptionFunc) (gomilvus.ResultSet, error) {
+	found := make([]int64, 0)
+	for _, partitionName := range partitionNames {
+		for _, key := range c.rows[partitionName] {
+			if lo.Contains(ids.(*entity.ColumnInt64).Data(), key) {
+				found = append(found, key)
+			}
+		}
+	}
+	return gomilvus.ResultSet{entity.NewColumnInt64(outputFields[0], found)}, nil
+}
+
+// newRestoreTestContext returns a context restoring into the fake milvus
+func newRestoreTestContext(t *testing.T, client gomilvus.Client) *BackupContext {
+	b := newEncryptionTestContext(t)
+	b.params.BackupCfg.RestoreParallelism = 1
+	b.bulkinsertWorkerPools = make(map[string]*common.WorkerPool)
+	b.milvusClient = &MilvusClient{client: client}
+	return b
+}
+
+func TestRestoreSelectedPartitions(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeMilvusClient()
+	b := newRestoreTestContext(t, client)
+
+	partition := func(id int64, name string) *backuppb.PartitionBackupInfo {
+		return &backuppb.PartitionBackupInfo{
+			PartitionId:    id,
+			PartitionName:  name,
+			CollectionId:   testCollectionID,
+			SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: id * 10, PartitionId: id, GroupId: id * 10, Size: 10}},
+		}
+	}
+	collection := &backuppb.CollectionBackupInfo{
+		CollectionId:     testCollectionID,
+		DbName:           "default",
+		CollectionName:   "coll",
+		PartitionBackups: []*backuppb.PartitionBackupInfo{partition(11, "p1"), partition(12, "p2"), partition(13, "p3")},
+		L0Segments:       []*backuppb.SegmentBackupInfo{{SegmentId: 20, PartitionId: -1, IsL0: true}},
+	}
+	selected, err := selectPartitionBackups(collection, []string{"p1", "p3"})
+	require.NoError(t, err)
+	task := &backuppb.RestoreCollectionTask{
+		Id:                   "coll",
+		CollBackup:           selected,
+		TargetDbName:         "default",
+		TargetCollectionName: "coll",
+		SkipCreateCollection: true,
+		SelectedPartitions:   []string{"p1", "p3"},
+	}
+	b.meta.AddRestoreTask(&backuppb.RestoreBackupTask{Id: "restore", CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{task}})
+
+	backupPath := b.backupRootPath + "/selected"
+	_, err = b.executeRestoreCollectionTask(ctx, b.backupBucketName, backupPath, task, "restore")
+	require.NoError(t, err)
+
+	// the unselected partition is untouched, and the l0 segment of the collection is bulk inserted into the selected partitions only
+	partitionNames := make([]string, 0)
+	for name := range client.bulkInserts {
+		partitionNames = append(partitionNames, name)
+	}
+	sort.Strings(partitionNames)
+	assert.Equal(t, []string{"p1", "p3"}, partitionNames)
+	l0Files := fmt.Sprintf("%s/binlogs/delta_log/%d/-1/20", backupPath, testCollectionID)
+	for _, p := range []*backuppb.PartitionBackupInfo{partition(11, "p1"), partition(13, "p3")} {
+		files := client.bulkInserts[p.GetPartitionName()]
+		// the l0 segments are bulk inserted after the segment groups
+		require.Len(t, files, 2)
+		assert.True(t, strings.HasPrefix(files[0], fmt.Sprintf("%s/binlogs/insert_log/%d/%d/", backupPath, testCollectionID, p.GetPartitionId())))
+		assert.Equal(t, l0Files, files[1])
+	}
+
+	// the l0 segment is recorded as finished for each partition, so that a resumed task skips them
+	restored := b.meta.GetRestoreTask("restore").GetCollectionRestoreTasks()[0]
+	assert.Empty(t, restored.GetFinishedL0SegmentIds())
+	assert.True(t, isRestoreL0SegmentFinished(restored, 11, 20))
+	assert.True(t, isRestoreL0SegmentFinished(restored, 13, 20))
+	assert.False(t, isRestoreL0SegmentFinished(restored, 12, 20))
+
+	// without selection, the l0 segment of the collection is bulk inserted into the whole collection
+	assert.Equal(t, []*backuppb.PartitionBackupInfo{{PartitionId: -1}}, collectionL0Partitions(&backuppb.RestoreCollectionTask{CollBackup: collection}))
+}
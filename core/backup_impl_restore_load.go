@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// restoreLoadState loads the restored collection or partitions which were loaded at backup time.
+// It waits for the indexes of the collection to be built before loading, and the loading progress is recorded into the task.
+func (b *BackupContext) restoreLoadState(ctx context.Context, task *backuppb.RestoreCollectionTask, parentTaskID string) error {
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	log := log.With(
+		zap.String("target_db_name", targetDBName),
+		zap.String("target_collection_name", targetCollectionName))
+
+	load, partitions := loadStateOfBackup(task.GetCollBackup())
+	if !load {
+		log.Info("collection is not loaded at backup time, skip loading")
+		return nil
+	}
+
+	indexedFields, err := b.waitIndexesBuilt(ctx, targetDBName, targetCollectionName, task.GetCollBackup().GetSchema())
+	if err != nil {
+		log.Error("fail to wait for the indexes to be built", zap.Error(err))
+		return err
+	}
+	for field := range vectorFieldsOfSchema(task.GetCollBackup().GetSchema()) {
+		if !indexedFields[field] {
+			return fmt.Errorf("fail to load collection %s.%s, vector field %s has no index, restore the indexes by restoreIndex", targetDBName, targetCollectionName, field)
+		}
+	}
+
+	log.Info("load restored collection", zap.Strings("partitions", partitions), zap.Int32("replicaNumber", task.GetLoadReplicaNumber()))
+	task.LoadPartitions = partitions
+	b.meta.UpdateRestoreTask(parentTaskID, setCollectionRestoreLoadProgress(task.GetId(), partitions, 0))
+	if len(partitions) == 0 {
+		err = b.getMilvusClient().LoadCollection(ctx, targetDBName, targetCollectionName, task.GetLoadReplicaNumber())
+	} else {
+		err = b.getMilvusClient().LoadPartitions(ctx, targetDBName, targetCollectionName, partitions, task.GetLoadReplicaNumber())
+	}
+	if err != nil {
+		log.Error("fail to load restored collection", zap.Error(err))
+		return err
+	}
+	return b.watchLoadingProgress(ctx, task, parentTaskID, LOAD_TIMEOUT, LOAD_SLEEP_INTERVAL)
+}
+
+// loadStateOfBackup returns whether the collection was loaded at backup time and the partitions loaded,
+// partitions is empty if the whole collection was loaded.
+// A collection or partition still loading at backup time counts as loaded.
+func loadStateOfBackup(collection *backuppb.CollectionBackupInfo) (bool, []string) {
+	if collection.GetLoadState() == LoadState_Loaded {
+		return true, nil
+	}
+	partitions := make([]string, 0)
+	partial := false
+	for _, partition := range collection.GetPartitionBackups() {
+		if partition.GetLoadState() == LoadState_Loaded || partition.GetLoadState() == LoadState_Loading {
+			partitions = append(partitions, partition.GetPartitionName())
+		} else if partition.GetLoadState() == LoadState_NotLoad {
+			partial = true
+		}
+	}
+	// a loading collection is loaded as a whole unless some of its partitions were not loaded
+	if collection.GetLoadState() == LoadState_Loading && !partial {
+		return true, nil
+	}
+	return len(partitions) > 0, partitions
+}
+
+// waitIndexesBuilt waits until the indexes of the collection are built, the fields with index are returned
+func (b *BackupContext) waitIndexesBuilt(ctx context.Context, db, collectionName string, schema *backuppb.CollectionSchema) (map[string]bool, error) {
+	indexedFields := make(map[string]bool)
+	for _, field := range schema.GetFields() {
+		indexes, err := b.getMilvusClient().DescribeIndex(ctx, db, collectionName, field.GetName())
+		if err != nil {
+			if strings.Contains(err.Error(), "index not found") ||
+				strings.HasPrefix(err.Error(), "index doesn't exist") {
+				continue
+			}
+			return nil, err
+		}
+		for _, index := range indexes {
+			indexedFields[field.GetName()] = true
+			for {
+				state, err := b.getMilvusClient().GetIndexState(ctx, db, collectionName, field.GetName(), index.Name())
+				if err != nil {
+					return nil, err
+				}
+				if state == entity.IndexState(commonpb.IndexState_Finished) {
+					break
+				}
+				if state == entity.IndexState(commonpb.IndexState_Failed) {
+					return nil, fmt.Errorf("fail to build index %s on field %s", index.Name(), field.GetName())
+				}
+				log.Info("wait for index to be built",
+					zap.String("collection", collectionName),
+					zap.String("field", field.GetName()),
+					zap.String("index", index.Name()),
+					zap.Int32("state", int32(state)))
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(time.Second * time.Duration(LOAD_SLEEP_INTERVAL)):
+				}
+			}
+		}
+	}
+	return indexedFields, nil
+}
+
+// watchLoadingProgress waits until the restored collection is loaded, it fails if the progress hangs for timeout seconds
+func (b *BackupContext) watchLoadingProgress(ctx context.Context, task *backuppb.RestoreCollectionTask, parentTaskID string, timeout int64, sleepSeconds int) error {
+	lastProgress := int64(0)
+	lastUpdateTime := time.Now().Unix()
+	for {
+		progress, err := b.getMilvusClient().GetLoadingProgress(ctx, task.GetTargetDbName(), task.GetTargetCollectionName(), task.GetLoadPartitions())
+		if err != nil {
+			return err
+		}
+		currentTimestamp := time.Now().Unix()
+		log.Info("loading progress",
+			zap.String("target_db_name", task.GetTargetDbName()),
+			zap.String("target_collection_name", task.GetTargetCollectionName()),
+			zap.Int64("progress", progress))
+		if progress > lastProgress {
+			lastProgress = progress
+			lastUpdateTime = currentTimestamp
+			task.LoadProgress = progress
+			b.meta.UpdateRestoreTask(parentTaskID, setCollectionRestoreLoadProgress(task.GetId(), task.GetLoadPartitions(), progress))
+		}
+		if progress >= 100 {
+			return nil
+		}
+		if currentTimestamp-lastUpdateTime >= timeout {
+			log.Warn(fmt.Sprintf("loading progress hang for more than %d s", timeout))
+			return errors.New("load collection timeout")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second * time.Duration(sleepSeconds)):
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestLoadStateOfBackup(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{
+		LoadState: LoadState_Loaded,
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{PartitionName: "p1", LoadState: LoadState_Loaded},
+			{PartitionName: "p2", LoadState: LoadState_NotLoad},
+		},
+	}
+	load, partitions := loadStateOfBackup(collection)
+	assert.True(t, load)
+	assert.Empty(t, partitions)
+
+	// partially loaded collection, only the loaded partitions are loaded
+	collection.LoadState = LoadState_Loading
+	load, partitions = loadStateOfBackup(collection)
+	assert.True(t, load)
+	assert.Equal(t, []string{"p1"}, partitions)
+
+	// the collection was still loading, it is loaded as a whole
+	collection.PartitionBackups[0].LoadState = LoadState_Loading
+	collection.PartitionBackups[1].LoadState = LoadState_Loading
+	load, partitions = loadStateOfBackup(collection)
+	assert.True(t, load)
+	assert.Empty(t, partitions)
+	// the load states of the partitions are not recorded
+	collection.PartitionBackups[0].LoadState = ""
+	collection.PartitionBackups[1].LoadState = ""
+	load, partitions = loadStateOfBackup(collection)
+	assert.True(t, load)
+	assert.Empty(t, partitions)
+
+	collection.LoadState = LoadState_NotLoad
+	collection.PartitionBackups[0].LoadState = LoadState_NotLoad
+	collection.PartitionBackups[1].LoadState = LoadState_NotLoad
+	load, _ = loadStateOfBackup(collection)
+	assert.False(t, load)
+}
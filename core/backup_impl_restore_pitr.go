@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+// parseRestoreToTimestamp returns the timestamp to restore the data to, 0 means restore to the backup timestamp
+func parseRestoreToTimestamp(request *backuppb.RestoreBackupRequest) (uint64, error) {
+	if request.GetRestoreToTimestamp() != 0 {
+		return request.GetRestoreToTimestamp(), nil
+	}
+	if request.GetRestoreToTime() == "" {
+		return 0, nil
+	}
+	restoreTo, err := time.Parse(time.RFC3339, request.GetRestoreToTime())
+	if err != nil {
+		return 0, fmt.Errorf("illegal restore_to_time %s, should be in RFC3339 format: %w", request.GetRestoreToTime(), err)
+	}
+	return utils.ComposeTS(restoreTo.UnixMilli(), 0), nil
+}
+
+// backupBinlogRelativePath returns the path of a binlog relative to the backup directory
+// milvus_rootpath/insert_log/collection_id/partition_id/segment_id/field_id/log_id =>
+// binlogs/insert_log/collection_id/partition_id/group_id/segment_id/field_id/log_id
+func backupBinlogRelativePath(logPath string, groupID int64) string {
+	relativePath := logPath
+	for _, dir := range []string{INSERT_LOG_DIR, DELTA_LOG_DIR} {
+		if index := strings.Index(logPath, dir+SEPERATOR); index >= 0 {
+			relativePath = logPath[index:]
+			break
+		}
+	}
+	if groupID != 0 {
+		// log_dir/collection_id/partition_id/...
+		splits := strings.SplitN(relativePath, SEPERATOR, 4)
+		if len(splits) == 4 {
+			relativePath = strings.Join([]string{splits[0], splits[1], splits[2], strconv.FormatInt(groupID, 10), splits[3]}, SEPERATOR)
+		}
+	}
+	return BINGLOG_DIR + SEPERATOR + relativePath
+}
+
+// isBinlogBefore checks whether the binlog has data not later than the timestamp, binlogs without timestamp are kept
+func isBinlogBefore(binlog *backuppb.Binlog, ts uint64) bool {
+	return binlog.GetTimestampFrom() == 0 || binlog.GetTimestampFrom() <= ts
+}
+
+// restoreBinlogsOfGroup picks the binlogs of the segment group to restore the data to the timestamp.
+// Segments whose insert binlogs are all later than the timestamp are skipped, rows of the rest are filtered by bulk insert.
+// Deltalogs later than the timestamp are skipped. filtered reports whether any binlog of the group is skipped.
+func restoreBinlogsOfGroup(segments []*backuppb.SegmentBackupInfo, groupID int64, ts uint64) (insertFiles []string, deltaFiles []string, size int64, filtered bool) {
+	for _, segment := range segments {
+		if segment.GetGroupId() != groupID {
+			continue
+		}
+		segmentInsertFiles := make([]string, 0)
+		beforeTs := false
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				segmentInsertFiles = append(segmentInsertFiles, backupBinlogRelativePath(binlog.GetLogPath(), groupID))
+				beforeTs = beforeTs || isBinlogBefore(binlog, ts)
+			}
+		}
+		if !beforeTs {
+			filtered = true
+			continue
+		}
+		insertFiles = append(insertFiles, segmentInsertFiles...)
+		size += segment.GetSize()
+		for _, fieldBinlog := range segment.GetDeltalogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				if isBinlogBefore(binlog, ts) {
+					deltaFiles = append(deltaFiles, backupBinlogRelativePath(binlog.GetLogPath(), groupID))
+				} else {
+					filtered = true
+				}
+			}
+		}
+	}
+	return insertFiles, deltaFiles, size, filtered
+}
+
+// restoreDeltalogsOfL0Segment picks the deltalogs of the l0 segment not later than the timestamp
+func restoreDeltalogsOfL0Segment(segment *backuppb.SegmentBackupInfo, ts uint64) (deltaFiles []string, filtered bool) {
+	for _, fieldBinlog := range segment.GetDeltalogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if isBinlogBefore(binlog, ts) {
+				deltaFiles = append(deltaFiles, backupBinlogRelativePath(binlog.GetLogPath(), 0))
+			} else {
+				filtered = true
+			}
+		}
+	}
+	return deltaFiles, filtered
+}
+
+// copyBinlogsToDir copies the binlogs of backup into the temporary dir of milvus bucket,
+// binlogs of deduplicated backups are copied from blobs, binlogs of encrypted backups are decrypted and compressed binlogs are decompressed
+func (b *BackupContext) copyBinlogsToDir(ctx context.Context, backupBucketName string, manifests *blobManifestCache, dataKeys *backupDataKeyCache, codecs map[string]string, backupPath string, relativeFiles []string, tempDir string) error {
+	manifest, err := b.getBlobManifest(ctx, manifests, backupBucketName, backupPath)
+	if err != nil {
+		return err
+	}
+	dataKey, err := b.getBackupDataKey(ctx, dataKeys, backupBucketName, backupPath)
+	if err != nil {
+		return err
+	}
+	backupRootPath := backupPath[:strings.LastIndex(backupPath, SEPERATOR)]
+	for _, relativeFile := range relativeFiles {
+		sourcePath := backupPath + SEPERATOR + relativeFile
+		if manifest != nil {
+			if ref, ok := manifest.Files[relativeFile]; ok {
+				sourcePath = BlobPath(backupRootPath, ref.Hash)
+			}
+		}
+		targetPath := tempDir + backupPath + SEPERATOR + relativeFile
+		err := retry.Do(ctx, func() error {
+			if dataKey != nil || codecs[sourcePath] != compression.None {
+				return b.copyDecoded(ctx, backupBucketName, dataKey, codecs, sourcePath, targetPath)
+			}
+			return b.copyBetweenStorages(ctx, storage.BackupStorage, backupBucketName, sourcePath, storage.MilvusStorage, b.milvusBucketName, targetPath)
+		}, b.storageRetryOptions()...)
+		if err != nil {
+			log.Error("fail to copy binlog to temporary restore dir", zap.String("from", sourcePath), zap.String("to", targetPath), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// backupGroupDirs returns the insert and delta dirs of the segment group in backup, delta dir is empty if there is no deltalog
+func backupGroupDirs(backupPath string, partition *backuppb.PartitionBackupInfo, groupID int64, hasDelta bool) []string {
+	groupDir := fmt.Sprintf("%v/%v/", partition.GetCollectionId(), partition.GetPartitionId())
+	if groupID != 0 {
+		groupDir = groupDir + fmt.Sprintf("%d/", groupID)
+	}
+	insertPath := fmt.Sprintf("%s/%s/%s/%s", backupPath, BINGLOG_DIR, INSERT_LOG_DIR, groupDir)
+	if !hasDelta {
+		return []string{insertPath, ""}
+	}
+	deltaPath := fmt.Sprintf("%s/%s/%s/%s", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, groupDir)
+	return []string{insertPath, deltaPath}
+}
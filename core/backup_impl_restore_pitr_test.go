@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestParseRestoreToTimestamp(t *testing.T) {
+	ts, err := parseRestoreToTimestamp(&backuppb.RestoreBackupRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), ts)
+
+	ts, err = parseRestoreToTimestamp(&backuppb.RestoreBackupRequest{RestoreToTimestamp: 100, RestoreToTime: "2024-03-01T14:05:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), ts)
+
+	ts, err = parseRestoreToTimestamp(&backuppb.RestoreBackupRequest{RestoreToTime: "2024-03-01T14:05:00Z"})
+	assert.NoError(t, err)
+	physical, _ := utils.ParseTS(ts)
+	assert.True(t, physical.Equal(time.Date(2024, 3, 1, 14, 5, 0, 0, time.UTC)))
+
+	_, err = parseRestoreToTimestamp(&backuppb.RestoreBackupRequest{RestoreToTime: "2024-03-01 14:05"})
+	assert.Error(t, err)
+}
+
+func TestBackupBinlogRelativePath(t *testing.T) {
+	assert.Equal(t, "binlogs/insert_log/1/2/3/100/5", backupBinlogRelativePath("files/insert_log/1/2/3/100/5", 0))
+	assert.Equal(t, "binlogs/insert_log/1/2/7/3/100/5", backupBinlogRelativePath("files/insert_log/1/2/3/100/5", 7))
+	assert.Equal(t, "binlogs/delta_log/1/2/7/3/5", backupBinlogRelativePath("delta_log/1/2/3/5", 7))
+}
+
+func TestRestoreBinlogsOfGroup(t *testing.T) {
+	binlog := func(path string, from, to uint64) *backuppb.FieldBinlog {
+		return &backuppb.FieldBinlog{Binlogs: []*backuppb.Binlog{{LogPath: path, TimestampFrom: from, TimestampTo: to}}}
+	}
+	segments := []*backuppb.SegmentBackupInfo{
+		{
+			SegmentId: 3, GroupId: 7, Size: 10,
+			Binlogs:   []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/3/100/1", 10, 20)},
+			Deltalogs: []*backuppb.FieldBinlog{binlog("files/delta_log/1/2/3/2", 15, 18), binlog("files/delta_log/1/2/3/3", 40, 50)},
+		},
+		{
+			SegmentId: 4, GroupId: 7, Size: 20,
+			Binlogs: []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/4/100/1", 35, 60)},
+		},
+		{
+			SegmentId: 5, GroupId: 8, Size: 30,
+			Binlogs: []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/5/100/1", 1, 2)},
+		},
+	}
+
+	insertFiles, deltaFiles, size, filtered := restoreBinlogsOfGroup(segments, 7, 30)
+	assert.True(t, filtered)
+	assert.Equal(t, []string{"binlogs/insert_log/1/2/7/3/100/1"}, insertFiles)
+	assert.Equal(t, []string{"binlogs/delta_log/1/2/7/3/2"}, deltaFiles)
+	assert.Equal(t, int64(10), size)
+
+	_, _, size, filtered = restoreBinlogsOfGroup(segments, 7, 100)
+	assert.False(t, filtered)
+	assert.Equal(t, int64(30), size)
+
+	insertFiles, _, _, filtered = restoreBinlogsOfGroup(segments, 7, 5)
+	assert.True(t, filtered)
+	assert.Empty(t, insertFiles)
+
+	deltaFiles, filtered = restoreDeltalogsOfL0Segment(segments[0], 30)
+	assert.True(t, filtered)
+	assert.Equal(t, []string{"binlogs/delta_log/1/2/3/2"}, deltaFiles)
+
+	assert.Equal(t, []string{"backup/b1/binlogs/insert_log/1/2/7/", ""},
+		backupGroupDirs("backup/b1", &backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2}, 7, false))
+	assert.Equal(t, []string{"backup/b1/binlogs/insert_log/1/2/", "backup/b1/binlogs/delta_log/1/2/"},
+		backupGroupDirs("backup/b1", &backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2}, 0, true))
+}
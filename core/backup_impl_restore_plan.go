@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// dryRunRestore returns the plan of the restore task without executing it, only the read operations of milvus are called.
+// The plan follows executeRestoreCollectionTask, the groups and l0 segments finished by a resumed task are excluded.
+func (b *BackupContext) dryRunRestore(ctx context.Context, request *backuppb.RestoreBackupRequest, task *backuppb.RestoreBackupTask, plan *backuppb.RestorePlan) *backuppb.RestoreBackupResponse {
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	// target collection name -> source collection name
+	targets := make(map[string]string)
+	for _, collectionTask := range task.GetCollectionRestoreTasks() {
+		if collectionTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			continue
+		}
+		collectionPlan, err := b.planRestoreCollection(ctx, collectionTask, lo.Contains(plan.GetCreateDatabases(), collectionTask.GetTargetDbName()))
+		if err != nil {
+			log.Error("fail to plan restore collection",
+				zap.String("target_db_name", collectionTask.GetTargetDbName()),
+				zap.String("target_collection_name", collectionTask.GetTargetCollectionName()),
+				zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		source := collectionPlan.GetSourceDbName() + "." + collectionPlan.GetSourceCollectionName()
+		target := collectionPlan.GetTargetDbName() + "." + collectionPlan.GetTargetCollectionName()
+		if other, ok := targets[target]; ok {
+			plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("collections %s and %s are restored into the same collection %s", other, source, target))
+		}
+		targets[target] = source
+		if !collectionPlan.GetExist() && !collectionPlan.GetCreate() {
+			plan.Conflicts = append(plan.Conflicts, fmt.Sprintf("collection %s doesn't exist but skipCreateCollection is set", target))
+		}
+		// aliases of a new database don't exist
+		if !lo.Contains(plan.GetCreateDatabases(), collectionTask.GetTargetDbName()) {
+			conflicts, err := b.aliasConflicts(ctx, collectionTask)
+			if err != nil {
+				log.Error("fail to check the aliases to restore", zap.String("target", target), zap.Error(err))
+				resp.Code = backuppb.ResponseCode_Fail
+				resp.Msg = err.Error()
+				return resp
+			}
+			plan.Conflicts = append(plan.Conflicts, conflicts...)
+		}
+		plan.Collections = append(plan.Collections, collectionPlan)
+		plan.Size += collectionPlan.GetSize()
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = fmt.Sprintf("dry run, %d collections to restore, %d conflicts", len(plan.GetCollections()), len(plan.GetConflicts()))
+	resp.Plan = plan
+	log.Info("finish dry run restore",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.Int("collections", len(plan.GetCollections())),
+		zap.Strings("createDatabases", plan.GetCreateDatabases()),
+		zap.Strings("conflicts", plan.GetConflicts()),
+		zap.Int64("size", plan.GetSize()))
+	return resp
+}
+
+// planRestoreCollection returns the plan of the collection restore task, newDatabase means the target database is to be created
+func (b *BackupContext) planRestoreCollection(ctx context.Context, task *backuppb.RestoreCollectionTask, newDatabase bool) (*backuppb.RestoreCollectionPlan, error) {
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	collectionPlan := &backuppb.RestoreCollectionPlan{
+		SourceDbName:         task.GetCollBackup().GetDbName(),
+		SourceCollectionName: task.GetCollBackup().GetCollectionName(),
+		TargetDbName:         targetDBName,
+		TargetCollectionName: targetCollectionName,
+	}
+	if !newDatabase {
+		exist, err := b.getMilvusClient().HasCollection(ctx, targetDBName, targetCollectionName)
+		if err != nil {
+			return nil, err
+		}
+		collectionPlan.Exist = exist
+	}
+	collectionPlan.Drop = task.GetDropExistCollection() && collectionPlan.GetExist()
+	collectionPlan.Create = !task.GetSkipCreateCollection() || task.GetDropExistCollection()
+	// indexes of a recreated collection don't exist
+	collectionPlan.DropIndex = task.GetDropExistIndex() && collectionPlan.GetExist() && !collectionPlan.GetCreate()
+
+	if task.GetRestoreIndex() {
+		vectorFields := vectorFieldsOfSchema(task.GetCollBackup().GetSchema())
+		for _, index := range task.GetCollBackup().GetIndexInfos() {
+			collectionPlan.Indexes = append(collectionPlan.Indexes, restoredIndexInfo(index, vectorFields[index.GetFieldName()] && task.GetUseAutoIndex()))
+		}
+	}
+
+	hasPartitionKey := lo.ContainsBy(task.GetCollBackup().GetSchema().GetFields(), func(field *backuppb.FieldSchema) bool {
+		return field.GetIsPartitionKey()
+	})
+	for _, partitionBackup := range task.GetCollBackup().GetPartitionBackups() {
+		partitionPlan := planRestorePartition(task, partitionBackup)
+		if collectionPlan.GetCreate() {
+			// the default partition and the partitions of partition key are created with the collection
+			partitionPlan.Create = partitionBackup.GetPartitionName() != "_default" && !hasPartitionKey
+		} else if collectionPlan.GetExist() {
+			exist, err := b.getMilvusClient().HasPartition(ctx, targetDBName, targetCollectionName, partitionBackup.GetPartitionName())
+			if err != nil {
+				return nil, err
+			}
+			partitionPlan.Create = !exist
+		}
+		collectionPlan.Partitions = append(collectionPlan.Partitions, partitionPlan)
+		collectionPlan.Size += partitionPlan.GetSize()
+	}
+
+	if len(task.GetSelectedPartitions()) == 0 {
+		for _, segment := range task.GetCollBackup().GetL0Segments() {
+			if planRestoreL0Segment(task, -1, segment) {
+				collectionPlan.L0SegmentIds = append(collectionPlan.L0SegmentIds, segment.GetSegmentId())
+			}
+		}
+	}
+	if task.GetRestoreLoadState() {
+		collectionPlan.Load, collectionPlan.LoadPartitions = loadStateOfBackup(task.GetCollBackup())
+	}
+	collectionPlan.Aliases = task.GetAliases()
+	return collectionPlan, nil
+}
+
+// planRestorePartition returns the segment groups and l0 segments of the partition to bulk insert
+func planRestorePartition(task *backuppb.RestoreCollectionTask, partitionBackup *backuppb.PartitionBackupInfo) *backuppb.RestorePartitionPlan {
+	partitionPlan := &backuppb.RestorePartitionPlan{
+		PartitionName: partitionBackup.GetPartitionName(),
+	}
+	l0Segments := lo.Filter(partitionBackup.GetSegmentBackups(), func(segment *backuppb.SegmentBackupInfo, _ int) bool {
+		return segment.GetIsL0()
+	})
+	notl0Segments := lo.Filter(partitionBackup.GetSegmentBackups(), func(segment *backuppb.SegmentBackupInfo, _ int) bool {
+		return !segment.GetIsL0()
+	})
+	groupIds := collectGroupIdsFromSegments(notl0Segments)
+	for _, groupId := range groupIds {
+		if isRestoreGroupFinished(task, partitionBackup.GetPartitionId(), groupId) {
+			continue
+		}
+		groupPlan := &backuppb.RestoreGroupPlan{GroupId: groupId}
+		ts := task.GetRestoreToTimestamp()
+		if ts != 0 {
+			insertFiles, deltaFiles, size, filtered := restoreBinlogsOfGroup(notl0Segments, groupId, ts)
+			if filtered {
+				if len(insertFiles)+len(deltaFiles) == 0 {
+					continue
+				}
+				groupPlan.Size = size
+				for _, segment := range notl0Segments {
+					if segment.GetGroupId() == groupId && isSegmentBefore(segment, ts) {
+						groupPlan.SegmentIds = append(groupPlan.SegmentIds, segment.GetSegmentId())
+					}
+				}
+				partitionPlan.Groups = append(partitionPlan.Groups, groupPlan)
+				partitionPlan.Size += groupPlan.GetSize()
+				continue
+			}
+		}
+		for _, segment := range notl0Segments {
+			if segment.GetGroupId() == groupId {
+				groupPlan.SegmentIds = append(groupPlan.SegmentIds, segment.GetSegmentId())
+				groupPlan.Size += segment.GetSize()
+			}
+		}
+		// backward compatible old backup without group id, the partition is restored as a whole
+		if len(groupIds) == 1 && groupId == 0 {
+			groupPlan.Size = partitionBackup.GetSize()
+		}
+		partitionPlan.Groups = append(partitionPlan.Groups, groupPlan)
+		partitionPlan.Size += groupPlan.GetSize()
+	}
+
+	for _, segment := range l0Segments {
+		if planRestoreL0Segment(task, segment.GetPartitionId(), segment) {
+			partitionPlan.L0SegmentIds = append(partitionPlan.L0SegmentIds, segment.GetSegmentId())
+		}
+	}
+	// the l0 segments of the collection are bulk inserted into each selected partition
+	if len(task.GetSelectedPartitions()) > 0 {
+		for _, segment := range task.GetCollBackup().GetL0Segments() {
+			if planRestoreL0Segment(task, partitionBackup.GetPartitionId(), segment) {
+				partitionPlan.L0SegmentIds = append(partitionPlan.L0SegmentIds, segment.GetSegmentId())
+			}
+		}
+	}
+	return partitionPlan
+}
+
+// planRestoreL0Segment returns whether the l0 segment is to be bulk inserted
+func planRestoreL0Segment(task *backuppb.RestoreCollectionTask, partitionID int64, segment *backuppb.SegmentBackupInfo) bool {
+	if isRestoreL0SegmentFinished(task, partitionID, segment.GetSegmentId()) {
+		return false
+	}
+	if task.GetRestoreToTimestamp() != 0 {
+		deltaFiles, filtered := restoreDeltalogsOfL0Segment(segment, task.GetRestoreToTimestamp())
+		if filtered && len(deltaFiles) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSegmentBefore returns whether any insert binlog of the segment is not later than the timestamp
+func isSegmentBefore(segment *backuppb.SegmentBackupInfo, ts uint64) bool {
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if isBinlogBefore(binlog, ts) {
+				return true
+			}
+		}
+	}
+	return false
+}
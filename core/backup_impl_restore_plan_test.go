@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestPlanRestorePartition(t *testing.T) {
+	binlog := func(path string, from, to uint64) *backuppb.FieldBinlog {
+		return &backuppb.FieldBinlog{Binlogs: []*backuppb.Binlog{{LogPath: path, TimestampFrom: from, TimestampTo: to}}}
+	}
+	partition := &backuppb.PartitionBackupInfo{
+		PartitionId:   2,
+		PartitionName: "p1",
+		Size:          100,
+		SegmentBackups: []*backuppb.SegmentBackupInfo{
+			{SegmentId: 3, GroupId: 7, Size: 10, Binlogs: []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/3/100/1", 10, 20)}},
+			{SegmentId: 4, GroupId: 7, Size: 20, Binlogs: []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/4/100/1", 35, 60)}},
+			{SegmentId: 5, GroupId: 8, Size: 30, Binlogs: []*backuppb.FieldBinlog{binlog("files/insert_log/1/2/5/100/1", 40, 50)}},
+			{SegmentId: 6, PartitionId: 2, IsL0: true, Deltalogs: []*backuppb.FieldBinlog{binlog("files/delta_log/1/2/6/1", 45, 50)}},
+		},
+	}
+
+	task := &backuppb.RestoreCollectionTask{Id: "coll"}
+	plan := planRestorePartition(task, partition)
+	assert.Equal(t, "p1", plan.GetPartitionName())
+	assert.Equal(t, int64(60), plan.GetSize())
+	assert.Len(t, plan.GetGroups(), 2)
+	assert.Equal(t, int64(7), plan.GetGroups()[0].GetGroupId())
+	assert.Equal(t, []int64{3, 4}, plan.GetGroups()[0].GetSegmentIds())
+	assert.Equal(t, int64(30), plan.GetGroups()[0].GetSize())
+	assert.Equal(t, []int64{6}, plan.GetL0SegmentIds())
+
+	// point in time restore skips the segments and deltalogs later than the timestamp
+	task.RestoreToTimestamp = 30
+	plan = planRestorePartition(task, partition)
+	assert.Len(t, plan.GetGroups(), 1)
+	assert.Equal(t, []int64{3}, plan.GetGroups()[0].GetSegmentIds())
+	assert.Equal(t, int64(10), plan.GetSize())
+	assert.Empty(t, plan.GetL0SegmentIds())
+
+	// the groups and l0 segments finished by a resumed task are excluded
+	task.RestoreToTimestamp = 0
+	task.PartitionRestoreTasks = []*backuppb.RestorePartitionTask{{PartitionId: 2, FinishedGroupIds: []int64{7}, FinishedL0SegmentIds: []int64{6}}}
+	plan = planRestorePartition(task, partition)
+	assert.Len(t, plan.GetGroups(), 1)
+	assert.Equal(t, int64(8), plan.GetGroups()[0].GetGroupId())
+	assert.Empty(t, plan.GetL0SegmentIds())
+
+	// old backup without group id is restored as a whole
+	oldPartition := &backuppb.PartitionBackupInfo{
+		PartitionId:    2,
+		Size:           100,
+		SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 3, Size: 10}, {SegmentId: 4, Size: 20}},
+	}
+	plan = planRestorePartition(&backuppb.RestoreCollectionTask{}, oldPartition)
+	assert.Len(t, plan.GetGroups(), 1)
+	assert.Equal(t, []int64{3, 4}, plan.GetGroups()[0].GetSegmentIds())
+	assert.Equal(t, int64(100), plan.GetSize())
+}
+
+func TestRestoredIndexInfo(t *testing.T) {
+	schema := &backuppb.CollectionSchema{
+		Fields: []*backuppb.FieldSchema{
+			{Name: "id", DataType: backuppb.DataType_Int64},
+			{Name: "vector", DataType: backuppb.DataType_FloatVector},
+		},
+	}
+	assert.Equal(t, map[string]bool{"vector": true}, vectorFieldsOfSchema(schema))
+
+	index := &backuppb.IndexInfo{
+		FieldName: "vector",
+		IndexName: "idx",
+		IndexType: "HNSW",
+		Params:    map[string]string{"index_type": "HNSW", "metric_type": "L2", "M": "16"},
+	}
+	restored := restoredIndexInfo(index, true)
+	assert.Equal(t, "AUTOINDEX", restored.GetIndexType())
+	assert.Equal(t, map[string]string{"index_type": "AUTOINDEX", "metric_type": "L2"}, restored.GetParams())
+	assert.Equal(t, index.GetParams(), restoredIndexInfo(index, false).GetParams())
+
+	trie := restoredIndexInfo(&backuppb.IndexInfo{IndexType: "marisa-trie", Params: map[string]string{"index_type": "marisa-trie"}}, false)
+	assert.Equal(t, "Trie", trie.GetIndexType())
+	assert.Equal(t, "Trie", trie.GetParams()["index_type"])
+}
+
+func TestPlanRestoreSelectedPartition(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{
+		PartitionBackups: []*backuppb.PartitionBackupInfo{{PartitionId: 2, PartitionName: "p1"}},
+		L0Segments:       []*backuppb.SegmentBackupInfo{{SegmentId: 9, PartitionId: -1, IsL0: true}},
+	}
+	task := &backuppb.RestoreCollectionTask{Id: "coll", CollBackup: collection}
+	assert.Empty(t, planRestorePartition(task, collection.GetPartitionBackups()[0]).GetL0SegmentIds())
+
+	// the l0 segments of the collection are bulk inserted into each selected partition
+	task.SelectedPartitions = []string{"p1"}
+	assert.Equal(t, []int64{9}, planRestorePartition(task, collection.GetPartitionBackups()[0]).GetL0SegmentIds())
+	task.PartitionRestoreTasks = []*backuppb.RestorePartitionTask{{PartitionId: 2, FinishedL0SegmentIds: []int64{9}}}
+	assert.Empty(t, planRestorePartition(task, collection.GetPartitionBackups()[0]).GetL0SegmentIds())
+}
@@ -0,0 +1,384 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/binlog"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+	"github.com/zilliztech/milvus-backup/internal/util/encryption"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+// verifyRestoredCollection compares the restored collection with the backup after bulk insert and records the result into the task.
+// The rows of each partition are counted and the primary keys sampled from the insert binlogs of the backup are queried back
+// if the collection is loaded, otherwise only the rows of the collection statistics are compared for the collections created
+// by the restore. An existing collection which is not loaded is reported as not verified.
+// A mismatch or an unverified collection fails the task only if strict_verify is set.
+func (b *BackupContext) verifyRestoredCollection(ctx context.Context, backupPath string, temp *restoreTempFiles, task *backuppb.RestoreCollectionTask, parentTaskID string) error {
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	log := log.With(
+		zap.String("target_db_name", targetDBName),
+		zap.String("target_collection_name", targetCollectionName))
+
+	result := &backuppb.RestoreVerifyResult{
+		Partitions:     restoreVerifyPartitions(task),
+		CollectionRows: -1,
+	}
+	state, err := b.getMilvusClient().GetLoadState(ctx, targetDBName, targetCollectionName, nil)
+	if err != nil {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("fail to get the load state of the collection: %s", err))
+	} else if state == entity.LoadStateLoaded {
+		result.Verified = true
+		pkField, _ := lo.Find(task.GetCollBackup().GetSchema().GetFields(), func(field *backuppb.FieldSchema) bool {
+			return field.GetIsPrimaryKey()
+		})
+		// the partition results are in the order of the partition backups
+		for i, partition := range result.GetPartitions() {
+			partitionBackup := task.GetCollBackup().GetPartitionBackups()[i]
+			mismatches, err := b.verifyRestoredPartition(ctx, backupPath, temp, task, pkField, partitionBackup, partition)
+			if err != nil {
+				mismatches = append(mismatches, fmt.Sprintf("fail to verify partition %s: %s", partition.GetPartitionName(), err))
+			}
+			result.Mismatches = append(result.Mismatches, mismatches...)
+		}
+	} else if !task.GetSkipCreateCollection() || task.GetDropExistCollection() {
+		// the collection is not loaded, only the collection created by the restore is compared as a whole
+		result.Verified = true
+		stats, err := b.getMilvusClient().GetCollectionStatistics(ctx, targetDBName, targetCollectionName)
+		if err == nil {
+			result.CollectionRows, err = strconv.ParseInt(stats["row_count"], 10, 64)
+		}
+		if err != nil {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("fail to get the statistics of the collection: %s", err))
+		} else {
+			var minRows, maxRows int64
+			for _, partition := range result.GetPartitions() {
+				minRows += partition.GetMinRows()
+				maxRows += partition.GetMaxRows()
+			}
+			if mismatch := checkRestoredRows("collection "+targetCollectionName, result.GetCollectionRows(), minRows, maxRows); mismatch != "" {
+				result.Mismatches = append(result.Mismatches, mismatch)
+			}
+		}
+	}
+	// the rows of an existing collection are shared with the rows it has before the restore, they are not checked unless it is loaded
+	result.Passed = result.GetVerified() && len(result.GetMismatches()) == 0
+	b.meta.UpdateRestoreTask(parentTaskID, setCollectionRestoreVerifyResult(task.GetId(), result))
+	task.VerifyResult = result
+
+	if result.GetPassed() {
+		log.Info("verify restored collection passed", zap.Int64("collectionRows", result.GetCollectionRows()))
+		return nil
+	}
+	if !result.GetVerified() && len(result.GetMismatches()) == 0 {
+		log.Warn("the existing collection is not loaded, the restored rows are not verified", zap.Bool("strict", task.GetStrictVerify()))
+		if task.GetStrictVerify() {
+			return fmt.Errorf("restored collection %s.%s is not verified, as the existing collection is not loaded", targetDBName, targetCollectionName)
+		}
+		return nil
+	}
+	log.Warn("verify restored collection failed", zap.Strings("mismatches", result.GetMismatches()), zap.Bool("strict", task.GetStrictVerify()))
+	if task.GetStrictVerify() {
+		return fmt.Errorf("verify restored collection %s.%s failed: %s", targetDBName, targetCollectionName, strings.Join(result.GetMismatches(), "; "))
+	}
+	return nil
+}
+
+// verifyRestoredPartition counts the rows of the loaded partition and queries the primary keys sampled from the backup back,
+// the mismatches are returned and recorded into the partition result
+func (b *BackupContext) verifyRestoredPartition(ctx context.Context, backupPath string, temp *restoreTempFiles, task *backuppb.RestoreCollectionTask,
+	pkField *backuppb.FieldSchema, partitionBackup *backuppb.PartitionBackupInfo, partition *backuppb.RestorePartitionVerifyResult) ([]string, error) {
+	db := task.GetTargetDbName()
+	collectionName := task.GetTargetCollectionName()
+	partitionNames := []string{partition.GetPartitionName()}
+	consistency := gomilvus.WithSearchQueryConsistencyLevel(entity.ClStrong)
+	count, err := b.getMilvusClient().Query(ctx, db, collectionName, partitionNames, "", []string{"count(*)"}, consistency)
+	if err != nil {
+		return nil, err
+	}
+	countColumn := count.GetColumn("count(*)")
+	if countColumn == nil || countColumn.Len() == 0 {
+		return nil, fmt.Errorf("count(*) is not returned")
+	}
+	partition.ActualRows, err = countColumn.GetAsInt64(0)
+	if err != nil {
+		return nil, err
+	}
+	mismatches := make([]string, 0)
+	if mismatch := checkRestoredRows("partition "+partition.GetPartitionName(), partition.GetActualRows(), partition.GetMinRows(), partition.GetMaxRows()); mismatch != "" {
+		mismatches = append(mismatches, mismatch)
+	}
+
+	sampleSize := b.params.BackupCfg.RestoreVerifySampleSize
+	if sampleSize <= 0 {
+		return mismatches, nil
+	}
+	keys, err := b.sampleBackupPrimaryKeys(ctx, backupPath, temp, partitionBackup, pkField.GetFieldID(), task.GetRestoreToTimestamp(), sampleSize)
+	if err != nil {
+		return mismatches, err
+	}
+	if len(keys) == 0 {
+		return mismatches, nil
+	}
+	var ids entity.Column
+	switch keys[0].(type) {
+	case int64:
+		ids = entity.NewColumnInt64(pkField.GetName(), lo.Map(keys, func(key interface{}, _ int) int64 { return key.(int64) }))
+	case string:
+		ids = entity.NewColumnVarChar(pkField.GetName(), lo.Map(keys, func(key interface{}, _ int) string { return key.(string) }))
+	default:
+		return mismatches, fmt.Errorf("unsupported primary key type %T", keys[0])
+	}
+	queried, err := b.getMilvusClient().QueryByPks(ctx, db, collectionName, partitionNames, ids, []string{pkField.GetName()}, consistency)
+	if err != nil {
+		return mismatches, err
+	}
+	var found []interface{}
+	if column := queried.GetColumn(pkField.GetName()); column != nil {
+		for i := 0; i < column.Len(); i++ {
+			value, err := column.Get(i)
+			if err != nil {
+				return mismatches, err
+			}
+			found = append(found, value)
+		}
+	}
+	// keys of the backup may be deleted by the restored deltalogs, only the keys found more than once are mismatched then
+	hasDeletes := hasDeltalogs(task.GetCollBackup().GetL0Segments()) || hasDeltalogs(partitionBackup.GetSegmentBackups())
+	partition.SampledKeys = int64(len(keys))
+	partition.MismatchedKeys = countMismatchedKeys(keys, found, hasDeletes)
+	if partition.GetMismatchedKeys() > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("%d of %d primary keys sampled from the backup of partition %s are not found or found more than once",
+			partition.GetMismatchedKeys(), partition.GetSampledKeys(), partition.GetPartitionName()))
+	}
+	return mismatches, nil
+}
+
+// sampleBackupPrimaryKeys samples the primary keys restored into the partition from the insert binlogs of the backup.
+// The binlogs are picked evenly from the restored segments and the first keys of each binlog, the binlogs across
+// the timestamp of a point in time restore are skipped as their rows are filtered by bulk insert.
+func (b *BackupContext) sampleBackupPrimaryKeys(ctx context.Context, backupPath string, temp *restoreTempFiles,
+	partitionBackup *backuppb.PartitionBackupInfo, pkFieldID int64, ts uint64, sampleSize int) ([]interface{}, error) {
+	binlogPaths := make([]string, 0)
+	for _, segment := range partitionBackup.GetSegmentBackups() {
+		if segment.GetIsL0() || (ts != 0 && !isSegmentBefore(segment, ts)) {
+			continue
+		}
+		segmentBackupPath := RefBackupPath(backupPath, segment.GetRefBackup())
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			if fieldBinlog.GetFieldID() != pkFieldID {
+				continue
+			}
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				if ts != 0 && (binlog.GetTimestampTo() == 0 || binlog.GetTimestampTo() > ts) {
+					continue
+				}
+				binlogPaths = append(binlogPaths, segmentBackupPath+SEPERATOR+backupBinlogRelativePath(binlog.GetLogPath(), segment.GetGroupId()))
+			}
+		}
+	}
+	if len(binlogPaths) > sampleSize {
+		picked := make([]string, 0, sampleSize)
+		for i := 0; i < sampleSize; i++ {
+			picked = append(picked, binlogPaths[i*len(binlogPaths)/sampleSize])
+		}
+		binlogPaths = picked
+	}
+
+	keys := make([]interface{}, 0, sampleSize)
+	for i, binlogPath := range binlogPaths {
+		// the rest of the sample is shared by the rest of the binlogs, only the quota of the binlog is decoded
+		quota := (sampleSize - len(keys) + len(binlogPaths) - i - 1) / (len(binlogPaths) - i)
+		if quota == 0 {
+			continue
+		}
+		data, err := b.readBackupBinlog(ctx, temp, binlogPath)
+		if err != nil {
+			return nil, err
+		}
+		values, err := binlog.ReadValues(data, quota)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read primary keys of %s: %w", binlogPath, err)
+		}
+		keys = append(keys, values...)
+	}
+	return keys, nil
+}
+
+// readBackupBinlog reads a binlog of backup decoded, from the blob area if the backup is deduplicated
+func (b *BackupContext) readBackupBinlog(ctx context.Context, temp *restoreTempFiles, binlogPath string) ([]byte, error) {
+	backupPathOfFile, relativePath := splitBackupBinlogPath(binlogPath)
+	manifest, err := b.getBlobManifest(ctx, temp.blobManifests, temp.backupBucketName, backupPathOfFile)
+	if err != nil {
+		return nil, err
+	}
+	if ref, ok := manifest.getRef(relativePath); ok {
+		backupRootPath := backupPathOfFile[:strings.LastIndex(backupPathOfFile, SEPERATOR)]
+		var data []byte
+		err = retry.Do(ctx, func() error {
+			var err error
+			data, err = b.getThrottledStorageClient(storage.BackupStorage).Read(ctx, temp.backupBucketName, BlobPath(backupRootPath, ref.Hash))
+			return err
+		}, b.storageRetryOptions()...)
+		return data, err
+	}
+	dataKey, err := b.getBackupDataKey(ctx, temp.dataKeys, temp.backupBucketName, backupPathOfFile)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	err = retry.Do(ctx, func() error {
+		reader, err := b.getThrottledStorageClient(storage.BackupStorage).Reader(ctx, temp.backupBucketName, binlogPath)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		var decryptReader io.Reader = reader
+		if dataKey != nil {
+			decryptReader, err = encryption.NewDecryptReader(dataKey, reader)
+			if err != nil {
+				return err
+			}
+		}
+		decompressReader, err := compression.NewReader(temp.binlogCodecs[binlogPath], decryptReader)
+		if err != nil {
+			return err
+		}
+		defer decompressReader.Close()
+		data, err = io.ReadAll(decompressReader)
+		return err
+	}, b.storageRetryOptions()...)
+	return data, err
+}
+
+// restoreVerifyPartitions returns the range of rows restored into each partition by the backup,
+// the groups finished by a resumed task are counted as well as they have been restored.
+// Rows deleted by the collection level l0 segments may be of any partition, they are subtracted from the minimum of every partition.
+func restoreVerifyPartitions(task *backuppb.RestoreCollectionTask) []*backuppb.RestorePartitionVerifyResult {
+	ts := task.GetRestoreToTimestamp()
+	collectionDeletes, collectionDeletesKnown := deletedEntriesOfSegments(task.GetCollBackup().GetL0Segments(), ts)
+	results := make([]*backuppb.RestorePartitionVerifyResult, 0, len(task.GetCollBackup().GetPartitionBackups()))
+	for _, partitionBackup := range task.GetCollBackup().GetPartitionBackups() {
+		var minRows, maxRows int64
+		segments := make([]*backuppb.SegmentBackupInfo, 0)
+		for _, segment := range partitionBackup.GetSegmentBackups() {
+			// segments later than the timestamp are skipped by point in time restore
+			if !segment.GetIsL0() && ts != 0 && !isSegmentBefore(segment, ts) {
+				continue
+			}
+			segments = append(segments, segment)
+			if !segment.GetIsL0() {
+				segmentMin, segmentMax := restoredRowsOfSegment(segment, ts)
+				minRows += segmentMin
+				maxRows += segmentMax
+			}
+		}
+		deletes, known := deletedEntriesOfSegments(segments, ts)
+		if known && collectionDeletesKnown {
+			minRows -= deletes + collectionDeletes
+		}
+		if !known || !collectionDeletesKnown || minRows < 0 {
+			minRows = 0
+		}
+		results = append(results, &backuppb.RestorePartitionVerifyResult{
+			PartitionName: partitionBackup.GetPartitionName(),
+			MinRows:       minRows,
+			MaxRows:       maxRows,
+			ActualRows:    -1,
+		})
+	}
+	return results
+}
+
+// restoredRowsOfSegment returns the range of rows restored from the segment, rows of the binlogs across the timestamp
+// are filtered by bulk insert so they are uncertain
+func restoredRowsOfSegment(segment *backuppb.SegmentBackupInfo, ts uint64) (minRows int64, maxRows int64) {
+	if ts == 0 || len(segment.GetBinlogs()) == 0 {
+		return segment.GetNumOfRows(), segment.GetNumOfRows()
+	}
+	// every field has the same rows in its binlogs
+	for _, binlog := range segment.GetBinlogs()[0].GetBinlogs() {
+		if binlog.GetTimestampTo() != 0 && binlog.GetTimestampTo() <= ts {
+			minRows += binlog.GetEntriesNum()
+			maxRows += binlog.GetEntriesNum()
+		} else if isBinlogBefore(binlog, ts) {
+			maxRows += binlog.GetEntriesNum()
+		}
+	}
+	// entries of the binlogs are not recorded
+	if maxRows == 0 {
+		return 0, segment.GetNumOfRows()
+	}
+	return minRows, maxRows
+}
+
+// deletedEntriesOfSegments sums the entries of the deltalogs restored from the segments,
+// known is false if the entries of a non-empty deltalog are not recorded
+func deletedEntriesOfSegments(segments []*backuppb.SegmentBackupInfo, ts uint64) (entries int64, known bool) {
+	for _, segment := range segments {
+		for _, fieldBinlog := range segment.GetDeltalogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				if ts != 0 && !isBinlogBefore(binlog, ts) {
+					continue
+				}
+				if binlog.GetEntriesNum() == 0 && binlog.GetLogSize() > 0 {
+					return 0, false
+				}
+				entries += binlog.GetEntriesNum()
+			}
+		}
+	}
+	return entries, true
+}
+
+// checkRestoredRows returns the mismatch if the rows are out of the range, empty if they match
+func checkRestoredRows(name string, rows, minRows, maxRows int64) string {
+	if rows >= minRows && rows <= maxRows {
+		return ""
+	}
+	if minRows == maxRows {
+		return fmt.Sprintf("%s has %d rows, expected %d rows", name, rows, maxRows)
+	}
+	return fmt.Sprintf("%s has %d rows, expected %d to %d rows", name, rows, minRows, maxRows)
+}
+
+// hasDeltalogs returns whether any deltalog is restored from the segments
+func hasDeltalogs(segments []*backuppb.SegmentBackupInfo) bool {
+	for _, segment := range segments {
+		for _, fieldBinlog := range segment.GetDeltalogs() {
+			if len(fieldBinlog.GetBinlogs()) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// countMismatchedKeys returns the number of sampled keys which are not found exactly once,
+// the keys not found are allowed if they may be deleted
+func countMismatchedKeys(sampled []interface{}, found []interface{}, allowMissing bool) int64 {
+	counts := make(map[interface{}]int, len(found))
+	for _, key := range found {
+		counts[key]++
+	}
+	var mismatched int64
+	for _, key := range sampled {
+		if counts[key] > 1 || (counts[key] == 0 && !allowMissing) {
+			mismatched++
+		}
+	}
+	return mismatched
+}
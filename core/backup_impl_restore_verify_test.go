@@ -0,0 +1,239 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/binlog/binlogtest"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+func TestRestoreVerifyPartitions(t *testing.T) {
+	binlog := func(entries int64, from, to uint64) *backuppb.FieldBinlog {
+		return &backuppb.FieldBinlog{Binlogs: []*backuppb.Binlog{{EntriesNum: entries, TimestampFrom: from, TimestampTo: to, LogSize: 10}}}
+	}
+	collection := &backuppb.CollectionBackupInfo{
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{
+				PartitionName: "p1",
+				SegmentBackups: []*backuppb.SegmentBackupInfo{
+					{SegmentId: 1, NumOfRows: 100, Binlogs: []*backuppb.FieldBinlog{binlog(100, 10, 20)}, Deltalogs: []*backuppb.FieldBinlog{binlog(5, 25, 30)}},
+					{SegmentId: 2, NumOfRows: 50, Binlogs: []*backuppb.FieldBinlog{binlog(50, 30, 50)}},
+					{SegmentId: 3, NumOfRows: 30, Binlogs: []*backuppb.FieldBinlog{binlog(30, 60, 70)}},
+					{SegmentId: 4, IsL0: true, Deltalogs: []*backuppb.FieldBinlog{binlog(10, 55, 65)}},
+				},
+			},
+			{
+				PartitionName:  "p2",
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 5, NumOfRows: 20}},
+			},
+		},
+		L0Segments: []*backuppb.SegmentBackupInfo{{SegmentId: 6, IsL0: true, Deltalogs: []*backuppb.FieldBinlog{binlog(2, 35, 40)}}},
+	}
+	task := &backuppb.RestoreCollectionTask{CollBackup: collection}
+
+	results := restoreVerifyPartitions(task)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "p1", results[0].GetPartitionName())
+	assert.Equal(t, int64(180-5-10-2), results[0].GetMinRows())
+	assert.Equal(t, int64(180), results[0].GetMaxRows())
+	assert.Equal(t, int64(-1), results[0].GetActualRows())
+	assert.Equal(t, int64(18), results[1].GetMinRows())
+	assert.Equal(t, int64(20), results[1].GetMaxRows())
+
+	// point in time restore, rows of the binlog across the timestamp are uncertain and the later segments are skipped
+	task.RestoreToTimestamp = 40
+	results = restoreVerifyPartitions(task)
+	assert.Equal(t, int64(100-5-2), results[0].GetMinRows())
+	assert.Equal(t, int64(150), results[0].GetMaxRows())
+
+	// entries of the deltalogs are not recorded, deleted rows are unknown
+	task.RestoreToTimestamp = 0
+	collection.L0Segments[0].Deltalogs[0].Binlogs[0].EntriesNum = 0
+	results = restoreVerifyPartitions(task)
+	assert.Equal(t, int64(0), results[0].GetMinRows())
+	assert.Equal(t, int64(180), results[0].GetMaxRows())
+}
+
+func TestCheckRestoredRows(t *testing.T) {
+	assert.Empty(t, checkRestoredRows("partition p1", 10, 8, 10))
+	assert.Equal(t, "partition p1 has 11 rows, expected 8 to 10 rows", checkRestoredRows("partition p1", 11, 8, 10))
+	assert.Equal(t, "partition p1 has 0 rows, expected 10 rows", checkRestoredRows("partition p1", 0, 10, 10))
+}
+
+func TestCountMismatchedKeys(t *testing.T) {
+	sampled := []interface{}{int64(1), int64(2), int64(3)}
+	assert.Equal(t, int64(0), countMismatchedKeys(sampled, []interface{}{int64(3), int64(2), int64(1)}, false))
+	// key 2 is restored twice and key 3 is missing
+	assert.Equal(t, int64(2), countMismatchedKeys(sampled, []interface{}{int64(1), int64(2), int64(2)}, false))
+	assert.Equal(t, int64(1), countMismatchedKeys([]interface{}{"a", "b"}, []interface{}{"a"}, false))
+	// key 3 may be deleted
+	assert.Equal(t, int64(1), countMismatchedKeys(sampled, []interface{}{int64(1), int64(2), int64(2)}, true))
+}
+
+// newVerifyTestBackup writes the primary key binlogs of a backup of two partitions, keys 1 to 10 in two binlogs
+// of partition p1 and keys 11 to 15 in a zstd compressed binlog of partition p2
+func newVerifyTestBackup(t *testing.T, b *BackupContext, backupPath string) *backuppb.CollectionBackupInfo {
+	ctx := context.Background()
+	writeBinlog := func(segment *backuppb.SegmentBackupInfo, logID int64, keys []int64, ts uint64, codec string) {
+		logPath := fmt.Sprintf("files/insert_log/%d/%d/%d/100/%d", testCollectionID, segment.GetPartitionId(), segment.GetSegmentId(), logID)
+		segment.Binlogs[0].Binlogs = append(segment.Binlogs[0].Binlogs, &backuppb.Binlog{
+			LogPath: logPath, EntriesNum: int64(len(keys)), TimestampFrom: ts - 5, TimestampTo: ts, Compression: codec,
+		})
+		values := lo.Map(keys, func(key int64, _ int) interface{} { return key })
+		data := binlogtest.WriteInsertBinlog(binlogtest.WriteParquet(t, binlogtest.Column{Values: values, Codec: binlogtest.CodecSnappy}))
+		if codec != compression.None {
+			var err error
+			data, err = compression.Compress(codec, data)
+			require.NoError(t, err)
+		}
+		binlogPath := backupPath + SEPERATOR + backupBinlogRelativePath(logPath, segment.GetGroupId())
+		require.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, binlogPath, data))
+	}
+	segment := func(partitionID, segmentID, groupID int64) *backuppb.SegmentBackupInfo {
+		return &backuppb.SegmentBackupInfo{
+			SegmentId:   segmentID,
+			PartitionId: partitionID,
+			GroupId:     groupID,
+			// binlogs of the other fields are not read
+			Binlogs: []*backuppb.FieldBinlog{{FieldID: 100}, {FieldID: 101, Binlogs: []*backuppb.Binlog{{LogPath: "files/insert_log/not_exist", EntriesNum: 5}}}},
+		}
+	}
+	segment1 := segment(11, 21, 0)
+	writeBinlog(segment1, 1, []int64{1, 2, 3, 4, 5}, 10, compression.None)
+	writeBinlog(segment1, 2, []int64{6, 7, 8, 9, 10}, 20, compression.None)
+	segment1.NumOfRows = 10
+	segment2 := segment(12, 22, 31)
+	writeBinlog(segment2, 1, []int64{11, 12, 13, 14, 15}, 10, compression.Zstd)
+	segment2.NumOfRows = 5
+	return &backuppb.CollectionBackupInfo{
+		CollectionId:   testCollectionID,
+		DbName:         "default",
+		CollectionName: "coll",
+		Schema: &backuppb.CollectionSchema{Fields: []*backuppb.FieldSchema{
+			{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+			{FieldID: 101, Name: "vector", DataType: backuppb.DataType_FloatVector},
+		}},
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{PartitionId: 11, PartitionName: "p1", SegmentBackups: []*backuppb.SegmentBackupInfo{segment1}},
+			{PartitionId: 12, PartitionName: "p2", SegmentBackups: []*backuppb.SegmentBackupInfo{segment2}},
+		},
+	}
+}
+
+func newVerifyTestTempFiles(b *BackupContext, backupPath string, collection *backuppb.CollectionBackupInfo) *restoreTempFiles {
+	return &restoreTempFiles{
+		backupBucketName: b.backupBucketName,
+		blobManifests:    newBlobManifestCache(),
+		dataKeys:         newBackupDataKeyCache(),
+		binlogCodecs:     binlogCompressions(backupPath, collection),
+	}
+}
+
+func TestSampleBackupPrimaryKeys(t *testing.T) {
+	ctx := context.Background()
+	b := newRestoreTestContext(t, newFakeMilvusClient())
+	b.storageRetrySleep = time.Millisecond
+	backupPath := b.backupRootPath + "/verify"
+	collection := newVerifyTestBackup(t, b, backupPath)
+	temp := newVerifyTestTempFiles(b, backupPath, collection)
+
+	// the sample is shared evenly by the binlogs
+	keys, err := b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[0], 100, 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(6), int64(7)}, keys)
+	keys, err = b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[0], 100, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(1)}, keys)
+	keys, err = b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[0], 100, 0, 100)
+	require.NoError(t, err)
+	assert.Len(t, keys, 10)
+
+	// the compressed binlog of a segment group
+	keys, err = b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[1], 100, 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(11), int64(12), int64(13), int64(14)}, keys)
+
+	// point in time restore, the binlog across the timestamp is skipped
+	keys, err = b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[0], 100, 15, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3), int64(4)}, keys)
+
+	// the binlog is missing in the backup
+	_, err = b.sampleBackupPrimaryKeys(ctx, backupPath, temp, collection.GetPartitionBackups()[0], 101, 0, 4)
+	assert.Error(t, err)
+}
+
+func TestVerifyRestoredCollection(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeMilvusClient()
+	b := newRestoreTestContext(t, client)
+	b.storageRetrySleep = time.Millisecond
+	b.params.BackupCfg.RestoreVerifySampleSize = 4
+	backupPath := b.backupRootPath + "/verify"
+	collection := newVerifyTestBackup(t, b, backupPath)
+	temp := newVerifyTestTempFiles(b, backupPath, collection)
+	task := &backuppb.RestoreCollectionTask{
+		Id:                   "coll",
+		CollBackup:           collection,
+		TargetDbName:         "default",
+		TargetCollectionName: "coll",
+		Verify:               true,
+	}
+	b.meta.AddRestoreTask(&backuppb.RestoreBackupTask{Id: "restore", CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{task}})
+	verify := func(strict bool) error {
+		task.StrictVerify = strict
+		return b.verifyRestoredCollection(ctx, backupPath, temp, task, "restore")
+	}
+
+	// the loaded collection, the keys sampled from the backup are queried back
+	client.loadState = entity.LoadStateLoaded
+	client.rows["p1"] = []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	client.rows["p2"] = []int64{11, 12, 13, 14, 15}
+	require.NoError(t, verify(true))
+	result := b.meta.GetRestoreTask("restore").GetCollectionRestoreTasks()[0].GetVerifyResult()
+	assert.True(t, result.GetVerified())
+	assert.True(t, result.GetPassed())
+	assert.Equal(t, int64(10), result.GetPartitions()[0].GetActualRows())
+	assert.Equal(t, int64(4), result.GetPartitions()[1].GetSampledKeys())
+
+	// key 2 is missing and key 6 is restored twice
+	client.rows["p1"] = []int64{1, 3, 4, 5, 6, 6, 7, 8, 9, 10}
+	require.NoError(t, verify(false))
+	assert.False(t, task.GetVerifyResult().GetPassed())
+	assert.Equal(t, int64(2), task.GetVerifyResult().GetPartitions()[0].GetMismatchedKeys())
+	assert.Error(t, verify(true))
+
+	// key 2 may be deleted by the deltalogs of the backup
+	collection.PartitionBackups[0].SegmentBackups[0].Deltalogs = []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{{EntriesNum: 1, LogSize: 10}}}}
+	require.NoError(t, verify(false))
+	assert.Equal(t, int64(1), task.GetVerifyResult().GetPartitions()[0].GetMismatchedKeys())
+	collection.PartitionBackups[0].SegmentBackups[0].Deltalogs = nil
+	client.rows["p1"] = []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	// the existing collection is not loaded, its rows are not verified
+	client.loadState = entity.LoadStateNotLoad
+	task.SkipCreateCollection = true
+	require.NoError(t, verify(false))
+	assert.False(t, task.GetVerifyResult().GetVerified())
+	assert.False(t, task.GetVerifyResult().GetPassed())
+	assert.Empty(t, task.GetVerifyResult().GetMismatches())
+	assert.Error(t, verify(true))
+
+	// the collection created by the restore is compared by its statistics
+	task.SkipCreateCollection = false
+	require.NoError(t, verify(true))
+	assert.True(t, task.GetVerifyResult().GetVerified())
+	assert.Equal(t, int64(15), task.GetVerifyResult().GetCollectionRows())
+	client.rows["p2"] = []int64{11}
+	assert.Error(t, verify(true))
+}
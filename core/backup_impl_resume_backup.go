@@ -0,0 +1,306 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+func (b *BackupContext) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) *backuppb.BackupInfoResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive ResumeBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.Bool("async", request.GetAsync()))
+
+	resp := &backuppb.BackupInfoResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	if running := b.meta.GetBackupByName(request.GetBackupName()); running != nil && isTaskInterrupted(running.GetStateCode()) {
+		errMsg := fmt.Sprintf("backup is still executing: %s", request.GetBackupName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, request.GetBackupName()))
+	if err != nil {
+		log.Error("fail to check backup meta", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if exist {
+		errMsg := fmt.Sprintf("backup has been completed: %s", request.GetBackupName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+
+	checkpoint, err := b.readBackupCheckpoint(ctx, request.GetBackupName())
+	if err != nil {
+		log.Error("fail to read backup checkpoint", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if checkpoint == nil {
+		errMsg := fmt.Sprintf("no checkpoint to resume the backup: %s", request.GetBackupName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+
+	// dedup may be enabled after the backup is created
+	if err := b.checkDedupSupported(checkpoint.GetEncryption() != nil, checkpoint.GetBinlogCompression()); err != nil {
+		log.Warn("fail to resume backup", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	backup, err := b.rebuildBackupFromCheckpoint(ctx, request.GetRequestId(), checkpoint)
+	if err != nil {
+		log.Error("fail to rebuild backup from checkpoint", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	taskCtx := b.registerTask(backup.GetId())
+	if request.GetAsync() {
+		go b.executeResumeBackup(taskCtx, request, backup)
+		asyncResp := &backuppb.BackupInfoResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Success,
+			Msg:       "resume backup is executing asynchronously",
+			Data:      backup,
+		}
+		return asyncResp
+	} else {
+		err := b.executeResumeBackup(taskCtx, request, backup)
+		resp.Data = b.meta.GetBackup(backup.GetId())
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+		} else {
+			resp.Code = backuppb.ResponseCode_Success
+			resp.Msg = "success"
+		}
+		return resp
+	}
+}
+
+// readBackupCheckpoint reads the checkpoint of an unfinished backup, return nil if there is no checkpoint
+func (b *BackupContext) readBackupCheckpoint(ctx context.Context, backupName string) (*backuppb.BackupInfo, error) {
+	checkpointPath := CheckpointMetaPath(b.backupRootPath, backupName)
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	encryptionInfo, err := b.readEncryptionMeta(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := b.backupDataKey(encryptionInfo)
+	if err != nil {
+		return nil, err
+	}
+	metaCompression, err := b.readCompressionMeta(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := b.readBackupMetaFile(ctx, b.backupBucketName, checkpointPath, dataKey, metaCompression)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := &backuppb.BackupInfo{}
+	err = json.Unmarshal(bytes, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// rebuildBackupFromCheckpoint adds the checkpoint into meta as a new backup task with the given id.
+// Backuped segments whose binlogs are missing in the backup bucket will be copied again.
+func (b *BackupContext) rebuildBackupFromCheckpoint(ctx context.Context, id string, checkpoint *backuppb.BackupInfo) (*backuppb.BackupInfo, error) {
+	manifest, err := b.readBlobManifest(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+checkpoint.GetName())
+	if err != nil {
+		return nil, err
+	}
+	checksumManifest, err := b.readChecksumManifest(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+checkpoint.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	// the old task of the same backup is replaced
+	b.meta.RemoveBackupsByName(checkpoint.GetName())
+
+	backup := &backuppb.BackupInfo{
+		Id:              id,
+		StateCode:       backuppb.BackupTaskStateCode_BACKUP_INITIAL,
+		StartTime:       checkpoint.GetStartTime(),
+		Name:            checkpoint.GetName(),
+		BackupTimestamp: checkpoint.GetBackupTimestamp(),
+		MilvusVersion:   checkpoint.GetMilvusVersion(),
+		ParentBackup:    checkpoint.GetParentBackup(),
+		// binlogs copied before are encrypted by the data key of the checkpoint
+		Encryption:        checkpoint.GetEncryption(),
+		MetaCompression:   checkpoint.GetMetaCompression(),
+		BinlogCompression: checkpoint.GetBinlogCompression(),
+	}
+	b.meta.AddBackup(backup)
+	if manifest != nil {
+		for path, ref := range manifest.Files {
+			b.meta.AddBlobRef(id, path, ref)
+		}
+	}
+	// checksums of the segments copied again are overwritten
+	if checksumManifest != nil {
+		for path, checksum := range checksumManifest.Files {
+			b.meta.AddFileChecksum(id, path, checksum)
+		}
+	}
+
+	backupBinlogPath := BackupBinlogDirPath(b.backupRootPath, checkpoint.GetName())
+	addSegment := func(segment *backuppb.SegmentBackupInfo) error {
+		// segments referencing the parent backup are not copied into this backup
+		if segment.GetBackuped() && segment.GetRefBackup() == "" {
+			exist, err := b.isSegmentBackupExist(ctx, backupBinlogPath, manifest, segment)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				log.Info("binlogs of backuped segment are missing, will copy it again", zap.Int64("segmentID", segment.GetSegmentId()))
+				segment.Backuped = false
+			}
+		}
+		b.meta.AddSegment(segment)
+		return nil
+	}
+
+	var backupedNum, totalNum int
+	for _, collection := range checkpoint.GetCollectionBackups() {
+		collectionBackup := proto.Clone(collection).(*backuppb.CollectionBackupInfo)
+		collectionBackup.Id = id
+		// size is summed up from segments again
+		collectionBackup.Size = 0
+		collectionBackup.PartitionBackups = nil
+		b.meta.ResetCollectionPartitions(collectionBackup.GetCollectionId())
+		for _, segment := range collectionBackup.GetL0Segments() {
+			if err := addSegment(segment); err != nil {
+				return nil, err
+			}
+		}
+		b.meta.AddCollection(collectionBackup)
+		for _, partition := range collection.GetPartitionBackups() {
+			partitionBackup := proto.Clone(partition).(*backuppb.PartitionBackupInfo)
+			partitionBackup.Size = 0
+			segments := partitionBackup.GetSegmentBackups()
+			partitionBackup.SegmentBackups = nil
+			for _, segment := range segments {
+				if err := addSegment(segment); err != nil {
+					return nil, err
+				}
+				totalNum++
+				if segment.GetBackuped() {
+					backupedNum++
+				}
+			}
+			b.meta.AddPartition(partitionBackup)
+		}
+	}
+	log.Info("rebuild backup from checkpoint",
+		zap.String("backupName", checkpoint.GetName()),
+		zap.String("id", id),
+		zap.Int("segmentNum", totalNum),
+		zap.Int("backupedSegmentNum", backupedNum))
+	return b.meta.GetBackup(id), nil
+}
+
+// isSegmentBackupExist checks whether all the binlogs of the segment are present in the backup bucket
+func (b *BackupContext) isSegmentBackupExist(ctx context.Context, backupBinlogPath string, manifest *BlobManifest, segment *backuppb.SegmentBackupInfo) (bool, error) {
+	fieldBinlogs := make([]*backuppb.FieldBinlog, 0, len(segment.GetBinlogs())+len(segment.GetDeltalogs()))
+	fieldBinlogs = append(fieldBinlogs, segment.GetBinlogs()...)
+	fieldBinlogs = append(fieldBinlogs, segment.GetDeltalogs()...)
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			targetPath := binlogBackupPath(binlog.GetLogPath(), b.milvusRootPath, backupBinlogPath, segment.GetPartitionId(), segment.GetGroupId())
+			if manifest != nil {
+				_, relativePath := splitBackupBinlogPath(targetPath)
+				if ref, ok := manifest.Files[relativePath]; ok {
+					targetPath = BlobPath(b.backupRootPath, ref.Hash)
+				}
+			}
+			exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, b.backupBucketName, targetPath)
+			if err != nil || !exist {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (b *BackupContext) executeResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest, backupInfo *backuppb.BackupInfo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.finishBackupTask(backupInfo.GetId())
+
+	resumeGC := b.pauseMilvusGCIfEnabled(ctx, request.GetGcPauseEnable(), request.GetGcPauseSeconds(), request.GetGcPauseAddress())
+	defer resumeGC()
+
+	b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_EXECUTING))
+	err := b.backupCollectionsData(ctx, backupInfo.GetId(), backupInfo.GetParentBackup())
+	if err != nil {
+		b.failBackup(backupInfo.GetId(), err)
+		// the task context may be cancelled
+		b.writeBackupCheckpoint(b.ctx, backupInfo.GetId())
+		return err
+	}
+	b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_SUCCESS), setEndTime(time.Now().UnixNano()/int64(time.Millisecond)))
+
+	err = b.writeBackupInfoMeta(ctx, backupInfo.GetId())
+	if err != nil {
+		b.meta.UpdateBackup(backupInfo.Id, setStateCode(backuppb.BackupTaskStateCode_BACKUP_FAIL), setErrorMessage(err.Error()))
+		return err
+	}
+	b.removeBackupCheckpoint(ctx, backupInfo.GetName())
+	log.Info("finish executeResumeBackup",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()))
+	return nil
+}
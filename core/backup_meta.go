@@ -3,21 +3,26 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
 )
 
 const (
-	META_PREFIX          = "meta"
-	BACKUP_META_FILE     = "backup_meta.json"
-	COLLECTION_META_FILE = "collection_meta.json"
-	PARTITION_META_FILE  = "partition_meta.json"
-	SEGMENT_META_FILE    = "segment_meta.json"
-	FULL_META_FILE       = "full_meta.json"
-	CP_META_FILE         = "channel_cp_meta.json"
-	SEPERATOR            = "/"
+	META_PREFIX           = "meta"
+	BACKUP_META_FILE      = "backup_meta.json"
+	COLLECTION_META_FILE  = "collection_meta.json"
+	PARTITION_META_FILE   = "partition_meta.json"
+	SEGMENT_META_FILE     = "segment_meta.json"
+	FULL_META_FILE        = "full_meta.json"
+	CP_META_FILE          = "channel_cp_meta.json"
+	CHECKPOINT_META_FILE  = "checkpoint_meta.json"
+	ENCRYPTION_META_FILE  = "encryption_meta.json"
+	COMPRESSION_META_FILE = "compression_meta.json"
+	SEPERATOR             = "/"
 
 	BINGLOG_DIR    = "binlogs"
 	INSERT_LOG_DIR = "insert_log"
@@ -91,16 +96,20 @@ func treeToLevel(backup *backuppb.BackupInfo) (LeveledBackupInfo, error) {
 	}
 	backup.Size = backupSize
 	backupLevel := &backuppb.BackupInfo{
-		Id:              backup.GetId(),
-		StateCode:       backup.GetStateCode(),
-		ErrorMessage:    backup.GetErrorMessage(),
-		StartTime:       backup.GetStartTime(),
-		EndTime:         backup.GetEndTime(),
-		Progress:        backup.GetProgress(),
-		Name:            backup.GetName(),
-		BackupTimestamp: backup.GetBackupTimestamp(),
-		Size:            backup.GetSize(),
-		MilvusVersion:   backup.GetMilvusVersion(),
+		Id:                backup.GetId(),
+		StateCode:         backup.GetStateCode(),
+		ErrorMessage:      backup.GetErrorMessage(),
+		StartTime:         backup.GetStartTime(),
+		EndTime:           backup.GetEndTime(),
+		Progress:          backup.GetProgress(),
+		Name:              backup.GetName(),
+		BackupTimestamp:   backup.GetBackupTimestamp(),
+		Size:              backup.GetSize(),
+		MilvusVersion:     backup.GetMilvusVersion(),
+		ParentBackup:      backup.GetParentBackup(),
+		Encryption:        backup.GetEncryption(),
+		MetaCompression:   backup.GetMetaCompression(),
+		BinlogCompression: backup.GetBinlogCompression(),
 	}
 
 	return LeveledBackupInfo{
@@ -149,15 +158,19 @@ func serialize(backup *backuppb.BackupInfo) (*BackupMetaBytes, error) {
 // levelToTree rebuild complete tree structure BackupInfo from backup-collection-partition-segment 4-level structure
 func levelToTree(level *LeveledBackupInfo) (*backuppb.BackupInfo, error) {
 	backupInfo := &backuppb.BackupInfo{
-		Id:              level.backupLevel.GetId(),
-		StateCode:       level.backupLevel.GetStateCode(),
-		ErrorMessage:    level.backupLevel.GetErrorMessage(),
-		StartTime:       level.backupLevel.GetStartTime(),
-		EndTime:         level.backupLevel.GetEndTime(),
-		Progress:        level.backupLevel.GetProgress(),
-		Name:            level.backupLevel.GetName(),
-		BackupTimestamp: level.backupLevel.GetBackupTimestamp(),
-		MilvusVersion:   level.backupLevel.GetMilvusVersion(),
+		Id:                level.backupLevel.GetId(),
+		StateCode:         level.backupLevel.GetStateCode(),
+		ErrorMessage:      level.backupLevel.GetErrorMessage(),
+		StartTime:         level.backupLevel.GetStartTime(),
+		EndTime:           level.backupLevel.GetEndTime(),
+		Progress:          level.backupLevel.GetProgress(),
+		Name:              level.backupLevel.GetName(),
+		BackupTimestamp:   level.backupLevel.GetBackupTimestamp(),
+		MilvusVersion:     level.backupLevel.GetMilvusVersion(),
+		ParentBackup:      level.backupLevel.GetParentBackup(),
+		Encryption:        level.backupLevel.GetEncryption(),
+		MetaCompression:   level.backupLevel.GetMetaCompression(),
+		BinlogCompression: level.backupLevel.GetBinlogCompression(),
 	}
 	segmentDict := make(map[string][]*backuppb.SegmentBackupInfo, len(level.segmentLevel.GetInfos()))
 	for _, segment := range level.segmentLevel.GetInfos() {
@@ -251,23 +264,72 @@ func ChannelCPMetaPath(backupRootPath, backupName string) string {
 	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + CP_META_FILE
 }
 
+// CheckpointMetaPath is where the progress of an unfinished backup is saved, used to resume the backup
+func CheckpointMetaPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + CHECKPOINT_META_FILE
+}
+
+// EncryptionMetaPath is where the wrapped data key of an encrypted backup is saved, it is not encrypted
+func EncryptionMetaPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + ENCRYPTION_META_FILE
+}
+
+// CompressionMetaPath is where the compression codec of the meta files is saved, it is neither compressed nor encrypted
+func CompressionMetaPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + COMPRESSION_META_FILE
+}
+
 func BackupBinlogDirPath(backupRootPath, backupName string) string {
 	return backupRootPath + SEPERATOR + backupName + SEPERATOR + BINGLOG_DIR
 }
 
+// RefBackupPath returns the path of the referenced backup, which is a sibling of the given backup path.
+// If refBackup is empty, return the backup path itself.
+func RefBackupPath(backupPath, refBackup string) string {
+	if refBackup == "" {
+		return backupPath
+	}
+	return backupPath[:strings.LastIndex(backupPath, SEPERATOR)+1] + refBackup
+}
+
+// collectRefBackups returns the names of all backups referenced by the segments of the backup
+func collectRefBackups(backup *backuppb.BackupInfo) []string {
+	dict := make(map[string]bool)
+	refBackups := make([]string, 0)
+	add := func(segments []*backuppb.SegmentBackupInfo) {
+		for _, segment := range segments {
+			if segment.GetRefBackup() != "" && !dict[segment.GetRefBackup()] {
+				dict[segment.GetRefBackup()] = true
+				refBackups = append(refBackups, segment.GetRefBackup())
+			}
+		}
+	}
+	for _, collection := range backup.GetCollectionBackups() {
+		add(collection.GetL0Segments())
+		for _, partition := range collection.GetPartitionBackups() {
+			add(partition.GetSegmentBackups())
+		}
+	}
+	return refBackups
+}
+
 func SimpleListBackupsResponse(input *backuppb.ListBackupsResponse) *backuppb.ListBackupsResponse {
 	simpleBackupInfos := make([]*backuppb.BackupInfo, 0)
 	for _, backup := range input.GetData() {
 		simpleBackupInfos = append(simpleBackupInfos, &backuppb.BackupInfo{
-			Id:              backup.GetId(),
-			Name:            backup.GetName(),
-			StateCode:       backup.GetStateCode(),
-			ErrorMessage:    backup.GetErrorMessage(),
-			BackupTimestamp: backup.GetBackupTimestamp(),
-			Size:            backup.GetSize(),
-			StartTime:       backup.GetStartTime(),
-			EndTime:         backup.GetEndTime(),
-			MilvusVersion:   backup.GetMilvusVersion(),
+			Id:                backup.GetId(),
+			Name:              backup.GetName(),
+			StateCode:         backup.GetStateCode(),
+			ErrorMessage:      backup.GetErrorMessage(),
+			BackupTimestamp:   backup.GetBackupTimestamp(),
+			Size:              backup.GetSize(),
+			StartTime:         backup.GetStartTime(),
+			EndTime:           backup.GetEndTime(),
+			MilvusVersion:     backup.GetMilvusVersion(),
+			ParentBackup:      backup.GetParentBackup(),
+			Encryption:        backup.GetEncryption(),
+			MetaCompression:   backup.GetMetaCompression(),
+			BinlogCompression: backup.GetBinlogCompression(),
 		})
 	}
 	return &backuppb.ListBackupsResponse{
@@ -331,3 +393,43 @@ func SimpleRestoreResponse(input *backuppb.RestoreBackupResponse) *backuppb.Rest
 }
 
 type DbCollections = map[string][]string
+
+// DbCollectionPartitions selects the partitions of collections by database and collection names,
+// collections not in it are selected with all their partitions
+type DbCollectionPartitions = map[string]map[string][]string
+
+// parseDbCollections parses the db_collections selector. Each database maps to a list of collections, or to an object of
+// collections to their partitions, such as {"db1":["coll1"],"db2":{"coll2":["p1","p2"],"coll3":[]}}.
+// An empty list or object selects all collections of the database, and an empty list of partitions selects all partitions.
+func parseDbCollections(dbCollectionsStr string) (DbCollections, DbCollectionPartitions, error) {
+	var raw map[string]jsoniter.RawMessage
+	if err := jsoniter.UnmarshalFromString(dbCollectionsStr, &raw); err != nil {
+		return nil, nil, err
+	}
+	dbCollections := make(DbCollections, len(raw))
+	dbPartitions := make(DbCollectionPartitions)
+	for db, value := range raw {
+		var collections []string
+		if err := jsoniter.Unmarshal(value, &collections); err == nil {
+			dbCollections[db] = collections
+			continue
+		}
+		var collectionPartitions map[string][]string
+		if err := jsoniter.Unmarshal(value, &collectionPartitions); err != nil {
+			return nil, nil, fmt.Errorf("collections of database %s should be a list of collections or an object of collections to partitions: %w", db, err)
+		}
+		collections = make([]string, 0, len(collectionPartitions))
+		for collection, partitions := range collectionPartitions {
+			collections = append(collections, collection)
+			if len(partitions) > 0 {
+				if dbPartitions[db] == nil {
+					dbPartitions[db] = make(map[string][]string)
+				}
+				dbPartitions[db][collection] = partitions
+			}
+		}
+		sort.Strings(collections)
+		dbCollections[db] = collections
+	}
+	return dbCollections, dbPartitions, nil
+}
@@ -2,11 +2,14 @@ package core
 
 import (
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/kv"
 	"github.com/zilliztech/milvus-backup/internal/log"
 )
 
@@ -20,7 +23,21 @@ type MetaManager struct {
 	collectionBackupReverse    map[int64]string                                    // collectionID -> backupId
 	backupNameToIdDict         map[string]string
 	restoreTasks               map[string]*backuppb.RestoreBackupTask
+	blobManifests              map[string]*BlobManifest     // backupId -> BlobManifest, only for deduplicated backups
+	checksumManifests          map[string]*ChecksumManifest // backupId -> ChecksumManifest
 	mu                         sync.Mutex
+
+	// history keeps the finished backup tasks reloaded from the task store. They are read only and kept apart
+	// from the running tasks, so that their partitions and segments are never merged with the ones of other backups.
+	history map[string]*storedBackupTask // backupId -> task
+	// ids of the tasks in order of adding, the oldest finished tasks beyond historySize are removed
+	backupTaskIDs  []string
+	restoreTaskIDs []string
+	historySize    int
+
+	// store persists the task states, tasks only live in memory if it is nil
+	store   kv.BaseKV
+	storeMu sync.Mutex
 }
 
 func newMetaManager() *MetaManager {
@@ -34,6 +51,9 @@ func newMetaManager() *MetaManager {
 		collectionBackupReverse:    make(map[int64]string, 0),
 		backupNameToIdDict:         make(map[string]string, 0),
 		restoreTasks:               make(map[string]*backuppb.RestoreBackupTask, 0),
+		blobManifests:              make(map[string]*BlobManifest, 0),
+		checksumManifests:          make(map[string]*ChecksumManifest, 0),
+		history:                    make(map[string]*storedBackupTask, 0),
 		mu:                         sync.Mutex{},
 	}
 }
@@ -64,19 +84,27 @@ func (meta *MetaManager) GetBackupByName(name string) *backuppb.BackupInfo {
 
 func (meta *MetaManager) AddBackup(backup *backuppb.BackupInfo) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
+	_, exist := meta.backups[backup.Id]
+	if !exist {
+		meta.backupTaskIDs = append(meta.backupTaskIDs, backup.Id)
+	}
 	meta.backups[backup.Id] = backup
 	meta.backupNameToIdDict[backup.Name] = backup.Id
+	meta.mu.Unlock()
+	meta.persistBackupTask(backup.Id)
+	if !exist {
+		meta.pruneTaskHistory()
+	}
 }
 
 func (meta *MetaManager) AddCollection(collection *backuppb.CollectionBackupInfo) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
 	if _, exist := meta.collections[collection.Id]; !exist {
 		meta.collections[collection.Id] = make(map[int64]*backuppb.CollectionBackupInfo, 0)
 	}
 	meta.collections[collection.Id][collection.GetCollectionId()] = collection
 	meta.collectionBackupReverse[collection.GetCollectionId()] = collection.Id
+	meta.mu.Unlock()
 }
 
 func (meta *MetaManager) AddPartition(partition *backuppb.PartitionBackupInfo) {
@@ -166,13 +194,17 @@ func setMilvusVersion(milvusVersion string) BackupOpt {
 
 func (meta *MetaManager) UpdateBackup(backupID string, opts ...BackupOpt) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
 	backup := meta.backups[backupID]
 	cBackup := proto.Clone(backup).(*backuppb.BackupInfo)
 	for _, opt := range opts {
 		opt(cBackup)
 	}
 	meta.backups[backup.Id] = cBackup
+	meta.mu.Unlock()
+	// the progress in between is persisted by the checkpoints
+	if cBackup.GetStateCode() != backup.GetStateCode() {
+		meta.persistBackupTask(backupID)
+	}
 }
 
 type CollectionOpt func(collection *backuppb.CollectionBackupInfo)
@@ -300,13 +332,16 @@ func (meta *MetaManager) GetCollections(backupID string) map[int64]*backuppb.Col
 
 func (meta *MetaManager) UpdateCollection(backupID string, collectionID int64, opts ...CollectionOpt) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
 	backup := meta.collections[backupID][collectionID]
 	cBackup := proto.Clone(backup).(*backuppb.CollectionBackupInfo)
 	for _, opt := range opts {
 		opt(cBackup)
 	}
 	meta.collections[backupID][collectionID] = cBackup
+	meta.mu.Unlock()
+	if cBackup.GetStateCode() != backup.GetStateCode() {
+		meta.persistBackupTask(backupID)
+	}
 }
 
 type PartitionOpt func(partition *backuppb.PartitionBackupInfo)
@@ -328,6 +363,33 @@ func (meta *MetaManager) GetPartitions(collectionID int64) map[int64]*backuppb.P
 	return partitions
 }
 
+// ResetCollectionPartitions removes the partitions and segments of the collection. They are indexed by collection,
+// a new backup of the collection should not see the ones left by an earlier backup of it, e.g. the segments compacted since then.
+func (meta *MetaManager) ResetCollectionPartitions(collectionID int64) {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.removeCollectionPartitions(collectionID)
+}
+
+// removeCollectionPartitions removes the partitions and segments of the collection, the caller should hold meta.mu
+func (meta *MetaManager) removeCollectionPartitions(collectionID int64) {
+	for partitionID := range meta.partitions[collectionID] {
+		for segmentID := range meta.segments[partitionID] {
+			delete(meta.segmentPartitionReverse, segmentID)
+		}
+		delete(meta.segments, partitionID)
+		delete(meta.partitionCollectionReverse, partitionID)
+	}
+	delete(meta.partitions, collectionID)
+	// collection level l0 segments of all the collections are under partition -1
+	for segmentID, segment := range meta.segments[-1] {
+		if segment.GetCollectionId() == collectionID {
+			delete(meta.segments[-1], segmentID)
+			delete(meta.segmentPartitionReverse, segmentID)
+		}
+	}
+}
+
 func (meta *MetaManager) UpdatePartition(collectionID int64, partitionID int64, opts ...PartitionOpt) {
 	meta.mu.Lock()
 	defer meta.mu.Unlock()
@@ -412,6 +474,25 @@ func setSegmentBackuped(backuped bool) SegmentOpt {
 	}
 }
 
+// setSegmentBinlogCompression records the compression codec of the insert logs and delta logs copied into backup
+func setSegmentBinlogCompression(codec string) SegmentOpt {
+	return func(segment *backuppb.SegmentBackupInfo) {
+		for _, fieldBinlogs := range [][]*backuppb.FieldBinlog{segment.GetBinlogs(), segment.GetDeltalogs()} {
+			for _, fieldBinlog := range fieldBinlogs {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					binlog.Compression = codec
+				}
+			}
+		}
+	}
+}
+
+func setSegmentRefBackup(refBackup string) SegmentOpt {
+	return func(segment *backuppb.SegmentBackupInfo) {
+		segment.RefBackup = refBackup
+	}
+}
+
 func (meta *MetaManager) UpdateSegment(partitionID int64, segmentID int64, opts ...SegmentOpt) {
 	meta.mu.Lock()
 	defer meta.mu.Unlock()
@@ -496,6 +577,62 @@ func (meta *MetaManager) GetFullMeta(id string) *backuppb.BackupInfo {
 	return cloneBackup
 }
 
+func (meta *MetaManager) AddBlobRef(backupID string, path string, ref BlobRef) {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	if _, exist := meta.blobManifests[backupID]; !exist {
+		meta.blobManifests[backupID] = &BlobManifest{Files: make(map[string]BlobRef, 0)}
+	}
+	meta.blobManifests[backupID].Files[path] = ref
+}
+
+func (meta *MetaManager) GetBlobManifest(backupID string) *BlobManifest {
+	manifest, exist := meta.GetBlobManifests()[backupID]
+	if !exist {
+		return nil
+	}
+	return manifest
+}
+
+// GetBlobManifests returns a copy of all blob manifests
+func (meta *MetaManager) GetBlobManifests() map[string]*BlobManifest {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	manifests := make(map[string]*BlobManifest, len(meta.blobManifests))
+	for id, manifest := range meta.blobManifests {
+		files := make(map[string]BlobRef, len(manifest.Files))
+		for path, ref := range manifest.Files {
+			files[path] = ref
+		}
+		manifests[id] = &BlobManifest{Files: files}
+	}
+	return manifests
+}
+
+func (meta *MetaManager) AddFileChecksum(backupID string, path string, checksum FileChecksum) {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	if _, exist := meta.checksumManifests[backupID]; !exist {
+		meta.checksumManifests[backupID] = &ChecksumManifest{Files: make(map[string]FileChecksum, 0)}
+	}
+	meta.checksumManifests[backupID].Files[path] = checksum
+}
+
+// GetChecksumManifest returns a copy of the checksum manifest of the backup, nil if no file is recorded
+func (meta *MetaManager) GetChecksumManifest(backupID string) *ChecksumManifest {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	manifest, exist := meta.checksumManifests[backupID]
+	if !exist {
+		return nil
+	}
+	files := make(map[string]FileChecksum, len(manifest.Files))
+	for path, checksum := range manifest.Files {
+		files[path] = checksum
+	}
+	return &ChecksumManifest{Files: files}
+}
+
 type RestoreTaskOpt func(task *backuppb.RestoreBackupTask)
 
 func setRestoreStateCode(stateCode backuppb.RestoreTaskStateCode) RestoreTaskOpt {
@@ -539,23 +676,108 @@ func addCollectionRestoredSize(collectionID, restoredSize int64) RestoreTaskOpt
 	}
 }
 
+func setCollectionRestoreStateCode(collectionTaskID string, stateCode backuppb.RestoreTaskStateCode) RestoreTaskOpt {
+	return func(task *backuppb.RestoreBackupTask) {
+		for _, coll := range task.GetCollectionRestoreTasks() {
+			if coll.GetId() == collectionTaskID {
+				coll.StateCode = stateCode
+			}
+		}
+	}
+}
+
+func setCollectionRestoreVerifyResult(collectionTaskID string, result *backuppb.RestoreVerifyResult) RestoreTaskOpt {
+	return func(task *backuppb.RestoreBackupTask) {
+		for _, coll := range task.GetCollectionRestoreTasks() {
+			if coll.GetId() == collectionTaskID {
+				coll.VerifyResult = result
+			}
+		}
+	}
+}
+
+func setCollectionRestoreLoadProgress(collectionTaskID string, partitions []string, progress int64) RestoreTaskOpt {
+	return func(task *backuppb.RestoreBackupTask) {
+		for _, coll := range task.GetCollectionRestoreTasks() {
+			if coll.GetId() == collectionTaskID {
+				coll.LoadPartitions = partitions
+				coll.LoadProgress = progress
+			}
+		}
+	}
+}
+
+// getOrAddPartitionRestoreTask returns the partition task of the collection task, add one if not exist
+func getOrAddPartitionRestoreTask(coll *backuppb.RestoreCollectionTask, partitionID int64, partitionName string) *backuppb.RestorePartitionTask {
+	for _, part := range coll.GetPartitionRestoreTasks() {
+		if part.GetPartitionId() == partitionID {
+			return part
+		}
+	}
+	part := &backuppb.RestorePartitionTask{
+		Id:            utils.UUID(),
+		StateCode:     backuppb.RestoreTaskStateCode_EXECUTING,
+		StartTime:     time.Now().Unix(),
+		PartitionId:   partitionID,
+		PartitionName: partitionName,
+	}
+	coll.PartitionRestoreTasks = append(coll.PartitionRestoreTasks, part)
+	return part
+}
+
+func addFinishedRestoreGroup(collectionTaskID string, partitionID int64, partitionName string, groupID int64) RestoreTaskOpt {
+	return func(task *backuppb.RestoreBackupTask) {
+		for _, coll := range task.GetCollectionRestoreTasks() {
+			if coll.GetId() == collectionTaskID {
+				part := getOrAddPartitionRestoreTask(coll, partitionID, partitionName)
+				part.FinishedGroupIds = append(part.FinishedGroupIds, groupID)
+			}
+		}
+	}
+}
+
+// addFinishedRestoreL0Segment records a restored l0 segment, partitionID -1 means a collection level l0 segment
+func addFinishedRestoreL0Segment(collectionTaskID string, partitionID int64, partitionName string, segmentID int64) RestoreTaskOpt {
+	return func(task *backuppb.RestoreBackupTask) {
+		for _, coll := range task.GetCollectionRestoreTasks() {
+			if coll.GetId() == collectionTaskID {
+				if partitionID == -1 {
+					coll.FinishedL0SegmentIds = append(coll.FinishedL0SegmentIds, segmentID)
+				} else {
+					part := getOrAddPartitionRestoreTask(coll, partitionID, partitionName)
+					part.FinishedL0SegmentIds = append(part.FinishedL0SegmentIds, segmentID)
+				}
+			}
+		}
+	}
+}
+
 func (meta *MetaManager) UpdateRestoreTask(restoreID string, opts ...RestoreTaskOpt) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
 	backup := meta.restoreTasks[restoreID]
 	cBackup := proto.Clone(backup).(*backuppb.RestoreBackupTask)
 	for _, opt := range opts {
 		opt(cBackup)
 	}
 	meta.restoreTasks[backup.Id] = cBackup
+	meta.mu.Unlock()
+	meta.persistRestoreTask(restoreID)
 }
 
 //CollectionRestoreTasks []*RestoreCollectionTask `protobuf:"bytes,6,rep,name=collection_restore_tasks,json=collectionRestoreTasks,proto3" json:"collection_restore_tasks,omitempty"`
 
 func (meta *MetaManager) AddRestoreTask(task *backuppb.RestoreBackupTask) {
 	meta.mu.Lock()
-	defer meta.mu.Unlock()
+	_, exist := meta.restoreTasks[task.Id]
+	if !exist {
+		meta.restoreTaskIDs = append(meta.restoreTaskIDs, task.Id)
+	}
 	meta.restoreTasks[task.Id] = task
+	meta.mu.Unlock()
+	meta.persistRestoreTask(task.Id)
+	if !exist {
+		meta.pruneTaskHistory()
+	}
 }
 
 func (meta *MetaManager) GetRestoreTask(taskID string) *backuppb.RestoreBackupTask {
@@ -563,3 +785,13 @@ func (meta *MetaManager) GetRestoreTask(taskID string) *backuppb.RestoreBackupTa
 	defer meta.mu.Unlock()
 	return meta.restoreTasks[taskID]
 }
+
+func (meta *MetaManager) GetRestoreTasks() []*backuppb.RestoreBackupTask {
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	tasks := make([]*backuppb.RestoreBackupTask, 0, len(meta.restoreTasks))
+	for _, task := range meta.restoreTasks {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
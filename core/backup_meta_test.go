@@ -8,7 +8,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
@@ -132,6 +134,7 @@ func TestBackupSerialize(t *testing.T) {
 		Name:              "backup",
 		BackupTimestamp:   0,
 		CollectionBackups: []*backuppb.CollectionBackupInfo{collection},
+		ParentBackup:      "parent",
 	}
 
 	serData, err := serialize(backup)
@@ -142,6 +145,8 @@ func TestBackupSerialize(t *testing.T) {
 	log.Info(string(serData.SegmentMetaBytes))
 
 	deserBackup, err := deserialize(serData)
+	assert.NoError(t, err)
+	assert.Equal(t, "parent", deserBackup.GetParentBackup())
 	log.Info(deserBackup.String())
 }
 
@@ -156,6 +161,92 @@ func TestDbCollectionJson(t *testing.T) {
 	println(dbCollection2)
 }
 
+func TestParseDbCollections(t *testing.T) {
+	dbCollections, dbPartitions, err := parseDbCollections(`{"db1":["coll1","coll2"],"db2":[],"db3":{"coll4":["p1","p2"],"coll3":[]},"db4":{}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, DbCollections{
+		"db1": {"coll1", "coll2"},
+		"db2": {},
+		"db3": {"coll3", "coll4"},
+		"db4": {},
+	}, dbCollections)
+	assert.Equal(t, DbCollectionPartitions{"db3": {"coll4": {"p1", "p2"}}}, dbPartitions)
+	assert.Empty(t, dbPartitions["db1"]["coll1"])
+
+	_, _, err = parseDbCollections(`{"db1":"coll1"}`)
+	assert.Error(t, err)
+	_, _, err = parseDbCollections(`{"db1":{"coll1":"p1"}}`)
+	assert.Error(t, err)
+}
+
+func TestSelectPartitionBackups(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{
+		DbName:         "db1",
+		CollectionName: "coll",
+		Size:           60,
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{PartitionName: "p1", Size: 10, SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 1}, {SegmentId: 2, IsL0: true}}},
+			{PartitionName: "p2", Size: 20},
+			{PartitionName: "p3", Size: 30},
+		},
+		L0Segments: []*backuppb.SegmentBackupInfo{{SegmentId: 3, PartitionId: -1, IsL0: true}},
+	}
+	selected, err := selectPartitionBackups(collection, []string{"p3", "p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p3", "p1"}, lo.Map(selected.GetPartitionBackups(), func(partition *backuppb.PartitionBackupInfo, _ int) string {
+		return partition.GetPartitionName()
+	}))
+	assert.Equal(t, int64(40), selected.GetSize())
+	// the l0 segments of the selected partitions and the collection are kept
+	assert.Len(t, selected.GetPartitionBackups()[1].GetSegmentBackups(), 2)
+	assert.Len(t, selected.GetL0Segments(), 1)
+	// the backup is not modified
+	assert.Len(t, collection.GetPartitionBackups(), 3)
+	assert.Equal(t, int64(60), collection.GetSize())
+
+	_, err = selectPartitionBackups(collection, []string{"p4"})
+	assert.Error(t, err)
+}
+
+func TestRefBackupPath(t *testing.T) {
+	assert.Equal(t, "backup/b1", RefBackupPath("backup/b1", ""))
+	assert.Equal(t, "backup/b0", RefBackupPath("backup/b1", "b0"))
+	assert.Equal(t, "/b0", RefBackupPath("/b1", "b0"))
+
+	backup := &backuppb.BackupInfo{
+		Name: "b2",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{
+				L0Segments: []*backuppb.SegmentBackupInfo{{SegmentId: 1, RefBackup: "b0"}},
+				PartitionBackups: []*backuppb.PartitionBackupInfo{
+					{SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 2, RefBackup: "b1"}, {SegmentId: 3}, {SegmentId: 4, RefBackup: "b0"}}},
+				},
+			},
+		},
+	}
+	assert.ElementsMatch(t, []string{"b0", "b1"}, collectRefBackups(backup))
+}
+
+func TestIsSameSegmentBinlogs(t *testing.T) {
+	segment := &backuppb.SegmentBackupInfo{
+		SegmentId: 1,
+		Binlogs:   []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{{LogPath: "insert_log/1/2/1/100/1", LogSize: 10}}}},
+		Deltalogs: []*backuppb.FieldBinlog{{FieldID: 0}},
+	}
+	same := proto.Clone(segment).(*backuppb.SegmentBackupInfo)
+	same.GroupId = 1
+	same.RefBackup = "b0"
+	assert.True(t, isSameSegmentBinlogs(segment, same))
+
+	withDelta := proto.Clone(segment).(*backuppb.SegmentBackupInfo)
+	withDelta.Deltalogs = []*backuppb.FieldBinlog{{FieldID: 0, Binlogs: []*backuppb.Binlog{{LogPath: "delta_log/1/2/1/3", LogSize: 5}}}}
+	assert.False(t, isSameSegmentBinlogs(segment, withDelta))
+
+	resized := proto.Clone(segment).(*backuppb.SegmentBackupInfo)
+	resized.Binlogs[0].Binlogs[0].LogSize = 11
+	assert.False(t, isSameSegmentBinlogs(segment, resized))
+}
+
 func readBackup(backupDir string) (*backuppb.BackupInfo, error) {
 	readByteFunc := func(filepath string) ([]byte, error) {
 		file, err := os.OpenFile(filepath, os.O_RDWR, 0666)
@@ -231,3 +322,25 @@ func TestReadBackupFile(t *testing.T) {
 	fmt.Sprintf(segmentMetaStr)
 	//log.Info("segment meta", zap.String("value", string(output.SegmentMetaBytes)))
 }
+
+func TestSplitBackupBinlogPath(t *testing.T) {
+	backupPath, relativePath := splitBackupBinlogPath("backup/b1/binlogs/insert_log/1/2/3/")
+	assert.Equal(t, "backup/b1", backupPath)
+	assert.Equal(t, "binlogs/insert_log/1/2/3/", relativePath)
+
+	backupPath, relativePath = splitBackupBinlogPath("files/insert_log/1/2/3/4/100/5")
+	assert.Equal(t, "", backupPath)
+	assert.Equal(t, "files/insert_log/1/2/3/4/100/5", relativePath)
+
+	assert.Equal(t, "backup/.blobs/ab/abcdef", BlobPath("backup", "abcdef"))
+	assert.Equal(t, "backup/b1/meta/blob_manifest.json", BlobManifestPath("backup", "b1"))
+}
+
+func TestBinlogBackupPath(t *testing.T) {
+	assert.Equal(t, "backup/b1/binlogs/insert_log/1/2/3/3/100/5",
+		binlogBackupPath("files/insert_log/1/2/3/100/5", "files", "backup/b1/binlogs", 2, 3))
+	assert.Equal(t, "backup/b1/binlogs/insert_log/1/2/3/100/5",
+		binlogBackupPath("files/insert_log/1/2/3/100/5", "files", "backup/b1/binlogs", 2, 0))
+	assert.Equal(t, "backup/b1/binlogs/delta_log/1/2/3/3/100/5",
+		binlogBackupPath("delta_log/1/2/3/100/5", "", "backup/b1/binlogs", 2, 3))
+}
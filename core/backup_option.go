@@ -5,6 +5,8 @@ import "strings"
 // BackupConfig for setting params used by backup context and server.
 type BackupConfig struct {
 	port string
+	// grpc server is disabled if empty
+	grpcPort string
 }
 
 func newDefaultBackupConfig() *BackupConfig {
@@ -24,3 +26,15 @@ func Port(port string) BackupOption {
 		c.port = port
 	}
 }
+
+func GrpcPort(port string) BackupOption {
+	return func(c *BackupConfig) {
+		if port == "" {
+			return
+		}
+		if !strings.HasPrefix(port, ":") {
+			port = ":" + port
+		}
+		c.grpcPort = port
+	}
+}
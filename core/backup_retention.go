@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// retentionDecision is the decision of a retention policy on a backup
+type retentionDecision struct {
+	keep   bool
+	reason string
+}
+
+// backupTime returns the time the backup was taken
+func backupTime(backup *backuppb.BackupInfo) time.Time {
+	if backup.GetStartTime() != 0 {
+		return time.UnixMilli(backup.GetStartTime())
+	}
+	t, _ := utils.ParseTS(backup.GetBackupTimestamp())
+	return t
+}
+
+// selectBackup checks whether the backup contains any collection selected by the db collections,
+// an empty selector selects all backups and a database without collections selects all collections of it
+func selectBackup(backup *backuppb.BackupInfo, selector DbCollections) bool {
+	if len(selector) == 0 {
+		return true
+	}
+	for _, collection := range backup.GetCollectionBackups() {
+		dbName := collection.GetDbName()
+		if dbName == "" {
+			dbName = "default"
+		}
+		collections, ok := selector[dbName]
+		if !ok && dbName == "default" {
+			collections, ok = selector[""]
+		}
+		if !ok {
+			continue
+		}
+		if len(collections) == 0 {
+			return true
+		}
+		for _, name := range collections {
+			if name == collection.GetCollectionName() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyRetentionPolicy decides which of the successful backups selected by the policy are kept, keyed by backup name.
+// Backups are kept if any keep rule keeps them, or all of them are kept if the policy has no keep rule.
+// Then the kept backups are pruned from the oldest once their total size exceeds the max size, the latest backup is always kept.
+func applyRetentionPolicy(policy paramtable.RetentionConfig, backups []*backuppb.BackupInfo, now time.Time) (map[string]retentionDecision, error) {
+	var selector DbCollections
+	if policy.DbCollections != "" {
+		var err error
+		// backups are selected by collections, the partitions are ignored
+		if selector, _, err = parseDbCollections(policy.DbCollections); err != nil {
+			return nil, fmt.Errorf("illegal dbCollections of retention policy %s: %w", policy.Name, err)
+		}
+	}
+
+	selected := make([]*backuppb.BackupInfo, 0)
+	for _, backup := range backups {
+		if backup.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_SUCCESS && selectBackup(backup, selector) {
+			selected = append(selected, backup)
+		}
+	}
+	// the latest first
+	sort.SliceStable(selected, func(i, j int) bool {
+		return backupTime(selected[i]).After(backupTime(selected[j]))
+	})
+
+	hasKeepRule := policy.KeepLast > 0 || policy.KeepDaily > 0 || policy.KeepWeekly > 0
+	dailySince := now.AddDate(0, 0, -policy.KeepDaily)
+	weeklySince := now.AddDate(0, 0, -7*policy.KeepWeekly)
+	keptDays := make(map[string]bool)
+	keptWeeks := make(map[string]bool)
+	decisions := make(map[string]retentionDecision, len(selected))
+	var keptSize int64
+	keptNum := 0
+	for i, backup := range selected {
+		t := backupTime(backup).In(now.Location())
+		day := t.Format("2006-01-02")
+		year, week := t.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+
+		decision := retentionDecision{reason: "not kept by any keep rule"}
+		switch {
+		case !hasKeepRule:
+			decision = retentionDecision{keep: true, reason: "no keep rule"}
+		case i < policy.KeepLast:
+			decision = retentionDecision{keep: true, reason: fmt.Sprintf("one of the latest %d backups", policy.KeepLast)}
+		case policy.KeepDaily > 0 && t.After(dailySince) && !keptDays[day]:
+			decision = retentionDecision{keep: true, reason: "latest backup of day " + day}
+		case policy.KeepWeekly > 0 && t.After(weeklySince) && !keptWeeks[weekKey]:
+			decision = retentionDecision{keep: true, reason: "latest backup of week " + weekKey}
+		}
+		if decision.keep && policy.MaxSize > 0 && keptNum > 0 && keptSize+backup.GetSize() > policy.MaxSize {
+			decision = retentionDecision{reason: fmt.Sprintf("total size of kept backups exceeds max size %d", policy.MaxSize)}
+		}
+		if decision.keep {
+			keptSize += backup.GetSize()
+			keptNum++
+		}
+		// the latest backup of a day or week is counted even if it is kept by other rules
+		if policy.KeepDaily > 0 && t.After(dailySince) {
+			keptDays[day] = true
+		}
+		if policy.KeepWeekly > 0 && t.After(weeklySince) {
+			keptWeeks[weekKey] = true
+		}
+		decisions[backup.GetName()] = decision
+	}
+	return decisions, nil
+}
+
+// restoringBackupIDs returns the ids of backups which are read by in-progress restores.
+// Restores of the backup server are also found from the task store when running outside of the server.
+func (b *BackupContext) restoringBackupIDs() map[string]bool {
+	ids := make(map[string]bool)
+	tasks := b.meta.GetRestoreTasks()
+	if b.taskStore == nil {
+		if store, err := newTaskStore(b.params.BackupCfg); err != nil {
+			log.Warn("fail to open task store to find in-progress restores", zap.Error(err))
+		} else {
+			storedTasks, err := loadStoredRestoreTasks(store)
+			if err != nil {
+				log.Warn("fail to load restore tasks from task store", zap.Error(err))
+			}
+			tasks = append(tasks, storedTasks...)
+		}
+	}
+	for _, task := range tasks {
+		if !isRestoreTaskInterrupted(task.GetStateCode()) {
+			continue
+		}
+		for _, collectionTask := range task.GetCollectionRestoreTasks() {
+			ids[collectionTask.GetCollBackup().GetId()] = true
+		}
+	}
+	return ids
+}
+
+// pruneDecisions combines the decisions of the retention policies, a backup is only pruned if no policy keeps it.
+// Backups read by in-progress restores and parents of kept incremental backups are always kept.
+func pruneDecisions(policies []paramtable.RetentionConfig, backups []*backuppb.BackupInfo, restoring map[string]bool, now time.Time) ([]*backuppb.RetentionDecision, error) {
+	results := make(map[string]*backuppb.RetentionDecision)
+	for _, policy := range policies {
+		decisions, err := applyRetentionPolicy(policy, backups, now)
+		if err != nil {
+			return nil, err
+		}
+		for name, decision := range decisions {
+			result, exist := results[name]
+			if !exist {
+				result = &backuppb.RetentionDecision{BackupName: name, Keep: decision.keep, Reason: policy.Name + ": " + decision.reason}
+				results[name] = result
+			} else if decision.keep && !result.GetKeep() {
+				result.Keep = true
+				result.Reason = policy.Name + ": " + decision.reason
+			}
+			result.Policies = append(result.Policies, policy.Name)
+		}
+	}
+
+	backupsByName := make(map[string]*backuppb.BackupInfo, len(backups))
+	for _, backup := range backups {
+		backupsByName[backup.GetName()] = backup
+	}
+	for name, result := range results {
+		if !result.GetKeep() && restoring[backupsByName[name].GetId()] {
+			result.Keep = true
+			result.Reason = "read by an in-progress restore"
+		}
+	}
+	// keep the parent chains of kept backups, incremental backups are not able to be restored without them
+	for _, backup := range backups {
+		if result, exist := results[backup.GetName()]; exist && !result.GetKeep() {
+			continue
+		}
+		visited := map[string]bool{backup.GetName(): true}
+		child := backup.GetName()
+		for parent := backup.GetParentBackup(); parent != "" && !visited[parent]; parent = backupsByName[parent].GetParentBackup() {
+			if result, exist := results[parent]; exist && !result.GetKeep() {
+				result.Keep = true
+				result.Reason = "parent of incremental backup " + child
+			}
+			visited[parent] = true
+			child = parent
+		}
+	}
+
+	decisions := make([]*backuppb.RetentionDecision, 0, len(results))
+	for _, result := range results {
+		decisions = append(decisions, result)
+	}
+	// the latest first, so that incremental backups are deleted before their parents
+	sort.Slice(decisions, func(i, j int) bool {
+		return backupTime(backupsByName[decisions[i].GetBackupName()]).After(backupTime(backupsByName[decisions[j].GetBackupName()]))
+	})
+	return decisions, nil
+}
+
+func (b *BackupContext) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) *backuppb.PruneBackupsResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive PruneBackupsRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.Bool("dryRun", request.GetDryRun()),
+		zap.String("policy", request.GetPolicy()))
+
+	resp := &backuppb.PruneBackupsResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	policies := make([]paramtable.RetentionConfig, 0)
+	for _, policy := range b.params.BackupCfg.RetentionPolicies {
+		if request.GetPolicy() == "" || request.GetPolicy() == policy.Name {
+			policies = append(policies, policy)
+		}
+	}
+	if len(policies) == 0 {
+		errMsg := "no retention policy is defined"
+		if request.GetPolicy() != "" {
+			errMsg = fmt.Sprintf("retention policy doesn't exist: %s", request.GetPolicy())
+		}
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+
+	// prunes are serialized, so that a backup is not deleted twice
+	b.pruneMu.Lock()
+	defer b.pruneMu.Unlock()
+
+	listResp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{})
+	if listResp.GetCode() != backuppb.ResponseCode_Success {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = listResp.GetMsg()
+		return resp
+	}
+	decisions, err := pruneDecisions(policies, listResp.GetData(), b.restoringBackupIDs(), time.Now())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	resp.Data = decisions
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	if request.GetDryRun() {
+		return resp
+	}
+
+	failed := make([]string, 0)
+	for _, decision := range decisions {
+		if decision.GetKeep() {
+			continue
+		}
+		// a restore may start during the prune
+		backup := b.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: decision.GetBackupName()}).GetData()
+		if backup != nil && b.restoringBackupIDs()[backup.GetId()] {
+			decision.Keep = true
+			decision.Reason = "read by an in-progress restore"
+			continue
+		}
+		log.Info("prune backup", zap.String("backupName", decision.GetBackupName()), zap.String("reason", decision.GetReason()))
+		deleteResp := b.DeleteBackup(ctx, &backuppb.DeleteBackupRequest{BackupName: decision.GetBackupName()})
+		if deleteResp.GetCode() != backuppb.ResponseCode_Success {
+			log.Warn("fail to prune backup", zap.String("backupName", decision.GetBackupName()), zap.String("msg", deleteResp.GetMsg()))
+			decision.Keep = true
+			decision.Reason = "fail to delete: " + deleteResp.GetMsg()
+			failed = append(failed, decision.GetBackupName())
+		}
+	}
+	if len(failed) > 0 {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("fail to prune backups: %s", strings.Join(failed, ", "))
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func retentionTestBackup(name string, t time.Time, size int64, collection string) *backuppb.BackupInfo {
+	return &backuppb.BackupInfo{
+		Id:        name + "_id",
+		Name:      name,
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		StartTime: t.UnixMilli(),
+		Size:      size,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{DbName: "default", CollectionName: collection},
+		},
+	}
+}
+
+func decisionsByName(decisions []*backuppb.RetentionDecision) map[string]bool {
+	keeps := make(map[string]bool, len(decisions))
+	for _, decision := range decisions {
+		keeps[decision.GetBackupName()] = decision.GetKeep()
+	}
+	return keeps
+}
+
+func TestApplyRetentionPolicy(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	backups := []*backuppb.BackupInfo{
+		retentionTestBackup("b0", now.Add(-1*time.Hour), 10, "c1"),
+		retentionTestBackup("b1", now.Add(-2*time.Hour), 10, "c1"),
+		retentionTestBackup("b2", now.Add(-3*time.Hour), 10, "c1"),
+		retentionTestBackup("b3", now.AddDate(0, 0, -1), 10, "c1"),
+		retentionTestBackup("b4", now.AddDate(0, 0, -2), 10, "c1"),
+		retentionTestBackup("b5", now.AddDate(0, 0, -9), 10, "c1"),
+		retentionTestBackup("b6", now.AddDate(0, 0, -30), 10, "c1"),
+		retentionTestBackup("other", now.AddDate(0, 0, -30), 10, "c2"),
+	}
+	failed := retentionTestBackup("failed", now.AddDate(0, 0, -30), 10, "c1")
+	failed.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+	backups = append(backups, failed)
+
+	decisions, err := applyRetentionPolicy(paramtable.RetentionConfig{
+		Name:          "p",
+		DbCollections: `{"default":["c1"]}`,
+		KeepLast:      2,
+		KeepDaily:     3,
+		KeepWeekly:    2,
+	}, backups, now)
+	assert.NoError(t, err)
+	// neither selected nor successful
+	assert.Len(t, decisions, 7)
+	assert.True(t, decisions["b0"].keep)
+	assert.True(t, decisions["b1"].keep)
+	// b0 is the latest of the day
+	assert.False(t, decisions["b2"].keep)
+	assert.True(t, decisions["b3"].keep)
+	assert.True(t, decisions["b4"].keep)
+	// latest of the week before
+	assert.True(t, decisions["b5"].keep)
+	assert.False(t, decisions["b6"].keep)
+
+	decisions, err = applyRetentionPolicy(paramtable.RetentionConfig{Name: "size", MaxSize: 25}, backups, now)
+	assert.NoError(t, err)
+	assert.True(t, decisions["b0"].keep)
+	assert.True(t, decisions["b1"].keep)
+	assert.False(t, decisions["b2"].keep)
+
+	_, err = applyRetentionPolicy(paramtable.RetentionConfig{Name: "bad", DbCollections: "{"}, backups, now)
+	assert.Error(t, err)
+}
+
+func TestPruneDecisions(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	backups := []*backuppb.BackupInfo{
+		retentionTestBackup("b0", now.Add(-1*time.Hour), 10, "c1"),
+		retentionTestBackup("b1", now.Add(-2*time.Hour), 10, "c1"),
+		retentionTestBackup("b2", now.Add(-3*time.Hour), 10, "c1"),
+		retentionTestBackup("b3", now.Add(-4*time.Hour), 10, "c1"),
+		retentionTestBackup("b4", now.Add(-5*time.Hour), 10, "c1"),
+	}
+	// b0 is an incremental backup of b2
+	backups[0].ParentBackup = "b2"
+
+	policies := []paramtable.RetentionConfig{
+		{Name: "last", KeepLast: 1},
+		{Name: "c2", DbCollections: `{"default":["c2"]}`, KeepLast: 1},
+	}
+	decisions, err := pruneDecisions(policies, backups, map[string]bool{"b3_id": true}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "b0", decisions[0].GetBackupName())
+	keeps := decisionsByName(decisions)
+	assert.True(t, keeps["b0"])
+	assert.False(t, keeps["b1"])
+	// parent of the kept incremental backup
+	assert.True(t, keeps["b2"])
+	// being restored
+	assert.True(t, keeps["b3"])
+	assert.False(t, keeps["b4"])
+
+	// kept if any policy keeps it
+	policies = append(policies, paramtable.RetentionConfig{Name: "all"})
+	decisions, err = pruneDecisions(policies, backups, nil, now)
+	assert.NoError(t, err)
+	for _, decision := range decisions {
+		assert.True(t, decision.GetKeep())
+	}
+}
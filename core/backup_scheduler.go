@@ -0,0 +1,537 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/cron"
+)
+
+const (
+	SCHEDULE_PREFIX = "schedule/"
+
+	SCHEDULE_SOURCE_CONFIG = "config"
+	SCHEDULE_SOURCE_API    = "api"
+
+	SCHEDULE_NAME                  = "SCHEDULE_NAME"
+	DEFAULT_SCHEDULE_NAME_TEMPLATE = "{schedule}_{time}"
+	// number of the latest runs kept in the history of a schedule
+	SCHEDULE_HISTORY_SIZE = 20
+
+	SKIPPED_SCHEDULE_RUN_MESSAGE = "skipped as the last run of the schedule is still running"
+)
+
+// backupSchedule is a schedule with its parsed cron expression
+type backupSchedule struct {
+	info    *backuppb.BackupSchedule
+	cron    *cron.Schedule
+	running bool
+}
+
+// storedSchedule is the persisted form of a schedule.
+// db_collections is kept as a string as google.protobuf.Value is not able to be unmarshalled by encoding/json.
+type storedSchedule struct {
+	Name          string                  `json:"name"`
+	Cron          string                  `json:"cron"`
+	DbCollections string                  `json:"db_collections,omitempty"`
+	NameTemplate  string                  `json:"name_template,omitempty"`
+	MetaOnly      bool                    `json:"meta_only,omitempty"`
+	Force         bool                    `json:"force,omitempty"`
+	Source        string                  `json:"source"`
+	LastRunTime   int64                   `json:"last_run_time,omitempty"`
+	History       []*backuppb.ScheduleRun `json:"history,omitempty"`
+}
+
+func scheduleKey(name string) string {
+	return SCHEDULE_PREFIX + name
+}
+
+// BackupScheduler runs the backup schedules in server mode
+type BackupScheduler struct {
+	backupContext *BackupContext
+
+	mu        sync.Mutex
+	schedules map[string]*backupSchedule
+	// wakes up the loop to recompute the next run time when schedules change
+	notify chan struct{}
+}
+
+func newBackupScheduler(backupContext *BackupContext) *BackupScheduler {
+	return &BackupScheduler{
+		backupContext: backupContext,
+		schedules:     make(map[string]*backupSchedule),
+		notify:        make(chan struct{}, 1),
+	}
+}
+
+// renderBackupName fills the placeholders of the name template with the schedule name and the start time of the run
+func renderBackupName(nameTemplate string, scheduleName string, startTime time.Time) string {
+	if nameTemplate == "" {
+		nameTemplate = DEFAULT_SCHEDULE_NAME_TEMPLATE
+	}
+	return strings.NewReplacer(
+		"{schedule}", scheduleName,
+		"{time}", startTime.UTC().Format("2006_01_02_15_04_05"),
+		"{timestamp}", strconv.FormatInt(startTime.Unix(), 10),
+	).Replace(nameTemplate)
+}
+
+// newBackupSchedule validates the schedule and parses its cron expression
+func newBackupSchedule(info *backuppb.BackupSchedule) (*backupSchedule, error) {
+	if err := utils.ValidateType(info.GetName(), SCHEDULE_NAME); err != nil {
+		return nil, err
+	}
+	cronSchedule, err := cron.Parse(info.GetCron())
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateType(renderBackupName(info.GetNameTemplate(), info.GetName(), time.Now()), BACKUP_NAME); err != nil {
+		return nil, fmt.Errorf("illegal name template %s: %w", info.GetNameTemplate(), err)
+	}
+	if dbCollectionsStr := utils.GetScheduleDBCollections(info); dbCollectionsStr != "" {
+		if _, _, err := parseDbCollections(dbCollectionsStr); err != nil {
+			return nil, fmt.Errorf("illegal db_collections %s: %w", dbCollectionsStr, err)
+		}
+	}
+	return &backupSchedule{
+		info: info,
+		cron: cronSchedule,
+	}, nil
+}
+
+// init loads the schedules defined in config and the ones created by API from the task store, together with their history.
+// Runs interrupted by the restart of server are marked as failed.
+func (s *BackupScheduler) init(configs []paramtable.ScheduleConfig) error {
+	stored := make(map[string]*storedSchedule)
+	if store := s.backupContext.taskStore; store != nil {
+		keys, values, err := store.LoadWithPrefix(SCHEDULE_PREFIX)
+		if err != nil {
+			return err
+		}
+		for i, value := range values {
+			schedule := &storedSchedule{}
+			if err := json.Unmarshal([]byte(value), schedule); err != nil {
+				log.Warn("fail to parse stored schedule, skip it", zap.String("key", keys[i]), zap.Error(err))
+				continue
+			}
+			stored[schedule.Name] = schedule
+		}
+	}
+
+	now := time.Now()
+	for _, config := range configs {
+		info := &backuppb.BackupSchedule{
+			Name:         config.Name,
+			Cron:         config.Cron,
+			NameTemplate: config.NameTemplate,
+			MetaOnly:     config.MetaOnly,
+			Force:        config.Force,
+			Source:       SCHEDULE_SOURCE_CONFIG,
+		}
+		if config.DbCollections != "" {
+			info.DbCollections = utils.WrapDBCollections(config.DbCollections)
+		}
+		schedule, err := newBackupSchedule(info)
+		if err != nil {
+			log.Error("illegal backup schedule in config", zap.String("name", config.Name), zap.Error(err))
+			return err
+		}
+		if history, exist := stored[config.Name]; exist {
+			info.LastRunTime = history.LastRunTime
+			info.History = history.History
+		}
+		s.schedules[info.GetName()] = schedule
+	}
+	for name, storedInfo := range stored {
+		if _, exist := s.schedules[name]; exist {
+			continue
+		}
+		if storedInfo.Source != SCHEDULE_SOURCE_API {
+			// the schedule is removed from config
+			if err := s.backupContext.taskStore.Remove(scheduleKey(name)); err != nil {
+				log.Warn("fail to remove backup schedule from task store", zap.String("name", name), zap.Error(err))
+			}
+			continue
+		}
+		info := &backuppb.BackupSchedule{
+			Name:         storedInfo.Name,
+			Cron:         storedInfo.Cron,
+			NameTemplate: storedInfo.NameTemplate,
+			MetaOnly:     storedInfo.MetaOnly,
+			Force:        storedInfo.Force,
+			Source:       storedInfo.Source,
+			LastRunTime:  storedInfo.LastRunTime,
+			History:      storedInfo.History,
+		}
+		if storedInfo.DbCollections != "" {
+			info.DbCollections = utils.WrapDBCollections(storedInfo.DbCollections)
+		}
+		schedule, err := newBackupSchedule(info)
+		if err != nil {
+			log.Warn("fail to load stored schedule, skip it", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		s.schedules[name] = schedule
+	}
+
+	for _, schedule := range s.schedules {
+		for _, run := range schedule.info.GetHistory() {
+			if run.GetEndTime() == 0 {
+				run.EndTime = now.Unix()
+				run.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+				run.Msg = INTERRUPTED_TASK_MESSAGE
+			}
+		}
+		schedule.info.NextRunTime = nextRunTime(schedule.cron, now)
+		s.persist(schedule)
+	}
+	log.Info("load backup schedules", zap.Int("scheduleNum", len(s.schedules)))
+	return nil
+}
+
+func nextRunTime(cronSchedule *cron.Schedule, now time.Time) int64 {
+	next := cronSchedule.Next(now)
+	if next.IsZero() {
+		return 0
+	}
+	return next.Unix()
+}
+
+// persist saves the schedule into the task store, failure only logs a warning, the caller should hold s.mu
+func (s *BackupScheduler) persist(schedule *backupSchedule) {
+	store := s.backupContext.taskStore
+	if store == nil {
+		return
+	}
+	info := schedule.info
+	bytes, err := json.Marshal(&storedSchedule{
+		Name:          info.GetName(),
+		Cron:          info.GetCron(),
+		DbCollections: utils.GetScheduleDBCollections(info),
+		NameTemplate:  info.GetNameTemplate(),
+		MetaOnly:      info.GetMetaOnly(),
+		Force:         info.GetForce(),
+		Source:        info.GetSource(),
+		LastRunTime:   info.GetLastRunTime(),
+		History:       info.GetHistory(),
+	})
+	if err == nil {
+		err = store.Save(scheduleKey(info.GetName()), string(bytes))
+	}
+	if err != nil {
+		log.Warn("fail to persist backup schedule", zap.String("name", info.GetName()), zap.Error(err))
+	}
+}
+
+// start runs the schedules until the backup context is done
+func (s *BackupScheduler) start() {
+	go s.loop()
+}
+
+func (s *BackupScheduler) loop() {
+	ctx := s.backupContext.ctx
+	for {
+		var timerC <-chan time.Time
+		var timer *time.Timer
+		if next := s.nextWakeUpTime(); next != 0 {
+			timer = time.NewTimer(time.Until(time.Unix(next, 0)))
+			timerC = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-s.notify:
+			if timer != nil {
+				timer.Stop()
+			}
+		case now := <-timerC:
+			s.runDueSchedules(now)
+		}
+	}
+}
+
+func (s *BackupScheduler) wakeUp() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// nextWakeUpTime returns the earliest next run time of the schedules, 0 if no schedule is going to run
+func (s *BackupScheduler) nextWakeUpTime() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next int64
+	for _, schedule := range s.schedules {
+		runTime := schedule.info.GetNextRunTime()
+		if runTime != 0 && (next == 0 || runTime < next) {
+			next = runTime
+		}
+	}
+	return next
+}
+
+// runDueSchedules starts the schedules whose next run time is reached.
+// A schedule whose last run is still running is skipped, the skipped run is recorded in its history.
+func (s *BackupScheduler) runDueSchedules(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, schedule := range s.schedules {
+		info := schedule.info
+		if info.GetNextRunTime() == 0 || info.GetNextRunTime() > now.Unix() {
+			continue
+		}
+		info.LastRunTime = now.Unix()
+		info.NextRunTime = nextRunTime(schedule.cron, now)
+		if schedule.running {
+			log.Warn("skip backup schedule as its last run is still running", zap.String("name", info.GetName()))
+			s.addRun(schedule, &backuppb.ScheduleRun{
+				StartTime: now.Unix(),
+				EndTime:   now.Unix(),
+				StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL,
+				Msg:       SKIPPED_SCHEDULE_RUN_MESSAGE,
+			})
+			continue
+		}
+		run := &backuppb.ScheduleRun{
+			StartTime:  now.Unix(),
+			BackupName: renderBackupName(info.GetNameTemplate(), info.GetName(), now),
+			StateCode:  backuppb.BackupTaskStateCode_BACKUP_EXECUTING,
+		}
+		s.addRun(schedule, run)
+		schedule.running = true
+		request := &backuppb.CreateBackupRequest{
+			BackupName:    run.GetBackupName(),
+			DbCollections: info.GetDbCollections(),
+			MetaOnly:      info.GetMetaOnly(),
+			Force:         info.GetForce(),
+		}
+		go s.runSchedule(schedule, run, request)
+	}
+}
+
+// addRun adds the run into the history of the schedule, the caller should hold s.mu
+func (s *BackupScheduler) addRun(schedule *backupSchedule, run *backuppb.ScheduleRun) {
+	history := append([]*backuppb.ScheduleRun{run}, schedule.info.GetHistory()...)
+	if len(history) > SCHEDULE_HISTORY_SIZE {
+		history = history[:SCHEDULE_HISTORY_SIZE]
+	}
+	schedule.info.History = history
+	s.persist(schedule)
+}
+
+// runSchedule creates the backup of the run and records its result
+func (s *BackupScheduler) runSchedule(schedule *backupSchedule, run *backuppb.ScheduleRun, request *backuppb.CreateBackupRequest) {
+	log.Info("run backup schedule", zap.String("name", schedule.info.GetName()), zap.String("backupName", request.GetBackupName()))
+	resp := s.backupContext.CreateBackup(s.backupContext.ctx, request)
+
+	// enforce the retention policies after each scheduled backup
+	if resp.GetCode() == backuppb.ResponseCode_Success && len(s.backupContext.params.BackupCfg.RetentionPolicies) > 0 {
+		pruneResp := s.backupContext.PruneBackups(s.backupContext.ctx, &backuppb.PruneBackupsRequest{})
+		if pruneResp.GetCode() != backuppb.ResponseCode_Success {
+			log.Warn("fail to prune backups after scheduled backup", zap.String("name", schedule.info.GetName()), zap.String("msg", pruneResp.GetMsg()))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule.running = false
+	run.EndTime = time.Now().Unix()
+	run.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+	if resp.GetData() != nil {
+		run.StateCode = resp.GetData().GetStateCode()
+	}
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		run.Msg = resp.GetMsg()
+		log.Warn("backup schedule run failed", zap.String("name", schedule.info.GetName()), zap.String("backupName", request.GetBackupName()), zap.String("msg", resp.GetMsg()))
+	}
+	// the schedule may be deleted during the run
+	if s.schedules[schedule.info.GetName()] == schedule {
+		s.persist(schedule)
+	}
+}
+
+// startScheduler loads the backup schedules and starts running them, only used by server
+func (b *BackupContext) startScheduler() error {
+	scheduler := newBackupScheduler(b)
+	if err := scheduler.init(b.params.BackupCfg.Schedules); err != nil {
+		return err
+	}
+	scheduler.start()
+	b.scheduler = scheduler
+	return nil
+}
+
+func (b *BackupContext) CreateSchedule(ctx context.Context, request *backuppb.CreateScheduleRequest) *backuppb.ScheduleResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	info := &backuppb.BackupSchedule{
+		Name:          request.GetName(),
+		Cron:          request.GetCron(),
+		DbCollections: request.GetDbCollections(),
+		NameTemplate:  request.GetNameTemplate(),
+		MetaOnly:      request.GetMetaOnly(),
+		Force:         request.GetForce(),
+		Source:        SCHEDULE_SOURCE_API,
+	}
+	log.Info("receive CreateScheduleRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("name", request.GetName()),
+		zap.String("cron", request.GetCron()),
+		zap.String("databaseCollections", utils.GetScheduleDBCollections(info)),
+		zap.String("nameTemplate", request.GetNameTemplate()),
+		zap.Bool("metaOnly", request.GetMetaOnly()),
+		zap.Bool("force", request.GetForce()))
+
+	resp := &backuppb.ScheduleResponse{
+		RequestId: request.GetRequestId(),
+	}
+	if b.scheduler == nil {
+		resp.Code = backuppb.ResponseCode_Not_Support
+		resp.Msg = "backup schedules are only run by the backup server"
+		return resp
+	}
+
+	schedule, err := newBackupSchedule(info)
+	if err != nil {
+		log.Error("illegal backup schedule", zap.String("name", request.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	s := b.scheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.schedules[info.GetName()]; exist {
+		errMsg := fmt.Sprintf("backup schedule already exist with the name: %s", info.GetName())
+		log.Error(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+	info.NextRunTime = nextRunTime(schedule.cron, time.Now())
+	s.schedules[info.GetName()] = schedule
+	s.persist(schedule)
+	s.wakeUp()
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = proto.Clone(info).(*backuppb.BackupSchedule)
+	return resp
+}
+
+func (b *BackupContext) ListSchedules(ctx context.Context, request *backuppb.ListSchedulesRequest) *backuppb.ListSchedulesResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive ListSchedulesRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("name", request.GetName()))
+
+	resp := &backuppb.ListSchedulesResponse{
+		RequestId: request.GetRequestId(),
+	}
+	if b.scheduler == nil {
+		resp.Code = backuppb.ResponseCode_Not_Support
+		resp.Msg = "backup schedules are only run by the backup server"
+		return resp
+	}
+
+	s := b.scheduler
+	s.mu.Lock()
+	schedules := make([]*backuppb.BackupSchedule, 0, len(s.schedules))
+	for name, schedule := range s.schedules {
+		if request.GetName() != "" && request.GetName() != name {
+			continue
+		}
+		schedules = append(schedules, proto.Clone(schedule.info).(*backuppb.BackupSchedule))
+	}
+	s.mu.Unlock()
+	if request.GetName() != "" && len(schedules) == 0 {
+		errMsg := fmt.Sprintf("backup schedule doesn't exist: %s", request.GetName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].GetName() < schedules[j].GetName()
+	})
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = schedules
+	return resp
+}
+
+func (b *BackupContext) DeleteSchedule(ctx context.Context, request *backuppb.DeleteScheduleRequest) *backuppb.ScheduleResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive DeleteScheduleRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("name", request.GetName()))
+
+	resp := &backuppb.ScheduleResponse{
+		RequestId: request.GetRequestId(),
+	}
+	if b.scheduler == nil {
+		resp.Code = backuppb.ResponseCode_Not_Support
+		resp.Msg = "backup schedules are only run by the backup server"
+		return resp
+	}
+	if request.GetName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty schedule name"
+		return resp
+	}
+
+	s := b.scheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, exist := s.schedules[request.GetName()]
+	if !exist {
+		errMsg := fmt.Sprintf("backup schedule doesn't exist: %s", request.GetName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = errMsg
+		return resp
+	}
+	if schedule.info.GetSource() == SCHEDULE_SOURCE_CONFIG {
+		errMsg := fmt.Sprintf("backup schedule is defined in config, remove it from config instead: %s", request.GetName())
+		log.Warn(errMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errMsg
+		return resp
+	}
+	delete(s.schedules, request.GetName())
+	if store := b.taskStore; store != nil {
+		if err := store.Remove(scheduleKey(request.GetName())); err != nil {
+			log.Warn("fail to remove backup schedule from task store", zap.String("name", request.GetName()), zap.Error(err))
+		}
+	}
+	s.wakeUp()
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = proto.Clone(schedule.info).(*backuppb.BackupSchedule)
+	return resp
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
+)
+
+func TestRenderBackupName(t *testing.T) {
+	startTime := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, "nightly_2024_03_01_02_00_00", renderBackupName("", "nightly", startTime))
+	assert.Equal(t, "bak_nightly_1709258400", renderBackupName("bak_{schedule}_{timestamp}", "nightly", startTime))
+}
+
+func TestNewBackupSchedule(t *testing.T) {
+	_, err := newBackupSchedule(&backuppb.BackupSchedule{Name: "nightly", Cron: "0 2 * * *"})
+	assert.NoError(t, err)
+	_, err = newBackupSchedule(&backuppb.BackupSchedule{Name: "nightly", Cron: "0 25 * * *"})
+	assert.Error(t, err)
+	_, err = newBackupSchedule(&backuppb.BackupSchedule{Name: "night-ly", Cron: "@daily"})
+	assert.Error(t, err)
+	_, err = newBackupSchedule(&backuppb.BackupSchedule{Name: "nightly", Cron: "@daily", NameTemplate: "{time}"})
+	assert.Error(t, err)
+}
+
+func TestSchedulerInit(t *testing.T) {
+	store := memkv.NewMemoryKV()
+	stored := []*storedSchedule{
+		{
+			Name:        "hourly",
+			Cron:        "@hourly",
+			Source:      SCHEDULE_SOURCE_API,
+			LastRunTime: 100,
+			History: []*backuppb.ScheduleRun{
+				{StartTime: 100, BackupName: "hourly_1", StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING},
+			},
+		},
+		// removed from config
+		{Name: "removed", Cron: "@daily", Source: SCHEDULE_SOURCE_CONFIG},
+	}
+	for _, schedule := range stored {
+		bytes, err := json.Marshal(schedule)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Save(scheduleKey(schedule.Name), string(bytes)))
+	}
+
+	b := CreateBackupContext(context.Background(), paramtable.BackupParams{})
+	b.taskStore = store
+	scheduler := newBackupScheduler(b)
+	err := scheduler.init([]paramtable.ScheduleConfig{
+		{Name: "nightly", Cron: "0 2 * * *", DbCollections: `{"default":[]}`, MetaOnly: true},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, scheduler.schedules, 2)
+
+	nightly := scheduler.schedules["nightly"].info
+	assert.Equal(t, SCHEDULE_SOURCE_CONFIG, nightly.GetSource())
+	assert.True(t, nightly.GetNextRunTime() > time.Now().Unix())
+
+	// the run interrupted by the restart is marked as failed
+	hourly := scheduler.schedules["hourly"].info
+	assert.Equal(t, int64(100), hourly.GetLastRunTime())
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_FAIL, hourly.GetHistory()[0].GetStateCode())
+	assert.Equal(t, INTERRUPTED_TASK_MESSAGE, hourly.GetHistory()[0].GetMsg())
+
+	_, err = store.Load(scheduleKey("removed"))
+	assert.Error(t, err)
+}
+
+func TestSchedulerSkipRunningSchedule(t *testing.T) {
+	b := CreateBackupContext(context.Background(), paramtable.BackupParams{})
+	scheduler := newBackupScheduler(b)
+	schedule, err := newBackupSchedule(&backuppb.BackupSchedule{Name: "hourly", Cron: "@hourly"})
+	assert.NoError(t, err)
+	now := time.Now()
+	schedule.info.NextRunTime = now.Unix()
+	schedule.running = true
+	scheduler.schedules["hourly"] = schedule
+
+	scheduler.runDueSchedules(now)
+	assert.Equal(t, now.Unix(), schedule.info.GetLastRunTime())
+	assert.True(t, schedule.info.GetNextRunTime() > now.Unix())
+	assert.Len(t, schedule.info.GetHistory(), 1)
+	assert.Equal(t, SKIPPED_SCHEDULE_RUN_MESSAGE, schedule.info.GetHistory()[0].GetMsg())
+}
+
+func TestScheduleAPI(t *testing.T) {
+	b := CreateBackupContext(context.Background(), paramtable.BackupParams{})
+	ctx := context.Background()
+
+	resp := b.CreateSchedule(ctx, &backuppb.CreateScheduleRequest{Name: "hourly", Cron: "@hourly"})
+	assert.Equal(t, backuppb.ResponseCode_Not_Support, resp.GetCode())
+
+	b.taskStore = memkv.NewMemoryKV()
+	b.scheduler = newBackupScheduler(b)
+	assert.NoError(t, b.scheduler.init([]paramtable.ScheduleConfig{{Name: "nightly", Cron: "0 2 * * *"}}))
+
+	resp = b.CreateSchedule(ctx, &backuppb.CreateScheduleRequest{Name: "hourly", Cron: "@hourly"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.True(t, resp.GetData().GetNextRunTime() > 0)
+	resp = b.CreateSchedule(ctx, &backuppb.CreateScheduleRequest{Name: "hourly", Cron: "@daily"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = b.CreateSchedule(ctx, &backuppb.CreateScheduleRequest{Name: "bad", Cron: "* *"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+
+	listResp := b.ListSchedules(ctx, &backuppb.ListSchedulesRequest{})
+	assert.Equal(t, backuppb.ResponseCode_Success, listResp.GetCode())
+	assert.Len(t, listResp.GetData(), 2)
+	assert.Equal(t, "hourly", listResp.GetData()[0].GetName())
+	listResp = b.ListSchedules(ctx, &backuppb.ListSchedulesRequest{Name: "not_exist"})
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, listResp.GetCode())
+
+	// schedules in config can not be deleted by API
+	resp = b.DeleteSchedule(ctx, &backuppb.DeleteScheduleRequest{Name: "nightly"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = b.DeleteSchedule(ctx, &backuppb.DeleteScheduleRequest{Name: "hourly"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	_, err := b.taskStore.Load(scheduleKey("hourly"))
+	assert.Error(t, err)
+	listResp = b.ListSchedules(ctx, &backuppb.ListSchedulesRequest{})
+	assert.Len(t, listResp.GetData(), 1)
+}
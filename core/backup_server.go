@@ -9,6 +9,7 @@ import (
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
 	"github.com/zilliztech/milvus-backup/internal/log"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"net/http"
 	"net/http/pprof"
 )
@@ -21,6 +22,16 @@ const (
 	DELETE_BACKUP_API  = "/delete"
 	RESTORE_BACKUP_API = "/restore"
 	GET_RESTORE_API    = "/get_restore"
+	RESUME_BACKUP_API  = "/resume"
+	CANCEL_BACKUP_API  = "/cancel_backup"
+	CANCEL_RESTORE_API = "/cancel_restore"
+	SCHEDULE_API       = "/schedule"
+	PRUNE_BACKUPS_API  = "/prune"
+	VERIFY_BACKUP_API  = "/verify"
+	EXPORT_BACKUP_API  = "/export"
+	IMPORT_BACKUP_API  = "/import"
+	COPY_BACKUP_API    = "/copy"
+	THROTTLE_API       = "/throttle"
 
 	API_V1_PREFIX = "/api/v1"
 
@@ -33,6 +44,7 @@ const (
 type Server struct {
 	backupContext *BackupContext
 	engine        *gin.Engine
+	grpcServer    *grpc.Server
 	config        *BackupConfig
 }
 
@@ -42,7 +54,15 @@ func NewServer(ctx context.Context, params paramtable.BackupParams, opts ...Back
 		opt(c)
 	}
 	backupContext := CreateBackupContext(ctx, params)
-	err := backupContext.Start()
+	err := backupContext.InitTaskStore()
+	if err != nil {
+		return nil, err
+	}
+	err = backupContext.Start()
+	if err != nil {
+		return nil, err
+	}
+	err = backupContext.startScheduler()
 	if err != nil {
 		return nil, err
 	}
@@ -54,10 +74,16 @@ func NewServer(ctx context.Context, params paramtable.BackupParams, opts ...Back
 
 func (s *Server) Init() {
 	s.registerHTTPServer()
+	if s.config.grpcPort != "" {
+		s.registerGrpcServer()
+	}
 }
 
 func (s *Server) Start() {
 	s.registerProfilePort()
+	if s.grpcServer != nil {
+		s.startGrpcServer()
+	}
 	err := s.engine.Run(s.config.port)
 	if err != nil {
 		log.Error("Failed to start server", zap.Error(err))
@@ -113,6 +139,19 @@ func (h *Handlers) RegisterRoutesTo(router gin.IRouter) {
 	router.POST(RESTORE_BACKUP_API, wrapHandler(h.handleRestoreBackup))
 	router.GET(GET_RESTORE_API, wrapHandler(h.handleGetRestore))
 	router.GET(CHECK_API, wrapHandler(h.handleCheck))
+	router.POST(RESUME_BACKUP_API, wrapHandler(h.handleResumeBackup))
+	router.DELETE(CANCEL_BACKUP_API, wrapHandler(h.handleCancelBackup))
+	router.DELETE(CANCEL_RESTORE_API, wrapHandler(h.handleCancelRestore))
+	router.POST(SCHEDULE_API, wrapHandler(h.handleCreateSchedule))
+	router.GET(SCHEDULE_API, wrapHandler(h.handleListSchedules))
+	router.DELETE(SCHEDULE_API, wrapHandler(h.handleDeleteSchedule))
+	router.POST(PRUNE_BACKUPS_API, wrapHandler(h.handlePruneBackups))
+	router.POST(VERIFY_BACKUP_API, wrapHandler(h.handleVerifyBackup))
+	router.POST(EXPORT_BACKUP_API, wrapHandler(h.handleExportBackup))
+	router.POST(IMPORT_BACKUP_API, wrapHandler(h.handleImportBackup))
+	router.POST(COPY_BACKUP_API, wrapHandler(h.handleCopyBackup))
+	router.GET(THROTTLE_API, wrapHandler(h.handleGetThrottle))
+	router.POST(THROTTLE_API, wrapHandler(h.handleUpdateThrottle))
 	router.GET(DOCS_API, ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
 
@@ -151,6 +190,31 @@ func (h *Handlers) handleCreateBackup(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// ResumeBackup Resume backup interface
+// @Summary Resume backup interface
+// @Description Resume a failed or interrupted backup from its checkpoint, only the segments not backuped yet will be copied
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.ResumeBackupRequest   true  "ResumeBackupRequest JSON"
+// @Success 200 {object} backuppb.BackupInfoResponse
+// @Router /resume [post]
+func (h *Handlers) handleResumeBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.ResumeBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.ResumeBackup(h.backupContext.ctx, &requestBody)
+	if h.backupContext.params.HTTPCfg.SimpleResponse {
+		resp = SimpleBackupResponse(resp)
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
 // ListBackups List Backups interface
 // @Summary List Backups interface
 // @Description List all backups in current storage
@@ -216,9 +280,33 @@ func (h *Handlers) handleDeleteBackup(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// CancelBackup Cancel backup interface
+// @Summary Cancel backup interface
+// @Description Cancel a running backup with the given name, the partial backup is removed if cleanup is true
+// @Tags Backup
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param backup_name query string true "backup_name"
+// @Param cleanup query bool false "cleanup"
+// @Success 200 {object} backuppb.BackupInfoResponse
+// @Router /cancel_backup [delete]
+func (h *Handlers) handleCancelBackup(c *gin.Context) (interface{}, error) {
+	req := backuppb.CancelBackupRequest{
+		RequestId:  c.GetHeader("request_id"),
+		BackupName: c.Query("backup_name"),
+		Cleanup:    c.Query("cleanup") == "true",
+	}
+	resp := h.backupContext.CancelBackup(h.backupContext.ctx, &req)
+	if h.backupContext.params.HTTPCfg.SimpleResponse {
+		resp = SimpleBackupResponse(resp)
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
 // RestoreBackup Restore interface
 // @Summary Restore interface
-// @Description Submit a request to restore the data from backup
+// @Description Submit a request to restore the data from backup, only return the plan of the restore if dry_run is true, verify the restored collections if verify is true, load the collections loaded at backup time if restore_load_state is true, recreate the aliases if restore_aliases is true
 // @Tags Restore
 // @Accept application/json
 // @Produce application/json
@@ -268,6 +356,239 @@ func (h *Handlers) handleGetRestore(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// CancelRestore Cancel restore interface
+// @Summary Cancel restore interface
+// @Description Cancel a running restore task with the given id, the target collections created by it are dropped if cleanup is true
+// @Tags Restore
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @param id query string true "id"
+// @Param cleanup query bool false "cleanup"
+// @Success 200 {object} backuppb.RestoreBackupResponse
+// @Router /cancel_restore [delete]
+func (h *Handlers) handleCancelRestore(c *gin.Context) (interface{}, error) {
+	req := backuppb.CancelRestoreRequest{
+		RequestId: c.GetHeader("request_id"),
+		Id:        c.Query("id"),
+		Cleanup:   c.Query("cleanup") == "true",
+	}
+	resp := h.backupContext.CancelRestore(h.backupContext.ctx, &req)
+	if h.backupContext.params.HTTPCfg.SimpleResponse {
+		resp = SimpleRestoreResponse(resp)
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// CreateSchedule Create schedule interface
+// @Summary Create schedule interface
+// @Description Create a backup schedule run by the server, backups are created by the cron expression of the schedule
+// @Tags Schedule
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.CreateScheduleRequest   true  "CreateScheduleRequest JSON"
+// @Success 200 {object} backuppb.ScheduleResponse
+// @Router /schedule [post]
+func (h *Handlers) handleCreateSchedule(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.CreateScheduleRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.CreateSchedule(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ListSchedules List schedules interface
+// @Summary List schedules interface
+// @Description List the backup schedules with their last and next run time and run history
+// @Tags Schedule
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param name query string false "name"
+// @Success 200 {object} backuppb.ListSchedulesResponse
+// @Router /schedule [get]
+func (h *Handlers) handleListSchedules(c *gin.Context) (interface{}, error) {
+	req := backuppb.ListSchedulesRequest{
+		RequestId: c.GetHeader("request_id"),
+		Name:      c.Query("name"),
+	}
+	resp := h.backupContext.ListSchedules(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// DeleteSchedule Delete schedule interface
+// @Summary Delete schedule interface
+// @Description Delete a backup schedule created by API, backups created by it are kept
+// @Tags Schedule
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param name query string true "name"
+// @Success 200 {object} backuppb.ScheduleResponse
+// @Router /schedule [delete]
+func (h *Handlers) handleDeleteSchedule(c *gin.Context) (interface{}, error) {
+	req := backuppb.DeleteScheduleRequest{
+		RequestId: c.GetHeader("request_id"),
+		Name:      c.Query("name"),
+	}
+	resp := h.backupContext.DeleteSchedule(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// PruneBackups Prune backups interface
+// @Summary Prune backups interface
+// @Description Delete the backups not kept by the retention policies, only evaluate the policies if dry_run is true
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.PruneBackupsRequest   true  "PruneBackupsRequest JSON"
+// @Success 200 {object} backuppb.PruneBackupsResponse
+// @Router /prune [post]
+func (h *Handlers) handlePruneBackups(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.PruneBackupsRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.PruneBackups(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// VerifyBackup Verify backup interface
+// @Summary Verify backup interface
+// @Description Check the files of a backup against its checksum manifest and segment meta, report the missing, extra, truncated or mismatched files
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.VerifyBackupRequest   true  "VerifyBackupRequest JSON"
+// @Success 200 {object} backuppb.VerifyBackupResponse
+// @Router /verify [post]
+func (h *Handlers) handleVerifyBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.VerifyBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.VerifyBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ExportBackup Export backup interface
+// @Summary Export backup interface
+// @Description Export a backup with the backups referenced by it into a single tar archive on the local disk of the server
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.ExportBackupRequest   true  "ExportBackupRequest JSON"
+// @Success 200 {object} backuppb.BackupArchiveResponse
+// @Router /export [post]
+func (h *Handlers) handleExportBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.ExportBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.ExportBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ImportBackup Import backup interface
+// @Summary Import backup interface
+// @Description Import a backup from an archive created by the export interface on the local disk of the server
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.ImportBackupRequest   true  "ImportBackupRequest JSON"
+// @Success 200 {object} backuppb.BackupArchiveResponse
+// @Router /import [post]
+func (h *Handlers) handleImportBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.ImportBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.ImportBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// CopyBackup Copy backup interface
+// @Summary Copy backup interface
+// @Description Copy a backup with the backups referenced by it into another bucket, path or storage, and verify the copied files
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.CopyBackupRequest   true  "CopyBackupRequest JSON"
+// @Success 200 {object} backuppb.CopyBackupResponse
+// @Router /copy [post]
+func (h *Handlers) handleCopyBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.CopyBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.CopyBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// GetThrottle Get throttle interface
+// @Summary Get throttle interface
+// @Description Get the throttle config of the data copies and the limits in effect
+// @Tags Throttle
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Success 200 {object} backuppb.ThrottleResponse
+// @Router /throttle [get]
+func (h *Handlers) handleGetThrottle(c *gin.Context) (interface{}, error) {
+	req := backuppb.GetThrottleRequest{
+		RequestId: c.GetHeader("request_id"),
+	}
+	resp := h.backupContext.GetThrottle(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// UpdateThrottle Update throttle interface
+// @Summary Update throttle interface
+// @Description Replace the throttle config of the data copies at runtime, it takes effect on the files copied afterwards and is not persisted
+// @Tags Throttle
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.UpdateThrottleRequest   true  "UpdateThrottleRequest JSON"
+// @Success 200 {object} backuppb.ThrottleResponse
+// @Router /throttle [post]
+func (h *Handlers) handleUpdateThrottle(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.UpdateThrottleRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	resp := h.backupContext.UpdateThrottle(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
 func (h *Handlers) handleCheck(c *gin.Context) (interface{}, error) {
 	resp := h.backupContext.Check(h.backupContext.ctx)
 	c.JSON(http.StatusOK, resp)
@@ -34,3 +34,14 @@ func TestProfileService(t *testing.T) {
 	time.Sleep(1000 * time.Second)
 
 }
+
+func TestGrpcPortOption(t *testing.T) {
+	c := newDefaultBackupConfig()
+	assert.Equal(t, "", c.grpcPort)
+
+	GrpcPort("")(c)
+	assert.Equal(t, "", c.grpcPort)
+
+	GrpcPort("50051")(c)
+	assert.Equal(t, ":50051", c.grpcPort)
+}
@@ -0,0 +1,412 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/kv"
+	filekv "github.com/zilliztech/milvus-backup/internal/kv/file"
+	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	TASK_STORE_FILE   = "file"
+	TASK_STORE_MEMORY = "memory"
+
+	BACKUP_TASK_PREFIX  = "backup-task/"
+	RESTORE_TASK_PREFIX = "restore-task/"
+
+	INTERRUPTED_TASK_MESSAGE   = "task is interrupted by the restart of backup server"
+	INTERRUPTED_BACKUP_MESSAGE = INTERRUPTED_TASK_MESSAGE + ", resume it by ResumeBackup"
+)
+
+// newTaskStore creates the kv to persist the states of backup and restore tasks
+func newTaskStore(cfg paramtable.BackupConfig) (kv.BaseKV, error) {
+	switch cfg.TaskStoreType {
+	case TASK_STORE_FILE:
+		return filekv.NewFileKV(cfg.TaskStorePath)
+	case TASK_STORE_MEMORY:
+		return memkv.NewMemoryKV(), nil
+	default:
+		return nil, fmt.Errorf("unsupported task store type: %s", cfg.TaskStoreType)
+	}
+}
+
+// storedBackupTask is the persisted form of a backup task.
+// The infos are kept as they are in MetaManager, so that the task can be rebuilt exactly.
+type storedBackupTask struct {
+	Backup      *backuppb.BackupInfo             `json:"backup"`
+	Collections []*backuppb.CollectionBackupInfo `json:"collections"`
+	Partitions  []*backuppb.PartitionBackupInfo  `json:"partitions"`
+	Segments    []*backuppb.SegmentBackupInfo    `json:"segments"`
+	BlobRefs    map[string]BlobRef               `json:"blob_refs,omitempty"`
+	Checksums   map[string]FileChecksum          `json:"checksums,omitempty"`
+}
+
+func backupTaskKey(backupID string) string {
+	return BACKUP_TASK_PREFIX + backupID
+}
+
+func restoreTaskKey(restoreID string) string {
+	return RESTORE_TASK_PREFIX + restoreID
+}
+
+func isTaskInterrupted(stateCode backuppb.BackupTaskStateCode) bool {
+	return stateCode == backuppb.BackupTaskStateCode_BACKUP_INITIAL || stateCode == backuppb.BackupTaskStateCode_BACKUP_EXECUTING
+}
+
+func isRestoreTaskInterrupted(stateCode backuppb.RestoreTaskStateCode) bool {
+	return stateCode == backuppb.RestoreTaskStateCode_INITIAL || stateCode == backuppb.RestoreTaskStateCode_EXECUTING
+}
+
+// snapshotBackupTask returns the persisted form of the backup task, the caller should hold meta.mu
+func (meta *MetaManager) snapshotBackupTask(backupID string) *storedBackupTask {
+	backup, exist := meta.backups[backupID]
+	if !exist {
+		return nil
+	}
+	task := &storedBackupTask{
+		Backup:      backup,
+		Collections: make([]*backuppb.CollectionBackupInfo, 0),
+		Partitions:  make([]*backuppb.PartitionBackupInfo, 0),
+		Segments:    make([]*backuppb.SegmentBackupInfo, 0),
+	}
+	for collectionID, collection := range meta.collections[backupID] {
+		task.Collections = append(task.Collections, collection)
+		for partitionID, partition := range meta.partitions[collectionID] {
+			task.Partitions = append(task.Partitions, partition)
+			for _, segment := range meta.segments[partitionID] {
+				task.Segments = append(task.Segments, segment)
+			}
+		}
+	}
+	if manifest, exist := meta.blobManifests[backupID]; exist {
+		task.BlobRefs = make(map[string]BlobRef, len(manifest.Files))
+		for path, ref := range manifest.Files {
+			task.BlobRefs[path] = ref
+		}
+	}
+	if manifest, exist := meta.checksumManifests[backupID]; exist {
+		task.Checksums = make(map[string]FileChecksum, len(manifest.Files))
+		for path, checksum := range manifest.Files {
+			task.Checksums[path] = checksum
+		}
+	}
+	return task
+}
+
+// persistBackupTask saves the backup task into the task store, failure only logs a warning
+// as the task itself is still able to go on.
+// The whole task is rewritten, so it is only persisted on the state transitions and the checkpoints of the backup
+// instead of every update. The infos are replaced instead of modified by updates, the snapshot is marshaled
+// out of meta.mu.
+func (meta *MetaManager) persistBackupTask(backupID string) {
+	meta.storeMu.Lock()
+	defer meta.storeMu.Unlock()
+	if meta.store == nil {
+		return
+	}
+	meta.mu.Lock()
+	task := meta.snapshotBackupTask(backupID)
+	meta.mu.Unlock()
+	if task == nil {
+		return
+	}
+	bytes, err := json.Marshal(task)
+	if err == nil {
+		err = meta.store.Save(backupTaskKey(backupID), string(bytes))
+	}
+	if err != nil {
+		log.Warn("fail to persist backup task", zap.String("backupId", backupID), zap.Error(err))
+	}
+}
+
+// persistRestoreTask saves the restore task into the task store, failure only logs a warning
+func (meta *MetaManager) persistRestoreTask(restoreID string) {
+	meta.storeMu.Lock()
+	defer meta.storeMu.Unlock()
+	if meta.store == nil {
+		return
+	}
+	meta.mu.Lock()
+	task, exist := meta.restoreTasks[restoreID]
+	var bytes []byte
+	var err error
+	if exist {
+		bytes, err = json.Marshal(task)
+	}
+	meta.mu.Unlock()
+	if !exist {
+		return
+	}
+	if err == nil {
+		err = meta.store.Save(restoreTaskKey(restoreID), string(bytes))
+	}
+	if err != nil {
+		log.Warn("fail to persist restore task", zap.String("restoreId", restoreID), zap.Error(err))
+	}
+}
+
+// loadStoredRestoreTasks loads the restore tasks from the task store, tasks unable to be parsed are skipped
+func loadStoredRestoreTasks(store kv.BaseKV) ([]*backuppb.RestoreBackupTask, error) {
+	keys, values, err := store.LoadWithPrefix(RESTORE_TASK_PREFIX)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*backuppb.RestoreBackupTask, 0, len(values))
+	for i, value := range values {
+		task := &backuppb.RestoreBackupTask{}
+		err := json.Unmarshal([]byte(value), task)
+		if err != nil {
+			log.Warn("fail to parse stored restore task, skip it", zap.String("key", keys[i]), zap.Error(err))
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// fullMeta assembles the backup info of the stored task the same way as GetFullMeta of a running task
+func (task *storedBackupTask) fullMeta() *backuppb.BackupInfo {
+	meta := newMetaManager()
+	meta.AddBackup(task.Backup)
+	for _, collection := range task.Collections {
+		meta.AddCollection(collection)
+	}
+	for _, partition := range task.Partitions {
+		meta.AddPartition(partition)
+	}
+	for _, segment := range task.Segments {
+		meta.AddSegment(segment)
+	}
+	return meta.GetFullMeta(task.Backup.GetId())
+}
+
+// InitTaskStore reloads the task history from the task store and persists the later changes of tasks into it.
+// Tasks which were still running are interrupted by the restart, they are marked as failed,
+// interrupted backups are able to be resumed from their checkpoints.
+// Reloaded backup tasks are only served by the read only history, restore tasks are reloaded to be resumed.
+// At most historySize finished tasks of each kind are kept, 0 means no limit.
+func (meta *MetaManager) InitTaskStore(store kv.BaseKV, historySize int) error {
+	interruptedBackups := make([]*storedBackupTask, 0)
+	interruptedRestores := make([]string, 0)
+
+	keys, values, err := store.LoadWithPrefix(BACKUP_TASK_PREFIX)
+	if err != nil {
+		return err
+	}
+	backupTasks := make([]*storedBackupTask, 0, len(values))
+	for i, value := range values {
+		task := &storedBackupTask{}
+		err := json.Unmarshal([]byte(value), task)
+		if err != nil {
+			log.Warn("fail to parse stored backup task, skip it", zap.String("key", keys[i]), zap.Error(err))
+			continue
+		}
+		if task.Backup == nil {
+			continue
+		}
+		backupTasks = append(backupTasks, task)
+	}
+	sort.SliceStable(backupTasks, func(i, j int) bool {
+		return backupTasks[i].Backup.GetStartTime() < backupTasks[j].Backup.GetStartTime()
+	})
+	meta.mu.Lock()
+	for _, task := range backupTasks {
+		if isTaskInterrupted(task.Backup.GetStateCode()) {
+			log.Info("mark interrupted backup task as failed", zap.String("backupId", task.Backup.GetId()), zap.String("backupName", task.Backup.GetName()))
+			task.Backup.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+			task.Backup.ErrorMessage = INTERRUPTED_BACKUP_MESSAGE
+			interruptedBackups = append(interruptedBackups, task)
+		}
+		meta.history[task.Backup.GetId()] = task
+		meta.backupTaskIDs = append(meta.backupTaskIDs, task.Backup.GetId())
+	}
+	meta.mu.Unlock()
+
+	restoreTasks, err := loadStoredRestoreTasks(store)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(restoreTasks, func(i, j int) bool {
+		return restoreTasks[i].GetStartTime() < restoreTasks[j].GetStartTime()
+	})
+	// the store is set after loading, so that the tasks are not persisted back half loaded
+	for _, task := range restoreTasks {
+		meta.AddRestoreTask(task)
+		if isRestoreTaskInterrupted(task.GetStateCode()) {
+			log.Info("mark interrupted restore task as failed", zap.String("restoreId", task.GetId()))
+			meta.UpdateRestoreTask(task.GetId(),
+				setRestoreStateCode(backuppb.RestoreTaskStateCode_FAIL),
+				setRestoreErrorMessage(INTERRUPTED_TASK_MESSAGE))
+			interruptedRestores = append(interruptedRestores, task.GetId())
+		}
+	}
+	log.Info("load tasks from task store",
+		zap.Int("backupTaskNum", len(backupTasks)),
+		zap.Int("restoreTaskNum", len(restoreTasks)),
+		zap.Int("interruptedBackupNum", len(interruptedBackups)),
+		zap.Strings("interruptedRestores", interruptedRestores))
+
+	meta.storeMu.Lock()
+	meta.store = store
+	for _, task := range interruptedBackups {
+		bytes, err := json.Marshal(task)
+		if err == nil {
+			err = store.Save(backupTaskKey(task.Backup.GetId()), string(bytes))
+		}
+		if err != nil {
+			log.Warn("fail to persist backup task", zap.String("backupId", task.Backup.GetId()), zap.Error(err))
+		}
+	}
+	meta.storeMu.Unlock()
+	for _, id := range interruptedRestores {
+		meta.persistRestoreTask(id)
+	}
+	// tasks are pruned once the store is set, so that they are removed from it too
+	meta.mu.Lock()
+	meta.historySize = historySize
+	meta.mu.Unlock()
+	meta.pruneTaskHistory()
+	return nil
+}
+
+// GetBackupHistory returns the full meta of a backup task reloaded from the task store, nil if not found
+func (meta *MetaManager) GetBackupHistory(backupID string) *backuppb.BackupInfo {
+	meta.mu.Lock()
+	task, exist := meta.history[backupID]
+	meta.mu.Unlock()
+	if !exist {
+		return nil
+	}
+	return task.fullMeta()
+}
+
+// GetBackupHistoryByName returns the full meta of the latest backup task of the name reloaded from the task store, nil if not found
+func (meta *MetaManager) GetBackupHistoryByName(name string) *backuppb.BackupInfo {
+	meta.mu.Lock()
+	var latest *storedBackupTask
+	for _, task := range meta.history {
+		if task.Backup.GetName() == name && (latest == nil || task.Backup.GetStartTime() > latest.Backup.GetStartTime()) {
+			latest = task
+		}
+	}
+	meta.mu.Unlock()
+	if latest == nil {
+		return nil
+	}
+	return latest.fullMeta()
+}
+
+// pruneTaskHistory removes the oldest finished tasks beyond historySize from meta and the task store,
+// running tasks are never removed
+func (meta *MetaManager) pruneTaskHistory() {
+	meta.mu.Lock()
+	if meta.historySize <= 0 {
+		meta.mu.Unlock()
+		return
+	}
+	finishedBackups := make([]string, 0)
+	for _, id := range meta.backupTaskIDs {
+		if backup, exist := meta.backups[id]; exist && isTaskInterrupted(backup.GetStateCode()) {
+			continue
+		}
+		finishedBackups = append(finishedBackups, id)
+	}
+	finishedRestores := make([]string, 0)
+	for _, id := range meta.restoreTaskIDs {
+		if isRestoreTaskInterrupted(meta.restoreTasks[id].GetStateCode()) {
+			continue
+		}
+		finishedRestores = append(finishedRestores, id)
+	}
+	historySize := meta.historySize
+	meta.mu.Unlock()
+
+	if len(finishedBackups) > historySize {
+		for _, id := range finishedBackups[:len(finishedBackups)-historySize] {
+			meta.RemoveBackup(id)
+		}
+	}
+	if len(finishedRestores) > historySize {
+		for _, id := range finishedRestores[:len(finishedRestores)-historySize] {
+			meta.removeRestoreTask(id)
+		}
+	}
+}
+
+// removeRestoreTask removes the restore task from meta and the task store
+func (meta *MetaManager) removeRestoreTask(restoreID string) {
+	meta.mu.Lock()
+	delete(meta.restoreTasks, restoreID)
+	meta.restoreTaskIDs = lo.Without(meta.restoreTaskIDs, restoreID)
+	meta.mu.Unlock()
+
+	meta.storeMu.Lock()
+	defer meta.storeMu.Unlock()
+	if meta.store == nil {
+		return
+	}
+	if err := meta.store.Remove(restoreTaskKey(restoreID)); err != nil {
+		log.Warn("fail to remove restore task from task store", zap.String("restoreId", restoreID), zap.Error(err))
+	}
+}
+
+// RemoveBackupsByName removes the running and the history tasks of the backup name
+func (meta *MetaManager) RemoveBackupsByName(name string) {
+	meta.mu.Lock()
+	ids := make([]string, 0)
+	if id, exist := meta.backupNameToIdDict[name]; exist {
+		ids = append(ids, id)
+	}
+	for id, task := range meta.history {
+		if task.Backup.GetName() == name {
+			ids = append(ids, id)
+		}
+	}
+	meta.mu.Unlock()
+	for _, id := range ids {
+		meta.RemoveBackup(id)
+	}
+}
+
+// RemoveBackup removes the backup task and its infos from meta, the history and the task store
+func (meta *MetaManager) RemoveBackup(backupID string) {
+	meta.mu.Lock()
+	delete(meta.history, backupID)
+	meta.backupTaskIDs = lo.Without(meta.backupTaskIDs, backupID)
+	if backup, exist := meta.backups[backupID]; exist {
+		if meta.backupNameToIdDict[backup.GetName()] == backupID {
+			delete(meta.backupNameToIdDict, backup.GetName())
+		}
+		delete(meta.backups, backupID)
+	}
+	for collectionID := range meta.collections[backupID] {
+		// partitions and segments of the collection belong to a later backup
+		if meta.collectionBackupReverse[collectionID] != backupID {
+			continue
+		}
+		meta.removeCollectionPartitions(collectionID)
+		delete(meta.collectionBackupReverse, collectionID)
+	}
+	delete(meta.collections, backupID)
+	delete(meta.blobManifests, backupID)
+	delete(meta.checksumManifests, backupID)
+	meta.mu.Unlock()
+
+	meta.storeMu.Lock()
+	defer meta.storeMu.Unlock()
+	if meta.store == nil {
+		return
+	}
+	if err := meta.store.Remove(backupTaskKey(backupID)); err != nil {
+		log.Warn("fail to remove backup task from task store", zap.String("backupId", backupID), zap.Error(err))
+	}
+}
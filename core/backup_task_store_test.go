@@ -0,0 +1,173 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
+)
+
+func TestTaskStoreReload(t *testing.T) {
+	store := memkv.NewMemoryKV()
+
+	meta := newMetaManager()
+	assert.NoError(t, meta.InitTaskStore(store, 0))
+	meta.AddBackup(&backuppb.BackupInfo{Id: "b1", Name: "backup1", StateCode: backuppb.BackupTaskStateCode_BACKUP_INITIAL})
+	meta.AddCollection(&backuppb.CollectionBackupInfo{Id: "b1", CollectionId: 1, CollectionName: "coll"})
+	meta.AddPartition(&backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2})
+	meta.AddSegment(&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3, Size: 10})
+	meta.UpdateBackup("b1", setStateCode(backuppb.BackupTaskStateCode_BACKUP_EXECUTING))
+	meta.AddBackup(&backuppb.BackupInfo{Id: "b2", Name: "backup2", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS})
+	meta.AddRestoreTask(&backuppb.RestoreBackupTask{Id: "r1", StateCode: backuppb.RestoreTaskStateCode_EXECUTING})
+
+	// restart
+	reloaded := newMetaManager()
+	assert.NoError(t, reloaded.InitTaskStore(store, 0))
+
+	// reloaded backups are only served by the history
+	assert.Nil(t, reloaded.GetBackup("b1"))
+	backup := reloaded.GetBackupHistory("b1")
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_FAIL, backup.GetStateCode())
+	assert.Equal(t, INTERRUPTED_BACKUP_MESSAGE, backup.GetErrorMessage())
+	assert.Equal(t, 1, len(backup.GetCollectionBackups()))
+	assert.Equal(t, int64(3), backup.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetSegmentId())
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_SUCCESS, reloaded.GetBackupHistoryByName("backup2").GetStateCode())
+
+	restore := reloaded.GetRestoreTask("r1")
+	assert.Equal(t, backuppb.RestoreTaskStateCode_FAIL, restore.GetStateCode())
+
+	// interrupted states are persisted
+	again := newMetaManager()
+	assert.NoError(t, again.InitTaskStore(store, 0))
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_FAIL, again.GetBackupHistory("b1").GetStateCode())
+
+	again.RemoveBackupsByName("backup2")
+	assert.Nil(t, again.GetBackupHistoryByName("backup2"))
+	_, err := store.Load(backupTaskKey("b2"))
+	assert.Error(t, err)
+}
+
+func TestTaskStoreHistoryNotMerged(t *testing.T) {
+	store := memkv.NewMemoryKV()
+
+	// two backups of the same collection, partitions and segments are indexed by collection at runtime
+	meta := newMetaManager()
+	assert.NoError(t, meta.InitTaskStore(store, 0))
+	meta.AddBackup(&backuppb.BackupInfo{Id: "b1", Name: "backup1", StartTime: 1, StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS})
+	meta.AddCollection(&backuppb.CollectionBackupInfo{Id: "b1", CollectionId: 1})
+	meta.AddPartition(&backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2})
+	meta.AddSegment(&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3})
+	meta.persistBackupTask("b1")
+	meta.AddBackup(&backuppb.BackupInfo{Id: "b2", Name: "backup2", StartTime: 2, StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS})
+	meta.AddCollection(&backuppb.CollectionBackupInfo{Id: "b2", CollectionId: 1})
+	meta.AddPartition(&backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2})
+	meta.AddSegment(&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 4})
+	meta.persistBackupTask("b2")
+
+	reloaded := newMetaManager()
+	assert.NoError(t, reloaded.InitTaskStore(store, 0))
+	segmentIDs := func(backup *backuppb.BackupInfo) []int64 {
+		ids := make([]int64, 0)
+		for _, partition := range backup.GetCollectionBackups()[0].GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				ids = append(ids, segment.GetSegmentId())
+			}
+		}
+		return ids
+	}
+	// b1 is kept as it was persisted, instead of being merged with the partitions of b2
+	assert.ElementsMatch(t, []int64{3}, segmentIDs(reloaded.GetBackupHistory("b1")))
+	assert.ElementsMatch(t, []int64{3, 4}, segmentIDs(reloaded.GetBackupHistory("b2")))
+	// a new backup of the collection doesn't change the history
+	reloaded.AddBackup(&backuppb.BackupInfo{Id: "b3", Name: "backup3", StartTime: 3, StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING})
+	reloaded.AddCollection(&backuppb.CollectionBackupInfo{Id: "b3", CollectionId: 1})
+	reloaded.AddPartition(&backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2})
+	reloaded.AddSegment(&backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 5})
+	assert.ElementsMatch(t, []int64{3, 4}, segmentIDs(reloaded.GetBackupHistory("b2")))
+	assert.ElementsMatch(t, []int64{3}, segmentIDs(reloaded.GetBackupHistory("b1")))
+}
+
+func TestTaskStorePersistOnStateChange(t *testing.T) {
+	store := memkv.NewMemoryKV()
+
+	meta := newMetaManager()
+	assert.NoError(t, meta.InitTaskStore(store, 0))
+	meta.AddBackup(&backuppb.BackupInfo{Id: "b1", Name: "backup1", StateCode: backuppb.BackupTaskStateCode_BACKUP_INITIAL})
+	meta.AddCollection(&backuppb.CollectionBackupInfo{Id: "b1", CollectionId: 1})
+	meta.UpdateCollection("b1", 1, setCollectionSize(10))
+	meta.UpdateBackup("b1", setSize(10))
+	persisted, err := store.Load(backupTaskKey("b1"))
+	assert.NoError(t, err)
+	assert.NotContains(t, persisted, `"collections":[{`)
+
+	// the task is rewritten on state transitions only, the progress in between is persisted by the checkpoints
+	meta.UpdateBackup("b1", setStateCode(backuppb.BackupTaskStateCode_BACKUP_EXECUTING))
+	persisted, err = store.Load(backupTaskKey("b1"))
+	assert.NoError(t, err)
+	assert.Contains(t, persisted, `"collections":[{`)
+}
+
+func TestTaskStoreHistorySize(t *testing.T) {
+	store := memkv.NewMemoryKV()
+
+	meta := newMetaManager()
+	assert.NoError(t, meta.InitTaskStore(store, 0))
+	for i, id := range []string{"b1", "b2", "b3"} {
+		meta.AddBackup(&backuppb.BackupInfo{Id: id, Name: id, StartTime: int64(i), StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS})
+	}
+	for i, id := range []string{"r1", "r2", "r3"} {
+		meta.AddRestoreTask(&backuppb.RestoreBackupTask{Id: id, StartTime: int64(i), StateCode: backuppb.RestoreTaskStateCode_SUCCESS})
+	}
+
+	// the oldest finished tasks are removed on reload
+	reloaded := newMetaManager()
+	assert.NoError(t, reloaded.InitTaskStore(store, 2))
+	assert.Nil(t, reloaded.GetBackupHistory("b1"))
+	assert.NotNil(t, reloaded.GetBackupHistory("b2"))
+	assert.Nil(t, reloaded.GetRestoreTask("r1"))
+	assert.NotNil(t, reloaded.GetRestoreTask("r2"))
+	keys, _, err := store.LoadWithPrefix("")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{backupTaskKey("b2"), backupTaskKey("b3"), restoreTaskKey("r2"), restoreTaskKey("r3")}, keys)
+
+	// running tasks are kept, the oldest finished one is removed once a new task is added
+	reloaded.AddBackup(&backuppb.BackupInfo{Id: "b4", Name: "b4", StartTime: 4, StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING})
+	assert.NotNil(t, reloaded.GetBackupHistory("b2"))
+	reloaded.UpdateBackup("b4", setStateCode(backuppb.BackupTaskStateCode_BACKUP_SUCCESS))
+	reloaded.AddBackup(&backuppb.BackupInfo{Id: "b5", Name: "b5", StartTime: 5, StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING})
+	assert.Nil(t, reloaded.GetBackupHistory("b2"))
+	assert.NotNil(t, reloaded.GetBackupHistory("b3"))
+	assert.NotNil(t, reloaded.GetBackup("b4"))
+	assert.NotNil(t, reloaded.GetBackup("b5"))
+}
+
+func TestRestoreProgressReload(t *testing.T) {
+	store := memkv.NewMemoryKV()
+
+	meta := newMetaManager()
+	assert.NoError(t, meta.InitTaskStore(store, 0))
+	meta.AddRestoreTask(&backuppb.RestoreBackupTask{
+		Id:        "r1",
+		StateCode: backuppb.RestoreTaskStateCode_EXECUTING,
+		CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{
+			{Id: "c1", CollBackup: &backuppb.CollectionBackupInfo{CollectionId: 1}},
+		},
+	})
+	meta.UpdateRestoreTask("r1", addFinishedRestoreGroup("c1", 2, "_default", 100))
+	meta.UpdateRestoreTask("r1", addFinishedRestoreL0Segment("c1", 2, "_default", 7))
+	meta.UpdateRestoreTask("r1", addFinishedRestoreL0Segment("c1", -1, "", 8))
+
+	// restart
+	reloaded := newMetaManager()
+	assert.NoError(t, reloaded.InitTaskStore(store, 0))
+	collTask := reloaded.GetRestoreTask("r1").GetCollectionRestoreTasks()[0]
+	assert.Equal(t, 1, len(collTask.GetPartitionRestoreTasks()))
+	assert.True(t, isRestoreGroupFinished(collTask, 2, 100))
+	assert.False(t, isRestoreGroupFinished(collTask, 2, 101))
+	assert.False(t, isRestoreGroupFinished(collTask, 3, 100))
+	assert.True(t, isRestoreL0SegmentFinished(collTask, 2, 7))
+	assert.True(t, isRestoreL0SegmentFinished(collTask, -1, 8))
+	assert.False(t, isRestoreL0SegmentFinished(collTask, -1, 7))
+}
@@ -0,0 +1,224 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// throttleConfigFromParams converts the throttle in config
+func throttleConfigFromParams(cfg paramtable.BackupConfig) *backuppb.ThrottleConfig {
+	config := &backuppb.ThrottleConfig{
+		BytesPerSecond:    cfg.ThrottleBytesPerSecond,
+		RequestsPerSecond: int32(cfg.ThrottleRequestsPerSecond),
+	}
+	for _, profile := range cfg.ThrottleProfiles {
+		config.Profiles = append(config.Profiles, &backuppb.ThrottleProfile{
+			Name:              profile.Name,
+			Start:             profile.Start,
+			End:               profile.End,
+			BytesPerSecond:    profile.BytesPerSecond,
+			RequestsPerSecond: int32(profile.RequestsPerSecond),
+		})
+	}
+	return config
+}
+
+// parseClock parses "HH:MM" into the minutes since midnight
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expect HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func validateThrottleConfig(config *backuppb.ThrottleConfig) error {
+	if config.GetBytesPerSecond() < 0 || config.GetRequestsPerSecond() < 0 {
+		return errors.New("limits of throttle should not be negative")
+	}
+	names := make(map[string]bool, len(config.GetProfiles()))
+	for _, profile := range config.GetProfiles() {
+		if profile.GetName() == "" {
+			return errors.New("empty name of throttle profile")
+		}
+		if names[profile.GetName()] {
+			return fmt.Errorf("duplicated throttle profile %s", profile.GetName())
+		}
+		names[profile.GetName()] = true
+		if _, err := parseClock(profile.GetStart()); err != nil {
+			return fmt.Errorf("throttle profile %s: %w", profile.GetName(), err)
+		}
+		if _, err := parseClock(profile.GetEnd()); err != nil {
+			return fmt.Errorf("throttle profile %s: %w", profile.GetName(), err)
+		}
+		if profile.GetBytesPerSecond() < 0 || profile.GetRequestsPerSecond() < 0 {
+			return fmt.Errorf("limits of throttle profile %s should not be negative", profile.GetName())
+		}
+	}
+	return nil
+}
+
+// activeThrottleProfile returns the first profile active at now, nil if none is active.
+// A profile is active from its start (inclusive) to its end (exclusive), it is active all day if start equals end.
+func activeThrottleProfile(config *backuppb.ThrottleConfig, now time.Time) *backuppb.ThrottleProfile {
+	minute := now.Hour()*60 + now.Minute()
+	for _, profile := range config.GetProfiles() {
+		start, err := parseClock(profile.GetStart())
+		if err != nil {
+			continue
+		}
+		end, err := parseClock(profile.GetEnd())
+		if err != nil {
+			continue
+		}
+		var active bool
+		switch {
+		case start == end:
+			active = true
+		case start < end:
+			active = minute >= start && minute < end
+		default:
+			// crosses midnight
+			active = minute >= start || minute < end
+		}
+		if active {
+			return profile
+		}
+	}
+	return nil
+}
+
+// refreshThrottle applies the limits active at now to the throttle, throttleMu should be held
+func (b *BackupContext) refreshThrottle(now time.Time) {
+	if b.throttle == nil {
+		b.throttleConfig = throttleConfigFromParams(b.params.BackupCfg)
+		b.throttle = storage.NewThrottle(0, 0)
+	}
+	bytesPerSecond, requestsPerSecond := b.throttleConfig.GetBytesPerSecond(), b.throttleConfig.GetRequestsPerSecond()
+	profileName := ""
+	if profile := activeThrottleProfile(b.throttleConfig, now); profile != nil {
+		bytesPerSecond, requestsPerSecond = profile.GetBytesPerSecond(), profile.GetRequestsPerSecond()
+		profileName = profile.GetName()
+	}
+	if profileName != b.throttleProfile {
+		log.Info("throttle profile changed",
+			zap.String("from", b.throttleProfile),
+			zap.String("to", profileName),
+			zap.Int64("bytesPerSecond", bytesPerSecond),
+			zap.Int32("requestsPerSecond", requestsPerSecond))
+		b.throttleProfile = profileName
+	}
+	b.throttle.SetLimits(bytesPerSecond, int(requestsPerSecond))
+}
+
+// getThrottle returns the throttle of the data copies with the limits active now
+func (b *BackupContext) getThrottle() *storage.Throttle {
+	b.throttleMu.Lock()
+	defer b.throttleMu.Unlock()
+	b.refreshThrottle(time.Now())
+	return b.throttle
+}
+
+// getThrottledStorageClient returns the storage client of the role limited by the throttle, it is used by the data copies of backup copy
+// and restore. The time-of-day profiles are checked on each call, so get it for each file instead of holding it.
+// Only the source of a copy is throttled, so that the bytes streamed are counted once.
+func (b *BackupContext) getThrottledStorageClient(role storage.StorageRole) storage.ChunkManager {
+	return storage.NewThrottledChunkManager(b.getStorageClient(role), b.getThrottle())
+}
+
+// throttleInfo returns the throttle config and the limits active now
+func (b *BackupContext) throttleInfo() *backuppb.ThrottleInfo {
+	b.throttleMu.Lock()
+	defer b.throttleMu.Unlock()
+	b.refreshThrottle(time.Now())
+	bytesPerSecond, requestsPerSecond := b.throttle.Limits()
+	return &backuppb.ThrottleInfo{
+		Config:            proto.Clone(b.throttleConfig).(*backuppb.ThrottleConfig),
+		ActiveProfile:     b.throttleProfile,
+		BytesPerSecond:    bytesPerSecond,
+		RequestsPerSecond: int32(requestsPerSecond),
+	}
+}
+
+func (b *BackupContext) GetThrottle(ctx context.Context, request *backuppb.GetThrottleRequest) *backuppb.ThrottleResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive GetThrottleRequest", zap.String("requestId", request.GetRequestId()))
+
+	resp := &backuppb.ThrottleResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = b.throttleInfo()
+	return resp
+}
+
+func (b *BackupContext) UpdateThrottle(ctx context.Context, request *backuppb.UpdateThrottleRequest) *backuppb.ThrottleResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive UpdateThrottleRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("throttle", request.GetThrottle().String()))
+
+	resp := &backuppb.ThrottleResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetThrottle() == nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty throttle"
+		return resp
+	}
+	if err := validateThrottleConfig(request.GetThrottle()); err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	b.throttleMu.Lock()
+	if b.throttle == nil {
+		b.throttle = storage.NewThrottle(0, 0)
+	}
+	b.throttleConfig = proto.Clone(request.GetThrottle()).(*backuppb.ThrottleConfig)
+	b.throttleMu.Unlock()
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Data = b.throttleInfo()
+	resp.Msg = fmt.Sprintf("update throttle, bytes per second: %d, requests per second: %d, active profile: %s",
+		resp.Data.GetBytesPerSecond(), resp.Data.GetRequestsPerSecond(), resp.Data.GetActiveProfile())
+	log.Info("finish UpdateThrottle", zap.String("requestId", request.GetRequestId()), zap.String("msg", resp.Msg))
+	return resp
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/util/compression"
+)
+
+func TestActiveThrottleProfile(t *testing.T) {
+	config := &backuppb.ThrottleConfig{
+		Profiles: []*backuppb.ThrottleProfile{
+			{Name: "business", Start: "08:00", End: "20:00"},
+			{Name: "night", Start: "22:00", End: "06:00"},
+		},
+	}
+	at := func(clock string) string {
+		now, err := time.Parse("15:04", clock)
+		assert.NoError(t, err)
+		return activeThrottleProfile(config, now).GetName()
+	}
+	assert.Equal(t, "business", at("08:00"))
+	assert.Equal(t, "business", at("19:59"))
+	assert.Equal(t, "", at("20:00"))
+	assert.Equal(t, "", at("06:00"))
+	assert.Equal(t, "night", at("23:30"))
+	assert.Equal(t, "night", at("05:59"))
+
+	// the first active profile takes effect
+	config.Profiles = append([]*backuppb.ThrottleProfile{{Name: "all", Start: "00:00", End: "00:00"}}, config.Profiles...)
+	assert.Equal(t, "all", at("12:00"))
+}
+
+func TestUpdateThrottle(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+	b.params.BackupCfg.ThrottleBytesPerSecond = 1024
+
+	resp := b.GetThrottle(ctx, &backuppb.GetThrottleRequest{})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, int64(1024), resp.GetData().GetBytesPerSecond())
+	assert.Equal(t, int32(0), resp.GetData().GetRequestsPerSecond())
+
+	for _, invalid := range []*backuppb.ThrottleConfig{
+		nil,
+		{BytesPerSecond: -1},
+		{Profiles: []*backuppb.ThrottleProfile{{Start: "08:00", End: "20:00"}}},
+		{Profiles: []*backuppb.ThrottleProfile{{Name: "a", Start: "8 am", End: "20:00"}}},
+		{Profiles: []*backuppb.ThrottleProfile{{Name: "a", Start: "08:00", End: "20:00"}, {Name: "a", Start: "22:00", End: "06:00"}}},
+	} {
+		resp = b.UpdateThrottle(ctx, &backuppb.UpdateThrottleRequest{Throttle: invalid})
+		assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	}
+
+	resp = b.UpdateThrottle(ctx, &backuppb.UpdateThrottleRequest{Throttle: &backuppb.ThrottleConfig{
+		BytesPerSecond: 2048,
+		Profiles:       []*backuppb.ThrottleProfile{{Name: "all", Start: "00:00", End: "00:00", RequestsPerSecond: 10}},
+	}})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, "all", resp.GetData().GetActiveProfile())
+	assert.Equal(t, int64(0), resp.GetData().GetBytesPerSecond())
+	assert.Equal(t, int32(10), resp.GetData().GetRequestsPerSecond())
+	bytesPerSecond, requestsPerSecond := b.getThrottle().Limits()
+	assert.Equal(t, int64(0), bytesPerSecond)
+	assert.Equal(t, 10, requestsPerSecond)
+	assert.Equal(t, int64(2048), b.GetThrottle(ctx, &backuppb.GetThrottleRequest{}).GetData().GetConfig().GetBytesPerSecond())
+}
+
+func TestThrottledCopyCountsOnce(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+	content := make([]byte, 1000)
+	_, err := rand.Read(content)
+	assert.NoError(t, err)
+	binlogPath := b.milvusRootPath + "/insert_log/1/2/3/4/5"
+	assert.NoError(t, b.getStorageClient(storage.MilvusStorage).Write(ctx, b.milvusBucketName, binlogPath, content))
+
+	// the burst of one second covers the binlog, it would wait for another second if the written bytes were counted again
+	b.params.BackupCfg.ThrottleBytesPerSecond = 1000
+	start := time.Now()
+	_, err = b.copyBinlogEncoded(ctx, nil, compression.Gzip, binlogPath, b.backupRootPath+"/throttled/binlogs/insert_log/1/2/3/3/4/5")
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// the checksum reads wait for the requests
+	b.UpdateThrottle(ctx, &backuppb.UpdateThrottleRequest{Throttle: &backuppb.ThrottleConfig{RequestsPerSecond: 1}})
+	_, err = b.fileChecksum(ctx, storage.MilvusStorage, b.milvusBucketName, binlogPath)
+	assert.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = b.fileChecksum(timeoutCtx, storage.MilvusStorage, b.milvusBucketName, binlogPath)
+	assert.Error(t, err)
+}
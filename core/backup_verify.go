@@ -0,0 +1,392 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+const CHECKSUM_MANIFEST_FILE = "checksum_manifest.json"
+
+// ChecksumManifest records the size and checksum of every binlog copied into a backup, as it is stored in the backup bucket.
+// The key of Files is the path relative to the backup directory, e.g. binlogs/insert_log/1/2/3/4/100/5
+type ChecksumManifest struct {
+	Files map[string]FileChecksum `json:"files"`
+}
+
+type FileChecksum struct {
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+func ChecksumManifestPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + CHECKSUM_MANIFEST_FILE
+}
+
+// fileChecksum reads the file of the storage of the role to compute its size and checksum, the read is throttled like the data copies
+func (b *BackupContext) fileChecksum(ctx context.Context, role storage.StorageRole, bucketName, filePath string) (FileChecksum, error) {
+	reader, err := b.getThrottledStorageClient(role).Reader(ctx, bucketName, filePath)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	defer reader.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return FileChecksum{}, err
+	}
+	return FileChecksum{Size: size, Sha256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// checksumReader computes the size and checksum of the content read through it
+type checksumReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
+
+func newChecksumReader(reader io.Reader) *checksumReader {
+	return &checksumReader{reader: reader, hash: sha256.New()}
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *checksumReader) checksum() FileChecksum {
+	return FileChecksum{Size: r.size, Sha256: hex.EncodeToString(r.hash.Sum(nil))}
+}
+
+// recordFileChecksum records the checksum of a binlog copied into backup, which is computed from the content written while copying.
+// If checksumReadBack is enabled, the file is read back from the backup bucket instead, so that the checksum is of what is actually stored
+func (b *BackupContext) recordFileChecksum(ctx context.Context, backupID string, targetPath string, checksum FileChecksum) error {
+	_, relativePath := splitBackupBinlogPath(targetPath)
+	if !b.params.BackupCfg.ChecksumReadBack {
+		b.meta.AddFileChecksum(backupID, relativePath, checksum)
+		return nil
+	}
+	return retry.Do(ctx, func() error {
+		checksum, err := b.fileChecksum(ctx, storage.BackupStorage, b.backupBucketName, targetPath)
+		if err != nil {
+			return err
+		}
+		b.meta.AddFileChecksum(backupID, relativePath, checksum)
+		return nil
+	}, b.storageRetryOptions()...)
+}
+
+// readChecksumManifest reads the checksum manifest of the backup, return nil if the backup doesn't have one
+func (b *BackupContext) readChecksumManifest(ctx context.Context, bucketName, backupPath string) (*ChecksumManifest, error) {
+	manifestPath := backupPath + SEPERATOR + META_PREFIX + SEPERATOR + CHECKSUM_MANIFEST_FILE
+	exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	bytes, err := b.getStorageClient(storage.BackupStorage).Read(ctx, bucketName, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ChecksumManifest{}
+	err = json.Unmarshal(bytes, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (b *BackupContext) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) *backuppb.VerifyBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive VerifyBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()),
+		zap.String("bucketName", request.GetBucketName()),
+		zap.String("path", request.GetPath()),
+		zap.Bool("checksum", request.GetChecksum()))
+
+	resp := &backuppb.VerifyBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	var backupBucketName string
+	var backupPath string
+	if request.GetBucketName() == "" || request.GetPath() == "" {
+		backupBucketName = b.backupBucketName
+		backupPath = b.backupRootPath + SEPERATOR + request.GetBackupName()
+	} else {
+		backupBucketName = request.GetBucketName()
+		backupPath = request.GetPath() + SEPERATOR + request.GetBackupName()
+	}
+	backup, err := b.readBackup(ctx, backupBucketName, backupPath)
+	if err != nil {
+		log.Error("fail to read backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if backup == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = "not found backup " + request.GetBackupName()
+		return resp
+	}
+
+	result, err := b.verifyBackup(ctx, backupBucketName, backupPath, backup, request.GetChecksum())
+	if err != nil {
+		log.Error("fail to verify backup", zap.String("backupPath", backupPath), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Data = result
+	if len(result.GetProblems()) == 0 {
+		resp.Msg = fmt.Sprintf("backup %s is intact, %d files checked", backup.GetName(), result.GetCheckedFiles())
+	} else {
+		resp.Msg = fmt.Sprintf("backup %s is corrupted, %d of %d files failed the verification", backup.GetName(), len(result.GetProblems()), result.GetCheckedFiles())
+	}
+	log.Info("finish VerifyBackup", zap.String("requestId", request.GetRequestId()), zap.String("msg", resp.Msg))
+	return resp
+}
+
+// backupFiles holds what is known about the binlogs of a backup, the backup itself or a backup referenced by it
+type backupFiles struct {
+	// actual sizes of the files under the binlog dir, keyed by path
+	sizes     map[string]int64
+	checksums *ChecksumManifest
+	blobs     *BlobManifest
+}
+
+// verifyBackup checks every binlog of the backup, including the ones in referenced backups, against the checksum manifest,
+// and reports the files in the binlog dir of the backup recorded by neither the manifest nor the segments.
+// Binlogs of deduplicated backups are checked against their blobs.
+func (b *BackupContext) verifyBackup(ctx context.Context, bucketName, backupPath string, backup *backuppb.BackupInfo, checksum bool) (*backuppb.VerifyBackupResult, error) {
+	filesOfBackups := make(map[string]*backupFiles, 0)
+	getFiles := func(path string) (*backupFiles, error) {
+		if files, ok := filesOfBackups[path]; ok {
+			return files, nil
+		}
+		keys, sizes, err := b.getStorageClient(storage.BackupStorage).ListWithPrefix(ctx, bucketName, path+SEPERATOR+BINGLOG_DIR+SEPERATOR, true)
+		if err != nil {
+			return nil, err
+		}
+		files := &backupFiles{sizes: make(map[string]int64, len(keys))}
+		for i, key := range keys {
+			files.sizes[key] = sizes[i]
+		}
+		files.checksums, err = b.readChecksumManifest(ctx, bucketName, path)
+		if err != nil {
+			return nil, err
+		}
+		files.blobs, err = b.readBlobManifest(ctx, bucketName, path)
+		if err != nil {
+			return nil, err
+		}
+		filesOfBackups[path] = files
+		return files, nil
+	}
+
+	result := &backuppb.VerifyBackupResult{
+		BackupName: backup.GetName(),
+		Problems:   make([]*backuppb.FileVerifyResult, 0),
+	}
+	// files of the backup itself already checked
+	checked := make(map[string]bool, 0)
+
+	// verifyFile compares the file with the recorded size and checksum, an empty sha256 means the checksum is not recorded
+	verifyFile := func(filePath string, expectedSize int64, expectedSha256 string, actualSize int64, exist bool) (*backuppb.FileVerifyResult, error) {
+		result.CheckedFiles++
+		problem := &backuppb.FileVerifyResult{
+			Path:         filePath,
+			ExpectedSize: expectedSize,
+			ActualSize:   actualSize,
+		}
+		switch {
+		case !exist:
+			problem.State = backuppb.FileVerifyState_FILE_MISSING
+			problem.ActualSize = -1
+			return problem, nil
+		case expectedSize < 0:
+			result.UnrecordedFiles++
+			return nil, nil
+		case actualSize < expectedSize:
+			problem.State = backuppb.FileVerifyState_FILE_TRUNCATED
+			return problem, nil
+		case actualSize > expectedSize:
+			problem.State = backuppb.FileVerifyState_FILE_MISMATCHED
+			return problem, nil
+		}
+		if checksum && expectedSha256 != "" {
+			actual, err := b.fileChecksum(ctx, storage.BackupStorage, bucketName, filePath)
+			if err != nil {
+				return nil, err
+			}
+			if actual.Sha256 != expectedSha256 {
+				problem.State = backuppb.FileVerifyState_FILE_MISMATCHED
+				return problem, nil
+			}
+		}
+		return nil, nil
+	}
+
+	verifySegment := func(collection *backuppb.CollectionBackupInfo, segment *backuppb.SegmentBackupInfo) error {
+		segmentBackupPath := RefBackupPath(backupPath, segment.GetRefBackup())
+		files, err := getFiles(segmentBackupPath)
+		if err != nil {
+			return err
+		}
+		fieldBinlogs := make([]*backuppb.FieldBinlog, 0, len(segment.GetBinlogs())+len(segment.GetDeltalogs()))
+		fieldBinlogs = append(fieldBinlogs, segment.GetBinlogs()...)
+		fieldBinlogs = append(fieldBinlogs, segment.GetDeltalogs()...)
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				relativePath := backupBinlogRelativePath(binlog.GetLogPath(), segment.GetGroupId())
+				filePath := segmentBackupPath + SEPERATOR + relativePath
+				var problem *backuppb.FileVerifyResult
+				if ref, ok := files.blobs.getRef(relativePath); ok {
+					// deduplicated backup, the binlog is stored as a blob
+					blobPath := BlobPath(segmentBackupPath[:strings.LastIndex(segmentBackupPath, SEPERATOR)], ref.Hash)
+					exist, err := b.getStorageClient(storage.BackupStorage).Exist(ctx, bucketName, blobPath)
+					if err != nil {
+						return err
+					}
+					var size int64
+					if exist {
+						size, err = b.getStorageClient(storage.BackupStorage).Size(ctx, bucketName, blobPath)
+						if err != nil {
+							return err
+						}
+					}
+					problem, err = verifyFile(blobPath, ref.Size, ref.Hash, size, exist)
+					if err != nil {
+						return err
+					}
+				} else {
+					if segmentBackupPath == backupPath {
+						checked[filePath] = true
+					}
+					size, exist := files.sizes[filePath]
+					expected, recorded := files.checksums.getChecksum(relativePath)
+					if !recorded {
+						expected.Size = -1
+					}
+					problem, err = verifyFile(filePath, expected.Size, expected.Sha256, size, exist)
+					if err != nil {
+						return err
+					}
+				}
+				if problem != nil {
+					problem.CollectionId = collection.GetCollectionId()
+					problem.CollectionName = collection.GetCollectionName()
+					problem.PartitionId = segment.GetPartitionId()
+					problem.SegmentId = segment.GetSegmentId()
+					result.Problems = append(result.Problems, problem)
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, collection := range backup.GetCollectionBackups() {
+		for _, segment := range collection.GetL0Segments() {
+			if err := verifySegment(collection, segment); err != nil {
+				return nil, err
+			}
+		}
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				if err := verifySegment(collection, segment); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	files, err := getFiles(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	// files recorded by the manifest but not by the segments
+	if files.checksums != nil {
+		for relativePath, expected := range files.checksums.Files {
+			filePath := backupPath + SEPERATOR + relativePath
+			if checked[filePath] {
+				continue
+			}
+			checked[filePath] = true
+			size, exist := files.sizes[filePath]
+			problem, err := verifyFile(filePath, expected.Size, expected.Sha256, size, exist)
+			if err != nil {
+				return nil, err
+			}
+			if problem != nil {
+				result.Problems = append(result.Problems, problem)
+			}
+		}
+	}
+	for filePath, size := range files.sizes {
+		if !checked[filePath] {
+			result.Problems = append(result.Problems, &backuppb.FileVerifyResult{
+				Path:         filePath,
+				State:        backuppb.FileVerifyState_FILE_EXTRA,
+				ExpectedSize: -1,
+				ActualSize:   size,
+			})
+		}
+	}
+	sort.Slice(result.Problems, func(i, j int) bool {
+		return result.Problems[i].GetPath() < result.Problems[j].GetPath()
+	})
+	return result, nil
+}
+
+func (m *ChecksumManifest) getChecksum(relativePath string) (FileChecksum, bool) {
+	if m == nil {
+		return FileChecksum{}, false
+	}
+	checksum, ok := m.Files[relativePath]
+	return checksum, ok
+}
+
+func (m *BlobManifest) getRef(relativePath string) (BlobRef, bool) {
+	if m == nil {
+		return BlobRef{}, false
+	}
+	ref, ok := m.Files[relativePath]
+	return ref, ok
+}
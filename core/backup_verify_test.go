@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func TestVerifyBackup(t *testing.T) {
+	ctx := context.Background()
+	b := newEncryptionTestContext(t)
+	backupPath := b.backupRootPath + SEPERATOR + "verify"
+	binlogDir := backupPath + "/binlogs/insert_log/1/2/3/3/4/"
+
+	binlogs := make([]*backuppb.Binlog, 0)
+	for _, logID := range []string{"5", "6", "7", "8", "10"} {
+		binlogs = append(binlogs, &backuppb.Binlog{LogPath: b.milvusRootPath + "/insert_log/1/2/3/4/" + logID})
+	}
+	backup := &backuppb.BackupInfo{
+		Name: "verify",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			CollectionName: "coll",
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId: 2,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{
+					PartitionId: 2,
+					SegmentId:   4,
+					GroupId:     3,
+					Binlogs:     []*backuppb.FieldBinlog{{Binlogs: binlogs}},
+				}},
+			}},
+		}},
+	}
+
+	write := func(path string, content string) {
+		assert.NoError(t, b.getStorageClient(storage.BackupStorage).Write(ctx, b.backupBucketName, path, []byte(content)))
+	}
+	for _, logID := range []string{"5", "6", "7", "8"} {
+		write(binlogDir+logID, "binlog "+logID)
+	}
+	// recorded checksums of the files as they were copied
+	manifest := &ChecksumManifest{Files: make(map[string]FileChecksum, 0)}
+	for _, logID := range []string{"5", "6", "7", "8"} {
+		checksum, err := b.fileChecksum(ctx, storage.BackupStorage, b.backupBucketName, binlogDir+logID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len("binlog "+logID)), checksum.Size)
+		manifest.Files["binlogs/insert_log/1/2/3/3/4/"+logID] = checksum
+	}
+	assert.NoError(t, b.getStorageClient(storage.BackupStorage).Remove(ctx, b.backupBucketName, binlogDir+"6"))
+	write(binlogDir+"7", "binlog")
+	write(binlogDir+"8", "binlog x")
+	// not recorded by the manifest
+	write(binlogDir+"10", "binlog 10")
+	// recorded by neither the manifest nor the segments
+	write(binlogDir+"99", "binlog 99")
+	bytes, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	write(ChecksumManifestPath(b.backupRootPath, "verify"), string(bytes))
+
+	states := func(result *backuppb.VerifyBackupResult) map[string]backuppb.FileVerifyState {
+		dict := make(map[string]backuppb.FileVerifyState, 0)
+		for _, problem := range result.GetProblems() {
+			dict[problem.GetPath()] = problem.GetState()
+		}
+		return dict
+	}
+
+	result, err := b.verifyBackup(ctx, b.backupBucketName, backupPath, backup, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), result.GetCheckedFiles())
+	assert.Equal(t, int64(1), result.GetUnrecordedFiles())
+	assert.Equal(t, map[string]backuppb.FileVerifyState{
+		binlogDir + "6":  backuppb.FileVerifyState_FILE_MISSING,
+		binlogDir + "7":  backuppb.FileVerifyState_FILE_TRUNCATED,
+		binlogDir + "99": backuppb.FileVerifyState_FILE_EXTRA,
+	}, states(result))
+	for _, problem := range result.GetProblems() {
+		if problem.GetState() != backuppb.FileVerifyState_FILE_EXTRA {
+			assert.Equal(t, "coll", problem.GetCollectionName())
+			assert.Equal(t, int64(4), problem.GetSegmentId())
+		}
+	}
+
+	result, err = b.verifyBackup(ctx, b.backupBucketName, backupPath, backup, true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]backuppb.FileVerifyState{
+		binlogDir + "6":  backuppb.FileVerifyState_FILE_MISSING,
+		binlogDir + "7":  backuppb.FileVerifyState_FILE_TRUNCATED,
+		binlogDir + "8":  backuppb.FileVerifyState_FILE_MISMATCHED,
+		binlogDir + "99": backuppb.FileVerifyState_FILE_EXTRA,
+	}, states(result))
+}
@@ -2,9 +2,12 @@ package core
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/msgpb"
 	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
@@ -195,3 +198,126 @@ func (m *MilvusClient) DropIndex(ctx context.Context, db, collName string, index
 	}
 	return m.client.DropIndex(ctx, collName, "", gomilvus.WithIndexName(indexName))
 }
+
+func (m *MilvusClient) GetLoadState(ctx context.Context, db, collName string, partitionNames []string) (entity.LoadState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return entity.LoadStateNotExist, err
+	}
+	return m.client.GetLoadState(ctx, collName, partitionNames)
+}
+
+func (m *MilvusClient) GetCollectionStatistics(ctx context.Context, db, collName string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.GetCollectionStatistics(ctx, collName)
+}
+
+func (m *MilvusClient) Query(ctx context.Context, db, collName string, partitionNames []string, expr string, outputFields []string, opts ...gomilvus.SearchQueryOptionFunc) (gomilvus.ResultSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.Query(ctx, collName, partitionNames, expr, outputFields, opts...)
+}
+
+func (m *MilvusClient) QueryByPks(ctx context.Context, db, collName string, partitionNames []string, ids entity.Column, outputFields []string, opts ...gomilvus.SearchQueryOptionFunc) (gomilvus.ResultSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.QueryByPks(ctx, collName, partitionNames, ids, outputFields, opts...)
+}
+
+func (m *MilvusClient) GetIndexState(ctx context.Context, db, collName string, fieldName string, indexName string) (entity.IndexState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	return m.client.GetIndexState(ctx, collName, fieldName, gomilvus.WithIndexName(indexName))
+}
+
+func (m *MilvusClient) LoadCollection(ctx context.Context, db, collName string, replicaNumber int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	if replicaNumber > 0 {
+		return m.client.LoadCollection(ctx, collName, true, gomilvus.WithReplicaNumber(replicaNumber))
+	}
+	return m.client.LoadCollection(ctx, collName, true)
+}
+
+func (m *MilvusClient) LoadPartitions(ctx context.Context, db, collName string, partitionNames []string, replicaNumber int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	if replicaNumber > 0 {
+		return m.client.LoadPartitions(ctx, collName, partitionNames, true, func(req *milvuspb.LoadPartitionsRequest) {
+			req.ReplicaNumber = replicaNumber
+		})
+	}
+	return m.client.LoadPartitions(ctx, collName, partitionNames, true)
+}
+
+// ListAliases returns the aliases of the collection, they are read from the describe collection response as the sdk doesn't expose them
+func (m *MilvusClient) ListAliases(ctx context.Context, db, collName string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	grpcClient, ok := m.client.(*gomilvus.GrpcClient)
+	if !ok {
+		return nil, errors.New("list aliases is not supported by the milvus client")
+	}
+	resp, err := grpcClient.Service.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{
+		DbName:         db,
+		CollectionName: collName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, errors.New(resp.GetStatus().GetReason())
+	}
+	return resp.GetAliases(), nil
+}
+
+func (m *MilvusClient) CreateAlias(ctx context.Context, db, collName string, alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	return m.client.CreateAlias(ctx, collName, alias)
+}
+
+func (m *MilvusClient) AlterAlias(ctx context.Context, db, collName string, alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.client.UsingDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	return m.client.AlterAlias(ctx, collName, alias)
+}
@@ -1,7 +1,12 @@
 package paramtable
 
 import (
+	"fmt"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // BackupParams
@@ -40,11 +45,114 @@ type BackupConfig struct {
 
 	KeepTempFiles bool
 
+	// primary keys sampled from each partition to verify a restore, 0 disables the sampling
+	RestoreVerifySampleSize int
+
 	GcPauseEnable  bool
 	GcPauseSeconds int
 	GcPauseAddress string
+
+	DedupEnable bool
+
+	// read the binlogs back from the backup storage to record their checksums, rather than hashing them while copying
+	ChecksumReadBack bool
+
+	EncryptionEnable  bool
+	EncryptionKeyFile string
+	EncryptionKeyEnv  string
+
+	CompressionCodec string
+	CompressBinlog   bool
+
+	TaskStoreType        string
+	TaskStorePath        string
+	TaskStoreHistorySize int
+
+	// local directory the archives of the export and import APIs are confined to
+	ArchiveDir string
+
+	Schedules         []ScheduleConfig
+	RetentionPolicies []RetentionConfig
+	CopyTargets       []CopyTargetConfig
+
+	// limits of the data copies, 0 means no limit
+	ThrottleBytesPerSecond    int64
+	ThrottleRequestsPerSecond int
+	ThrottleProfiles          []ThrottleProfileConfig
+
+	// faults injected into the storage, only for testing
+	Faults []FaultConfig
+}
+
+// ScheduleConfig is a backup schedule defined in config, it is keyed by its name under backup.schedules
+type ScheduleConfig struct {
+	Name          string
+	Cron          string
+	DbCollections string
+	NameTemplate  string
+	MetaOnly      bool
+	Force         bool
+}
+
+// RetentionConfig is a retention policy defined in config, it is keyed by its name under backup.retention.
+// Backups selected by DbCollections are kept if any of the keep rules keeps them, and the total size of them is limited by MaxSize.
+type RetentionConfig struct {
+	Name          string
+	DbCollections string
+	// keep the latest n backups
+	KeepLast int
+	// keep the latest backup of each day in the last n days
+	KeepDaily int
+	// keep the latest backup of each week in the last n weeks
+	KeepWeekly int
+	// max total size of the kept backups in bytes, 0 means no limit
+	MaxSize int64
+}
+
+// CopyTargetConfig is a storage to copy backups to, it is keyed by its name under backup.copyTargets.
+// Backups are copied into BucketName/RootPath of the storage.
+type CopyTargetConfig struct {
+	Name            string
+	StorageType     string
+	Address         string
+	Port            string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	UseIAM          bool
+	IAMEndpoint     string
+	BucketName      string
+	RootPath        string
+}
+
+// ThrottleProfileConfig overrides the throttle limits between Start and End of every day, it is keyed by its name under backup.throttle.profiles.
+// Start and End are "HH:MM" in the local time of the server, a profile crossing midnight has an End earlier than its Start.
+type ThrottleProfileConfig struct {
+	Name              string
+	Start             string
+	End               string
+	BytesPerSecond    int64
+	RequestsPerSecond int
 }
 
+// FaultConfig is a rule of the faults injected into the storage to test the resilience, it is keyed by its name under
+// backup.faults, or set by the env BACKUP_FAULTS. See storage.FaultRule for the fields.
+type FaultConfig struct {
+	Name             string
+	Operations       []string
+	PathPattern      string
+	Latency          time.Duration
+	ErrorRate        float64
+	PartialWriteRate float64
+	MissingRate      float64
+	Skip             int
+	Times            int
+}
+
+// FaultsEnv sets the fault rules by env, rules are separated by ";" and their fields are key=value separated by spaces,
+// keys are the same as the ones in config, such as "operations=Copy,Exist pathPattern=insert_log errorRate=0.5 times=3"
+const FaultsEnv = "BACKUP_FAULTS"
+
 func (p *BackupConfig) init(base *BaseTable) {
 	p.Base = base
 
@@ -53,9 +161,23 @@ func (p *BackupConfig) init(base *BaseTable) {
 	p.initRestoreParallelism()
 	p.initBackupCopyDataParallelism()
 	p.initKeepTempFiles()
+	p.initRestoreVerifySampleSize()
 	p.initGcPauseEnable()
 	p.initGcPauseSeconds()
 	p.initGcPauseAddress()
+	p.initDedupEnable()
+	p.initChecksumReadBack()
+	p.initEncryption()
+	p.initCompression()
+	p.initTaskStoreType()
+	p.initTaskStorePath()
+	p.initTaskStoreHistorySize()
+	p.initArchiveDir()
+	p.initSchedules()
+	p.initRetentionPolicies()
+	p.initCopyTargets()
+	p.initThrottle()
+	p.initFaults()
 }
 
 func (p *BackupConfig) initMaxSegmentGroupSize() {
@@ -86,6 +208,10 @@ func (p *BackupConfig) initKeepTempFiles() {
 	p.KeepTempFiles, _ = strconv.ParseBool(keepTempFiles)
 }
 
+func (p *BackupConfig) initRestoreVerifySampleSize() {
+	p.RestoreVerifySampleSize = p.Base.ParseIntWithDefault("backup.restoreVerify.sampleSize", 100)
+}
+
 func (p *BackupConfig) initGcPauseEnable() {
 	enable := p.Base.LoadWithDefault("backup.gcPause.enable", "false")
 	p.GcPauseEnable, _ = strconv.ParseBool(enable)
@@ -101,6 +227,249 @@ func (p *BackupConfig) initGcPauseAddress() {
 	p.GcPauseAddress = address
 }
 
+func (p *BackupConfig) initDedupEnable() {
+	enable := p.Base.LoadWithDefault("backup.dedup.enable", "false")
+	p.DedupEnable, _ = strconv.ParseBool(enable)
+}
+
+func (p *BackupConfig) initChecksumReadBack() {
+	readBack := p.Base.LoadWithDefault("backup.checksum.readBack", "false")
+	p.ChecksumReadBack, _ = strconv.ParseBool(readBack)
+}
+
+func (p *BackupConfig) initEncryption() {
+	enable := p.Base.LoadWithDefault("backup.encryption.enable", "false")
+	p.EncryptionEnable, _ = strconv.ParseBool(enable)
+	p.EncryptionKeyFile = p.Base.LoadWithDefault("backup.encryption.keyFile", "")
+	p.EncryptionKeyEnv = p.Base.LoadWithDefault("backup.encryption.keyEnv", "")
+}
+
+func (p *BackupConfig) initCompression() {
+	p.CompressionCodec = p.Base.LoadWithDefault("backup.compression.codec", "none")
+	compressBinlog := p.Base.LoadWithDefault("backup.compression.binlog", "false")
+	p.CompressBinlog, _ = strconv.ParseBool(compressBinlog)
+}
+
+func (p *BackupConfig) initTaskStoreType() {
+	p.TaskStoreType = p.Base.LoadWithDefault("backup.taskStore.type", "file")
+}
+
+func (p *BackupConfig) initTaskStorePath() {
+	p.TaskStorePath = p.Base.LoadWithDefault("backup.taskStore.path", "data/task_store")
+}
+
+func (p *BackupConfig) initTaskStoreHistorySize() {
+	p.TaskStoreHistorySize = p.Base.ParseIntWithDefault("backup.taskStore.historySize", 1000)
+}
+
+func (p *BackupConfig) initArchiveDir() {
+	p.ArchiveDir = p.Base.LoadWithDefault("backup.archive.dir", "data/archives")
+}
+
+// loadNamedConfigs groups the configs keyed by name under the prefix, the keys are flattened as <prefix>.<name>.<field>
+func (p *BackupConfig) loadNamedConfigs(prefix string) map[string]map[string]string {
+	keys, values, err := p.Base.LoadRange(prefix+".", prefix+"/", 0)
+	if err != nil {
+		panic(err)
+	}
+	configs := make(map[string]map[string]string)
+	for i, key := range keys {
+		splits := strings.SplitN(strings.TrimPrefix(key, prefix+"."), ".", 2)
+		if len(splits) != 2 {
+			continue
+		}
+		if _, ok := configs[splits[0]]; !ok {
+			configs[splits[0]] = make(map[string]string)
+		}
+		configs[splits[0]][splits[1]] = values[i]
+	}
+	return configs
+}
+
+func sortedNames(configs map[string]map[string]string) []string {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *BackupConfig) initSchedules() {
+	configs := p.loadNamedConfigs("backup.schedules")
+	p.Schedules = make([]ScheduleConfig, 0, len(configs))
+	for _, name := range sortedNames(configs) {
+		fields := configs[name]
+		schedule := ScheduleConfig{
+			Name:          name,
+			Cron:          fields["cron"],
+			DbCollections: fields["dbcollections"],
+			NameTemplate:  fields["nametemplate"],
+		}
+		schedule.MetaOnly, _ = strconv.ParseBool(fields["metaonly"])
+		schedule.Force, _ = strconv.ParseBool(fields["force"])
+		p.Schedules = append(p.Schedules, schedule)
+	}
+}
+
+func (p *BackupConfig) initRetentionPolicies() {
+	configs := p.loadNamedConfigs("backup.retention")
+	p.RetentionPolicies = make([]RetentionConfig, 0, len(configs))
+	for _, name := range sortedNames(configs) {
+		fields := configs[name]
+		policy := RetentionConfig{
+			Name:          name,
+			DbCollections: fields["dbcollections"],
+		}
+		policy.KeepLast = p.Base.ParseIntWithDefault("backup.retention."+name+".keepLast", 0)
+		policy.KeepDaily = p.Base.ParseIntWithDefault("backup.retention."+name+".keepDaily", 0)
+		policy.KeepWeekly = p.Base.ParseIntWithDefault("backup.retention."+name+".keepWeekly", 0)
+		maxSize, err := p.Base.ParseDataSizeWithDefault("backup.retention."+name+".maxSize", "0")
+		if err != nil {
+			panic(err)
+		}
+		policy.MaxSize = maxSize
+		p.RetentionPolicies = append(p.RetentionPolicies, policy)
+	}
+}
+
+func (p *BackupConfig) initCopyTargets() {
+	configs := p.loadNamedConfigs("backup.copyTargets")
+	p.CopyTargets = make([]CopyTargetConfig, 0, len(configs))
+	for _, name := range sortedNames(configs) {
+		fields := configs[name]
+		target := CopyTargetConfig{
+			Name:            name,
+			StorageType:     fields["storagetype"],
+			Address:         fields["address"],
+			Port:            fields["port"],
+			AccessKeyID:     fields["accesskeyid"],
+			SecretAccessKey: fields["secretaccesskey"],
+			IAMEndpoint:     fields["iamendpoint"],
+			BucketName:      fields["bucketname"],
+			RootPath:        fields["rootpath"],
+		}
+		if target.StorageType == "" {
+			target.StorageType = Minio
+		}
+		if !supportedStorageType[target.StorageType] {
+			panic("unsupported storage type of copy target " + name + ": " + target.StorageType)
+		}
+		target.UseSSL, _ = strconv.ParseBool(fields["usessl"])
+		target.UseIAM, _ = strconv.ParseBool(fields["useiam"])
+		p.CopyTargets = append(p.CopyTargets, target)
+	}
+}
+
+func (p *BackupConfig) initThrottle() {
+	bytesPerSecond, err := p.Base.ParseDataSizeWithDefault("backup.throttle.bytesPerSecond", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.ThrottleBytesPerSecond = bytesPerSecond
+	p.ThrottleRequestsPerSecond = p.Base.ParseIntWithDefault("backup.throttle.requestsPerSecond", 0)
+
+	configs := p.loadNamedConfigs("backup.throttle.profiles")
+	p.ThrottleProfiles = make([]ThrottleProfileConfig, 0, len(configs))
+	for _, name := range sortedNames(configs) {
+		fields := configs[name]
+		profile := ThrottleProfileConfig{
+			Name:  name,
+			Start: fields["start"],
+			End:   fields["end"],
+		}
+		bytesPerSecond, err := p.Base.ParseDataSizeWithDefault("backup.throttle.profiles."+name+".bytesPerSecond", "0")
+		if err != nil {
+			panic(err)
+		}
+		profile.BytesPerSecond = bytesPerSecond
+		profile.RequestsPerSecond = p.Base.ParseIntWithDefault("backup.throttle.profiles."+name+".requestsPerSecond", 0)
+		p.ThrottleProfiles = append(p.ThrottleProfiles, profile)
+	}
+}
+
+func (p *BackupConfig) initFaults() {
+	configs := p.loadNamedConfigs("backup.faults")
+	p.Faults = make([]FaultConfig, 0, len(configs))
+	for _, name := range sortedNames(configs) {
+		fault, err := parseFaultConfig(name, configs[name])
+		if err != nil {
+			panic(err)
+		}
+		p.Faults = append(p.Faults, fault)
+	}
+	faults, err := parseFaultsEnv(os.Getenv(FaultsEnv))
+	if err != nil {
+		panic(err)
+	}
+	p.Faults = append(p.Faults, faults...)
+}
+
+// parseFaultsEnv parses the fault rules set by env, they are named env-<index>
+func parseFaultsEnv(value string) ([]FaultConfig, error) {
+	faults := make([]FaultConfig, 0)
+	for i, rule := range strings.Split(value, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		fields := make(map[string]string)
+		for _, field := range strings.Fields(rule) {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid field %q of %s, expect key=value", field, FaultsEnv)
+			}
+			fields[strings.ToLower(kv[0])] = kv[1]
+		}
+		fault, err := parseFaultConfig(fmt.Sprintf("env-%d", i), fields)
+		if err != nil {
+			return nil, err
+		}
+		faults = append(faults, fault)
+	}
+	return faults, nil
+}
+
+// parseFaultConfig parses the fields of a fault rule keyed by their lowercase names
+func parseFaultConfig(name string, fields map[string]string) (FaultConfig, error) {
+	fault := FaultConfig{
+		Name:        name,
+		PathPattern: fields["pathpattern"],
+	}
+	for _, op := range strings.Split(fields["operations"], ",") {
+		if op = strings.TrimSpace(op); op != "" {
+			fault.Operations = append(fault.Operations, op)
+		}
+	}
+	var err error
+	if value := fields["latency"]; value != "" {
+		if fault.Latency, err = time.ParseDuration(value); err != nil {
+			return fault, fmt.Errorf("invalid latency of fault %s: %w", name, err)
+		}
+	}
+	for key, rate := range map[string]*float64{
+		"errorrate":        &fault.ErrorRate,
+		"partialwriterate": &fault.PartialWriteRate,
+		"missingrate":      &fault.MissingRate,
+	} {
+		if value := fields[key]; value != "" {
+			if *rate, err = strconv.ParseFloat(value, 64); err != nil {
+				return fault, fmt.Errorf("invalid %s of fault %s: %w", key, name, err)
+			}
+		}
+	}
+	for key, count := range map[string]*int{
+		"skip":  &fault.Skip,
+		"times": &fault.Times,
+	} {
+		if value := fields[key]; value != "" {
+			if *count, err = strconv.Atoi(value); err != nil {
+				return fault, fmt.Errorf("invalid %s of fault %s: %w", key, name, err)
+			}
+		}
+	}
+	return fault, nil
+}
+
 type MilvusConfig struct {
 	Base *BaseTable
 
@@ -167,6 +536,7 @@ func (p *MilvusConfig) initTLSMode() {
 // --- minio ---
 const (
 	Local                     = "local"
+	Memory                    = "memory"
 	Minio                     = "minio"
 	S3                        = "s3"
 	CloudProviderAWS          = "aws"
@@ -180,6 +550,7 @@ const (
 
 var supportedStorageType = map[string]bool{
 	Local:                     true,
+	Memory:                    true,
 	Minio:                     true,
 	S3:                        true,
 	CloudProviderAWS:          true,
@@ -211,6 +582,16 @@ type MinioConfig struct {
 	BackupRootPath        string
 
 	StorageType string
+
+	// CrossStorage puts the backup storage on its own provider or account,
+	// the backup storage is accessed by the backup configs below, which default to the milvus storage ones
+	CrossStorage      bool
+	BackupStorageType string
+	BackupAddress     string
+	BackupPort        string
+	BackupUseSSL      bool
+	BackupUseIAM      bool
+	BackupIAMEndpoint string
 }
 
 func (p *MinioConfig) init(base *BaseTable) {
@@ -232,6 +613,14 @@ func (p *MinioConfig) init(base *BaseTable) {
 	p.initBackupSecretAccessKey()
 	p.initBackupBucketName()
 	p.initBackupRootPath()
+
+	p.initCrossStorage()
+	p.initBackupStorageType()
+	p.initBackupAddress()
+	p.initBackupPort()
+	p.initBackupUseSSL()
+	p.initBackupUseIAM()
+	p.initBackupIAMEndpoint()
 }
 
 func (p *MinioConfig) initAddress() {
@@ -310,6 +699,45 @@ func (p *MinioConfig) initBackupRootPath() {
 	p.BackupRootPath = rootPath
 }
 
+func (p *MinioConfig) initCrossStorage() {
+	crossStorage := p.Base.LoadWithDefault("minio.crossStorage", "false")
+	p.CrossStorage, _ = strconv.ParseBool(crossStorage)
+}
+
+func (p *MinioConfig) initBackupStorageType() {
+	engine := p.Base.LoadWithDefault("minio.backupStorageType", p.StorageType)
+	if !supportedStorageType[engine] {
+		panic("unsupported backup storage type:" + engine)
+	}
+	p.BackupStorageType = engine
+}
+
+func (p *MinioConfig) initBackupAddress() {
+	p.BackupAddress = p.Base.LoadWithDefault("minio.backupAddress", p.Address)
+}
+
+func (p *MinioConfig) initBackupPort() {
+	p.BackupPort = p.Base.LoadWithDefault("minio.backupPort", p.Port)
+}
+
+func (p *MinioConfig) initBackupUseSSL() {
+	usessl := p.Base.LoadWithDefault("minio.backupUseSSL", strconv.FormatBool(p.UseSSL))
+	p.BackupUseSSL, _ = strconv.ParseBool(usessl)
+}
+
+func (p *MinioConfig) initBackupUseIAM() {
+	useIAM := p.Base.LoadWithDefault("minio.backupUseIAM", strconv.FormatBool(p.UseIAM))
+	var err error
+	p.BackupUseIAM, err = strconv.ParseBool(useIAM)
+	if err != nil {
+		panic("parse bool backupUseIAM:" + err.Error())
+	}
+}
+
+func (p *MinioConfig) initBackupIAMEndpoint() {
+	p.BackupIAMEndpoint = p.Base.LoadWithDefault("minio.backupIamEndpoint", p.IAMEndpoint)
+}
+
 func (p *MinioConfig) initStorageType() {
 	engine := p.Base.LoadWithDefault("storage.storageType",
 		p.Base.LoadWithDefault("minio.storageType",
@@ -2,6 +2,7 @@ package paramtable
 
 import (
 	"testing"
+	"time"
 )
 
 func TestRootPathParams(t *testing.T) {
@@ -13,3 +14,28 @@ func TestRootPathParams(t *testing.T) {
 	//cfg.initRootPath()
 	println(params.MinioCfg.RootPath)
 }
+
+func TestParseFaultsEnv(t *testing.T) {
+	faults, err := parseFaultsEnv("operations=Copy,Exist pathPattern=insert_log errorRate=0.5 times=3; latency=100ms missingRate=1 skip=2;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(faults) != 2 {
+		t.Fatalf("expect 2 faults, got %d", len(faults))
+	}
+	first := faults[0]
+	if first.Name != "env-0" || len(first.Operations) != 2 || first.Operations[1] != "Exist" ||
+		first.PathPattern != "insert_log" || first.ErrorRate != 0.5 || first.Times != 3 {
+		t.Errorf("unexpected fault %+v", first)
+	}
+	second := faults[1]
+	if second.Name != "env-1" || second.Latency != 100*time.Millisecond || second.MissingRate != 1 || second.Skip != 2 {
+		t.Errorf("unexpected fault %+v", second)
+	}
+
+	for _, invalid := range []string{"errorRate", "errorRate=high", "latency=1", "times=1.5"} {
+		if _, err := parseFaultsEnv(invalid); err == nil {
+			t.Errorf("expect error of %q", invalid)
+		}
+	}
+}
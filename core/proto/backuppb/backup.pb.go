@@ -70,6 +70,7 @@ const (
 	BackupTaskStateCode_BACKUP_SUCCESS   BackupTaskStateCode = 2
 	BackupTaskStateCode_BACKUP_FAIL      BackupTaskStateCode = 3
 	BackupTaskStateCode_BACKUP_TIMEOUT   BackupTaskStateCode = 4
+	BackupTaskStateCode_BACKUP_CANCELLED BackupTaskStateCode = 5
 )
 
 var BackupTaskStateCode_name = map[int32]string{
@@ -78,6 +79,7 @@ var BackupTaskStateCode_name = map[int32]string{
 	2: "BACKUP_SUCCESS",
 	3: "BACKUP_FAIL",
 	4: "BACKUP_TIMEOUT",
+	5: "BACKUP_CANCELLED",
 }
 
 var BackupTaskStateCode_value = map[string]int32{
@@ -86,6 +88,7 @@ var BackupTaskStateCode_value = map[string]int32{
 	"BACKUP_SUCCESS":   2,
 	"BACKUP_FAIL":      3,
 	"BACKUP_TIMEOUT":   4,
+	"BACKUP_CANCELLED": 5,
 }
 
 func (x BackupTaskStateCode) String() string {
@@ -104,6 +107,7 @@ const (
 	RestoreTaskStateCode_SUCCESS   RestoreTaskStateCode = 2
 	RestoreTaskStateCode_FAIL      RestoreTaskStateCode = 3
 	RestoreTaskStateCode_TIMEOUT   RestoreTaskStateCode = 4
+	RestoreTaskStateCode_CANCELLED RestoreTaskStateCode = 5
 )
 
 var RestoreTaskStateCode_name = map[int32]string{
@@ -112,6 +116,7 @@ var RestoreTaskStateCode_name = map[int32]string{
 	2: "SUCCESS",
 	3: "FAIL",
 	4: "TIMEOUT",
+	5: "CANCELLED",
 }
 
 var RestoreTaskStateCode_value = map[string]int32{
@@ -120,6 +125,7 @@ var RestoreTaskStateCode_value = map[string]int32{
 	"SUCCESS":   2,
 	"FAIL":      3,
 	"TIMEOUT":   4,
+	"CANCELLED": 5,
 }
 
 func (x RestoreTaskStateCode) String() string {
@@ -267,6 +273,44 @@ func (FieldState) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_65240d19de191688, []int{5}
 }
 
+type FileVerifyState int32
+
+const (
+	FileVerifyState_FILE_OK FileVerifyState = 0
+	// the file is recorded but not found in backup
+	FileVerifyState_FILE_MISSING FileVerifyState = 1
+	// the file is found in backup but not recorded
+	FileVerifyState_FILE_EXTRA FileVerifyState = 2
+	// the file is smaller than recorded
+	FileVerifyState_FILE_TRUNCATED FileVerifyState = 3
+	// the size or checksum of the file is different from recorded
+	FileVerifyState_FILE_MISMATCHED FileVerifyState = 4
+)
+
+var FileVerifyState_name = map[int32]string{
+	0: "FILE_OK",
+	1: "FILE_MISSING",
+	2: "FILE_EXTRA",
+	3: "FILE_TRUNCATED",
+	4: "FILE_MISMATCHED",
+}
+
+var FileVerifyState_value = map[string]int32{
+	"FILE_OK":         0,
+	"FILE_MISSING":    1,
+	"FILE_EXTRA":      2,
+	"FILE_TRUNCATED":  3,
+	"FILE_MISMATCHED": 4,
+}
+
+func (x FileVerifyState) String() string {
+	return proto.EnumName(FileVerifyState_name, int32(x))
+}
+
+func (FileVerifyState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{6}
+}
+
 type IndexInfo struct {
 	FieldName            string            `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
 	IndexName            string            `protobuf:"bytes,2,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
@@ -356,9 +400,11 @@ type CollectionBackupInfo struct {
 	BackupPhysicalTimestamp uint64               `protobuf:"varint,19,opt,name=backup_physical_timestamp,json=backupPhysicalTimestamp,proto3" json:"backup_physical_timestamp,omitempty"`
 	ChannelCheckpoints      map[string]string    `protobuf:"bytes,20,rep,name=channel_checkpoints,json=channelCheckpoints,proto3" json:"channel_checkpoints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	L0Segments              []*SegmentBackupInfo `protobuf:"bytes,21,rep,name=l0_segments,json=l0Segments,proto3" json:"l0_segments,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}             `json:"-"`
-	XXX_unrecognized        []byte               `json:"-"`
-	XXX_sizecache           int32                `json:"-"`
+	// aliases of the collection at backup time
+	Aliases              []string `protobuf:"bytes,22,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CollectionBackupInfo) Reset()         { *m = CollectionBackupInfo{} }
@@ -533,6 +579,13 @@ func (m *CollectionBackupInfo) GetL0Segments() []*SegmentBackupInfo {
 	return nil
 }
 
+func (m *CollectionBackupInfo) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
 type PartitionBackupInfo struct {
 	PartitionId   int64  `protobuf:"varint,1,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
 	PartitionName string `protobuf:"bytes,2,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
@@ -627,9 +680,12 @@ type SegmentBackupInfo struct {
 	// separate segments into multi groups by size,
 	// segments in one group will be copied into one directory during backup
 	// and will bulkinsert in one call during restore
-	GroupId              int64    `protobuf:"varint,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Backuped             bool     `protobuf:"varint,10,opt,name=backuped,proto3" json:"backuped,omitempty"`
-	IsL0                 bool     `protobuf:"varint,11,opt,name=is_l0,json=isL0,proto3" json:"is_l0,omitempty"`
+	GroupId  int64 `protobuf:"varint,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Backuped bool  `protobuf:"varint,10,opt,name=backuped,proto3" json:"backuped,omitempty"`
+	IsL0     bool  `protobuf:"varint,11,opt,name=is_l0,json=isL0,proto3" json:"is_l0,omitempty"`
+	// name of the backup which physically holds the binlogs of this segment,
+	// empty means the binlogs are stored in the current backup. Set for the unchanged segments of an incremental backup
+	RefBackup            string   `protobuf:"bytes,12,opt,name=ref_backup,json=refBackup,proto3" json:"ref_backup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -737,6 +793,13 @@ func (m *SegmentBackupInfo) GetIsL0() bool {
 	return false
 }
 
+func (m *SegmentBackupInfo) GetRefBackup() string {
+	if m != nil {
+		return m.RefBackup
+	}
+	return ""
+}
+
 // *
 // root of backup
 type BackupInfo struct {
@@ -750,12 +813,20 @@ type BackupInfo struct {
 	// backup timestamp
 	BackupTimestamp uint64 `protobuf:"varint,8,opt,name=backup_timestamp,json=backupTimestamp,proto3" json:"backup_timestamp,omitempty"`
 	// array of collection backup
-	CollectionBackups    []*CollectionBackupInfo `protobuf:"bytes,9,rep,name=collection_backups,json=collectionBackups,proto3" json:"collection_backups,omitempty"`
-	Size                 int64                   `protobuf:"varint,10,opt,name=size,proto3" json:"size"`
-	MilvusVersion        string                  `protobuf:"bytes,11,opt,name=milvus_version,json=milvusVersion,proto3" json:"milvus_version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	CollectionBackups []*CollectionBackupInfo `protobuf:"bytes,9,rep,name=collection_backups,json=collectionBackups,proto3" json:"collection_backups,omitempty"`
+	Size              int64                   `protobuf:"varint,10,opt,name=size,proto3" json:"size"`
+	MilvusVersion     string                  `protobuf:"bytes,11,opt,name=milvus_version,json=milvusVersion,proto3" json:"milvus_version,omitempty"`
+	// name of the parent backup if this is an incremental backup
+	ParentBackup string `protobuf:"bytes,12,opt,name=parent_backup,json=parentBackup,proto3" json:"parent_backup,omitempty"`
+	// encryption of the backup data and meta, empty if the backup is not encrypted
+	Encryption *EncryptionInfo `protobuf:"bytes,13,opt,name=encryption,proto3" json:"encryption,omitempty"`
+	// compression codec of the meta files, empty if they are not compressed
+	MetaCompression string `protobuf:"bytes,14,opt,name=meta_compression,json=metaCompression,proto3" json:"meta_compression,omitempty"`
+	// compression codec of the binlogs copied into the backup, empty if they are not compressed
+	BinlogCompression    string   `protobuf:"bytes,15,opt,name=binlog_compression,json=binlogCompression,proto3" json:"binlog_compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BackupInfo) Reset()         { *m = BackupInfo{} }
@@ -860,6 +931,92 @@ func (m *BackupInfo) GetMilvusVersion() string {
 	return ""
 }
 
+func (m *BackupInfo) GetParentBackup() string {
+	if m != nil {
+		return m.ParentBackup
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetEncryption() *EncryptionInfo {
+	if m != nil {
+		return m.Encryption
+	}
+	return nil
+}
+
+func (m *BackupInfo) GetMetaCompression() string {
+	if m != nil {
+		return m.MetaCompression
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetBinlogCompression() string {
+	if m != nil {
+		return m.BinlogCompression
+	}
+	return ""
+}
+
+// *
+// Envelope encryption of a backup, the data key of the backup is wrapped by a master key
+type EncryptionInfo struct {
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// identifier of the master key which wraps the data key
+	MasterKeyId          string   `protobuf:"bytes,2,opt,name=master_key_id,json=masterKeyId,proto3" json:"master_key_id,omitempty"`
+	WrappedDataKey       []byte   `protobuf:"bytes,3,opt,name=wrapped_data_key,json=wrappedDataKey,proto3" json:"wrapped_data_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptionInfo) Reset()         { *m = EncryptionInfo{} }
+func (m *EncryptionInfo) String() string { return proto.CompactTextString(m) }
+func (*EncryptionInfo) ProtoMessage()    {}
+func (*EncryptionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{5}
+}
+
+func (m *EncryptionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptionInfo.Unmarshal(m, b)
+}
+func (m *EncryptionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptionInfo.Marshal(b, m, deterministic)
+}
+func (m *EncryptionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptionInfo.Merge(m, src)
+}
+func (m *EncryptionInfo) XXX_Size() int {
+	return xxx_messageInfo_EncryptionInfo.Size(m)
+}
+func (m *EncryptionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptionInfo proto.InternalMessageInfo
+
+func (m *EncryptionInfo) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *EncryptionInfo) GetMasterKeyId() string {
+	if m != nil {
+		return m.MasterKeyId
+	}
+	return ""
+}
+
+func (m *EncryptionInfo) GetWrappedDataKey() []byte {
+	if m != nil {
+		return m.WrappedDataKey
+	}
+	return nil
+}
+
 // *
 // For level storage
 type CollectionLevelBackupInfo struct {
@@ -873,7 +1030,7 @@ func (m *CollectionLevelBackupInfo) Reset()         { *m = CollectionLevelBackup
 func (m *CollectionLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*CollectionLevelBackupInfo) ProtoMessage()    {}
 func (*CollectionLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{5}
+	return fileDescriptor_65240d19de191688, []int{6}
 }
 
 func (m *CollectionLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -914,7 +1071,7 @@ func (m *PartitionLevelBackupInfo) Reset()         { *m = PartitionLevelBackupIn
 func (m *PartitionLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*PartitionLevelBackupInfo) ProtoMessage()    {}
 func (*PartitionLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{6}
+	return fileDescriptor_65240d19de191688, []int{7}
 }
 
 func (m *PartitionLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -955,7 +1112,7 @@ func (m *SegmentLevelBackupInfo) Reset()         { *m = SegmentLevelBackupInfo{}
 func (m *SegmentLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*SegmentLevelBackupInfo) ProtoMessage()    {}
 func (*SegmentLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{7}
+	return fileDescriptor_65240d19de191688, []int{8}
 }
 
 func (m *SegmentLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -995,6 +1152,7 @@ type CreateBackupRequest struct {
 	// async or not
 	Async bool `protobuf:"varint,4,opt,name=async,proto3" json:"async,omitempty"`
 	// database and collections to backup. A json string. To support database. 2023.7.7
+	// partitions are selected by an object of collections to partitions, for example: {"db1":{"collection1":["p1","p2"]}}
 	DbCollections *_struct.Value `protobuf:"bytes,5,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
 	// force backup skip flush, Should make sure data has been stored into disk when using it
 	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
@@ -1005,7 +1163,9 @@ type CreateBackupRequest struct {
 	// gc pause seconds, set it larger than the time cost of backup
 	GcPauseSeconds int32 `protobuf:"varint,9,opt,name=gc_pause_seconds,json=gcPauseSeconds,proto3" json:"gc_pause_seconds,omitempty"`
 	// gc pause API address
-	GcPauseAddress       string   `protobuf:"bytes,10,opt,name=gc_pause_address,json=gcPauseAddress,proto3" json:"gc_pause_address,omitempty"`
+	GcPauseAddress string `protobuf:"bytes,10,opt,name=gc_pause_address,json=gcPauseAddress,proto3" json:"gc_pause_address,omitempty"`
+	// if set, create an incremental backup based on the given backup, only new or changed segments will be copied
+	ParentBackup         string   `protobuf:"bytes,11,opt,name=parent_backup,json=parentBackup,proto3" json:"parent_backup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1015,7 +1175,7 @@ func (m *CreateBackupRequest) Reset()         { *m = CreateBackupRequest{} }
 func (m *CreateBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBackupRequest) ProtoMessage()    {}
 func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{8}
+	return fileDescriptor_65240d19de191688, []int{9}
 }
 
 func (m *CreateBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1106,6 +1266,216 @@ func (m *CreateBackupRequest) GetGcPauseAddress() string {
 	return ""
 }
 
+func (m *CreateBackupRequest) GetParentBackup() string {
+	if m != nil {
+		return m.ParentBackup
+	}
+	return ""
+}
+
+// *
+// Resume a failed or interrupted backup
+type ResumeBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the backup to resume
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// async or not
+	Async bool `protobuf:"varint,3,opt,name=async,proto3" json:"async,omitempty"`
+	// if true, stop GC to avoid the data compacted and GCed during backup, use it when the data to backup is very large.
+	GcPauseEnable bool `protobuf:"varint,4,opt,name=gc_pause_enable,json=gcPauseEnable,proto3" json:"gc_pause_enable,omitempty"`
+	// gc pause seconds, set it larger than the time cost of backup
+	GcPauseSeconds int32 `protobuf:"varint,5,opt,name=gc_pause_seconds,json=gcPauseSeconds,proto3" json:"gc_pause_seconds,omitempty"`
+	// gc pause API address
+	GcPauseAddress       string   `protobuf:"bytes,6,opt,name=gc_pause_address,json=gcPauseAddress,proto3" json:"gc_pause_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeBackupRequest) Reset()         { *m = ResumeBackupRequest{} }
+func (m *ResumeBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeBackupRequest) ProtoMessage()    {}
+func (*ResumeBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{10}
+}
+
+func (m *ResumeBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeBackupRequest.Unmarshal(m, b)
+}
+func (m *ResumeBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeBackupRequest.Merge(m, src)
+}
+func (m *ResumeBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeBackupRequest.Size(m)
+}
+func (m *ResumeBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeBackupRequest proto.InternalMessageInfo
+
+func (m *ResumeBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ResumeBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *ResumeBackupRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
+}
+
+func (m *ResumeBackupRequest) GetGcPauseEnable() bool {
+	if m != nil {
+		return m.GcPauseEnable
+	}
+	return false
+}
+
+func (m *ResumeBackupRequest) GetGcPauseSeconds() int32 {
+	if m != nil {
+		return m.GcPauseSeconds
+	}
+	return 0
+}
+
+func (m *ResumeBackupRequest) GetGcPauseAddress() string {
+	if m != nil {
+		return m.GcPauseAddress
+	}
+	return ""
+}
+
+type CancelBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the backup to cancel
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// if true, remove the partial backup directory after the backup is cancelled
+	Cleanup              bool     `protobuf:"varint,3,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelBackupRequest) Reset()         { *m = CancelBackupRequest{} }
+func (m *CancelBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelBackupRequest) ProtoMessage()    {}
+func (*CancelBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{11}
+}
+
+func (m *CancelBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelBackupRequest.Unmarshal(m, b)
+}
+func (m *CancelBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelBackupRequest.Merge(m, src)
+}
+func (m *CancelBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelBackupRequest.Size(m)
+}
+func (m *CancelBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelBackupRequest proto.InternalMessageInfo
+
+func (m *CancelBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CancelBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *CancelBackupRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
+type CancelRestoreRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// id of the restore task to cancel
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// if true, drop the target collections created by the restore after it is cancelled
+	Cleanup              bool     `protobuf:"varint,3,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelRestoreRequest) Reset()         { *m = CancelRestoreRequest{} }
+func (m *CancelRestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelRestoreRequest) ProtoMessage()    {}
+func (*CancelRestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{12}
+}
+
+func (m *CancelRestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelRestoreRequest.Unmarshal(m, b)
+}
+func (m *CancelRestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelRestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelRestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRestoreRequest.Merge(m, src)
+}
+func (m *CancelRestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelRestoreRequest.Size(m)
+}
+func (m *CancelRestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelRestoreRequest proto.InternalMessageInfo
+
+func (m *CancelRestoreRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CancelRestoreRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CancelRestoreRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
 // *
 // BackupInfoResponse
 type BackupInfoResponse struct {
@@ -1126,7 +1496,7 @@ func (m *BackupInfoResponse) Reset()         { *m = BackupInfoResponse{} }
 func (m *BackupInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*BackupInfoResponse) ProtoMessage()    {}
 func (*BackupInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{9}
+	return fileDescriptor_65240d19de191688, []int{13}
 }
 
 func (m *BackupInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -1197,7 +1567,7 @@ func (m *GetBackupRequest) Reset()         { *m = GetBackupRequest{} }
 func (m *GetBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBackupRequest) ProtoMessage()    {}
 func (*GetBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{10}
+	return fileDescriptor_65240d19de191688, []int{14}
 }
 
 func (m *GetBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1274,7 +1644,7 @@ func (m *ListBackupsRequest) Reset()         { *m = ListBackupsRequest{} }
 func (m *ListBackupsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBackupsRequest) ProtoMessage()    {}
 func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{11}
+	return fileDescriptor_65240d19de191688, []int{15}
 }
 
 func (m *ListBackupsRequest) XXX_Unmarshal(b []byte) error {
@@ -1327,7 +1697,7 @@ func (m *ListBackupsResponse) Reset()         { *m = ListBackupsResponse{} }
 func (m *ListBackupsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListBackupsResponse) ProtoMessage()    {}
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{12}
+	return fileDescriptor_65240d19de191688, []int{16}
 }
 
 func (m *ListBackupsResponse) XXX_Unmarshal(b []byte) error {
@@ -1390,7 +1760,7 @@ func (m *DeleteBackupRequest) Reset()         { *m = DeleteBackupRequest{} }
 func (m *DeleteBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBackupRequest) ProtoMessage()    {}
 func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{13}
+	return fileDescriptor_65240d19de191688, []int{17}
 }
 
 func (m *DeleteBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1441,7 +1811,7 @@ func (m *DeleteBackupResponse) Reset()         { *m = DeleteBackupResponse{} }
 func (m *DeleteBackupResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBackupResponse) ProtoMessage()    {}
 func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{14}
+	return fileDescriptor_65240d19de191688, []int{18}
 }
 
 func (m *DeleteBackupResponse) XXX_Unmarshal(b []byte) error {
@@ -1503,6 +1873,7 @@ type RestoreBackupRequest struct {
 	// if bucket_name and path is set. will override bucket/path in config.
 	Path string `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
 	// database and collections to restore. A json string. for example: {"db1":["collection1"],"db2":["collection2","collection3"]}
+	// partitions are selected by an object of collections to partitions, for example: {"db1":{"collection1":["p1","p2"]}}
 	DbCollections *_struct.Value `protobuf:"bytes,9,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
 	// if true only restore meta, not restore data
 	MetaOnly bool `protobuf:"varint,10,opt,name=metaOnly,proto3" json:"metaOnly,omitempty"`
@@ -1515,8 +1886,29 @@ type RestoreBackupRequest struct {
 	// if true, drop existing index of target collection before create
 	DropExistIndex bool `protobuf:"varint,14,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
 	// if true, will skip collection, use when collection exist, restore index or data
-	SkipCreateCollection bool     `protobuf:"varint,15,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
-	Id                   string   `protobuf:"bytes,16,opt,name=id,proto3" json:"id,omitempty"`
+	SkipCreateCollection bool   `protobuf:"varint,15,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	Id                   string `protobuf:"bytes,16,opt,name=id,proto3" json:"id,omitempty"`
+	// id of a failed or interrupted restore task to resume, only the groups and l0 segments not finished will be restored
+	ResumeRestoreId string `protobuf:"bytes,17,opt,name=resume_restore_id,json=resumeRestoreId,proto3" json:"resume_restore_id,omitempty"`
+	// restore the data to the timestamp, should not be later than the backup timestamp
+	RestoreToTimestamp uint64 `protobuf:"varint,18,opt,name=restore_to_timestamp,json=restoreToTimestamp,proto3" json:"restore_to_timestamp,omitempty"`
+	// restore the data to the time in RFC3339 format, used if restore_to_timestamp is not set
+	RestoreToTime string `protobuf:"bytes,19,opt,name=restore_to_time,json=restoreToTime,proto3" json:"restore_to_time,omitempty"`
+	// only validate the request and return the plan of the restore, nothing is created, dropped or copied
+	DryRun bool `protobuf:"varint,20,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// verify the row counts and sample the primary keys of the restored collections after bulk insert
+	Verify bool `protobuf:"varint,21,opt,name=verify,proto3" json:"verify,omitempty"`
+	// if true a mismatch found by the verification fails the restore of the collection
+	StrictVerify bool `protobuf:"varint,22,opt,name=strict_verify,json=strictVerify,proto3" json:"strict_verify,omitempty"`
+	// load the collections and partitions which were loaded at backup time, after the data is restored and the indexes are built
+	RestoreLoadState bool `protobuf:"varint,23,opt,name=restore_load_state,json=restoreLoadState,proto3" json:"restore_load_state,omitempty"`
+	// replica number to load the collections, 0 means the default of milvus
+	LoadReplicaNumber int32 `protobuf:"varint,24,opt,name=load_replica_number,json=loadReplicaNumber,proto3" json:"load_replica_number,omitempty"`
+	// recreate the aliases of the collections, the aliases are renamed by collection_renames and collection_suffix as collections
+	RestoreAliases bool `protobuf:"varint,25,opt,name=restore_aliases,json=restoreAliases,proto3" json:"restore_aliases,omitempty"`
+	// move the aliases which exist on other collections onto the restored collections, implies restore_aliases.
+	// collection_suffix is not applied to the aliases, so that the original aliases are moved
+	SwapAliases          bool     `protobuf:"varint,26,opt,name=swap_aliases,json=swapAliases,proto3" json:"swap_aliases,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1526,7 +1918,7 @@ func (m *RestoreBackupRequest) Reset()         { *m = RestoreBackupRequest{} }
 func (m *RestoreBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*RestoreBackupRequest) ProtoMessage()    {}
 func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{15}
+	return fileDescriptor_65240d19de191688, []int{19}
 }
 
 func (m *RestoreBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1659,29 +2051,105 @@ func (m *RestoreBackupRequest) GetId() string {
 	return ""
 }
 
-type RestorePartitionTask struct {
-	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	StateCode            RestoreTaskStateCode `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code"`
-	ErrorMessage         string               `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
-	StartTime            int64                `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime              int64                `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Progress             int32                `protobuf:"varint,6,opt,name=progress,proto3" json:"progress"`
-	PartBackup           *PartitionBackupInfo `protobuf:"bytes,7,opt,name=part_backup,json=partBackup,proto3" json:"part_backup,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *RestoreBackupRequest) GetResumeRestoreId() string {
+	if m != nil {
+		return m.ResumeRestoreId
+	}
+	return ""
 }
 
-func (m *RestorePartitionTask) Reset()         { *m = RestorePartitionTask{} }
-func (m *RestorePartitionTask) String() string { return proto.CompactTextString(m) }
-func (*RestorePartitionTask) ProtoMessage()    {}
-func (*RestorePartitionTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{16}
+func (m *RestoreBackupRequest) GetRestoreToTimestamp() uint64 {
+	if m != nil {
+		return m.RestoreToTimestamp
+	}
+	return 0
 }
 
-func (m *RestorePartitionTask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestorePartitionTask.Unmarshal(m, b)
-}
+func (m *RestoreBackupRequest) GetRestoreToTime() string {
+	if m != nil {
+		return m.RestoreToTime
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetVerify() bool {
+	if m != nil {
+		return m.Verify
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetStrictVerify() bool {
+	if m != nil {
+		return m.StrictVerify
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetRestoreLoadState() bool {
+	if m != nil {
+		return m.RestoreLoadState
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetLoadReplicaNumber() int32 {
+	if m != nil {
+		return m.LoadReplicaNumber
+	}
+	return 0
+}
+
+func (m *RestoreBackupRequest) GetRestoreAliases() bool {
+	if m != nil {
+		return m.RestoreAliases
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetSwapAliases() bool {
+	if m != nil {
+		return m.SwapAliases
+	}
+	return false
+}
+
+type RestorePartitionTask struct {
+	Id            string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StateCode     RestoreTaskStateCode `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code"`
+	ErrorMessage  string               `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	StartTime     int64                `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64                `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Progress      int32                `protobuf:"varint,6,opt,name=progress,proto3" json:"progress"`
+	PartBackup    *PartitionBackupInfo `protobuf:"bytes,7,opt,name=part_backup,json=partBackup,proto3" json:"part_backup,omitempty"`
+	PartitionId   int64                `protobuf:"varint,8,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
+	PartitionName string               `protobuf:"bytes,9,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	// segment groups which have been bulk inserted
+	FinishedGroupIds []int64 `protobuf:"varint,10,rep,packed,name=finished_group_ids,json=finishedGroupIds,proto3" json:"finished_group_ids,omitempty"`
+	// l0 segments of the partition which have been bulk inserted
+	FinishedL0SegmentIds []int64  `protobuf:"varint,11,rep,packed,name=finished_l0_segment_ids,json=finishedL0SegmentIds,proto3" json:"finished_l0_segment_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestorePartitionTask) Reset()         { *m = RestorePartitionTask{} }
+func (m *RestorePartitionTask) String() string { return proto.CompactTextString(m) }
+func (*RestorePartitionTask) ProtoMessage()    {}
+func (*RestorePartitionTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{20}
+}
+
+func (m *RestorePartitionTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestorePartitionTask.Unmarshal(m, b)
+}
 func (m *RestorePartitionTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_RestorePartitionTask.Marshal(b, m, deterministic)
 }
@@ -1746,6 +2214,34 @@ func (m *RestorePartitionTask) GetPartBackup() *PartitionBackupInfo {
 	return nil
 }
 
+func (m *RestorePartitionTask) GetPartitionId() int64 {
+	if m != nil {
+		return m.PartitionId
+	}
+	return 0
+}
+
+func (m *RestorePartitionTask) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *RestorePartitionTask) GetFinishedGroupIds() []int64 {
+	if m != nil {
+		return m.FinishedGroupIds
+	}
+	return nil
+}
+
+func (m *RestorePartitionTask) GetFinishedL0SegmentIds() []int64 {
+	if m != nil {
+		return m.FinishedL0SegmentIds
+	}
+	return nil
+}
+
 type RestoreCollectionTask struct {
 	Id                    string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	StateCode             RestoreTaskStateCode    `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code"`
@@ -1770,7 +2266,30 @@ type RestoreCollectionTask struct {
 	// if true drop index info
 	DropExistIndex bool `protobuf:"varint,17,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
 	// if true will skip create collections
-	SkipCreateCollection bool     `protobuf:"varint,18,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	SkipCreateCollection bool `protobuf:"varint,18,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	// collection level l0 segments which have been bulk inserted
+	FinishedL0SegmentIds []int64 `protobuf:"varint,19,rep,packed,name=finished_l0_segment_ids,json=finishedL0SegmentIds,proto3" json:"finished_l0_segment_ids,omitempty"`
+	// restore the data to the timestamp, 0 means the backup timestamp
+	RestoreToTimestamp uint64 `protobuf:"varint,20,opt,name=restore_to_timestamp,json=restoreToTimestamp,proto3" json:"restore_to_timestamp,omitempty"`
+	// verify the restored collection after bulk insert
+	Verify bool `protobuf:"varint,21,opt,name=verify,proto3" json:"verify,omitempty"`
+	// if true a verification mismatch fails the task
+	StrictVerify bool                 `protobuf:"varint,22,opt,name=strict_verify,json=strictVerify,proto3" json:"strict_verify,omitempty"`
+	VerifyResult *RestoreVerifyResult `protobuf:"bytes,23,opt,name=verify_result,json=verifyResult,proto3" json:"verify_result,omitempty"`
+	// load the collection or partitions as the load state of the backup
+	RestoreLoadState  bool  `protobuf:"varint,24,opt,name=restore_load_state,json=restoreLoadState,proto3" json:"restore_load_state,omitempty"`
+	LoadReplicaNumber int32 `protobuf:"varint,25,opt,name=load_replica_number,json=loadReplicaNumber,proto3" json:"load_replica_number,omitempty"`
+	// loaded partitions, empty means the whole collection is loaded
+	LoadPartitions []string `protobuf:"bytes,26,rep,name=load_partitions,json=loadPartitions,proto3" json:"load_partitions,omitempty"`
+	// progress of loading the restored collection, from 0 to 100
+	LoadProgress int64 `protobuf:"varint,27,opt,name=load_progress,json=loadProgress,proto3" json:"load_progress"`
+	// aliases to create for the restored collection, after the data is restored, loaded and verified
+	Aliases []string `protobuf:"bytes,28,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// move the aliases which exist on other collections onto the restored collection
+	SwapAliases bool `protobuf:"varint,29,opt,name=swap_aliases,json=swapAliases,proto3" json:"swap_aliases,omitempty"`
+	// partitions selected by db_collections, empty means all of them.
+	// l0 segments of the collection are bulk inserted into each selected partition rather than into the whole collection
+	SelectedPartitions   []string `protobuf:"bytes,30,rep,name=selected_partitions,json=selectedPartitions,proto3" json:"selected_partitions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1780,7 +2299,7 @@ func (m *RestoreCollectionTask) Reset()         { *m = RestoreCollectionTask{} }
 func (m *RestoreCollectionTask) String() string { return proto.CompactTextString(m) }
 func (*RestoreCollectionTask) ProtoMessage()    {}
 func (*RestoreCollectionTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{17}
+	return fileDescriptor_65240d19de191688, []int{21}
 }
 
 func (m *RestoreCollectionTask) XXX_Unmarshal(b []byte) error {
@@ -1927,6 +2446,247 @@ func (m *RestoreCollectionTask) GetSkipCreateCollection() bool {
 	return false
 }
 
+func (m *RestoreCollectionTask) GetFinishedL0SegmentIds() []int64 {
+	if m != nil {
+		return m.FinishedL0SegmentIds
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetRestoreToTimestamp() uint64 {
+	if m != nil {
+		return m.RestoreToTimestamp
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetVerify() bool {
+	if m != nil {
+		return m.Verify
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetStrictVerify() bool {
+	if m != nil {
+		return m.StrictVerify
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetVerifyResult() *RestoreVerifyResult {
+	if m != nil {
+		return m.VerifyResult
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetRestoreLoadState() bool {
+	if m != nil {
+		return m.RestoreLoadState
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetLoadReplicaNumber() int32 {
+	if m != nil {
+		return m.LoadReplicaNumber
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetLoadPartitions() []string {
+	if m != nil {
+		return m.LoadPartitions
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetLoadProgress() int64 {
+	if m != nil {
+		return m.LoadProgress
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetSwapAliases() bool {
+	if m != nil {
+		return m.SwapAliases
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetSelectedPartitions() []string {
+	if m != nil {
+		return m.SelectedPartitions
+	}
+	return nil
+}
+
+type RestorePartitionVerifyResult struct {
+	PartitionName string `protobuf:"bytes,1,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	// the rows restored should be within [min_rows, max_rows] by the num_of_rows of the restored segments,
+	// rows deleted by the restored deltalogs and rows of the binlogs across the restore timestamp are uncertain
+	MinRows int64 `protobuf:"varint,2,opt,name=min_rows,json=minRows,proto3" json:"min_rows,omitempty"`
+	MaxRows int64 `protobuf:"varint,3,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// rows counted in the restored partition, -1 if the collection is not loaded
+	ActualRows int64 `protobuf:"varint,4,opt,name=actual_rows,json=actualRows,proto3" json:"actual_rows,omitempty"`
+	// primary keys sampled from the insert binlogs of the backup and queried back from the restored partition
+	SampledKeys int64 `protobuf:"varint,5,opt,name=sampled_keys,json=sampledKeys,proto3" json:"sampled_keys,omitempty"`
+	// sampled primary keys which are not found or found more than once
+	MismatchedKeys       int64    `protobuf:"varint,6,opt,name=mismatched_keys,json=mismatchedKeys,proto3" json:"mismatched_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestorePartitionVerifyResult) Reset()         { *m = RestorePartitionVerifyResult{} }
+func (m *RestorePartitionVerifyResult) String() string { return proto.CompactTextString(m) }
+func (*RestorePartitionVerifyResult) ProtoMessage()    {}
+func (*RestorePartitionVerifyResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{22}
+}
+
+func (m *RestorePartitionVerifyResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestorePartitionVerifyResult.Unmarshal(m, b)
+}
+func (m *RestorePartitionVerifyResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestorePartitionVerifyResult.Marshal(b, m, deterministic)
+}
+func (m *RestorePartitionVerifyResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestorePartitionVerifyResult.Merge(m, src)
+}
+func (m *RestorePartitionVerifyResult) XXX_Size() int {
+	return xxx_messageInfo_RestorePartitionVerifyResult.Size(m)
+}
+func (m *RestorePartitionVerifyResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestorePartitionVerifyResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestorePartitionVerifyResult proto.InternalMessageInfo
+
+func (m *RestorePartitionVerifyResult) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *RestorePartitionVerifyResult) GetMinRows() int64 {
+	if m != nil {
+		return m.MinRows
+	}
+	return 0
+}
+
+func (m *RestorePartitionVerifyResult) GetMaxRows() int64 {
+	if m != nil {
+		return m.MaxRows
+	}
+	return 0
+}
+
+func (m *RestorePartitionVerifyResult) GetActualRows() int64 {
+	if m != nil {
+		return m.ActualRows
+	}
+	return 0
+}
+
+func (m *RestorePartitionVerifyResult) GetSampledKeys() int64 {
+	if m != nil {
+		return m.SampledKeys
+	}
+	return 0
+}
+
+func (m *RestorePartitionVerifyResult) GetMismatchedKeys() int64 {
+	if m != nil {
+		return m.MismatchedKeys
+	}
+	return 0
+}
+
+type RestoreVerifyResult struct {
+	Passed     bool                            `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	Partitions []*RestorePartitionVerifyResult `protobuf:"bytes,2,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	// rows of the collection statistics, checked instead of the partitions if the collection is not loaded, -1 if not counted
+	CollectionRows int64    `protobuf:"varint,3,opt,name=collection_rows,json=collectionRows,proto3" json:"collection_rows,omitempty"`
+	Mismatches     []string `protobuf:"bytes,4,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	// false if the restored rows are not checked, as the existing collection restored into is not loaded
+	Verified             bool     `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreVerifyResult) Reset()         { *m = RestoreVerifyResult{} }
+func (m *RestoreVerifyResult) String() string { return proto.CompactTextString(m) }
+func (*RestoreVerifyResult) ProtoMessage()    {}
+func (*RestoreVerifyResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{23}
+}
+
+func (m *RestoreVerifyResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreVerifyResult.Unmarshal(m, b)
+}
+func (m *RestoreVerifyResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreVerifyResult.Marshal(b, m, deterministic)
+}
+func (m *RestoreVerifyResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreVerifyResult.Merge(m, src)
+}
+func (m *RestoreVerifyResult) XXX_Size() int {
+	return xxx_messageInfo_RestoreVerifyResult.Size(m)
+}
+func (m *RestoreVerifyResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreVerifyResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreVerifyResult proto.InternalMessageInfo
+
+func (m *RestoreVerifyResult) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *RestoreVerifyResult) GetPartitions() []*RestorePartitionVerifyResult {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+func (m *RestoreVerifyResult) GetCollectionRows() int64 {
+	if m != nil {
+		return m.CollectionRows
+	}
+	return 0
+}
+
+func (m *RestoreVerifyResult) GetMismatches() []string {
+	if m != nil {
+		return m.Mismatches
+	}
+	return nil
+}
+
+func (m *RestoreVerifyResult) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
 type RestoreBackupTask struct {
 	Id                     string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	StateCode              RestoreTaskStateCode     `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code"`
@@ -1946,7 +2706,7 @@ func (m *RestoreBackupTask) Reset()         { *m = RestoreBackupTask{} }
 func (m *RestoreBackupTask) String() string { return proto.CompactTextString(m) }
 func (*RestoreBackupTask) ProtoMessage()    {}
 func (*RestoreBackupTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{18}
+	return fileDescriptor_65240d19de191688, []int{24}
 }
 
 func (m *RestoreBackupTask) XXX_Unmarshal(b []byte) error {
@@ -2030,38 +2790,396 @@ func (m *RestoreBackupTask) GetProgress() int32 {
 	return 0
 }
 
-type RestoreBackupResponse struct {
-	// uuid of the request to response
-	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
-	// response code. 0 means success. others are fail
-	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
-	// error msg if fail
-	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
-	// restore task info entity
-	Data                 *RestoreBackupTask `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type RestoreGroupPlan struct {
+	// 0 for the backups without segment groups
+	GroupId              int64    `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	SegmentIds           []int64  `protobuf:"varint,2,rep,packed,name=segment_ids,json=segmentIds,proto3" json:"segment_ids,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupResponse) Reset()         { *m = RestoreBackupResponse{} }
-func (m *RestoreBackupResponse) String() string { return proto.CompactTextString(m) }
-func (*RestoreBackupResponse) ProtoMessage()    {}
-func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{19}
+func (m *RestoreGroupPlan) Reset()         { *m = RestoreGroupPlan{} }
+func (m *RestoreGroupPlan) String() string { return proto.CompactTextString(m) }
+func (*RestoreGroupPlan) ProtoMessage()    {}
+func (*RestoreGroupPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{25}
 }
 
-func (m *RestoreBackupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreBackupResponse.Unmarshal(m, b)
+func (m *RestoreGroupPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreGroupPlan.Unmarshal(m, b)
 }
-func (m *RestoreBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreBackupResponse.Marshal(b, m, deterministic)
+func (m *RestoreGroupPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreGroupPlan.Marshal(b, m, deterministic)
 }
-func (m *RestoreBackupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreBackupResponse.Merge(m, src)
+func (m *RestoreGroupPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreGroupPlan.Merge(m, src)
 }
-func (m *RestoreBackupResponse) XXX_Size() int {
-	return xxx_messageInfo_RestoreBackupResponse.Size(m)
+func (m *RestoreGroupPlan) XXX_Size() int {
+	return xxx_messageInfo_RestoreGroupPlan.Size(m)
+}
+func (m *RestoreGroupPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreGroupPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreGroupPlan proto.InternalMessageInfo
+
+func (m *RestoreGroupPlan) GetGroupId() int64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *RestoreGroupPlan) GetSegmentIds() []int64 {
+	if m != nil {
+		return m.SegmentIds
+	}
+	return nil
+}
+
+func (m *RestoreGroupPlan) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RestorePartitionPlan struct {
+	PartitionName string `protobuf:"bytes,1,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	// whether the partition would be created
+	Create bool `protobuf:"varint,2,opt,name=create,proto3" json:"create,omitempty"`
+	// segment groups to bulk insert
+	Groups []*RestoreGroupPlan `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	// l0 segments of the partition to bulk insert
+	L0SegmentIds         []int64  `protobuf:"varint,4,rep,packed,name=l0_segment_ids,json=l0SegmentIds,proto3" json:"l0_segment_ids,omitempty"`
+	Size                 int64    `protobuf:"varint,5,opt,name=size,proto3" json:"size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestorePartitionPlan) Reset()         { *m = RestorePartitionPlan{} }
+func (m *RestorePartitionPlan) String() string { return proto.CompactTextString(m) }
+func (*RestorePartitionPlan) ProtoMessage()    {}
+func (*RestorePartitionPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{26}
+}
+
+func (m *RestorePartitionPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestorePartitionPlan.Unmarshal(m, b)
+}
+func (m *RestorePartitionPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestorePartitionPlan.Marshal(b, m, deterministic)
+}
+func (m *RestorePartitionPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestorePartitionPlan.Merge(m, src)
+}
+func (m *RestorePartitionPlan) XXX_Size() int {
+	return xxx_messageInfo_RestorePartitionPlan.Size(m)
+}
+func (m *RestorePartitionPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestorePartitionPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestorePartitionPlan proto.InternalMessageInfo
+
+func (m *RestorePartitionPlan) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *RestorePartitionPlan) GetCreate() bool {
+	if m != nil {
+		return m.Create
+	}
+	return false
+}
+
+func (m *RestorePartitionPlan) GetGroups() []*RestoreGroupPlan {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *RestorePartitionPlan) GetL0SegmentIds() []int64 {
+	if m != nil {
+		return m.L0SegmentIds
+	}
+	return nil
+}
+
+func (m *RestorePartitionPlan) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RestoreCollectionPlan struct {
+	SourceDbName         string `protobuf:"bytes,1,opt,name=source_db_name,json=sourceDbName,proto3" json:"source_db_name,omitempty"`
+	SourceCollectionName string `protobuf:"bytes,2,opt,name=source_collection_name,json=sourceCollectionName,proto3" json:"source_collection_name,omitempty"`
+	TargetDbName         string `protobuf:"bytes,3,opt,name=target_db_name,json=targetDbName,proto3" json:"target_db_name,omitempty"`
+	TargetCollectionName string `protobuf:"bytes,4,opt,name=target_collection_name,json=targetCollectionName,proto3" json:"target_collection_name,omitempty"`
+	// whether the target collection exists before the restore
+	Exist bool `protobuf:"varint,5,opt,name=exist,proto3" json:"exist,omitempty"`
+	// whether the existing target collection would be dropped by dropExistCollection
+	Drop bool `protobuf:"varint,6,opt,name=drop,proto3" json:"drop,omitempty"`
+	// whether the target collection would be created
+	Create bool `protobuf:"varint,7,opt,name=create,proto3" json:"create,omitempty"`
+	// whether the indexes of the existing target collection would be dropped by dropExistIndex
+	DropIndex bool `protobuf:"varint,8,opt,name=drop_index,json=dropIndex,proto3" json:"drop_index,omitempty"`
+	// indexes to create if restoreIndex, vector indexes are replaced by autoindex if useAutoIndex
+	Indexes    []*IndexInfo            `protobuf:"bytes,9,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Partitions []*RestorePartitionPlan `protobuf:"bytes,10,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	// l0 segments of the collection to bulk insert
+	L0SegmentIds []int64 `protobuf:"varint,11,rep,packed,name=l0_segment_ids,json=l0SegmentIds,proto3" json:"l0_segment_ids,omitempty"`
+	Size         int64   `protobuf:"varint,12,opt,name=size,proto3" json:"size"`
+	// whether the collection would be loaded by restore_load_state
+	Load bool `protobuf:"varint,13,opt,name=load,proto3" json:"load,omitempty"`
+	// partitions to load, empty means the whole collection
+	LoadPartitions []string `protobuf:"bytes,14,rep,name=load_partitions,json=loadPartitions,proto3" json:"load_partitions,omitempty"`
+	// aliases to create for the target collection
+	Aliases              []string `protobuf:"bytes,15,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreCollectionPlan) Reset()         { *m = RestoreCollectionPlan{} }
+func (m *RestoreCollectionPlan) String() string { return proto.CompactTextString(m) }
+func (*RestoreCollectionPlan) ProtoMessage()    {}
+func (*RestoreCollectionPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{27}
+}
+
+func (m *RestoreCollectionPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreCollectionPlan.Unmarshal(m, b)
+}
+func (m *RestoreCollectionPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreCollectionPlan.Marshal(b, m, deterministic)
+}
+func (m *RestoreCollectionPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreCollectionPlan.Merge(m, src)
+}
+func (m *RestoreCollectionPlan) XXX_Size() int {
+	return xxx_messageInfo_RestoreCollectionPlan.Size(m)
+}
+func (m *RestoreCollectionPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreCollectionPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreCollectionPlan proto.InternalMessageInfo
+
+func (m *RestoreCollectionPlan) GetSourceDbName() string {
+	if m != nil {
+		return m.SourceDbName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionPlan) GetSourceCollectionName() string {
+	if m != nil {
+		return m.SourceCollectionName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionPlan) GetTargetDbName() string {
+	if m != nil {
+		return m.TargetDbName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionPlan) GetTargetCollectionName() string {
+	if m != nil {
+		return m.TargetCollectionName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionPlan) GetExist() bool {
+	if m != nil {
+		return m.Exist
+	}
+	return false
+}
+
+func (m *RestoreCollectionPlan) GetDrop() bool {
+	if m != nil {
+		return m.Drop
+	}
+	return false
+}
+
+func (m *RestoreCollectionPlan) GetCreate() bool {
+	if m != nil {
+		return m.Create
+	}
+	return false
+}
+
+func (m *RestoreCollectionPlan) GetDropIndex() bool {
+	if m != nil {
+		return m.DropIndex
+	}
+	return false
+}
+
+func (m *RestoreCollectionPlan) GetIndexes() []*IndexInfo {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+func (m *RestoreCollectionPlan) GetPartitions() []*RestorePartitionPlan {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+func (m *RestoreCollectionPlan) GetL0SegmentIds() []int64 {
+	if m != nil {
+		return m.L0SegmentIds
+	}
+	return nil
+}
+
+func (m *RestoreCollectionPlan) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *RestoreCollectionPlan) GetLoad() bool {
+	if m != nil {
+		return m.Load
+	}
+	return false
+}
+
+func (m *RestoreCollectionPlan) GetLoadPartitions() []string {
+	if m != nil {
+		return m.LoadPartitions
+	}
+	return nil
+}
+
+func (m *RestoreCollectionPlan) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+type RestorePlan struct {
+	// databases to create
+	CreateDatabases []string                 `protobuf:"bytes,1,rep,name=create_databases,json=createDatabases,proto3" json:"create_databases,omitempty"`
+	Collections     []*RestoreCollectionPlan `protobuf:"bytes,2,rep,name=collections,proto3" json:"collections,omitempty"`
+	// conflicts which fail the restore, such as the target collection exists
+	Conflicts []string `protobuf:"bytes,3,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// total size of the data to restore
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestorePlan) Reset()         { *m = RestorePlan{} }
+func (m *RestorePlan) String() string { return proto.CompactTextString(m) }
+func (*RestorePlan) ProtoMessage()    {}
+func (*RestorePlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{28}
+}
+
+func (m *RestorePlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestorePlan.Unmarshal(m, b)
+}
+func (m *RestorePlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestorePlan.Marshal(b, m, deterministic)
+}
+func (m *RestorePlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestorePlan.Merge(m, src)
+}
+func (m *RestorePlan) XXX_Size() int {
+	return xxx_messageInfo_RestorePlan.Size(m)
+}
+func (m *RestorePlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestorePlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestorePlan proto.InternalMessageInfo
+
+func (m *RestorePlan) GetCreateDatabases() []string {
+	if m != nil {
+		return m.CreateDatabases
+	}
+	return nil
+}
+
+func (m *RestorePlan) GetCollections() []*RestoreCollectionPlan {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *RestorePlan) GetConflicts() []string {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+func (m *RestorePlan) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RestoreBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// restore task info entity
+	Data *RestoreBackupTask `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	// plan of the restore if dry_run
+	Plan                 *RestorePlan `protobuf:"bytes,5,opt,name=plan,proto3" json:"plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RestoreBackupResponse) Reset()         { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()    {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{29}
+}
+
+func (m *RestoreBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBackupResponse.Unmarshal(m, b)
+}
+func (m *RestoreBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBackupResponse.Merge(m, src)
+}
+func (m *RestoreBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBackupResponse.Size(m)
 }
 func (m *RestoreBackupResponse) XXX_DiscardUnknown() {
 	xxx_messageInfo_RestoreBackupResponse.DiscardUnknown(m)
@@ -2097,6 +3215,13 @@ func (m *RestoreBackupResponse) GetData() *RestoreBackupTask {
 	return nil
 }
 
+func (m *RestoreBackupResponse) GetPlan() *RestorePlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
 type GetRestoreStateRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
@@ -2111,7 +3236,7 @@ func (m *GetRestoreStateRequest) Reset()         { *m = GetRestoreStateRequest{}
 func (m *GetRestoreStateRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRestoreStateRequest) ProtoMessage()    {}
 func (*GetRestoreStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{20}
+	return fileDescriptor_65240d19de191688, []int{30}
 }
 
 func (m *GetRestoreStateRequest) XXX_Unmarshal(b []byte) error {
@@ -2159,7 +3284,7 @@ func (m *FieldBinlog) Reset()         { *m = FieldBinlog{} }
 func (m *FieldBinlog) String() string { return proto.CompactTextString(m) }
 func (*FieldBinlog) ProtoMessage()    {}
 func (*FieldBinlog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{21}
+	return fileDescriptor_65240d19de191688, []int{31}
 }
 
 func (m *FieldBinlog) XXX_Unmarshal(b []byte) error {
@@ -2195,11 +3320,13 @@ func (m *FieldBinlog) GetBinlogs() []*Binlog {
 }
 
 type Binlog struct {
-	EntriesNum           int64    `protobuf:"varint,1,opt,name=entries_num,json=entriesNum,proto3" json:"entries_num,omitempty"`
-	TimestampFrom        uint64   `protobuf:"varint,2,opt,name=timestamp_from,json=timestampFrom,proto3" json:"timestamp_from,omitempty"`
-	TimestampTo          uint64   `protobuf:"varint,3,opt,name=timestamp_to,json=timestampTo,proto3" json:"timestamp_to,omitempty"`
-	LogPath              string   `protobuf:"bytes,4,opt,name=log_path,json=logPath,proto3" json:"log_path,omitempty"`
-	LogSize              int64    `protobuf:"varint,5,opt,name=log_size,json=logSize,proto3" json:"log_size"`
+	EntriesNum    int64  `protobuf:"varint,1,opt,name=entries_num,json=entriesNum,proto3" json:"entries_num,omitempty"`
+	TimestampFrom uint64 `protobuf:"varint,2,opt,name=timestamp_from,json=timestampFrom,proto3" json:"timestamp_from,omitempty"`
+	TimestampTo   uint64 `protobuf:"varint,3,opt,name=timestamp_to,json=timestampTo,proto3" json:"timestamp_to,omitempty"`
+	LogPath       string `protobuf:"bytes,4,opt,name=log_path,json=logPath,proto3" json:"log_path,omitempty"`
+	LogSize       int64  `protobuf:"varint,5,opt,name=log_size,json=logSize,proto3" json:"log_size"`
+	// compression codec of the binlog copied into backup, empty if it is not compressed
+	Compression          string   `protobuf:"bytes,6,opt,name=compression,proto3" json:"compression,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2209,7 +3336,7 @@ func (m *Binlog) Reset()         { *m = Binlog{} }
 func (m *Binlog) String() string { return proto.CompactTextString(m) }
 func (*Binlog) ProtoMessage()    {}
 func (*Binlog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{22}
+	return fileDescriptor_65240d19de191688, []int{32}
 }
 
 func (m *Binlog) XXX_Unmarshal(b []byte) error {
@@ -2265,6 +3392,13 @@ func (m *Binlog) GetLogSize() int64 {
 	return 0
 }
 
+func (m *Binlog) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
 // copied from milvus common.proto
 type KeyValuePair struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -2278,7 +3412,7 @@ func (m *KeyValuePair) Reset()         { *m = KeyValuePair{} }
 func (m *KeyValuePair) String() string { return proto.CompactTextString(m) }
 func (*KeyValuePair) ProtoMessage()    {}
 func (*KeyValuePair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{23}
+	return fileDescriptor_65240d19de191688, []int{33}
 }
 
 func (m *KeyValuePair) XXX_Unmarshal(b []byte) error {
@@ -2333,7 +3467,7 @@ func (m *ValueField) Reset()         { *m = ValueField{} }
 func (m *ValueField) String() string { return proto.CompactTextString(m) }
 func (*ValueField) ProtoMessage()    {}
 func (*ValueField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{24}
+	return fileDescriptor_65240d19de191688, []int{34}
 }
 
 func (m *ValueField) XXX_Unmarshal(b []byte) error {
@@ -2494,7 +3628,7 @@ func (m *FieldSchema) Reset()         { *m = FieldSchema{} }
 func (m *FieldSchema) String() string { return proto.CompactTextString(m) }
 func (*FieldSchema) ProtoMessage()    {}
 func (*FieldSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{25}
+	return fileDescriptor_65240d19de191688, []int{35}
 }
 
 func (m *FieldSchema) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +3757,7 @@ func (m *CollectionSchema) Reset()         { *m = CollectionSchema{} }
 func (m *CollectionSchema) String() string { return proto.CompactTextString(m) }
 func (*CollectionSchema) ProtoMessage()    {}
 func (*CollectionSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{26}
+	return fileDescriptor_65240d19de191688, []int{36}
 }
 
 func (m *CollectionSchema) XXX_Unmarshal(b []byte) error {
@@ -2689,7 +3823,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{27}
+	return fileDescriptor_65240d19de191688, []int{37}
 }
 
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
@@ -2724,7 +3858,7 @@ func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
 func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckResponse) ProtoMessage()    {}
 func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{28}
+	return fileDescriptor_65240d19de191688, []int{38}
 }
 
 func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
@@ -2773,7 +3907,7 @@ func (m *MsgPosition) Reset()         { *m = MsgPosition{} }
 func (m *MsgPosition) String() string { return proto.CompactTextString(m) }
 func (*MsgPosition) ProtoMessage()    {}
 func (*MsgPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{29}
+	return fileDescriptor_65240d19de191688, []int{39}
 }
 
 func (m *MsgPosition) XXX_Unmarshal(b []byte) error {
@@ -2834,7 +3968,7 @@ func (m *ChannelPosition) Reset()         { *m = ChannelPosition{} }
 func (m *ChannelPosition) String() string { return proto.CompactTextString(m) }
 func (*ChannelPosition) ProtoMessage()    {}
 func (*ChannelPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{30}
+	return fileDescriptor_65240d19de191688, []int{40}
 }
 
 func (m *ChannelPosition) XXX_Unmarshal(b []byte) error {
@@ -2869,24 +4003,1990 @@ func (m *ChannelPosition) GetPosition() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("milvus.proto.backup.ResponseCode", ResponseCode_name, ResponseCode_value)
-	proto.RegisterEnum("milvus.proto.backup.BackupTaskStateCode", BackupTaskStateCode_name, BackupTaskStateCode_value)
-	proto.RegisterEnum("milvus.proto.backup.RestoreTaskStateCode", RestoreTaskStateCode_name, RestoreTaskStateCode_value)
-	proto.RegisterEnum("milvus.proto.backup.ConsistencyLevel", ConsistencyLevel_name, ConsistencyLevel_value)
-	proto.RegisterEnum("milvus.proto.backup.DataType", DataType_name, DataType_value)
-	proto.RegisterEnum("milvus.proto.backup.FieldState", FieldState_name, FieldState_value)
-	proto.RegisterType((*IndexInfo)(nil), "milvus.proto.backup.IndexInfo")
-	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.IndexInfo.ParamsEntry")
-	proto.RegisterType((*CollectionBackupInfo)(nil), "milvus.proto.backup.CollectionBackupInfo")
-	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.CollectionBackupInfo.ChannelCheckpointsEntry")
-	proto.RegisterType((*PartitionBackupInfo)(nil), "milvus.proto.backup.PartitionBackupInfo")
-	proto.RegisterType((*SegmentBackupInfo)(nil), "milvus.proto.backup.SegmentBackupInfo")
-	proto.RegisterType((*BackupInfo)(nil), "milvus.proto.backup.BackupInfo")
+type ScheduleRun struct {
+	// unix time in seconds when the run started
+	StartTime int64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// unix time in seconds when the run ended, 0 if it is still running
+	EndTime int64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// name of the backup created by the run
+	BackupName string              `protobuf:"bytes,3,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	StateCode  BackupTaskStateCode `protobuf:"varint,4,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.BackupTaskStateCode" json:"state_code"`
+	// error msg if the run fails or is skipped
+	Msg                  string   `protobuf:"bytes,5,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduleRun) Reset()         { *m = ScheduleRun{} }
+func (m *ScheduleRun) String() string { return proto.CompactTextString(m) }
+func (*ScheduleRun) ProtoMessage()    {}
+func (*ScheduleRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{41}
+}
+
+func (m *ScheduleRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleRun.Unmarshal(m, b)
+}
+func (m *ScheduleRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleRun.Marshal(b, m, deterministic)
+}
+func (m *ScheduleRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleRun.Merge(m, src)
+}
+func (m *ScheduleRun) XXX_Size() int {
+	return xxx_messageInfo_ScheduleRun.Size(m)
+}
+func (m *ScheduleRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleRun proto.InternalMessageInfo
+
+func (m *ScheduleRun) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ScheduleRun) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ScheduleRun) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *ScheduleRun) GetStateCode() BackupTaskStateCode {
+	if m != nil {
+		return m.StateCode
+	}
+	return BackupTaskStateCode_BACKUP_INITIAL
+}
+
+func (m *ScheduleRun) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+type BackupSchedule struct {
+	// unique name of the schedule
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// cron expression: minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly, @yearly
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// database and collections to backup, same as db_collections of CreateBackupRequest, empty to backup all
+	DbCollections *_struct.Value `protobuf:"bytes,3,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
+	// template of the backup name, supports {schedule}, {time} and {timestamp}
+	NameTemplate string `protobuf:"bytes,4,opt,name=name_template,json=nameTemplate,proto3" json:"name_template,omitempty"`
+	// only backup meta, including collection schema and index info
+	MetaOnly bool `protobuf:"varint,5,opt,name=meta_only,json=metaOnly,proto3" json:"meta_only,omitempty"`
+	// force backup skip flush
+	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
+	// where the schedule is defined: config or api
+	Source string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	// unix time in seconds of the last run, 0 if it never runs
+	LastRunTime int64 `protobuf:"varint,8,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	// unix time in seconds of the next run, 0 if it never runs again
+	NextRunTime int64 `protobuf:"varint,9,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	// latest runs of the schedule, the latest first
+	History              []*ScheduleRun `protobuf:"bytes,10,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BackupSchedule) Reset()         { *m = BackupSchedule{} }
+func (m *BackupSchedule) String() string { return proto.CompactTextString(m) }
+func (*BackupSchedule) ProtoMessage()    {}
+func (*BackupSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{42}
+}
+
+func (m *BackupSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupSchedule.Unmarshal(m, b)
+}
+func (m *BackupSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupSchedule.Marshal(b, m, deterministic)
+}
+func (m *BackupSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupSchedule.Merge(m, src)
+}
+func (m *BackupSchedule) XXX_Size() int {
+	return xxx_messageInfo_BackupSchedule.Size(m)
+}
+func (m *BackupSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupSchedule proto.InternalMessageInfo
+
+func (m *BackupSchedule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BackupSchedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *BackupSchedule) GetDbCollections() *_struct.Value {
+	if m != nil {
+		return m.DbCollections
+	}
+	return nil
+}
+
+func (m *BackupSchedule) GetNameTemplate() string {
+	if m != nil {
+		return m.NameTemplate
+	}
+	return ""
+}
+
+func (m *BackupSchedule) GetMetaOnly() bool {
+	if m != nil {
+		return m.MetaOnly
+	}
+	return false
+}
+
+func (m *BackupSchedule) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *BackupSchedule) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *BackupSchedule) GetLastRunTime() int64 {
+	if m != nil {
+		return m.LastRunTime
+	}
+	return 0
+}
+
+func (m *BackupSchedule) GetNextRunTime() int64 {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return 0
+}
+
+func (m *BackupSchedule) GetHistory() []*ScheduleRun {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type CreateScheduleRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// unique name of the schedule
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// cron expression: minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly, @yearly
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// database and collections to backup, same as db_collections of CreateBackupRequest, empty to backup all
+	DbCollections *_struct.Value `protobuf:"bytes,4,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
+	// template of the backup name, supports {schedule}, {time} and {timestamp}, default is {schedule}_{time}
+	NameTemplate string `protobuf:"bytes,5,opt,name=name_template,json=nameTemplate,proto3" json:"name_template,omitempty"`
+	// only backup meta, including collection schema and index info
+	MetaOnly bool `protobuf:"varint,6,opt,name=meta_only,json=metaOnly,proto3" json:"meta_only,omitempty"`
+	// force backup skip flush
+	Force                bool     `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateScheduleRequest) Reset()         { *m = CreateScheduleRequest{} }
+func (m *CreateScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateScheduleRequest) ProtoMessage()    {}
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{43}
+}
+
+func (m *CreateScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateScheduleRequest.Unmarshal(m, b)
+}
+func (m *CreateScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateScheduleRequest.Merge(m, src)
+}
+func (m *CreateScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateScheduleRequest.Size(m)
+}
+func (m *CreateScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateScheduleRequest proto.InternalMessageInfo
+
+func (m *CreateScheduleRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetDbCollections() *_struct.Value {
+	if m != nil {
+		return m.DbCollections
+	}
+	return nil
+}
+
+func (m *CreateScheduleRequest) GetNameTemplate() string {
+	if m != nil {
+		return m.NameTemplate
+	}
+	return ""
+}
+
+func (m *CreateScheduleRequest) GetMetaOnly() bool {
+	if m != nil {
+		return m.MetaOnly
+	}
+	return false
+}
+
+func (m *CreateScheduleRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type ScheduleResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// schedule entity
+	Data                 *BackupSchedule `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ScheduleResponse) Reset()         { *m = ScheduleResponse{} }
+func (m *ScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*ScheduleResponse) ProtoMessage()    {}
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{44}
+}
+
+func (m *ScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleResponse.Unmarshal(m, b)
+}
+func (m *ScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *ScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleResponse.Merge(m, src)
+}
+func (m *ScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_ScheduleResponse.Size(m)
+}
+func (m *ScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleResponse proto.InternalMessageInfo
+
+func (m *ScheduleResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ScheduleResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *ScheduleResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *ScheduleResponse) GetData() *BackupSchedule {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// only return the schedule with the name if set
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSchedulesRequest) Reset()         { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()    {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{45}
+}
+
+func (m *ListSchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesRequest.Unmarshal(m, b)
+}
+func (m *ListSchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesRequest.Merge(m, src)
+}
+func (m *ListSchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesRequest.Size(m)
+}
+func (m *ListSchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesRequest proto.InternalMessageInfo
+
+func (m *ListSchedulesRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ListSchedulesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListSchedulesResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// schedule entities
+	Data                 []*BackupSchedule `protobuf:"bytes,4,rep,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListSchedulesResponse) Reset()         { *m = ListSchedulesResponse{} }
+func (m *ListSchedulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesResponse) ProtoMessage()    {}
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{46}
+}
+
+func (m *ListSchedulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesResponse.Unmarshal(m, b)
+}
+func (m *ListSchedulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesResponse.Merge(m, src)
+}
+func (m *ListSchedulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesResponse.Size(m)
+}
+func (m *ListSchedulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesResponse proto.InternalMessageInfo
+
+func (m *ListSchedulesResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ListSchedulesResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *ListSchedulesResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *ListSchedulesResponse) GetData() []*BackupSchedule {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the schedule to delete
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteScheduleRequest) Reset()         { *m = DeleteScheduleRequest{} }
+func (m *DeleteScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteScheduleRequest) ProtoMessage()    {}
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{47}
+}
+
+func (m *DeleteScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteScheduleRequest.Unmarshal(m, b)
+}
+func (m *DeleteScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteScheduleRequest.Merge(m, src)
+}
+func (m *DeleteScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteScheduleRequest.Size(m)
+}
+func (m *DeleteScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteScheduleRequest proto.InternalMessageInfo
+
+func (m *DeleteScheduleRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *DeleteScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type PruneBackupsRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// only evaluate the retention policies without deleting any backup
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// only apply the retention policy with the name if set
+	Policy               string   `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneBackupsRequest) Reset()         { *m = PruneBackupsRequest{} }
+func (m *PruneBackupsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneBackupsRequest) ProtoMessage()    {}
+func (*PruneBackupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{48}
+}
+
+func (m *PruneBackupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneBackupsRequest.Unmarshal(m, b)
+}
+func (m *PruneBackupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneBackupsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneBackupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneBackupsRequest.Merge(m, src)
+}
+func (m *PruneBackupsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneBackupsRequest.Size(m)
+}
+func (m *PruneBackupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneBackupsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneBackupsRequest proto.InternalMessageInfo
+
+func (m *PruneBackupsRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PruneBackupsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *PruneBackupsRequest) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+type RetentionDecision struct {
+	BackupName string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// names of the retention policies selecting the backup
+	Policies []string `protobuf:"bytes,2,rep,name=policies,proto3" json:"policies,omitempty"`
+	// whether the backup is kept
+	Keep bool `protobuf:"varint,3,opt,name=keep,proto3" json:"keep,omitempty"`
+	// why the backup is kept or pruned
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetentionDecision) Reset()         { *m = RetentionDecision{} }
+func (m *RetentionDecision) String() string { return proto.CompactTextString(m) }
+func (*RetentionDecision) ProtoMessage()    {}
+func (*RetentionDecision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{49}
+}
+
+func (m *RetentionDecision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetentionDecision.Unmarshal(m, b)
+}
+func (m *RetentionDecision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetentionDecision.Marshal(b, m, deterministic)
+}
+func (m *RetentionDecision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetentionDecision.Merge(m, src)
+}
+func (m *RetentionDecision) XXX_Size() int {
+	return xxx_messageInfo_RetentionDecision.Size(m)
+}
+func (m *RetentionDecision) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetentionDecision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetentionDecision proto.InternalMessageInfo
+
+func (m *RetentionDecision) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *RetentionDecision) GetPolicies() []string {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+func (m *RetentionDecision) GetKeep() bool {
+	if m != nil {
+		return m.Keep
+	}
+	return false
+}
+
+func (m *RetentionDecision) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type PruneBackupsResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// decisions of the backups selected by the retention policies
+	Data                 []*RetentionDecision `protobuf:"bytes,4,rep,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PruneBackupsResponse) Reset()         { *m = PruneBackupsResponse{} }
+func (m *PruneBackupsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneBackupsResponse) ProtoMessage()    {}
+func (*PruneBackupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{50}
+}
+
+func (m *PruneBackupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneBackupsResponse.Unmarshal(m, b)
+}
+func (m *PruneBackupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneBackupsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneBackupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneBackupsResponse.Merge(m, src)
+}
+func (m *PruneBackupsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneBackupsResponse.Size(m)
+}
+func (m *PruneBackupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneBackupsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneBackupsResponse proto.InternalMessageInfo
+
+func (m *PruneBackupsResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *PruneBackupsResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *PruneBackupsResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *PruneBackupsResponse) GetData() []*RetentionDecision {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type VerifyBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the backup to verify
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,3,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// read the files to compare their checksums, otherwise only the existence and sizes are compared
+	Checksum             bool     `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyBackupRequest) Reset()         { *m = VerifyBackupRequest{} }
+func (m *VerifyBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyBackupRequest) ProtoMessage()    {}
+func (*VerifyBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{51}
+}
+
+func (m *VerifyBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyBackupRequest.Unmarshal(m, b)
+}
+func (m *VerifyBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBackupRequest.Merge(m, src)
+}
+func (m *VerifyBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyBackupRequest.Size(m)
+}
+func (m *VerifyBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBackupRequest proto.InternalMessageInfo
+
+func (m *VerifyBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *VerifyBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *VerifyBackupRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *VerifyBackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *VerifyBackupRequest) GetChecksum() bool {
+	if m != nil {
+		return m.Checksum
+	}
+	return false
+}
+
+type FileVerifyResult struct {
+	// path of the file in backup bucket
+	Path           string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	State          FileVerifyState `protobuf:"varint,2,opt,name=state,proto3,enum=milvus.proto.backup.FileVerifyState" json:"state,omitempty"`
+	CollectionId   int64           `protobuf:"varint,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	CollectionName string          `protobuf:"bytes,4,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	PartitionId    int64           `protobuf:"varint,5,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
+	SegmentId      int64           `protobuf:"varint,6,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	// recorded size, -1 if it is unknown
+	ExpectedSize int64 `protobuf:"varint,7,opt,name=expected_size,json=expectedSize,proto3" json:"expected_size"`
+	// size found in backup, -1 if the file is missing
+	ActualSize           int64    `protobuf:"varint,8,opt,name=actual_size,json=actualSize,proto3" json:"actual_size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileVerifyResult) Reset()         { *m = FileVerifyResult{} }
+func (m *FileVerifyResult) String() string { return proto.CompactTextString(m) }
+func (*FileVerifyResult) ProtoMessage()    {}
+func (*FileVerifyResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{52}
+}
+
+func (m *FileVerifyResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileVerifyResult.Unmarshal(m, b)
+}
+func (m *FileVerifyResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileVerifyResult.Marshal(b, m, deterministic)
+}
+func (m *FileVerifyResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileVerifyResult.Merge(m, src)
+}
+func (m *FileVerifyResult) XXX_Size() int {
+	return xxx_messageInfo_FileVerifyResult.Size(m)
+}
+func (m *FileVerifyResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileVerifyResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileVerifyResult proto.InternalMessageInfo
+
+func (m *FileVerifyResult) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileVerifyResult) GetState() FileVerifyState {
+	if m != nil {
+		return m.State
+	}
+	return FileVerifyState_FILE_OK
+}
+
+func (m *FileVerifyResult) GetCollectionId() int64 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *FileVerifyResult) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *FileVerifyResult) GetPartitionId() int64 {
+	if m != nil {
+		return m.PartitionId
+	}
+	return 0
+}
+
+func (m *FileVerifyResult) GetSegmentId() int64 {
+	if m != nil {
+		return m.SegmentId
+	}
+	return 0
+}
+
+func (m *FileVerifyResult) GetExpectedSize() int64 {
+	if m != nil {
+		return m.ExpectedSize
+	}
+	return 0
+}
+
+func (m *FileVerifyResult) GetActualSize() int64 {
+	if m != nil {
+		return m.ActualSize
+	}
+	return 0
+}
+
+type VerifyBackupResult struct {
+	BackupName string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// number of files checked
+	CheckedFiles int64 `protobuf:"varint,2,opt,name=checked_files,json=checkedFiles,proto3" json:"checked_files,omitempty"`
+	// number of files without recorded checksum, only their existence is checked
+	UnrecordedFiles int64 `protobuf:"varint,3,opt,name=unrecorded_files,json=unrecordedFiles,proto3" json:"unrecorded_files,omitempty"`
+	// files failing the verification
+	Problems             []*FileVerifyResult `protobuf:"bytes,4,rep,name=problems,proto3" json:"problems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *VerifyBackupResult) Reset()         { *m = VerifyBackupResult{} }
+func (m *VerifyBackupResult) String() string { return proto.CompactTextString(m) }
+func (*VerifyBackupResult) ProtoMessage()    {}
+func (*VerifyBackupResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{53}
+}
+
+func (m *VerifyBackupResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyBackupResult.Unmarshal(m, b)
+}
+func (m *VerifyBackupResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyBackupResult.Marshal(b, m, deterministic)
+}
+func (m *VerifyBackupResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBackupResult.Merge(m, src)
+}
+func (m *VerifyBackupResult) XXX_Size() int {
+	return xxx_messageInfo_VerifyBackupResult.Size(m)
+}
+func (m *VerifyBackupResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBackupResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBackupResult proto.InternalMessageInfo
+
+func (m *VerifyBackupResult) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *VerifyBackupResult) GetCheckedFiles() int64 {
+	if m != nil {
+		return m.CheckedFiles
+	}
+	return 0
+}
+
+func (m *VerifyBackupResult) GetUnrecordedFiles() int64 {
+	if m != nil {
+		return m.UnrecordedFiles
+	}
+	return 0
+}
+
+func (m *VerifyBackupResult) GetProblems() []*FileVerifyResult {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+type VerifyBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string              `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data                 *VerifyBackupResult `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *VerifyBackupResponse) Reset()         { *m = VerifyBackupResponse{} }
+func (m *VerifyBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyBackupResponse) ProtoMessage()    {}
+func (*VerifyBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{54}
+}
+
+func (m *VerifyBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyBackupResponse.Unmarshal(m, b)
+}
+func (m *VerifyBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *VerifyBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBackupResponse.Merge(m, src)
+}
+func (m *VerifyBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyBackupResponse.Size(m)
+}
+func (m *VerifyBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBackupResponse proto.InternalMessageInfo
+
+func (m *VerifyBackupResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *VerifyBackupResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *VerifyBackupResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *VerifyBackupResponse) GetData() *VerifyBackupResult {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ExportBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the backup to export
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,3,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// path of the archive file to write, relative to the archive dir of the server
+	ArchivePath string `protobuf:"bytes,5,opt,name=archive_path,json=archivePath,proto3" json:"archive_path,omitempty"`
+	// compression codec of the archive, support none, gzip, zstd. default none
+	Compression          string   `protobuf:"bytes,6,opt,name=compression,proto3" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportBackupRequest) Reset()         { *m = ExportBackupRequest{} }
+func (m *ExportBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportBackupRequest) ProtoMessage()    {}
+func (*ExportBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{55}
+}
+
+func (m *ExportBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportBackupRequest.Unmarshal(m, b)
+}
+func (m *ExportBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportBackupRequest.Merge(m, src)
+}
+func (m *ExportBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportBackupRequest.Size(m)
+}
+func (m *ExportBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportBackupRequest proto.InternalMessageInfo
+
+func (m *ExportBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ExportBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *ExportBackupRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *ExportBackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ExportBackupRequest) GetArchivePath() string {
+	if m != nil {
+		return m.ArchivePath
+	}
+	return ""
+}
+
+func (m *ExportBackupRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+type ImportBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// path of the archive file to read, relative to the archive dir of the server
+	ArchivePath string `protobuf:"bytes,2,opt,name=archive_path,json=archivePath,proto3" json:"archive_path,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,3,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path                 string   `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBackupRequest) Reset()         { *m = ImportBackupRequest{} }
+func (m *ImportBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportBackupRequest) ProtoMessage()    {}
+func (*ImportBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{56}
+}
+
+func (m *ImportBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBackupRequest.Unmarshal(m, b)
+}
+func (m *ImportBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBackupRequest.Merge(m, src)
+}
+func (m *ImportBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportBackupRequest.Size(m)
+}
+func (m *ImportBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBackupRequest proto.InternalMessageInfo
+
+func (m *ImportBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ImportBackupRequest) GetArchivePath() string {
+	if m != nil {
+		return m.ArchivePath
+	}
+	return ""
+}
+
+func (m *ImportBackupRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *ImportBackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type BackupArchiveResult struct {
+	// name of the exported or imported backup
+	BackupName  string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	ArchivePath string `protobuf:"bytes,2,opt,name=archive_path,json=archivePath,proto3" json:"archive_path,omitempty"`
+	// backups in the archive, the backup itself and the backups referenced by its segments
+	Backups []string `protobuf:"bytes,3,rep,name=backups,proto3" json:"backups,omitempty"`
+	// referenced backups already existing, not imported again
+	SkippedBackups []string `protobuf:"bytes,4,rep,name=skipped_backups,json=skippedBackups,proto3" json:"skipped_backups,omitempty"`
+	// number of files in the archive, including the blobs of deduplicated backups
+	Files int64 `protobuf:"varint,5,opt,name=files,proto3" json:"files,omitempty"`
+	// total size of the files in bytes, before the archive is compressed
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupArchiveResult) Reset()         { *m = BackupArchiveResult{} }
+func (m *BackupArchiveResult) String() string { return proto.CompactTextString(m) }
+func (*BackupArchiveResult) ProtoMessage()    {}
+func (*BackupArchiveResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{57}
+}
+
+func (m *BackupArchiveResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupArchiveResult.Unmarshal(m, b)
+}
+func (m *BackupArchiveResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupArchiveResult.Marshal(b, m, deterministic)
+}
+func (m *BackupArchiveResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupArchiveResult.Merge(m, src)
+}
+func (m *BackupArchiveResult) XXX_Size() int {
+	return xxx_messageInfo_BackupArchiveResult.Size(m)
+}
+func (m *BackupArchiveResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupArchiveResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupArchiveResult proto.InternalMessageInfo
+
+func (m *BackupArchiveResult) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *BackupArchiveResult) GetArchivePath() string {
+	if m != nil {
+		return m.ArchivePath
+	}
+	return ""
+}
+
+func (m *BackupArchiveResult) GetBackups() []string {
+	if m != nil {
+		return m.Backups
+	}
+	return nil
+}
+
+func (m *BackupArchiveResult) GetSkippedBackups() []string {
+	if m != nil {
+		return m.SkippedBackups
+	}
+	return nil
+}
+
+func (m *BackupArchiveResult) GetFiles() int64 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *BackupArchiveResult) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type BackupArchiveResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string               `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data                 *BackupArchiveResult `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BackupArchiveResponse) Reset()         { *m = BackupArchiveResponse{} }
+func (m *BackupArchiveResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupArchiveResponse) ProtoMessage()    {}
+func (*BackupArchiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{58}
+}
+
+func (m *BackupArchiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupArchiveResponse.Unmarshal(m, b)
+}
+func (m *BackupArchiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupArchiveResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupArchiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupArchiveResponse.Merge(m, src)
+}
+func (m *BackupArchiveResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupArchiveResponse.Size(m)
+}
+func (m *BackupArchiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupArchiveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupArchiveResponse proto.InternalMessageInfo
+
+func (m *BackupArchiveResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *BackupArchiveResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *BackupArchiveResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *BackupArchiveResponse) GetData() *BackupArchiveResult {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CopyBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the backup to copy
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,3,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// name of the copy target in config, copy within the backup storage if not set
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	// if target_bucket_name and target_path is set. will override the bucket/path of the target.
+	TargetBucketName string `protobuf:"bytes,6,opt,name=target_bucket_name,json=targetBucketName,proto3" json:"target_bucket_name,omitempty"`
+	// if target_bucket_name and target_path is set. will override the bucket/path of the target.
+	TargetPath string `protobuf:"bytes,7,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// read the copied files to compare their checksums in the verification, otherwise only the existence and sizes are compared
+	Checksum             bool     `protobuf:"varint,8,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyBackupRequest) Reset()         { *m = CopyBackupRequest{} }
+func (m *CopyBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyBackupRequest) ProtoMessage()    {}
+func (*CopyBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{59}
+}
+
+func (m *CopyBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyBackupRequest.Unmarshal(m, b)
+}
+func (m *CopyBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *CopyBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyBackupRequest.Merge(m, src)
+}
+func (m *CopyBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyBackupRequest.Size(m)
+}
+func (m *CopyBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyBackupRequest proto.InternalMessageInfo
+
+func (m *CopyBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetTargetBucketName() string {
+	if m != nil {
+		return m.TargetBucketName
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetTargetPath() string {
+	if m != nil {
+		return m.TargetPath
+	}
+	return ""
+}
+
+func (m *CopyBackupRequest) GetChecksum() bool {
+	if m != nil {
+		return m.Checksum
+	}
+	return false
+}
+
+type CopyBackupResult struct {
+	BackupName       string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	Target           string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	TargetBucketName string `protobuf:"bytes,3,opt,name=target_bucket_name,json=targetBucketName,proto3" json:"target_bucket_name,omitempty"`
+	TargetPath       string `protobuf:"bytes,4,opt,name=target_path,json=targetPath,proto3" json:"target_path,omitempty"`
+	// backups copied, the backups referenced by the segments are copied before the backup
+	Backups []string `protobuf:"bytes,5,rep,name=backups,proto3" json:"backups,omitempty"`
+	// referenced backups already existing in the target, not copied again
+	SkippedBackups []string `protobuf:"bytes,6,rep,name=skipped_backups,json=skippedBackups,proto3" json:"skipped_backups,omitempty"`
+	// number of files copied
+	CopiedFiles int64 `protobuf:"varint,7,opt,name=copied_files,json=copiedFiles,proto3" json:"copied_files,omitempty"`
+	// number of files already existing in the target with the same size, not copied again
+	SkippedFiles int64 `protobuf:"varint,8,opt,name=skipped_files,json=skippedFiles,proto3" json:"skipped_files,omitempty"`
+	// total size of the copied files in bytes
+	CopiedSize int64 `protobuf:"varint,9,opt,name=copied_size,json=copiedSize,proto3" json:"copied_size"`
+	// number of files checked by the verification
+	VerifiedFiles        int64    `protobuf:"varint,10,opt,name=verified_files,json=verifiedFiles,proto3" json:"verified_files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyBackupResult) Reset()         { *m = CopyBackupResult{} }
+func (m *CopyBackupResult) String() string { return proto.CompactTextString(m) }
+func (*CopyBackupResult) ProtoMessage()    {}
+func (*CopyBackupResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{60}
+}
+
+func (m *CopyBackupResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyBackupResult.Unmarshal(m, b)
+}
+func (m *CopyBackupResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyBackupResult.Marshal(b, m, deterministic)
+}
+func (m *CopyBackupResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyBackupResult.Merge(m, src)
+}
+func (m *CopyBackupResult) XXX_Size() int {
+	return xxx_messageInfo_CopyBackupResult.Size(m)
+}
+func (m *CopyBackupResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyBackupResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyBackupResult proto.InternalMessageInfo
+
+func (m *CopyBackupResult) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *CopyBackupResult) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *CopyBackupResult) GetTargetBucketName() string {
+	if m != nil {
+		return m.TargetBucketName
+	}
+	return ""
+}
+
+func (m *CopyBackupResult) GetTargetPath() string {
+	if m != nil {
+		return m.TargetPath
+	}
+	return ""
+}
+
+func (m *CopyBackupResult) GetBackups() []string {
+	if m != nil {
+		return m.Backups
+	}
+	return nil
+}
+
+func (m *CopyBackupResult) GetSkippedBackups() []string {
+	if m != nil {
+		return m.SkippedBackups
+	}
+	return nil
+}
+
+func (m *CopyBackupResult) GetCopiedFiles() int64 {
+	if m != nil {
+		return m.CopiedFiles
+	}
+	return 0
+}
+
+func (m *CopyBackupResult) GetSkippedFiles() int64 {
+	if m != nil {
+		return m.SkippedFiles
+	}
+	return 0
+}
+
+func (m *CopyBackupResult) GetCopiedSize() int64 {
+	if m != nil {
+		return m.CopiedSize
+	}
+	return 0
+}
+
+func (m *CopyBackupResult) GetVerifiedFiles() int64 {
+	if m != nil {
+		return m.VerifiedFiles
+	}
+	return 0
+}
+
+type CopyBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string            `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data                 *CopyBackupResult `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CopyBackupResponse) Reset()         { *m = CopyBackupResponse{} }
+func (m *CopyBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*CopyBackupResponse) ProtoMessage()    {}
+func (*CopyBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{61}
+}
+
+func (m *CopyBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyBackupResponse.Unmarshal(m, b)
+}
+func (m *CopyBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *CopyBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyBackupResponse.Merge(m, src)
+}
+func (m *CopyBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_CopyBackupResponse.Size(m)
+}
+func (m *CopyBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyBackupResponse proto.InternalMessageInfo
+
+func (m *CopyBackupResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CopyBackupResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *CopyBackupResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *CopyBackupResponse) GetData() *CopyBackupResult {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// ThrottleProfile overrides the limits of the throttle between start and end of every day
+type ThrottleProfile struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "HH:MM" in the local time of the server
+	Start string `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// "HH:MM" in the local time of the server, earlier than start if the profile crosses midnight
+	End string `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// 0 means no limit
+	BytesPerSecond int64 `protobuf:"varint,4,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// 0 means no limit
+	RequestsPerSecond    int32    `protobuf:"varint,5,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThrottleProfile) Reset()         { *m = ThrottleProfile{} }
+func (m *ThrottleProfile) String() string { return proto.CompactTextString(m) }
+func (*ThrottleProfile) ProtoMessage()    {}
+func (*ThrottleProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{62}
+}
+
+func (m *ThrottleProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottleProfile.Unmarshal(m, b)
+}
+func (m *ThrottleProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottleProfile.Marshal(b, m, deterministic)
+}
+func (m *ThrottleProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottleProfile.Merge(m, src)
+}
+func (m *ThrottleProfile) XXX_Size() int {
+	return xxx_messageInfo_ThrottleProfile.Size(m)
+}
+func (m *ThrottleProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottleProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottleProfile proto.InternalMessageInfo
+
+func (m *ThrottleProfile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ThrottleProfile) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *ThrottleProfile) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *ThrottleProfile) GetBytesPerSecond() int64 {
+	if m != nil {
+		return m.BytesPerSecond
+	}
+	return 0
+}
+
+func (m *ThrottleProfile) GetRequestsPerSecond() int32 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+type ThrottleConfig struct {
+	// limit of the bytes copied per second when no profile is active, 0 means no limit
+	BytesPerSecond int64 `protobuf:"varint,1,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// limit of the storage requests per second when no profile is active, 0 means no limit
+	RequestsPerSecond int32 `protobuf:"varint,2,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// the first active profile in order takes effect
+	Profiles             []*ThrottleProfile `protobuf:"bytes,3,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ThrottleConfig) Reset()         { *m = ThrottleConfig{} }
+func (m *ThrottleConfig) String() string { return proto.CompactTextString(m) }
+func (*ThrottleConfig) ProtoMessage()    {}
+func (*ThrottleConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{63}
+}
+
+func (m *ThrottleConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottleConfig.Unmarshal(m, b)
+}
+func (m *ThrottleConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottleConfig.Marshal(b, m, deterministic)
+}
+func (m *ThrottleConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottleConfig.Merge(m, src)
+}
+func (m *ThrottleConfig) XXX_Size() int {
+	return xxx_messageInfo_ThrottleConfig.Size(m)
+}
+func (m *ThrottleConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottleConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottleConfig proto.InternalMessageInfo
+
+func (m *ThrottleConfig) GetBytesPerSecond() int64 {
+	if m != nil {
+		return m.BytesPerSecond
+	}
+	return 0
+}
+
+func (m *ThrottleConfig) GetRequestsPerSecond() int32 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+func (m *ThrottleConfig) GetProfiles() []*ThrottleProfile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+type GetThrottleRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId            string   `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetThrottleRequest) Reset()         { *m = GetThrottleRequest{} }
+func (m *GetThrottleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetThrottleRequest) ProtoMessage()    {}
+func (*GetThrottleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{64}
+}
+
+func (m *GetThrottleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetThrottleRequest.Unmarshal(m, b)
+}
+func (m *GetThrottleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetThrottleRequest.Marshal(b, m, deterministic)
+}
+func (m *GetThrottleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThrottleRequest.Merge(m, src)
+}
+func (m *GetThrottleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetThrottleRequest.Size(m)
+}
+func (m *GetThrottleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThrottleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetThrottleRequest proto.InternalMessageInfo
+
+func (m *GetThrottleRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+type UpdateThrottleRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// replaces the current throttle config
+	Throttle             *ThrottleConfig `protobuf:"bytes,2,opt,name=throttle,proto3" json:"throttle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UpdateThrottleRequest) Reset()         { *m = UpdateThrottleRequest{} }
+func (m *UpdateThrottleRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateThrottleRequest) ProtoMessage()    {}
+func (*UpdateThrottleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{65}
+}
+
+func (m *UpdateThrottleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateThrottleRequest.Unmarshal(m, b)
+}
+func (m *UpdateThrottleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateThrottleRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateThrottleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateThrottleRequest.Merge(m, src)
+}
+func (m *UpdateThrottleRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateThrottleRequest.Size(m)
+}
+func (m *UpdateThrottleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateThrottleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateThrottleRequest proto.InternalMessageInfo
+
+func (m *UpdateThrottleRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *UpdateThrottleRequest) GetThrottle() *ThrottleConfig {
+	if m != nil {
+		return m.Throttle
+	}
+	return nil
+}
+
+type ThrottleInfo struct {
+	Config *ThrottleConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// name of the active profile, empty if no profile is active
+	ActiveProfile string `protobuf:"bytes,2,opt,name=active_profile,json=activeProfile,proto3" json:"active_profile,omitempty"`
+	// limits in effect
+	BytesPerSecond       int64    `protobuf:"varint,3,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	RequestsPerSecond    int32    `protobuf:"varint,4,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThrottleInfo) Reset()         { *m = ThrottleInfo{} }
+func (m *ThrottleInfo) String() string { return proto.CompactTextString(m) }
+func (*ThrottleInfo) ProtoMessage()    {}
+func (*ThrottleInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{66}
+}
+
+func (m *ThrottleInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottleInfo.Unmarshal(m, b)
+}
+func (m *ThrottleInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottleInfo.Marshal(b, m, deterministic)
+}
+func (m *ThrottleInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottleInfo.Merge(m, src)
+}
+func (m *ThrottleInfo) XXX_Size() int {
+	return xxx_messageInfo_ThrottleInfo.Size(m)
+}
+func (m *ThrottleInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottleInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottleInfo proto.InternalMessageInfo
+
+func (m *ThrottleInfo) GetConfig() *ThrottleConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *ThrottleInfo) GetActiveProfile() string {
+	if m != nil {
+		return m.ActiveProfile
+	}
+	return ""
+}
+
+func (m *ThrottleInfo) GetBytesPerSecond() int64 {
+	if m != nil {
+		return m.BytesPerSecond
+	}
+	return 0
+}
+
+func (m *ThrottleInfo) GetRequestsPerSecond() int32 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+type ThrottleResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string        `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data                 *ThrottleInfo `protobuf:"bytes,4,opt,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ThrottleResponse) Reset()         { *m = ThrottleResponse{} }
+func (m *ThrottleResponse) String() string { return proto.CompactTextString(m) }
+func (*ThrottleResponse) ProtoMessage()    {}
+func (*ThrottleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{67}
+}
+
+func (m *ThrottleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThrottleResponse.Unmarshal(m, b)
+}
+func (m *ThrottleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThrottleResponse.Marshal(b, m, deterministic)
+}
+func (m *ThrottleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThrottleResponse.Merge(m, src)
+}
+func (m *ThrottleResponse) XXX_Size() int {
+	return xxx_messageInfo_ThrottleResponse.Size(m)
+}
+func (m *ThrottleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThrottleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThrottleResponse proto.InternalMessageInfo
+
+func (m *ThrottleResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ThrottleResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *ThrottleResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *ThrottleResponse) GetData() *ThrottleInfo {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("milvus.proto.backup.ResponseCode", ResponseCode_name, ResponseCode_value)
+	proto.RegisterEnum("milvus.proto.backup.BackupTaskStateCode", BackupTaskStateCode_name, BackupTaskStateCode_value)
+	proto.RegisterEnum("milvus.proto.backup.RestoreTaskStateCode", RestoreTaskStateCode_name, RestoreTaskStateCode_value)
+	proto.RegisterEnum("milvus.proto.backup.ConsistencyLevel", ConsistencyLevel_name, ConsistencyLevel_value)
+	proto.RegisterEnum("milvus.proto.backup.DataType", DataType_name, DataType_value)
+	proto.RegisterEnum("milvus.proto.backup.FieldState", FieldState_name, FieldState_value)
+	proto.RegisterEnum("milvus.proto.backup.FileVerifyState", FileVerifyState_name, FileVerifyState_value)
+	proto.RegisterType((*IndexInfo)(nil), "milvus.proto.backup.IndexInfo")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.IndexInfo.ParamsEntry")
+	proto.RegisterType((*CollectionBackupInfo)(nil), "milvus.proto.backup.CollectionBackupInfo")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.CollectionBackupInfo.ChannelCheckpointsEntry")
+	proto.RegisterType((*PartitionBackupInfo)(nil), "milvus.proto.backup.PartitionBackupInfo")
+	proto.RegisterType((*SegmentBackupInfo)(nil), "milvus.proto.backup.SegmentBackupInfo")
+	proto.RegisterType((*BackupInfo)(nil), "milvus.proto.backup.BackupInfo")
+	proto.RegisterType((*EncryptionInfo)(nil), "milvus.proto.backup.EncryptionInfo")
 	proto.RegisterType((*CollectionLevelBackupInfo)(nil), "milvus.proto.backup.CollectionLevelBackupInfo")
 	proto.RegisterType((*PartitionLevelBackupInfo)(nil), "milvus.proto.backup.PartitionLevelBackupInfo")
 	proto.RegisterType((*SegmentLevelBackupInfo)(nil), "milvus.proto.backup.SegmentLevelBackupInfo")
 	proto.RegisterType((*CreateBackupRequest)(nil), "milvus.proto.backup.CreateBackupRequest")
+	proto.RegisterType((*ResumeBackupRequest)(nil), "milvus.proto.backup.ResumeBackupRequest")
+	proto.RegisterType((*CancelBackupRequest)(nil), "milvus.proto.backup.CancelBackupRequest")
+	proto.RegisterType((*CancelRestoreRequest)(nil), "milvus.proto.backup.CancelRestoreRequest")
 	proto.RegisterType((*BackupInfoResponse)(nil), "milvus.proto.backup.BackupInfoResponse")
 	proto.RegisterType((*GetBackupRequest)(nil), "milvus.proto.backup.GetBackupRequest")
 	proto.RegisterType((*ListBackupsRequest)(nil), "milvus.proto.backup.ListBackupsRequest")
@@ -2897,7 +5997,13 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.RestoreBackupRequest.CollectionRenamesEntry")
 	proto.RegisterType((*RestorePartitionTask)(nil), "milvus.proto.backup.RestorePartitionTask")
 	proto.RegisterType((*RestoreCollectionTask)(nil), "milvus.proto.backup.RestoreCollectionTask")
+	proto.RegisterType((*RestorePartitionVerifyResult)(nil), "milvus.proto.backup.RestorePartitionVerifyResult")
+	proto.RegisterType((*RestoreVerifyResult)(nil), "milvus.proto.backup.RestoreVerifyResult")
 	proto.RegisterType((*RestoreBackupTask)(nil), "milvus.proto.backup.RestoreBackupTask")
+	proto.RegisterType((*RestoreGroupPlan)(nil), "milvus.proto.backup.RestoreGroupPlan")
+	proto.RegisterType((*RestorePartitionPlan)(nil), "milvus.proto.backup.RestorePartitionPlan")
+	proto.RegisterType((*RestoreCollectionPlan)(nil), "milvus.proto.backup.RestoreCollectionPlan")
+	proto.RegisterType((*RestorePlan)(nil), "milvus.proto.backup.RestorePlan")
 	proto.RegisterType((*RestoreBackupResponse)(nil), "milvus.proto.backup.RestoreBackupResponse")
 	proto.RegisterType((*GetRestoreStateRequest)(nil), "milvus.proto.backup.GetRestoreStateRequest")
 	proto.RegisterType((*FieldBinlog)(nil), "milvus.proto.backup.FieldBinlog")
@@ -2910,202 +6016,375 @@ func init() {
 	proto.RegisterType((*CheckResponse)(nil), "milvus.proto.backup.CheckResponse")
 	proto.RegisterType((*MsgPosition)(nil), "milvus.proto.backup.MsgPosition")
 	proto.RegisterType((*ChannelPosition)(nil), "milvus.proto.backup.ChannelPosition")
+	proto.RegisterType((*ScheduleRun)(nil), "milvus.proto.backup.ScheduleRun")
+	proto.RegisterType((*BackupSchedule)(nil), "milvus.proto.backup.BackupSchedule")
+	proto.RegisterType((*CreateScheduleRequest)(nil), "milvus.proto.backup.CreateScheduleRequest")
+	proto.RegisterType((*ScheduleResponse)(nil), "milvus.proto.backup.ScheduleResponse")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "milvus.proto.backup.ListSchedulesRequest")
+	proto.RegisterType((*ListSchedulesResponse)(nil), "milvus.proto.backup.ListSchedulesResponse")
+	proto.RegisterType((*DeleteScheduleRequest)(nil), "milvus.proto.backup.DeleteScheduleRequest")
+	proto.RegisterType((*PruneBackupsRequest)(nil), "milvus.proto.backup.PruneBackupsRequest")
+	proto.RegisterType((*RetentionDecision)(nil), "milvus.proto.backup.RetentionDecision")
+	proto.RegisterType((*PruneBackupsResponse)(nil), "milvus.proto.backup.PruneBackupsResponse")
+	proto.RegisterType((*VerifyBackupRequest)(nil), "milvus.proto.backup.VerifyBackupRequest")
+	proto.RegisterType((*FileVerifyResult)(nil), "milvus.proto.backup.FileVerifyResult")
+	proto.RegisterType((*VerifyBackupResult)(nil), "milvus.proto.backup.VerifyBackupResult")
+	proto.RegisterType((*VerifyBackupResponse)(nil), "milvus.proto.backup.VerifyBackupResponse")
+	proto.RegisterType((*ExportBackupRequest)(nil), "milvus.proto.backup.ExportBackupRequest")
+	proto.RegisterType((*ImportBackupRequest)(nil), "milvus.proto.backup.ImportBackupRequest")
+	proto.RegisterType((*BackupArchiveResult)(nil), "milvus.proto.backup.BackupArchiveResult")
+	proto.RegisterType((*BackupArchiveResponse)(nil), "milvus.proto.backup.BackupArchiveResponse")
+	proto.RegisterType((*CopyBackupRequest)(nil), "milvus.proto.backup.CopyBackupRequest")
+	proto.RegisterType((*CopyBackupResult)(nil), "milvus.proto.backup.CopyBackupResult")
+	proto.RegisterType((*CopyBackupResponse)(nil), "milvus.proto.backup.CopyBackupResponse")
+	proto.RegisterType((*ThrottleProfile)(nil), "milvus.proto.backup.ThrottleProfile")
+	proto.RegisterType((*ThrottleConfig)(nil), "milvus.proto.backup.ThrottleConfig")
+	proto.RegisterType((*GetThrottleRequest)(nil), "milvus.proto.backup.GetThrottleRequest")
+	proto.RegisterType((*UpdateThrottleRequest)(nil), "milvus.proto.backup.UpdateThrottleRequest")
+	proto.RegisterType((*ThrottleInfo)(nil), "milvus.proto.backup.ThrottleInfo")
+	proto.RegisterType((*ThrottleResponse)(nil), "milvus.proto.backup.ThrottleResponse")
 }
 
 func init() { proto.RegisterFile("backup.proto", fileDescriptor_65240d19de191688) }
 
 var fileDescriptor_65240d19de191688 = []byte{
-	// 3037 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0x26, 0xde, 0x40, 0x2f, 0x00, 0x2e, 0x87, 0x14, 0x05, 0x51, 0x96, 0x45, 0x21, 0xb6, 0x4c,
-	0xc9, 0x15, 0x4a, 0xa6, 0x6d, 0xc5, 0x56, 0xc5, 0x0f, 0xf1, 0x21, 0x09, 0x96, 0x44, 0xb1, 0x96,
-	0x94, 0x4a, 0xe5, 0x3c, 0xb6, 0x16, 0xbb, 0x43, 0x60, 0xa3, 0xc5, 0x0e, 0xb2, 0xb3, 0x90, 0x05,
-	0x55, 0x25, 0x95, 0xaa, 0x5c, 0x72, 0xcc, 0x21, 0xa7, 0xfc, 0x83, 0xdc, 0x92, 0x4a, 0x39, 0x87,
-	0xfc, 0x83, 0xa4, 0x72, 0xcd, 0x6f, 0x48, 0xe5, 0x94, 0xdc, 0x72, 0x4d, 0x4d, 0xcf, 0xec, 0x03,
-	0xe0, 0x92, 0x04, 0x53, 0xae, 0x38, 0xce, 0x6d, 0xe7, 0x9b, 0xee, 0x9e, 0x99, 0x9e, 0x9e, 0xee,
-	0x9e, 0x9e, 0x85, 0x7a, 0xd7, 0xb2, 0x9f, 0x8f, 0x86, 0xeb, 0xc3, 0x80, 0x85, 0x8c, 0x2c, 0x0e,
-	0x5c, 0xef, 0xc5, 0x88, 0xcb, 0xd6, 0xba, 0xec, 0x5a, 0x79, 0xad, 0xc7, 0x58, 0xcf, 0xa3, 0x37,
-	0x10, 0xec, 0x8e, 0x0e, 0x6f, 0xf0, 0x30, 0x18, 0xd9, 0xa1, 0x24, 0x6a, 0xff, 0x2d, 0x07, 0xb5,
-	0x8e, 0xef, 0xd0, 0x97, 0x1d, 0xff, 0x90, 0x91, 0x4b, 0x00, 0x87, 0x2e, 0xf5, 0x1c, 0xd3, 0xb7,
-	0x06, 0xb4, 0x95, 0x5b, 0xcd, 0xad, 0xd5, 0x8c, 0x1a, 0x22, 0xbb, 0xd6, 0x80, 0x8a, 0x6e, 0x57,
-	0xd0, 0xca, 0xee, 0xbc, 0xec, 0x46, 0x64, 0xb2, 0x3b, 0x1c, 0x0f, 0x69, 0xab, 0x90, 0xea, 0x3e,
-	0x18, 0x0f, 0x29, 0xd9, 0x84, 0xf2, 0xd0, 0x0a, 0xac, 0x01, 0x6f, 0x15, 0x57, 0x0b, 0x6b, 0xda,
-	0xc6, 0xf5, 0xf5, 0x8c, 0xe9, 0xae, 0xc7, 0x93, 0x59, 0xdf, 0x43, 0xe2, 0x1d, 0x3f, 0x0c, 0xc6,
-	0x86, 0xe2, 0x5c, 0xf9, 0x10, 0xb4, 0x14, 0x4c, 0x74, 0x28, 0x3c, 0xa7, 0x63, 0x35, 0x51, 0xf1,
-	0x49, 0x96, 0xa0, 0xf4, 0xc2, 0xf2, 0x46, 0xd1, 0xec, 0x64, 0xe3, 0x76, 0xfe, 0x83, 0x5c, 0xfb,
-	0xaf, 0x55, 0x58, 0xda, 0x62, 0x9e, 0x47, 0xed, 0xd0, 0x65, 0xfe, 0x26, 0x8e, 0x86, 0x8b, 0x6e,
-	0x42, 0xde, 0x75, 0x94, 0x8c, 0xbc, 0xeb, 0x90, 0x7b, 0x00, 0x3c, 0xb4, 0x42, 0x6a, 0xda, 0xcc,
-	0x91, 0x72, 0x9a, 0x1b, 0x6b, 0x99, 0x73, 0x95, 0x42, 0x0e, 0x2c, 0xfe, 0x7c, 0x5f, 0x30, 0x6c,
-	0x31, 0x87, 0x1a, 0x35, 0x1e, 0x7d, 0x92, 0x36, 0xd4, 0x69, 0x10, 0xb0, 0xe0, 0x11, 0xe5, 0xdc,
-	0xea, 0x45, 0x1a, 0x99, 0xc0, 0x84, 0xce, 0x78, 0x68, 0x05, 0xa1, 0x19, 0xba, 0x03, 0xda, 0x2a,
-	0xae, 0xe6, 0xd6, 0x0a, 0x28, 0x22, 0x08, 0x0f, 0xdc, 0x01, 0x25, 0x17, 0xa0, 0x4a, 0x7d, 0x47,
-	0x76, 0x96, 0xb0, 0xb3, 0x42, 0x7d, 0x07, 0xbb, 0x56, 0xa0, 0x3a, 0x0c, 0x58, 0x2f, 0xa0, 0x9c,
-	0xb7, 0xca, 0xab, 0xb9, 0xb5, 0x92, 0x11, 0xb7, 0xc9, 0xb7, 0xa0, 0x61, 0xc7, 0x4b, 0x35, 0x5d,
-	0xa7, 0x55, 0x41, 0xde, 0x7a, 0x02, 0x76, 0x1c, 0x72, 0x1e, 0x2a, 0x4e, 0x57, 0x6e, 0x65, 0x15,
-	0x67, 0x56, 0x76, 0xba, 0xb8, 0x8f, 0x6f, 0xc1, 0x7c, 0x8a, 0x1b, 0x09, 0x6a, 0x48, 0xd0, 0x4c,
-	0x60, 0x24, 0xfc, 0x08, 0xca, 0xdc, 0xee, 0xd3, 0x81, 0xd5, 0x82, 0xd5, 0xdc, 0x9a, 0xb6, 0xf1,
-	0x66, 0xa6, 0x96, 0x12, 0xa5, 0xef, 0x23, 0xb1, 0xa1, 0x98, 0x70, 0xed, 0x7d, 0x2b, 0x70, 0xb8,
-	0xe9, 0x8f, 0x06, 0x2d, 0x0d, 0xd7, 0x50, 0x93, 0xc8, 0xee, 0x68, 0x40, 0x0c, 0x58, 0xb0, 0x99,
-	0xcf, 0x5d, 0x1e, 0x52, 0xdf, 0x1e, 0x9b, 0x1e, 0x7d, 0x41, 0xbd, 0x56, 0x1d, 0xb7, 0xe3, 0xb8,
-	0x81, 0x62, 0xea, 0x87, 0x82, 0xd8, 0xd0, 0xed, 0x29, 0x84, 0x3c, 0x81, 0x85, 0xa1, 0x15, 0x84,
-	0x2e, 0xae, 0x4c, 0xb2, 0xf1, 0x56, 0x03, 0xcd, 0x31, 0x7b, 0x8b, 0xf7, 0x22, 0xea, 0xc4, 0x60,
-	0x0c, 0x7d, 0x38, 0x09, 0x72, 0x72, 0x0d, 0x74, 0x49, 0x8f, 0x3b, 0xc5, 0x43, 0x6b, 0x30, 0x6c,
-	0x35, 0x57, 0x73, 0x6b, 0x45, 0x63, 0x5e, 0xe2, 0x07, 0x11, 0x4c, 0x08, 0x14, 0xb9, 0xfb, 0x8a,
-	0xb6, 0xe6, 0x71, 0x47, 0xf0, 0x9b, 0x5c, 0x84, 0x5a, 0xdf, 0xe2, 0x26, 0x1e, 0x95, 0x96, 0xbe,
-	0x9a, 0x5b, 0xab, 0x1a, 0xd5, 0xbe, 0xc5, 0xf1, 0x28, 0x90, 0x4f, 0x40, 0x93, 0xa7, 0xca, 0xf5,
-	0x0f, 0x19, 0x6f, 0x2d, 0xe0, 0x64, 0x5f, 0x3f, 0xf9, 0xec, 0x18, 0xf2, 0x20, 0x8a, 0x4f, 0x2e,
-	0xd4, 0xec, 0x31, 0xcb, 0x31, 0xd1, 0x30, 0x5b, 0x44, 0x1e, 0x4b, 0x81, 0xa0, 0xd1, 0x92, 0xdb,
-	0x70, 0x41, 0xcd, 0x7d, 0xd8, 0x1f, 0x73, 0xd7, 0xb6, 0xbc, 0xd4, 0x22, 0x16, 0x71, 0x11, 0xe7,
-	0x25, 0xc1, 0x9e, 0xea, 0x4f, 0x16, 0x13, 0xc0, 0xa2, 0xdd, 0xb7, 0x7c, 0x9f, 0x7a, 0xa6, 0xdd,
-	0xa7, 0xf6, 0xf3, 0x21, 0x73, 0xfd, 0x90, 0xb7, 0x96, 0x70, 0x8e, 0x77, 0x4e, 0xb1, 0x86, 0x44,
-	0xa3, 0xeb, 0x5b, 0x52, 0xc8, 0x56, 0x22, 0x43, 0x1e, 0x7b, 0x62, 0x1f, 0xe9, 0x20, 0xf7, 0x40,
-	0xf3, 0x6e, 0x9a, 0x9c, 0xf6, 0x06, 0x54, 0x8c, 0x75, 0x0e, 0xc7, 0xba, 0x9a, 0x39, 0xd6, 0xbe,
-	0x24, 0x4a, 0x6d, 0x1d, 0x78, 0x37, 0x15, 0xc8, 0x57, 0x76, 0xe0, 0xfc, 0x31, 0xe3, 0x9e, 0xc9,
-	0xaf, 0xfc, 0x22, 0x0f, 0x8b, 0x19, 0x56, 0x42, 0xae, 0x40, 0x3d, 0x31, 0x35, 0xe5, 0x60, 0x0a,
-	0x86, 0x16, 0x63, 0x1d, 0x87, 0xbc, 0x09, 0xcd, 0x84, 0x24, 0xe5, 0x53, 0x1b, 0x31, 0x8a, 0xc7,
-	0xec, 0xc8, 0x69, 0x2e, 0x64, 0x9c, 0xe6, 0xc7, 0x30, 0xaf, 0x74, 0x12, 0xdb, 0x75, 0xf1, 0x4c,
-	0xaa, 0x69, 0xf2, 0x34, 0xc4, 0x63, 0x43, 0x2d, 0xa5, 0x0c, 0x75, 0xd2, 0x94, 0xca, 0x53, 0xa6,
-	0xd4, 0xfe, 0x43, 0x01, 0x16, 0x8e, 0x08, 0xc6, 0x63, 0xae, 0x66, 0x16, 0xab, 0xa1, 0xa6, 0x90,
-	0x8e, 0x73, 0x74, 0x75, 0xf9, 0x8c, 0xd5, 0x4d, 0x2b, 0xb3, 0x70, 0x54, 0x99, 0xaf, 0x83, 0xe6,
-	0x8f, 0x06, 0x26, 0x3b, 0x34, 0x03, 0xf6, 0x05, 0x8f, 0x5c, 0xa9, 0x3f, 0x1a, 0x3c, 0x3e, 0x34,
-	0xd8, 0x17, 0x9c, 0xdc, 0x86, 0x4a, 0xd7, 0xf5, 0x3d, 0xd6, 0xe3, 0xad, 0x12, 0x2a, 0x66, 0x35,
-	0x53, 0x31, 0x77, 0x45, 0xb4, 0xdb, 0x44, 0x42, 0x23, 0x62, 0x20, 0x1f, 0x03, 0xba, 0x75, 0x8e,
-	0xdc, 0xe5, 0x19, 0xb9, 0x13, 0x16, 0xc1, 0xef, 0x50, 0x2f, 0xb4, 0x90, 0xbf, 0x32, 0x2b, 0x7f,
-	0xcc, 0x12, 0xef, 0x45, 0x35, 0xb5, 0x17, 0x17, 0xa0, 0xda, 0x0b, 0xd8, 0x68, 0x28, 0xd4, 0x51,
-	0x93, 0xa1, 0x01, 0xdb, 0x1d, 0x47, 0x84, 0x06, 0x29, 0x8f, 0x3a, 0xe8, 0x99, 0xab, 0x46, 0xdc,
-	0x26, 0x8b, 0x50, 0x72, 0xb9, 0xe9, 0xdd, 0x44, 0x7f, 0x5b, 0x35, 0x8a, 0x2e, 0x7f, 0x78, 0xb3,
-	0xfd, 0xfb, 0x02, 0xc0, 0xff, 0x77, 0x44, 0x24, 0x50, 0xc4, 0x03, 0x56, 0xc1, 0x11, 0xf1, 0x3b,
-	0xd3, 0x6b, 0x57, 0xb3, 0xbd, 0xf6, 0x33, 0x20, 0x29, 0x23, 0x8d, 0x0e, 0x58, 0x0d, 0x77, 0xf2,
-	0xda, 0xcc, 0x7e, 0xce, 0x58, 0xb0, 0xa7, 0xd0, 0x64, 0x6b, 0x21, 0xb5, 0xb5, 0x6f, 0x42, 0x53,
-	0x8a, 0x34, 0x5f, 0xd0, 0x80, 0xbb, 0xcc, 0xc7, 0xcd, 0xaa, 0x19, 0x0d, 0x89, 0x3e, 0x95, 0x60,
-	0xfb, 0xfb, 0x70, 0x21, 0x19, 0x05, 0xe3, 0x5b, 0x6a, 0x0f, 0x3f, 0x81, 0x92, 0x0c, 0x18, 0xb9,
-	0xb3, 0x4e, 0x52, 0xf2, 0xb5, 0x3f, 0x87, 0x56, 0xec, 0xd6, 0xa6, 0x85, 0x7f, 0x3c, 0x29, 0x7c,
-	0xf6, 0xd0, 0xa9, 0x64, 0x3f, 0x85, 0x65, 0xe5, 0x27, 0xa6, 0x25, 0x7f, 0x77, 0x52, 0xf2, 0xac,
-	0xce, 0x4b, 0xc9, 0xfd, 0x79, 0x01, 0x16, 0xb7, 0x02, 0x6a, 0x85, 0x54, 0xf6, 0x19, 0xf4, 0xc7,
-	0x23, 0xca, 0x43, 0xf2, 0x1a, 0xd4, 0x02, 0xf9, 0xd9, 0x89, 0xec, 0x3a, 0x01, 0xc8, 0x65, 0xd0,
-	0x94, 0x1d, 0xa4, 0x7c, 0x30, 0x48, 0x68, 0x57, 0x19, 0xca, 0x54, 0x42, 0xc4, 0x5b, 0x85, 0xd5,
-	0xc2, 0x5a, 0xcd, 0x98, 0x9f, 0xcc, 0x88, 0xb8, 0x88, 0x13, 0x16, 0x1f, 0xfb, 0x36, 0x1a, 0x6e,
-	0xd5, 0x90, 0x0d, 0xf2, 0x11, 0x34, 0x9d, 0xae, 0x99, 0xd0, 0x72, 0x34, 0x5d, 0x6d, 0x63, 0x79,
-	0x5d, 0x26, 0xe7, 0xeb, 0x51, 0x72, 0xbe, 0xfe, 0x54, 0xc4, 0x15, 0xa3, 0xe1, 0x74, 0x93, 0xad,
-	0x41, 0xa1, 0x87, 0x2c, 0xb0, 0xa5, 0xc7, 0xad, 0x1a, 0xb2, 0x21, 0xb2, 0x86, 0x01, 0x0d, 0x2d,
-	0x93, 0xf9, 0xde, 0x18, 0xed, 0xba, 0x6a, 0x54, 0x05, 0xf0, 0xd8, 0xf7, 0xc6, 0xe4, 0x2a, 0xcc,
-	0xf7, 0x6c, 0x73, 0x68, 0x8d, 0x38, 0x35, 0xa9, 0x6f, 0x75, 0x3d, 0xe9, 0x3c, 0xaa, 0x46, 0xa3,
-	0x67, 0xef, 0x09, 0x74, 0x07, 0x41, 0xb2, 0x06, 0x7a, 0x4c, 0xc7, 0xa9, 0xcd, 0x7c, 0x87, 0xa3,
-	0x37, 0x29, 0x19, 0x4d, 0x45, 0xb8, 0x2f, 0xd1, 0x09, 0x4a, 0xcb, 0x71, 0xf0, 0x94, 0x81, 0x4c,
-	0x0b, 0x15, 0xe5, 0x1d, 0x89, 0xb6, 0x7f, 0x9b, 0x03, 0x92, 0xda, 0x1b, 0xca, 0x87, 0xcc, 0xe7,
-	0xf4, 0x94, 0x4d, 0x78, 0x1f, 0x8a, 0x29, 0xef, 0x72, 0x25, 0x73, 0xdf, 0x23, 0x51, 0xe8, 0x56,
-	0x90, 0x5c, 0x44, 0xea, 0x01, 0xef, 0x29, 0x47, 0x22, 0x3e, 0xc9, 0xbb, 0x50, 0x74, 0xac, 0xd0,
-	0xc2, 0x0d, 0xd0, 0x36, 0x2e, 0x9f, 0xe0, 0xa6, 0x70, 0x76, 0x48, 0xdc, 0xfe, 0x73, 0x0e, 0xf4,
-	0x7b, 0x34, 0xfc, 0x4a, 0xad, 0xe6, 0x22, 0xd4, 0x14, 0x81, 0x0a, 0x58, 0xb5, 0xc8, 0x0d, 0x2b,
-	0xee, 0x91, 0xfd, 0x9c, 0x86, 0x92, 0xbb, 0xa8, 0xb8, 0x11, 0x42, 0x6e, 0x02, 0xc5, 0xa1, 0x15,
-	0xf6, 0xd1, 0x50, 0x6a, 0x06, 0x7e, 0x0b, 0xbf, 0xf0, 0x85, 0x1b, 0xf6, 0xd9, 0x28, 0x34, 0x1d,
-	0x1a, 0x5a, 0xae, 0xa7, 0x0c, 0xa2, 0xa1, 0xd0, 0x6d, 0x04, 0xdb, 0xdf, 0x03, 0xf2, 0xd0, 0xe5,
-	0x51, 0x20, 0x9f, 0x6d, 0x35, 0x19, 0x39, 0x7f, 0x3e, 0x2b, 0xe7, 0x6f, 0xff, 0x2e, 0x07, 0x8b,
-	0x13, 0xd2, 0xbf, 0xae, 0xdd, 0x2d, 0xcc, 0xbe, 0xbb, 0x07, 0xb0, 0xb8, 0x4d, 0x3d, 0xfa, 0xd5,
-	0x7a, 0x85, 0xf6, 0x4f, 0x60, 0x69, 0x52, 0xea, 0x7f, 0x55, 0x13, 0xed, 0x7f, 0x96, 0x60, 0xc9,
-	0xa0, 0x3c, 0x64, 0xc1, 0xd7, 0xe6, 0xec, 0xde, 0x86, 0x54, 0x40, 0x33, 0xf9, 0xe8, 0xf0, 0xd0,
-	0x7d, 0xa9, 0x4c, 0x39, 0x25, 0x63, 0x1f, 0x71, 0xc2, 0x26, 0x42, 0x68, 0x40, 0xa5, 0x64, 0x99,
-	0x8a, 0x7d, 0x7a, 0x9c, 0x1a, 0x8e, 0xac, 0x2e, 0x15, 0xb2, 0x0c, 0x29, 0x42, 0xde, 0x14, 0x52,
-	0x13, 0x51, 0x78, 0xe2, 0x8a, 0xcb, 0x69, 0x57, 0x3c, 0x75, 0xf0, 0x2a, 0xc7, 0x1e, 0xbc, 0x6a,
-	0xea, 0xe0, 0x1d, 0xf5, 0xdf, 0xb5, 0xb3, 0xf8, 0xef, 0x15, 0x88, 0x1d, 0x73, 0x94, 0x8f, 0xc5,
-	0x8e, 0xba, 0x0d, 0xf5, 0x40, 0xae, 0x13, 0x6f, 0x6f, 0x2a, 0x2d, 0x9b, 0xc0, 0x04, 0x8d, 0x70,
-	0xaf, 0xa3, 0x90, 0x49, 0x9a, 0xba, 0xa4, 0x49, 0x63, 0xe4, 0x26, 0x2c, 0x3a, 0x01, 0x1b, 0xee,
-	0xbc, 0x74, 0x79, 0x98, 0x8c, 0xdd, 0x6a, 0x20, 0x69, 0x56, 0x17, 0xb9, 0x0a, 0xcd, 0x18, 0x96,
-	0x72, 0x9b, 0x48, 0x3c, 0x85, 0x92, 0x0d, 0x58, 0xe2, 0xcf, 0xdd, 0xa1, 0x8c, 0xab, 0x29, 0xd1,
-	0xf3, 0x48, 0x9d, 0xd9, 0xa7, 0x32, 0x48, 0x3d, 0xca, 0x20, 0x57, 0xb6, 0x61, 0x39, 0x7b, 0xe3,
-	0xce, 0x74, 0xd5, 0xfa, 0x32, 0x1f, 0x9b, 0x7c, 0x9c, 0x5c, 0x88, 0x5c, 0xf3, 0x48, 0xc2, 0x7a,
-	0x3f, 0x23, 0x61, 0xbd, 0x76, 0x92, 0x8d, 0xfd, 0x0f, 0x66, 0xac, 0x1d, 0xc0, 0xeb, 0x8d, 0x4a,
-	0x36, 0xd1, 0x50, 0xcf, 0x92, 0x69, 0x81, 0x60, 0x96, 0xed, 0xf6, 0x97, 0x65, 0x38, 0xa7, 0x16,
-	0x9a, 0xec, 0xc2, 0x37, 0x5a, 0x71, 0x9f, 0x81, 0x26, 0x4e, 0x63, 0xa4, 0x9c, 0x32, 0x2a, 0xe7,
-	0x0c, 0x39, 0x2e, 0x08, 0x6e, 0xd9, 0x26, 0xef, 0xc1, 0x72, 0x68, 0x05, 0x3d, 0x1a, 0x9a, 0xd3,
-	0x11, 0x50, 0x3a, 0x87, 0x25, 0xd9, 0xbb, 0x35, 0x59, 0xfb, 0xb2, 0xe0, 0x7c, 0x72, 0x23, 0x55,
-	0xa7, 0xd5, 0x0c, 0x2d, 0xfe, 0x9c, 0xb7, 0xaa, 0x27, 0x64, 0xdc, 0x59, 0xe6, 0x6b, 0x9c, 0x8b,
-	0x25, 0xa5, 0xb4, 0x8a, 0x55, 0x3c, 0x25, 0xd8, 0x31, 0xf1, 0x8e, 0x20, 0xaf, 0x79, 0x91, 0x6f,
-	0x70, 0xf6, 0xc5, 0x5d, 0xe1, 0x2a, 0xcc, 0x87, 0x2c, 0x9e, 0x40, 0xea, 0x2a, 0xd1, 0x08, 0x99,
-	0x92, 0x86, 0x74, 0x69, 0x53, 0xd3, 0xa6, 0x4c, 0xed, 0x0d, 0x68, 0x2a, 0x0d, 0x44, 0x05, 0xc1,
-	0xba, 0xdc, 0x2d, 0x89, 0x6e, 0xcb, 0xb2, 0x60, 0xda, 0x8b, 0x35, 0x4e, 0xf1, 0x62, 0xcd, 0x19,
-	0xbc, 0xd8, 0xfc, 0xec, 0x5e, 0x4c, 0x3f, 0x8b, 0x17, 0x5b, 0x38, 0x93, 0x17, 0x23, 0xc7, 0x7b,
-	0xb1, 0xf6, 0xaf, 0x0b, 0xb0, 0x30, 0x11, 0x84, 0xbe, 0xd1, 0x67, 0xc6, 0x81, 0xd6, 0x44, 0x00,
-	0x4e, 0x9b, 0x6c, 0xf9, 0x84, 0x8a, 0x7c, 0xa6, 0xe7, 0x30, 0x96, 0xd3, 0x01, 0xf7, 0x24, 0xa3,
-	0xad, 0xcc, 0x66, 0xb4, 0xd5, 0xd3, 0x8c, 0xb6, 0x36, 0x69, 0xb4, 0xed, 0x3f, 0xe6, 0x62, 0xa7,
-	0xf6, 0xb5, 0x24, 0x60, 0xe4, 0xf6, 0xc4, 0x45, 0xe3, 0xea, 0xe9, 0x29, 0x0c, 0xea, 0x4d, 0x66,
-	0xa4, 0x77, 0x61, 0xf9, 0x1e, 0x0d, 0xa3, 0xa5, 0x0a, 0x03, 0x98, 0x2d, 0x7b, 0x93, 0xb6, 0x97,
-	0x8f, 0x6c, 0xaf, 0xfd, 0x43, 0xd0, 0x52, 0x25, 0x23, 0xd2, 0x82, 0x0a, 0xbe, 0xd6, 0x74, 0xb6,
-	0x55, 0x9d, 0x2d, 0x6a, 0x92, 0xf7, 0x93, 0xea, 0x57, 0x1e, 0xf7, 0xfa, 0x62, 0x76, 0xea, 0x3c,
-	0x59, 0xf8, 0x6a, 0xff, 0x26, 0x07, 0x65, 0x25, 0xfb, 0x32, 0x68, 0xd4, 0x0f, 0x03, 0x97, 0xca,
-	0x72, 0xbd, 0x94, 0x0f, 0x0a, 0xda, 0x1d, 0x0d, 0xc4, 0xed, 0x24, 0xae, 0xa3, 0x98, 0x87, 0x01,
-	0x1b, 0xe0, 0x3c, 0x8b, 0x46, 0x23, 0x46, 0xef, 0x06, 0x6c, 0x40, 0xae, 0x40, 0x3d, 0x21, 0x0b,
-	0x19, 0x6a, 0xb4, 0x68, 0x68, 0x31, 0x76, 0xc0, 0x84, 0x11, 0x7b, 0xac, 0x67, 0x62, 0x1a, 0x26,
-	0xd3, 0xc9, 0x8a, 0xc7, 0x7a, 0x7b, 0x22, 0x13, 0x53, 0x5d, 0xa9, 0xca, 0xa4, 0xe8, 0x12, 0xc6,
-	0xd2, 0xbe, 0x05, 0xf5, 0x07, 0x74, 0x8c, 0x09, 0xd8, 0x9e, 0xe5, 0x06, 0xb3, 0x66, 0x16, 0xed,
-	0x7f, 0xe5, 0x00, 0x90, 0x0b, 0x35, 0x49, 0x2e, 0x41, 0xad, 0xcb, 0x98, 0x67, 0xe2, 0xde, 0x0a,
-	0xe6, 0xea, 0xfd, 0x39, 0xa3, 0x2a, 0xa0, 0x6d, 0x2b, 0xb4, 0xc8, 0x45, 0xa8, 0xba, 0x7e, 0x28,
-	0x7b, 0x85, 0x98, 0xd2, 0xfd, 0x39, 0xa3, 0xe2, 0xfa, 0x21, 0x76, 0x5e, 0x82, 0x9a, 0xc7, 0xfc,
-	0x9e, 0xec, 0xc5, 0x1a, 0xa5, 0xe0, 0x15, 0x10, 0x76, 0x5f, 0x06, 0x38, 0xf4, 0x98, 0xa5, 0xb8,
-	0xc5, 0xca, 0xf2, 0xf7, 0xe7, 0x8c, 0x1a, 0x62, 0x48, 0x70, 0x05, 0x34, 0x87, 0x8d, 0xba, 0x1e,
-	0x95, 0x14, 0x62, 0x81, 0xb9, 0xfb, 0x73, 0x06, 0x48, 0x30, 0x22, 0xe1, 0x61, 0xe0, 0x46, 0x83,
-	0x60, 0x0d, 0x56, 0x90, 0x48, 0x30, 0x1a, 0xa6, 0x3b, 0x0e, 0x29, 0x97, 0x14, 0xe2, 0xfc, 0xd5,
-	0xc5, 0x30, 0x88, 0x09, 0x82, 0xcd, 0xb2, 0xb4, 0xdc, 0xf6, 0xdf, 0x8b, 0xca, 0x7c, 0xe4, 0xc3,
-	0xcc, 0x09, 0xe6, 0x13, 0x95, 0xcf, 0xf2, 0xa9, 0xf2, 0xd9, 0x1b, 0xd0, 0x74, 0xb9, 0x39, 0x0c,
-	0xdc, 0x81, 0x15, 0x8c, 0x4d, 0xa1, 0xea, 0x82, 0xf4, 0xe8, 0x2e, 0xdf, 0x93, 0xe0, 0x03, 0x3a,
-	0x26, 0xab, 0xa0, 0x39, 0x94, 0xdb, 0x81, 0x3b, 0x44, 0x77, 0x2b, 0xb7, 0x33, 0x0d, 0x91, 0xdb,
-	0x50, 0x13, 0xb3, 0x91, 0xaf, 0x86, 0x25, 0x3c, 0x95, 0x97, 0x32, 0x8d, 0x53, 0xcc, 0xfd, 0x60,
-	0x3c, 0xa4, 0x46, 0xd5, 0x51, 0x5f, 0x64, 0x13, 0x34, 0xc1, 0x66, 0xaa, 0x87, 0x45, 0xe9, 0xc6,
-	0xb2, 0xcf, 0x74, 0xda, 0x36, 0x0c, 0x10, 0x5c, 0xf2, 0x25, 0x91, 0x6c, 0x43, 0x5d, 0x3e, 0xb0,
-	0x28, 0x21, 0x95, 0x59, 0x85, 0xc8, 0x77, 0x19, 0x25, 0x65, 0x19, 0xca, 0x96, 0x08, 0x63, 0xdb,
-	0xaa, 0xce, 0xa2, 0x5a, 0xe4, 0x7d, 0x28, 0xc9, 0x6a, 0x79, 0x0d, 0x57, 0x76, 0xf9, 0xf8, 0xb2,
-	0xaf, 0x74, 0x03, 0x92, 0x9a, 0x7c, 0x0a, 0x75, 0xea, 0x51, 0x2c, 0x9a, 0xa3, 0x5e, 0x60, 0x16,
-	0xbd, 0x68, 0x8a, 0x05, 0x55, 0xb3, 0x0d, 0x0d, 0x87, 0x1e, 0x5a, 0x23, 0x2f, 0x34, 0xa5, 0xd1,
-	0x6b, 0x27, 0x14, 0x44, 0x12, 0xfb, 0x37, 0xea, 0x8a, 0x0b, 0x21, 0x7c, 0xd3, 0xe5, 0xa6, 0x33,
-	0xf6, 0xad, 0x81, 0x6b, 0xab, 0x8b, 0x47, 0xcd, 0xe5, 0xdb, 0x12, 0x20, 0x6b, 0xa0, 0x0b, 0x1b,
-	0x88, 0x13, 0x21, 0x61, 0x05, 0x32, 0x37, 0x68, 0xba, 0x3c, 0x4e, 0x72, 0x1e, 0xd0, 0x71, 0xfb,
-	0x2f, 0x39, 0xd0, 0xa7, 0x5f, 0x02, 0x63, 0xb3, 0xca, 0xa5, 0xcc, 0x6a, 0xca, 0x60, 0xf2, 0x47,
-	0x0d, 0x26, 0x51, 0x75, 0x61, 0x42, 0xd5, 0x1f, 0x40, 0x19, 0xed, 0x35, 0x7a, 0xf9, 0x38, 0xa1,
-	0xc4, 0x1e, 0xbd, 0x44, 0x4a, 0x7a, 0x72, 0x13, 0x96, 0x64, 0x91, 0x2c, 0x5a, 0xa9, 0x89, 0x1d,
-	0x68, 0x8d, 0x55, 0x83, 0xc8, 0x3e, 0xb5, 0x66, 0xe4, 0x6f, 0x37, 0xa1, 0x8e, 0xaf, 0x46, 0xca,
-	0x6d, 0xb7, 0x9f, 0x41, 0x43, 0xb5, 0x55, 0x10, 0x8a, 0xc2, 0x4c, 0xee, 0x3f, 0x0a, 0x33, 0xf9,
-	0xe4, 0x9e, 0xff, 0xb3, 0x1c, 0x68, 0x8f, 0x78, 0x6f, 0x8f, 0x71, 0xd4, 0xa5, 0xf0, 0x9f, 0xd1,
-	0x9b, 0x5b, 0x4a, 0x77, 0x9a, 0xc2, 0x30, 0x53, 0x5b, 0x82, 0xd2, 0x80, 0xf7, 0x3a, 0xdb, 0x28,
-	0xa6, 0x6e, 0xc8, 0x06, 0xe6, 0x6f, 0xbc, 0x77, 0x2f, 0x60, 0xa3, 0x61, 0x54, 0x8e, 0x8a, 0xda,
-	0x22, 0xea, 0x24, 0x35, 0xf0, 0x22, 0x7a, 0xe4, 0x04, 0x68, 0xdf, 0x81, 0x79, 0xf5, 0x52, 0x16,
-	0xcf, 0x22, 0x6b, 0xe7, 0x44, 0xb4, 0x56, 0xfd, 0x6a, 0x01, 0x71, 0xfb, 0xfa, 0x4f, 0xa1, 0x9e,
-	0x5e, 0x2d, 0xd1, 0xa0, 0xb2, 0x3f, 0xb2, 0x6d, 0xca, 0xb9, 0x3e, 0x47, 0xe6, 0x41, 0xdb, 0x65,
-	0xa1, 0xb9, 0x3f, 0x1a, 0x0e, 0x59, 0x10, 0xea, 0x39, 0xb2, 0x00, 0x8d, 0x5d, 0x66, 0xee, 0xd1,
-	0x60, 0xe0, 0x72, 0xee, 0x32, 0x5f, 0xcf, 0x93, 0x2a, 0x14, 0xef, 0x5a, 0xae, 0xa7, 0x17, 0xc8,
-	0x12, 0xcc, 0xe3, 0x99, 0xa3, 0x21, 0x0d, 0xcc, 0x1d, 0x91, 0x1b, 0xe9, 0xbf, 0x2c, 0x90, 0x4b,
-	0xd0, 0x52, 0x7b, 0x61, 0x3e, 0xee, 0xfe, 0x88, 0xda, 0xa1, 0x29, 0x44, 0xde, 0x65, 0x23, 0xdf,
-	0xd1, 0x7f, 0x55, 0xb8, 0xfe, 0x12, 0x16, 0x33, 0xde, 0x26, 0x08, 0x81, 0xe6, 0xe6, 0x9d, 0xad,
-	0x07, 0x4f, 0xf6, 0xcc, 0xce, 0x6e, 0xe7, 0xa0, 0x73, 0xe7, 0xa1, 0x3e, 0x47, 0x96, 0x40, 0x57,
-	0xd8, 0xce, 0xb3, 0x9d, 0xad, 0x27, 0x07, 0x9d, 0xdd, 0x7b, 0x7a, 0x2e, 0x45, 0xb9, 0xff, 0x64,
-	0x6b, 0x6b, 0x67, 0x7f, 0x5f, 0xcf, 0x8b, 0x79, 0x2b, 0xec, 0xee, 0x9d, 0xce, 0x43, 0xbd, 0x90,
-	0x22, 0x3a, 0xe8, 0x3c, 0xda, 0x79, 0xfc, 0xe4, 0x40, 0x2f, 0x5e, 0x7f, 0x1a, 0xdf, 0x59, 0x27,
-	0x87, 0xd6, 0xa0, 0x92, 0x8c, 0xd9, 0x80, 0x5a, 0x7a, 0x30, 0xa1, 0x9d, 0x78, 0x14, 0xb1, 0x72,
-	0x29, 0x5e, 0x83, 0x4a, 0x22, 0xf7, 0x99, 0x38, 0x4f, 0x53, 0xcf, 0xdb, 0x00, 0xe5, 0xfd, 0x30,
-	0x60, 0x7e, 0x4f, 0x9f, 0x43, 0x19, 0x54, 0x6a, 0x0f, 0x05, 0x6e, 0x0a, 0x55, 0x50, 0x47, 0xcf,
-	0x93, 0x26, 0xc0, 0xce, 0x0b, 0xea, 0x87, 0x23, 0xcb, 0xf3, 0xc6, 0x7a, 0x41, 0xb4, 0xb7, 0x46,
-	0x3c, 0x64, 0x03, 0xf7, 0x15, 0x75, 0xf4, 0xe2, 0xf5, 0x7f, 0xe4, 0xa0, 0x1a, 0xf9, 0x14, 0x31,
-	0xfa, 0x2e, 0xf3, 0xa9, 0x3e, 0x27, 0xbe, 0x36, 0x19, 0xf3, 0xf4, 0x9c, 0xf8, 0xea, 0xf8, 0xe1,
-	0x07, 0x7a, 0x9e, 0xd4, 0xa0, 0xd4, 0xf1, 0xc3, 0x77, 0x6e, 0xe9, 0x05, 0xf5, 0xf9, 0xee, 0x86,
-	0x5e, 0x54, 0x9f, 0xb7, 0xde, 0xd3, 0x4b, 0xe2, 0xf3, 0xae, 0x08, 0x6f, 0x3a, 0x88, 0xc9, 0x6d,
-	0x63, 0x1c, 0xd3, 0x35, 0x35, 0x51, 0xd7, 0xef, 0xe9, 0x4b, 0x62, 0x6e, 0x4f, 0xad, 0x60, 0xab,
-	0x6f, 0x05, 0xfa, 0x39, 0x41, 0x7f, 0x27, 0x08, 0xac, 0xb1, 0xbe, 0x2c, 0x46, 0xf9, 0x8c, 0x33,
-	0x5f, 0x3f, 0x4f, 0x74, 0xa8, 0x6f, 0xba, 0xbe, 0x15, 0x8c, 0x9f, 0x52, 0x3b, 0x64, 0x81, 0xee,
-	0x08, 0xcd, 0xa3, 0x58, 0x05, 0x50, 0x61, 0x31, 0x08, 0xbc, 0x73, 0x4b, 0x41, 0x87, 0xb8, 0x19,
-	0x93, 0x58, 0x8f, 0x9c, 0x83, 0x85, 0xfd, 0xa1, 0x15, 0x70, 0x9a, 0xe6, 0xee, 0x5f, 0x7f, 0x0a,
-	0x90, 0xb8, 0x60, 0x31, 0x1c, 0xb6, 0xe4, 0x7d, 0xc0, 0xd1, 0xe7, 0x50, 0x7a, 0x8c, 0x88, 0x59,
-	0xe7, 0x62, 0x68, 0x3b, 0x60, 0xc3, 0xa1, 0x80, 0xf2, 0x31, 0x1f, 0x42, 0xd4, 0xd1, 0x0b, 0x1b,
-	0x7f, 0x2a, 0xc1, 0xe2, 0x23, 0x3c, 0xf8, 0xd2, 0xf8, 0xf6, 0x69, 0xf0, 0xc2, 0xb5, 0x29, 0xb1,
-	0xa1, 0x9e, 0x7e, 0xa6, 0x20, 0xd9, 0xd7, 0xfa, 0x8c, 0x97, 0x8c, 0x95, 0xb7, 0x4e, 0xab, 0x78,
-	0xaa, 0x43, 0xd6, 0x9e, 0x23, 0x3f, 0x80, 0x5a, 0x5c, 0xd2, 0x26, 0xd9, 0x7f, 0x4c, 0x4c, 0x97,
-	0xbc, 0xcf, 0x22, 0xbe, 0x0b, 0x5a, 0xaa, 0x0e, 0x4c, 0xb2, 0x39, 0x8f, 0xd6, 0xa1, 0x57, 0xd6,
-	0x4e, 0x27, 0x8c, 0xc7, 0xa0, 0x50, 0x4f, 0x97, 0x58, 0x8f, 0xd1, 0x53, 0x46, 0x6d, 0x77, 0xe5,
-	0xda, 0x0c, 0x94, 0xf1, 0x30, 0x7d, 0x68, 0x4c, 0x24, 0xea, 0xe4, 0xda, 0xcc, 0xf5, 0xc8, 0x95,
-	0xeb, 0xb3, 0x90, 0xc6, 0x23, 0xf5, 0x00, 0x92, 0xbc, 0x9f, 0xbc, 0x7d, 0xdc, 0xa6, 0x64, 0x5c,
-	0x0c, 0xce, 0x38, 0xd0, 0x1e, 0x94, 0x30, 0x1e, 0x91, 0xec, 0xc8, 0x93, 0x8e, 0x5d, 0x2b, 0xed,
-	0x93, 0x48, 0x22, 0x89, 0x9b, 0x1f, 0x7e, 0xfe, 0x9d, 0x9e, 0x1b, 0xf6, 0x47, 0xdd, 0x75, 0x9b,
-	0x0d, 0x6e, 0xbc, 0x72, 0x3d, 0xcf, 0x7d, 0x15, 0x52, 0xbb, 0x7f, 0x43, 0x32, 0x7f, 0x5b, 0xb2,
-	0xdd, 0xb0, 0x59, 0xa0, 0xfe, 0x35, 0xbb, 0x21, 0x91, 0x61, 0xb7, 0x5b, 0xc6, 0xf6, 0xbb, 0xff,
-	0x0e, 0x00, 0x00, 0xff, 0xff, 0x33, 0x1d, 0x41, 0x89, 0xae, 0x26, 0x00, 0x00,
+	// 5365 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x3c, 0x4d, 0x93, 0x1b, 0x49,
+	0x56, 0x2e, 0x49, 0xad, 0x96, 0x9e, 0xd4, 0xea, 0xea, 0xec, 0x0f, 0xcb, 0x3d, 0xe3, 0x71, 0x4f,
+	0xcd, 0xd8, 0xdb, 0xf6, 0xee, 0xda, 0x5e, 0xef, 0xcc, 0xec, 0xac, 0xf7, 0xb3, 0xdd, 0xdd, 0xb6,
+	0xb5, 0xfe, 0x6a, 0xaa, 0xdb, 0x8e, 0x89, 0x05, 0xb6, 0xa2, 0xba, 0x2a, 0x5b, 0x5d, 0xb8, 0x54,
+	0x25, 0x2a, 0x4b, 0x1e, 0x6b, 0x62, 0x21, 0x38, 0x12, 0x10, 0x41, 0x70, 0xe0, 0xc4, 0x9d, 0x80,
+	0x03, 0x87, 0x85, 0x03, 0x2c, 0x04, 0x37, 0x88, 0x0d, 0x02, 0x88, 0xbd, 0xf1, 0x0f, 0xf8, 0x0c,
+	0x82, 0x58, 0x0e, 0x04, 0x7b, 0xe1, 0x40, 0xe4, 0xcb, 0xac, 0xaa, 0x2c, 0xa9, 0xa4, 0x96, 0x66,
+	0x26, 0x3c, 0xbb, 0xdc, 0x94, 0x2f, 0x5f, 0xbe, 0xcc, 0x7c, 0xdf, 0xf9, 0x32, 0x4b, 0xd0, 0x3c,
+	0xb6, 0x9d, 0xe7, 0x83, 0xfe, 0xf5, 0x7e, 0x14, 0xc6, 0x21, 0x59, 0xed, 0x79, 0xfe, 0x8b, 0x01,
+	0x13, 0xad, 0xeb, 0xa2, 0x6b, 0xf3, 0xf5, 0x6e, 0x18, 0x76, 0x7d, 0x7a, 0x03, 0x81, 0xc7, 0x83,
+	0x93, 0x1b, 0x2c, 0x8e, 0x06, 0x4e, 0x2c, 0x90, 0x8c, 0x7f, 0xd5, 0xa0, 0xde, 0x09, 0x5c, 0xfa,
+	0xb2, 0x13, 0x9c, 0x84, 0xe4, 0x22, 0xc0, 0x89, 0x47, 0x7d, 0xd7, 0x0a, 0xec, 0x1e, 0x6d, 0x6b,
+	0x5b, 0xda, 0x76, 0xdd, 0xac, 0x23, 0xe4, 0xb1, 0xdd, 0xa3, 0xbc, 0xdb, 0xe3, 0xb8, 0xa2, 0xbb,
+	0x24, 0xba, 0x11, 0x92, 0xef, 0x8e, 0x87, 0x7d, 0xda, 0x2e, 0x2b, 0xdd, 0x47, 0xc3, 0x3e, 0x25,
+	0x77, 0xa0, 0xda, 0xb7, 0x23, 0xbb, 0xc7, 0xda, 0x95, 0xad, 0xf2, 0x76, 0xe3, 0xd6, 0xb5, 0xeb,
+	0x05, 0xcb, 0xbd, 0x9e, 0x2e, 0xe6, 0xfa, 0x01, 0x22, 0xef, 0x07, 0x71, 0x34, 0x34, 0xe5, 0xc8,
+	0xcd, 0xaf, 0x42, 0x43, 0x01, 0x13, 0x1d, 0xca, 0xcf, 0xe9, 0x50, 0x2e, 0x94, 0xff, 0x24, 0x6b,
+	0xb0, 0xf0, 0xc2, 0xf6, 0x07, 0xc9, 0xea, 0x44, 0xe3, 0x76, 0xe9, 0x7d, 0xcd, 0xf8, 0x49, 0x0d,
+	0xd6, 0x76, 0x43, 0xdf, 0xa7, 0x4e, 0xec, 0x85, 0xc1, 0x1d, 0x9c, 0x0d, 0x37, 0xdd, 0x82, 0x92,
+	0xe7, 0x4a, 0x1a, 0x25, 0xcf, 0x25, 0xf7, 0x00, 0x58, 0x6c, 0xc7, 0xd4, 0x72, 0x42, 0x57, 0xd0,
+	0x69, 0xdd, 0xda, 0x2e, 0x5c, 0xab, 0x20, 0x72, 0x64, 0xb3, 0xe7, 0x87, 0x7c, 0xc0, 0x6e, 0xe8,
+	0x52, 0xb3, 0xce, 0x92, 0x9f, 0xc4, 0x80, 0x26, 0x8d, 0xa2, 0x30, 0x7a, 0x44, 0x19, 0xb3, 0xbb,
+	0x09, 0x47, 0x72, 0x30, 0xce, 0x33, 0x16, 0xdb, 0x51, 0x6c, 0xc5, 0x5e, 0x8f, 0xb6, 0x2b, 0x5b,
+	0xda, 0x76, 0x19, 0x49, 0x44, 0xf1, 0x91, 0xd7, 0xa3, 0xe4, 0x02, 0xd4, 0x68, 0xe0, 0x8a, 0xce,
+	0x05, 0xec, 0x5c, 0xa4, 0x81, 0x8b, 0x5d, 0x9b, 0x50, 0xeb, 0x47, 0x61, 0x37, 0xa2, 0x8c, 0xb5,
+	0xab, 0x5b, 0xda, 0xf6, 0x82, 0x99, 0xb6, 0xc9, 0x5b, 0xb0, 0xe4, 0xa4, 0x5b, 0xb5, 0x3c, 0xb7,
+	0xbd, 0x88, 0x63, 0x9b, 0x19, 0xb0, 0xe3, 0x92, 0xf3, 0xb0, 0xe8, 0x1e, 0x0b, 0x51, 0xd6, 0x70,
+	0x65, 0x55, 0xf7, 0x18, 0xe5, 0xf8, 0x39, 0x58, 0x56, 0x46, 0x23, 0x42, 0x1d, 0x11, 0x5a, 0x19,
+	0x18, 0x11, 0xbf, 0x01, 0x55, 0xe6, 0x9c, 0xd2, 0x9e, 0xdd, 0x86, 0x2d, 0x6d, 0xbb, 0x71, 0xeb,
+	0x72, 0x21, 0x97, 0x32, 0xa6, 0x1f, 0x22, 0xb2, 0x29, 0x07, 0xe1, 0xde, 0x4f, 0xed, 0xc8, 0x65,
+	0x56, 0x30, 0xe8, 0xb5, 0x1b, 0xb8, 0x87, 0xba, 0x80, 0x3c, 0x1e, 0xf4, 0x88, 0x09, 0x2b, 0x4e,
+	0x18, 0x30, 0x8f, 0xc5, 0x34, 0x70, 0x86, 0x96, 0x4f, 0x5f, 0x50, 0xbf, 0xdd, 0x44, 0x71, 0x4c,
+	0x9a, 0x28, 0xc5, 0x7e, 0xc8, 0x91, 0x4d, 0xdd, 0x19, 0x81, 0x90, 0xa7, 0xb0, 0xd2, 0xb7, 0xa3,
+	0xd8, 0xc3, 0x9d, 0x89, 0x61, 0xac, 0xbd, 0x84, 0xea, 0x58, 0x2c, 0xe2, 0x83, 0x04, 0x3b, 0x53,
+	0x18, 0x53, 0xef, 0xe7, 0x81, 0x8c, 0x5c, 0x05, 0x5d, 0xe0, 0xa3, 0xa4, 0x58, 0x6c, 0xf7, 0xfa,
+	0xed, 0xd6, 0x96, 0xb6, 0x5d, 0x31, 0x97, 0x05, 0xfc, 0x28, 0x01, 0x13, 0x02, 0x15, 0xe6, 0x7d,
+	0x44, 0xdb, 0xcb, 0x28, 0x11, 0xfc, 0x4d, 0x5e, 0x83, 0xfa, 0xa9, 0xcd, 0x2c, 0x34, 0x95, 0xb6,
+	0xbe, 0xa5, 0x6d, 0xd7, 0xcc, 0xda, 0xa9, 0xcd, 0xd0, 0x14, 0xc8, 0xb7, 0xa0, 0x21, 0xac, 0xca,
+	0x0b, 0x4e, 0x42, 0xd6, 0x5e, 0xc1, 0xc5, 0xbe, 0x31, 0xdd, 0x76, 0x4c, 0xf0, 0x92, 0x9f, 0x8c,
+	0xb3, 0xd9, 0x0f, 0x6d, 0xd7, 0x42, 0xc5, 0x6c, 0x13, 0x61, 0x96, 0x1c, 0x82, 0x4a, 0x4b, 0x6e,
+	0xc3, 0x05, 0xb9, 0xf6, 0xfe, 0xe9, 0x90, 0x79, 0x8e, 0xed, 0x2b, 0x9b, 0x58, 0xc5, 0x4d, 0x9c,
+	0x17, 0x08, 0x07, 0xb2, 0x3f, 0xdb, 0x4c, 0x04, 0xab, 0xce, 0xa9, 0x1d, 0x04, 0xd4, 0xb7, 0x9c,
+	0x53, 0xea, 0x3c, 0xef, 0x87, 0x5e, 0x10, 0xb3, 0xf6, 0x1a, 0xae, 0x71, 0xe7, 0x0c, 0x6d, 0xc8,
+	0x38, 0x7a, 0x7d, 0x57, 0x10, 0xd9, 0xcd, 0x68, 0x08, 0xb3, 0x27, 0xce, 0x58, 0x07, 0xb9, 0x07,
+	0x0d, 0xff, 0xa6, 0xc5, 0x68, 0xb7, 0x47, 0xf9, 0x5c, 0xeb, 0x38, 0xd7, 0x95, 0xc2, 0xb9, 0x0e,
+	0x05, 0x92, 0x22, 0x3a, 0xf0, 0x6f, 0x4a, 0x20, 0x23, 0x6d, 0x58, 0xb4, 0x7d, 0xcf, 0x66, 0x94,
+	0xb5, 0x37, 0xb6, 0xca, 0xdb, 0x75, 0x33, 0x69, 0x6e, 0xee, 0xc3, 0xf9, 0x09, 0x2b, 0x9a, 0xcb,
+	0xe3, 0xfc, 0x66, 0x09, 0x56, 0x0b, 0xf4, 0x87, 0xbc, 0x09, 0xcd, 0x4c, 0x09, 0xa5, 0xeb, 0x29,
+	0x9b, 0x8d, 0x14, 0xd6, 0x71, 0xc9, 0x65, 0x68, 0x65, 0x28, 0x8a, 0xb7, 0x5d, 0x4a, 0xa1, 0x68,
+	0x80, 0x63, 0x76, 0x5e, 0x2e, 0xb0, 0xf3, 0x27, 0xb0, 0x2c, 0xb9, 0x95, 0x6a, 0x7c, 0x65, 0x2e,
+	0xa6, 0xb5, 0x98, 0x0a, 0x62, 0xa9, 0x0a, 0x2f, 0x28, 0x2a, 0x9c, 0x57, 0xb2, 0xea, 0x88, 0x92,
+	0x19, 0xff, 0x58, 0x86, 0x95, 0x31, 0xc2, 0x7c, 0x50, 0xb2, 0xb2, 0x94, 0x0d, 0x75, 0x09, 0xe9,
+	0xb8, 0xe3, 0xbb, 0x2b, 0x15, 0xec, 0x6e, 0x94, 0x99, 0xe5, 0x71, 0x66, 0xbe, 0x01, 0x8d, 0x60,
+	0xd0, 0xb3, 0xc2, 0x13, 0x2b, 0x0a, 0x3f, 0x64, 0x89, 0x93, 0x0d, 0x06, 0xbd, 0x27, 0x27, 0x66,
+	0xf8, 0x21, 0x23, 0xb7, 0x61, 0xf1, 0xd8, 0x0b, 0xfc, 0xb0, 0xcb, 0xda, 0x0b, 0xc8, 0x98, 0xad,
+	0x42, 0xc6, 0xdc, 0xe5, 0x71, 0xf0, 0x0e, 0x22, 0x9a, 0xc9, 0x00, 0xf2, 0x4d, 0x40, 0x87, 0xcf,
+	0x70, 0x74, 0x75, 0xc6, 0xd1, 0xd9, 0x10, 0x3e, 0xde, 0xa5, 0x7e, 0x6c, 0xe3, 0xf8, 0xc5, 0x59,
+	0xc7, 0xa7, 0x43, 0x52, 0x59, 0xd4, 0x14, 0x59, 0x5c, 0x80, 0x5a, 0x37, 0x0a, 0x07, 0x7d, 0xce,
+	0x8e, 0xba, 0x08, 0x1a, 0xd8, 0xee, 0xb8, 0x3c, 0x68, 0x08, 0x7a, 0xd4, 0x45, 0x9f, 0x5d, 0x33,
+	0xd3, 0x36, 0x59, 0x85, 0x05, 0x8f, 0x59, 0xfe, 0x4d, 0xf4, 0xc4, 0x35, 0xb3, 0xe2, 0xb1, 0x87,
+	0x37, 0xb9, 0x88, 0x22, 0x7a, 0x22, 0x15, 0x07, 0xbd, 0x6f, 0xdd, 0xac, 0x47, 0xf4, 0x44, 0x48,
+	0xd1, 0xf8, 0x69, 0x05, 0xe0, 0xff, 0x77, 0x28, 0x25, 0x50, 0x41, 0xfb, 0x5b, 0xc4, 0x19, 0xf1,
+	0x77, 0xa1, 0xbb, 0xaf, 0x15, 0xbb, 0xfb, 0x0f, 0x80, 0x28, 0x3a, 0x9c, 0xd8, 0x5f, 0x1d, 0x05,
+	0x7d, 0x75, 0x66, 0x07, 0x69, 0xae, 0x38, 0x23, 0xd0, 0x4c, 0xf2, 0xa0, 0x48, 0xfe, 0x32, 0xb4,
+	0x04, 0x49, 0xeb, 0x05, 0x8d, 0x98, 0x17, 0x06, 0x28, 0xcb, 0xba, 0xb9, 0x24, 0xa0, 0xcf, 0x04,
+	0x90, 0x1b, 0x56, 0xdf, 0x8e, 0x32, 0x87, 0x20, 0xe5, 0xda, 0x14, 0x40, 0x31, 0x01, 0xd9, 0x05,
+	0xa0, 0x81, 0x13, 0x0d, 0xfb, 0x7c, 0xd2, 0xf6, 0x12, 0x06, 0xf8, 0xb7, 0x0a, 0x57, 0xbc, 0x9f,
+	0xa2, 0x09, 0x1f, 0x9b, 0x0d, 0xe3, 0x9c, 0xea, 0xd1, 0xd8, 0xb6, 0x9c, 0xb0, 0xd7, 0xe7, 0xec,
+	0xe4, 0xa4, 0x5a, 0x38, 0xd9, 0x32, 0x87, 0xef, 0x66, 0x60, 0xf2, 0x45, 0x20, 0xc2, 0xa8, 0x72,
+	0xc8, 0xcb, 0x88, 0xbc, 0x22, 0x7a, 0x14, 0x74, 0xe3, 0xfb, 0xd0, 0xca, 0xcf, 0x4b, 0x5e, 0x87,
+	0xba, 0xed, 0x77, 0xc3, 0xc8, 0x8b, 0x4f, 0x7b, 0x49, 0xee, 0x9a, 0x02, 0x88, 0x01, 0x4b, 0x3d,
+	0x9b, 0xc5, 0x34, 0xb2, 0x9e, 0xd3, 0x61, 0xe2, 0x4c, 0xea, 0x66, 0x43, 0x00, 0x1f, 0xd0, 0x61,
+	0xc7, 0x25, 0xdb, 0xa0, 0x7f, 0x18, 0xd9, 0xfd, 0x3e, 0x75, 0x2d, 0xd7, 0x8e, 0x6d, 0x8e, 0x89,
+	0x9a, 0xd6, 0x34, 0x5b, 0x12, 0xbe, 0x67, 0xc7, 0xf6, 0x03, 0x3a, 0x34, 0x7e, 0x09, 0x2e, 0x64,
+	0x72, 0xc2, 0xd4, 0x42, 0xb1, 0x82, 0x6f, 0xc1, 0x82, 0x88, 0xd5, 0xda, 0xbc, 0x62, 0x16, 0xe3,
+	0x8c, 0xef, 0x42, 0x3b, 0x8d, 0x1b, 0xa3, 0xc4, 0xbf, 0x99, 0x27, 0x3e, 0x7b, 0xd6, 0x22, 0x69,
+	0x3f, 0x83, 0x0d, 0xe9, 0x88, 0x47, 0x29, 0x7f, 0x3d, 0x4f, 0x79, 0xd6, 0xe8, 0x20, 0xe9, 0xfe,
+	0x51, 0x19, 0x56, 0x77, 0x23, 0x6a, 0xc7, 0x54, 0xf4, 0x99, 0xf4, 0x57, 0x07, 0x94, 0xc5, 0x5c,
+	0x2a, 0x91, 0xf8, 0xd9, 0x49, 0x3c, 0x43, 0x06, 0x20, 0x97, 0xa0, 0x21, 0x2d, 0x49, 0x09, 0x72,
+	0x20, 0x40, 0x8f, 0xa5, 0xa9, 0x8d, 0xe4, 0xa2, 0xac, 0x5d, 0xc6, 0x68, 0xbd, 0x9c, 0x4f, 0x46,
+	0x19, 0x0f, 0xc4, 0x36, 0x1b, 0x06, 0x0e, 0x9a, 0x7e, 0xcd, 0x14, 0x0d, 0xf2, 0x0d, 0x68, 0xb9,
+	0xc7, 0x56, 0x86, 0xcb, 0xd0, 0xf8, 0x1b, 0xb7, 0x36, 0xae, 0x8b, 0x73, 0xd1, 0xf5, 0xe4, 0x5c,
+	0x74, 0xfd, 0x19, 0x0f, 0xdc, 0xe6, 0x92, 0x7b, 0x9c, 0x89, 0x06, 0x89, 0x9e, 0x84, 0x91, 0x23,
+	0x42, 0x5a, 0xcd, 0x14, 0x0d, 0x9e, 0xb0, 0xa1, 0x5a, 0x87, 0x81, 0x3f, 0x44, 0xcf, 0x50, 0x33,
+	0x6b, 0x1c, 0xf0, 0x24, 0xf0, 0x87, 0xe4, 0x0a, 0x2c, 0x77, 0x1d, 0xab, 0x6f, 0x0f, 0x18, 0xb5,
+	0x68, 0x60, 0x1f, 0xfb, 0xc2, 0x3b, 0xd7, 0xcc, 0xa5, 0xae, 0x73, 0xc0, 0xa1, 0xfb, 0x08, 0xe4,
+	0xda, 0x96, 0xe2, 0x31, 0xea, 0x84, 0x81, 0xcb, 0xd0, 0x5d, 0x2f, 0x98, 0x2d, 0x89, 0x78, 0x28,
+	0xa0, 0x39, 0x4c, 0xdb, 0x75, 0xd1, 0x4f, 0x81, 0xc8, 0xc8, 0x25, 0xe6, 0x8e, 0x80, 0x8e, 0x5b,
+	0x76, 0x63, 0xdc, 0xb2, 0x8d, 0x7f, 0xd1, 0x60, 0xd5, 0xa4, 0x6c, 0xd0, 0xfb, 0x74, 0x45, 0x95,
+	0xf2, 0xbf, 0xac, 0xf2, 0xbf, 0x80, 0x1b, 0x95, 0x59, 0xb9, 0xb1, 0x30, 0x33, 0x37, 0xaa, 0x45,
+	0xdc, 0x30, 0x02, 0x58, 0xdd, 0xb5, 0x03, 0x87, 0xfa, 0x9f, 0xea, 0x3e, 0xdb, 0xb0, 0xe8, 0xf8,
+	0xd4, 0x0e, 0x06, 0x7d, 0xb9, 0xd3, 0xa4, 0x69, 0x7c, 0x0f, 0xd6, 0xc4, 0x7c, 0x26, 0x65, 0x71,
+	0x18, 0xd1, 0xd9, 0x26, 0x14, 0x41, 0xb3, 0x94, 0x06, 0xcd, 0xc9, 0xf4, 0x7f, 0xa0, 0x01, 0x51,
+	0x2c, 0x8f, 0xb2, 0x7e, 0x18, 0x30, 0x7a, 0x06, 0xf9, 0x77, 0xa1, 0xa2, 0x44, 0xdf, 0x37, 0x0b,
+	0xad, 0x3a, 0x21, 0x85, 0x61, 0x17, 0xd1, 0x79, 0xa2, 0xdb, 0x63, 0x5d, 0x19, 0x68, 0xf9, 0x4f,
+	0xf2, 0x65, 0xa8, 0x70, 0xaf, 0x88, 0xe2, 0x6b, 0xdc, 0xba, 0x34, 0x25, 0x8c, 0xe3, 0xea, 0x10,
+	0xd9, 0xf8, 0x3b, 0x0d, 0xf4, 0x7b, 0x34, 0xfe, 0x54, 0x05, 0xf0, 0x1a, 0xd4, 0x25, 0x82, 0xcc,
+	0xf7, 0xea, 0x49, 0x16, 0x23, 0x47, 0x0f, 0x9c, 0xe7, 0x34, 0x16, 0xa3, 0x2b, 0x72, 0x34, 0x82,
+	0x70, 0x34, 0x81, 0x4a, 0xdf, 0x8e, 0x4f, 0x51, 0xb9, 0xea, 0x26, 0xfe, 0xe6, 0x71, 0xf3, 0x43,
+	0x2f, 0x3e, 0x0d, 0x07, 0xb1, 0xe5, 0xd2, 0xd8, 0xf6, 0x7c, 0x69, 0xee, 0x4b, 0x12, 0xba, 0x87,
+	0x40, 0xe3, 0x17, 0x81, 0x3c, 0xf4, 0x98, 0xdc, 0x0c, 0x9b, 0x6d, 0x37, 0x05, 0x87, 0xe9, 0x52,
+	0xd1, 0x61, 0xda, 0xf8, 0x13, 0x0d, 0x56, 0x73, 0xd4, 0x3f, 0x2b, 0xe9, 0x96, 0x67, 0x97, 0xee,
+	0x11, 0xac, 0xee, 0x51, 0x9f, 0x7e, 0xba, 0x3e, 0xdf, 0xf8, 0x35, 0x58, 0xcb, 0x53, 0x7d, 0xa5,
+	0x9c, 0x30, 0xfe, 0xa7, 0x06, 0x6b, 0xd2, 0x80, 0x3f, 0xab, 0x50, 0xf6, 0x79, 0x50, 0x12, 0x3e,
+	0x8b, 0x0d, 0x4e, 0x4e, 0xbc, 0x97, 0x52, 0x95, 0x15, 0x1a, 0x87, 0x08, 0x27, 0x61, 0x2e, 0xc5,
+	0x8c, 0xa8, 0xa0, 0x2c, 0x4e, 0x32, 0xdf, 0x9e, 0xc4, 0x86, 0xb1, 0xdd, 0x29, 0x09, 0x89, 0x29,
+	0x48, 0x88, 0x23, 0xf8, 0x8a, 0x33, 0x0a, 0xcf, 0x1c, 0x7d, 0x55, 0x75, 0xf4, 0x23, 0x86, 0xb7,
+	0x38, 0xd1, 0xf0, 0x6a, 0x8a, 0xe1, 0x8d, 0x47, 0xe7, 0xfa, 0x3c, 0xd1, 0x79, 0x13, 0xd2, 0xb0,
+	0x9b, 0x1c, 0x67, 0x92, 0x36, 0x3f, 0x32, 0x44, 0x62, 0x9f, 0x58, 0x16, 0x91, 0xa7, 0x9a, 0x1c,
+	0x8c, 0xe3, 0xf0, 0x70, 0x31, 0x88, 0x43, 0x81, 0xd3, 0x14, 0x38, 0x2a, 0x8c, 0xdc, 0x84, 0x55,
+	0x37, 0x0a, 0xfb, 0xfb, 0x2f, 0x3d, 0x16, 0x67, 0x73, 0x63, 0x42, 0x5c, 0x33, 0x8b, 0xba, 0xc8,
+	0x15, 0x68, 0xa5, 0x60, 0x41, 0xb7, 0x85, 0xc8, 0x23, 0x50, 0x72, 0x0b, 0xd6, 0xd8, 0x73, 0xaf,
+	0x2f, 0xb2, 0x26, 0x85, 0xf4, 0x32, 0x62, 0x17, 0xf6, 0xc9, 0x60, 0xa1, 0xa7, 0xc1, 0xe2, 0x1a,
+	0xac, 0x44, 0x18, 0xca, 0x2d, 0xb9, 0x31, 0xee, 0x13, 0x57, 0x44, 0x86, 0x2d, 0x3a, 0xa4, 0xb0,
+	0x3b, 0x2e, 0xb9, 0x09, 0x6b, 0x09, 0x52, 0x1c, 0x2a, 0x47, 0x17, 0x82, 0x47, 0x17, 0x22, 0xfb,
+	0x8e, 0xc2, 0xec, 0xf4, 0x72, 0x05, 0x96, 0x47, 0x46, 0x60, 0x45, 0xa8, 0x6e, 0x2e, 0xe5, 0x90,
+	0xb1, 0x94, 0x18, 0x0d, 0xad, 0x68, 0x10, 0xb4, 0xd7, 0x70, 0xf1, 0x55, 0x37, 0x1a, 0x9a, 0x83,
+	0x80, 0x6c, 0x40, 0xf5, 0x05, 0x8d, 0xbc, 0x93, 0x61, 0x7b, 0x5d, 0xc0, 0x45, 0x8b, 0xe7, 0x29,
+	0x2c, 0x8e, 0x3c, 0x27, 0xb6, 0x64, 0xf7, 0x86, 0xe0, 0xbc, 0x00, 0x3e, 0x13, 0x48, 0x5f, 0x80,
+	0x64, 0x4d, 0x96, 0x52, 0x5b, 0x38, 0x8f, 0x98, 0xba, 0xec, 0x79, 0x98, 0xd6, 0xb1, 0xae, 0xc3,
+	0x2a, 0x62, 0x45, 0xb4, 0xef, 0x7b, 0x8e, 0xcd, 0x6b, 0x8a, 0xc7, 0x34, 0x6a, 0xb7, 0x31, 0x87,
+	0x58, 0xe1, 0x5d, 0xa6, 0xe8, 0x79, 0x8c, 0x1d, 0xdc, 0x31, 0x27, 0xd4, 0x93, 0x32, 0xd0, 0x05,
+	0x21, 0x26, 0x09, 0xde, 0x11, 0x50, 0x5e, 0x61, 0x60, 0x1f, 0xda, 0xfd, 0x14, 0x6b, 0x13, 0xb1,
+	0x1a, 0x1c, 0x26, 0x51, 0x36, 0xf7, 0x60, 0xa3, 0xd8, 0x7c, 0xe6, 0xaa, 0x17, 0xfd, 0x53, 0x39,
+	0x75, 0x3c, 0x69, 0x02, 0xcf, 0x4f, 0xc4, 0x63, 0xc7, 0xea, 0xfb, 0x05, 0xc7, 0xea, 0xab, 0xd3,
+	0x2c, 0xfd, 0x67, 0xf0, 0x5c, 0xdd, 0x01, 0xac, 0xd1, 0x24, 0x79, 0xea, 0xe2, 0x96, 0x36, 0xd7,
+	0x69, 0x06, 0xf8, 0x60, 0xd1, 0x1e, 0x2b, 0x01, 0xd5, 0x66, 0xa9, 0xa7, 0xd5, 0x8b, 0xea, 0x69,
+	0x5f, 0x00, 0x72, 0xe2, 0x05, 0x1e, 0x3b, 0xa5, 0xae, 0x95, 0x94, 0x50, 0x78, 0xaa, 0x5d, 0xde,
+	0x2e, 0x9b, 0x7a, 0xd2, 0x73, 0x4f, 0xd4, 0x52, 0x18, 0x79, 0x17, 0xce, 0xa7, 0xd8, 0x59, 0x49,
+	0x12, 0x87, 0x34, 0x70, 0xc8, 0x5a, 0xd2, 0xfd, 0x30, 0xa9, 0x3a, 0x76, 0x5c, 0x66, 0xfc, 0x0d,
+	0xc0, 0xba, 0x94, 0x4b, 0xa6, 0x34, 0x3f, 0xd7, 0x72, 0xfe, 0x0e, 0x34, 0xb8, 0x0b, 0x4f, 0x64,
+	0x59, 0x45, 0x59, 0xce, 0x71, 0xec, 0x05, 0x3e, 0x5a, 0x0a, 0xf3, 0x1d, 0xd8, 0x88, 0xed, 0xa8,
+	0x4b, 0x63, 0x6b, 0x34, 0x6d, 0x12, 0x11, 0x65, 0x4d, 0xf4, 0xee, 0xe6, 0x6f, 0x22, 0x6c, 0x38,
+	0x9f, 0xc9, 0x37, 0x75, 0x59, 0x36, 0x7b, 0xce, 0xda, 0xb5, 0x29, 0x87, 0xf0, 0x22, 0x6b, 0x33,
+	0xd7, 0x53, 0x4a, 0x0a, 0x57, 0xf1, 0x68, 0x25, 0x09, 0xbb, 0x16, 0x16, 0x5e, 0x44, 0x69, 0x2d,
+	0x09, 0x28, 0xee, 0x21, 0x2f, 0xc0, 0x5c, 0x81, 0xe5, 0x38, 0x4c, 0x17, 0xa0, 0xd4, 0x67, 0x96,
+	0xe2, 0x50, 0x52, 0x43, 0x3c, 0xd5, 0x32, 0x1a, 0x23, 0x96, 0xf1, 0x36, 0xb4, 0x24, 0x07, 0x92,
+	0xeb, 0x19, 0x59, 0x9e, 0x11, 0xd0, 0x3d, 0x71, 0x49, 0xa3, 0x86, 0xbe, 0xa5, 0x33, 0x42, 0x5f,
+	0x6b, 0x86, 0xd0, 0xb7, 0x3c, 0x7b, 0xe8, 0xd3, 0xe7, 0x09, 0x7d, 0x2b, 0x73, 0x85, 0x3e, 0x32,
+	0x25, 0xf4, 0x4d, 0x31, 0xb7, 0xd5, 0xc9, 0xe6, 0x36, 0x31, 0xea, 0xad, 0x4d, 0x8c, 0x7a, 0x9f,
+	0x28, 0x68, 0x3d, 0x82, 0x25, 0xd1, 0xcb, 0xb5, 0x60, 0xe0, 0xc7, 0xed, 0xf3, 0x53, 0x3c, 0x9b,
+	0x54, 0x09, 0x31, 0xd4, 0x44, 0x7c, 0xb3, 0xf9, 0x42, 0x69, 0x4d, 0x88, 0x81, 0xed, 0xf9, 0x62,
+	0xe0, 0x85, 0x29, 0x31, 0x10, 0xf1, 0x53, 0x8d, 0xe7, 0xd1, 0x8d, 0x67, 0xa4, 0x2d, 0x0e, 0x4e,
+	0xcd, 0x02, 0x95, 0x5f, 0x20, 0x26, 0x4a, 0xfb, 0x9a, 0x50, 0x7e, 0x44, 0x93, 0x30, 0xf5, 0x42,
+	0xe5, 0xf5, 0xdc, 0x85, 0xca, 0x58, 0x08, 0xbd, 0x38, 0x16, 0x42, 0xc9, 0x0d, 0x58, 0x65, 0x94,
+	0x8b, 0x9a, 0xe6, 0x96, 0xf3, 0x06, 0x12, 0x22, 0x49, 0x57, 0xb6, 0x24, 0xe3, 0xdf, 0x34, 0x78,
+	0x7d, 0xd4, 0x7e, 0x55, 0x46, 0x16, 0xf8, 0x7c, 0xad, 0xc8, 0xe7, 0x5f, 0x80, 0x5a, 0xcf, 0x0b,
+	0xc4, 0xd5, 0x80, 0xb8, 0x60, 0x58, 0xec, 0x79, 0x01, 0x5e, 0x0c, 0xf0, 0x2e, 0xfb, 0xa5, 0xe8,
+	0x2a, 0xcb, 0x2e, 0xfb, 0x25, 0x76, 0x5d, 0x82, 0x86, 0xed, 0xc4, 0x03, 0xdb, 0x57, 0xef, 0x14,
+	0x40, 0x80, 0x10, 0x81, 0x6f, 0xd9, 0xee, 0xf5, 0x7d, 0xea, 0xf2, 0x32, 0x22, 0x93, 0x2e, 0xb3,
+	0x21, 0x61, 0x0f, 0xe8, 0x90, 0x71, 0xee, 0xf7, 0x3c, 0xd6, 0xb3, 0x63, 0xe7, 0x34, 0xc1, 0xaa,
+	0x22, 0x56, 0x2b, 0x03, 0x73, 0x44, 0xe3, 0x9f, 0x45, 0xc1, 0x66, 0x54, 0x55, 0xb8, 0xa2, 0xf6,
+	0x6d, 0xc6, 0xa8, 0x88, 0x19, 0x35, 0x53, 0xb6, 0xc8, 0x2f, 0x00, 0x28, 0x2c, 0x2c, 0xa1, 0x03,
+	0xfc, 0xd2, 0x4c, 0x0e, 0x30, 0xa7, 0x89, 0x0a, 0x91, 0x91, 0x63, 0xac, 0xc2, 0x11, 0xe5, 0x18,
+	0x8b, 0xfb, 0x7e, 0x03, 0x20, 0x5d, 0xbd, 0xb8, 0x68, 0xaa, 0x9b, 0x0a, 0x84, 0xfb, 0x2d, 0x54,
+	0x70, 0x8f, 0xba, 0xc8, 0x93, 0x9a, 0x99, 0xb6, 0x8d, 0xdf, 0x2f, 0xc3, 0x4a, 0xee, 0x6c, 0xf2,
+	0x73, 0x1d, 0x15, 0x5d, 0x68, 0xe7, 0xce, 0x65, 0x6a, 0x50, 0xaa, 0x4e, 0x79, 0x01, 0x51, 0x98,
+	0x1b, 0x98, 0x1b, 0xea, 0x39, 0x6c, 0x5a, 0x58, 0x5a, 0x9c, 0x2d, 0x2c, 0xd5, 0xce, 0x0a, 0x4b,
+	0xf5, 0x7c, 0x58, 0x32, 0x8e, 0x41, 0x97, 0xa8, 0x98, 0x00, 0x1d, 0xf8, 0x76, 0x90, 0xbb, 0x69,
+	0xd2, 0xf2, 0x37, 0x4d, 0x97, 0xa0, 0xa1, 0x7a, 0xe8, 0x12, 0x7a, 0x68, 0x60, 0x99, 0x5f, 0x4e,
+	0xee, 0x2f, 0xca, 0xd9, 0xfd, 0x85, 0xf1, 0x63, 0x6d, 0x3c, 0x03, 0xc6, 0x89, 0x66, 0xb4, 0xe5,
+	0x0d, 0xa8, 0x3a, 0x18, 0x36, 0x50, 0x2d, 0x6a, 0xa6, 0x6c, 0xf1, 0x87, 0x0a, 0xb8, 0x2e, 0x71,
+	0xe0, 0x9e, 0xf4, 0x50, 0x61, 0x74, 0x7b, 0xa6, 0x1c, 0xc4, 0x23, 0xf2, 0x48, 0xc0, 0xa9, 0xe0,
+	0x76, 0x9a, 0xfe, 0xcd, 0xc3, 0xf1, 0x0d, 0x29, 0xd7, 0xa2, 0xc6, 0x8f, 0x2a, 0x05, 0xb9, 0x1e,
+	0xee, 0xe8, 0x6d, 0x68, 0xb1, 0x70, 0x10, 0x39, 0x34, 0x8d, 0xf2, 0x62, 0x47, 0x4d, 0x01, 0x95,
+	0x51, 0xfe, 0x1d, 0xd8, 0x90, 0x58, 0xc5, 0x45, 0xa4, 0x35, 0xd1, 0x3b, 0x92, 0x0d, 0x8d, 0x67,
+	0x10, 0xe5, 0x82, 0x0c, 0x62, 0x72, 0xa6, 0x55, 0x99, 0x92, 0x69, 0xad, 0xc1, 0x02, 0xe5, 0x71,
+	0x5c, 0x1a, 0xaf, 0x68, 0xf0, 0xbd, 0xf3, 0x08, 0x2f, 0x2b, 0x02, 0xf8, 0x5b, 0x11, 0xc6, 0x62,
+	0x4e, 0x18, 0x17, 0x01, 0x78, 0xbf, 0x7c, 0xee, 0x20, 0x4a, 0xe3, 0x75, 0x0e, 0x11, 0xa9, 0xc1,
+	0xfb, 0xb0, 0x88, 0x3d, 0x34, 0xb9, 0x26, 0x3b, 0xeb, 0xad, 0x43, 0x82, 0x4e, 0x3a, 0x39, 0xb7,
+	0x07, 0x73, 0xe4, 0x7d, 0x28, 0x6d, 0x65, 0x70, 0x81, 0xc4, 0x1b, 0x53, 0x24, 0xde, 0xcc, 0x24,
+	0xce, 0x61, 0x3c, 0x28, 0xca, 0x9c, 0x0c, 0x7f, 0x17, 0x85, 0xd9, 0x56, 0x61, 0x98, 0x55, 0x22,
+	0xe8, 0x72, 0x2e, 0x82, 0x1a, 0x3f, 0xd4, 0xa0, 0x91, 0xac, 0x9a, 0xab, 0x0f, 0x2f, 0x26, 0x21,
+	0x3b, 0xf1, 0xa6, 0xea, 0x18, 0x87, 0x68, 0xb2, 0x98, 0x84, 0xf0, 0xbd, 0x04, 0x4c, 0x1e, 0x8a,
+	0xec, 0x3c, 0x29, 0xb0, 0x94, 0xe6, 0x71, 0x3d, 0xc8, 0x18, 0x75, 0x38, 0x2f, 0x82, 0x39, 0x61,
+	0x70, 0xe2, 0x7b, 0x4e, 0x9c, 0x94, 0xaf, 0x32, 0x40, 0xca, 0x91, 0x8a, 0x62, 0x03, 0xff, 0xa5,
+	0xc1, 0x7a, 0xce, 0xab, 0xbf, 0xea, 0xd2, 0xe6, 0xed, 0x5c, 0xe1, 0xfa, 0xca, 0xd9, 0x25, 0x31,
+	0x74, 0xb8, 0x38, 0x86, 0xbc, 0x03, 0x95, 0xbe, 0x6f, 0x07, 0xf2, 0xd2, 0x68, 0x6b, 0xaa, 0x36,
+	0x71, 0x5e, 0x21, 0xb6, 0x71, 0x17, 0x36, 0xee, 0xd1, 0x38, 0xf1, 0xac, 0x3c, 0xde, 0x7c, 0xac,
+	0xab, 0x00, 0xe3, 0x7b, 0xd0, 0x50, 0xee, 0xfd, 0xb9, 0x7a, 0xe0, 0x63, 0xbc, 0xce, 0x5e, 0xe2,
+	0x6d, 0x65, 0x93, 0xbc, 0x9b, 0x3d, 0x61, 0x10, 0xf2, 0x7d, 0xad, 0xb8, 0x80, 0x9b, 0x7f, 0xbd,
+	0x60, 0xfc, 0xbd, 0x06, 0x55, 0x49, 0xfb, 0x12, 0x34, 0x68, 0x10, 0x47, 0x1e, 0x15, 0xaf, 0xb1,
+	0x04, 0x7d, 0x90, 0x20, 0xfe, 0x1c, 0xeb, 0x32, 0xb4, 0xd2, 0xe4, 0xd9, 0x3a, 0x89, 0xc2, 0x1e,
+	0xae, 0xb3, 0x62, 0x2e, 0xa5, 0xd0, 0xbb, 0x51, 0xd8, 0xe3, 0x79, 0x4f, 0x86, 0x16, 0x87, 0x28,
+	0x87, 0x8a, 0xd9, 0x48, 0x61, 0x47, 0x21, 0x8f, 0x1a, 0xfc, 0x9a, 0x17, 0x8b, 0x81, 0xc2, 0xd5,
+	0x2c, 0xfa, 0x61, 0xf7, 0x80, 0xd7, 0x03, 0x65, 0x97, 0xe2, 0x47, 0x79, 0x17, 0xc6, 0xa6, 0x2d,
+	0xae, 0xc6, 0xd9, 0xc5, 0xb0, 0xb8, 0xf1, 0x51, 0x41, 0xc6, 0x7b, 0xd0, 0x7c, 0x40, 0x87, 0x58,
+	0x28, 0x3c, 0xb0, 0xbd, 0x68, 0xd6, 0xda, 0x8b, 0xf1, 0x53, 0x0d, 0x00, 0x47, 0x21, 0xaf, 0xc9,
+	0x45, 0xa8, 0x1f, 0x87, 0xa1, 0x8f, 0x86, 0x25, 0x12, 0xab, 0xfb, 0xe7, 0xcc, 0x1a, 0x07, 0x71,
+	0x9b, 0x22, 0xaf, 0x41, 0xcd, 0x0b, 0x62, 0xd1, 0xcb, 0xc9, 0x2c, 0xdc, 0x3f, 0xc7, 0x5d, 0x50,
+	0x8c, 0x9d, 0x17, 0xa1, 0xee, 0x87, 0x41, 0x57, 0xf4, 0x62, 0x64, 0xe3, 0x63, 0x39, 0x08, 0xbb,
+	0x2f, 0x01, 0x9c, 0xf8, 0xa1, 0x2d, 0x47, 0xf3, 0xbd, 0x97, 0xee, 0x9f, 0x33, 0xeb, 0x08, 0x43,
+	0x84, 0x37, 0xa1, 0xe1, 0x86, 0x83, 0x63, 0x5f, 0x98, 0x35, 0xb2, 0x40, 0xbb, 0x7f, 0xce, 0x04,
+	0x01, 0x4c, 0x50, 0xf8, 0x81, 0x23, 0x99, 0x04, 0xf9, 0xc0, 0x51, 0x04, 0x30, 0x99, 0xe6, 0x78,
+	0x18, 0x53, 0x26, 0x30, 0xb8, 0xf7, 0x6d, 0xf2, 0x69, 0x10, 0xc6, 0x11, 0xee, 0x54, 0x85, 0x45,
+	0x18, 0xff, 0x5e, 0x91, 0x0a, 0x26, 0x5e, 0xe6, 0x4d, 0x51, 0xb0, 0xe4, 0x19, 0x44, 0x49, 0x79,
+	0x06, 0xf1, 0x36, 0xb4, 0x3c, 0x66, 0xf5, 0x23, 0xaf, 0x67, 0x47, 0xc3, 0xf4, 0xb2, 0xbc, 0x66,
+	0x36, 0x3d, 0x76, 0x20, 0x80, 0x0f, 0xe8, 0x90, 0xcb, 0xcd, 0xa5, 0xcc, 0x89, 0x3c, 0xf1, 0x90,
+	0x40, 0x08, 0x5c, 0x05, 0x91, 0xdb, 0x50, 0xc7, 0xeb, 0x76, 0x7c, 0x36, 0xba, 0x80, 0xd6, 0x7e,
+	0xb1, 0x50, 0x7d, 0xf9, 0xda, 0xf9, 0x53, 0x52, 0xb3, 0xe6, 0xca, 0x5f, 0xe4, 0x0e, 0x34, 0xf8,
+	0x30, 0x4b, 0xbe, 0x2c, 0x15, 0x79, 0x55, 0xb1, 0xaf, 0x50, 0x75, 0xc3, 0x04, 0x3e, 0x4a, 0x3c,
+	0x25, 0x25, 0x7b, 0xd0, 0x14, 0x2f, 0xec, 0x24, 0x91, 0xc5, 0x59, 0x89, 0x88, 0x87, 0x79, 0x92,
+	0xca, 0x06, 0x54, 0x6d, 0x7e, 0x72, 0xde, 0x93, 0x21, 0x4d, 0xb6, 0xc8, 0xbb, 0xb0, 0x20, 0x0e,
+	0x6d, 0x75, 0xdc, 0xd9, 0xa5, 0xc9, 0xaf, 0x7b, 0x84, 0xa3, 0x10, 0xd8, 0xe4, 0xdb, 0xd0, 0xa4,
+	0x3e, 0xc5, 0xf0, 0x83, 0x7c, 0x81, 0x59, 0xf8, 0xd2, 0x90, 0x43, 0x78, 0x83, 0xec, 0xc1, 0x92,
+	0x4b, 0x4f, 0xec, 0x81, 0x1f, 0x5b, 0x42, 0xe9, 0x1b, 0x53, 0x2e, 0xee, 0x32, 0xfd, 0x37, 0x9b,
+	0x72, 0x14, 0x82, 0xf0, 0x51, 0x2f, 0xb3, 0xdc, 0x61, 0x60, 0xf7, 0x3c, 0x47, 0x16, 0xc8, 0xeb,
+	0x1e, 0xdb, 0x13, 0x00, 0x7e, 0x19, 0xcb, 0x75, 0x20, 0xcd, 0xcd, 0xb8, 0x16, 0x88, 0xd0, 0xd7,
+	0xf2, 0x58, 0x1a, 0xd9, 0xf8, 0x93, 0x89, 0x7f, 0xd0, 0x40, 0x1f, 0x7d, 0x0a, 0x9a, 0xaa, 0x95,
+	0xa6, 0xa8, 0xd5, 0x88, 0xc2, 0x94, 0xc6, 0x15, 0x26, 0x63, 0x75, 0x39, 0xc7, 0xea, 0xf7, 0xa1,
+	0x8a, 0xfa, 0x9a, 0x3c, 0x70, 0x9b, 0xf2, 0x92, 0x2a, 0x79, 0x8a, 0x2a, 0xf0, 0x79, 0x91, 0x40,
+	0x5c, 0x4e, 0x27, 0x3b, 0xb5, 0xb0, 0x43, 0x26, 0x39, 0x44, 0xf4, 0xc9, 0x3d, 0xe3, 0x78, 0xa3,
+	0x05, 0x4d, 0x7c, 0x1c, 0x28, 0x1d, 0xbb, 0xf1, 0x01, 0x2c, 0xc9, 0xb6, 0x0c, 0x6e, 0x49, 0xf8,
+	0xd2, 0x3e, 0x56, 0xf8, 0x2a, 0x65, 0xf7, 0x51, 0xbf, 0xa1, 0x41, 0xe3, 0x11, 0xeb, 0x1e, 0x84,
+	0x0c, 0x79, 0xc9, 0x3d, 0x6c, 0xf2, 0xe8, 0x52, 0xe1, 0x5d, 0x43, 0xc2, 0x92, 0x24, 0xad, 0xc7,
+	0xba, 0x9d, 0x3d, 0x24, 0xd3, 0x34, 0x45, 0x03, 0x4b, 0x46, 0xac, 0x8b, 0xe9, 0x6d, 0x72, 0x6d,
+	0x9a, 0xb4, 0x79, 0x5c, 0xca, 0x4a, 0x23, 0x15, 0xf4, 0xd9, 0x19, 0xc0, 0xd8, 0x81, 0x65, 0xf9,
+	0x20, 0x32, 0x5d, 0x45, 0x91, 0xe4, 0xf8, 0xf1, 0x41, 0xf6, 0xcb, 0x0d, 0xa4, 0x6d, 0xe3, 0xaf,
+	0x35, 0x68, 0x70, 0xa6, 0xbb, 0x03, 0x9f, 0xf2, 0x9b, 0x81, 0xfc, 0xb9, 0x4a, 0x9b, 0x76, 0xae,
+	0x2a, 0xe5, 0xcf, 0x55, 0x23, 0x17, 0x6d, 0xe5, 0xb1, 0x8b, 0xb6, 0xfc, 0xab, 0xb3, 0xca, 0xc7,
+	0x7f, 0x75, 0x26, 0x65, 0xb1, 0x90, 0xc9, 0xe2, 0x3f, 0x4a, 0xd0, 0x12, 0x83, 0x92, 0xbd, 0x14,
+	0x32, 0x82, 0x40, 0xc5, 0x89, 0x52, 0x26, 0xe0, 0xef, 0x82, 0xab, 0xae, 0xf2, 0x3c, 0x57, 0x5d,
+	0x6f, 0xc1, 0x12, 0x27, 0x6d, 0xc5, 0xb4, 0xd7, 0xf7, 0xed, 0x58, 0xec, 0xab, 0x6e, 0x36, 0x39,
+	0xf0, 0x48, 0xc2, 0xf2, 0xef, 0x52, 0x16, 0x46, 0xaa, 0x82, 0xc5, 0x4f, 0x59, 0x36, 0xa0, 0x2a,
+	0xce, 0x10, 0xb2, 0xbe, 0x2a, 0x5b, 0xfc, 0xbd, 0x94, 0x6f, 0xb3, 0x98, 0xdf, 0xe9, 0x08, 0x29,
+	0xc8, 0xaa, 0x3a, 0x07, 0x9a, 0x83, 0x00, 0x25, 0x61, 0xc0, 0x52, 0x40, 0x5f, 0x2a, 0x38, 0xa2,
+	0x24, 0xda, 0xe0, 0xc0, 0x04, 0xe7, 0x36, 0x2c, 0x9e, 0x7a, 0x2c, 0x0e, 0xa3, 0x61, 0x1b, 0xa6,
+	0x18, 0xa5, 0xa2, 0x1a, 0x66, 0x32, 0xc0, 0xf8, 0x6f, 0x0d, 0xd6, 0x45, 0x19, 0x30, 0xed, 0x9e,
+	0x29, 0x8d, 0x2a, 0x0a, 0x56, 0x89, 0x48, 0xca, 0x53, 0x45, 0x52, 0xf9, 0x44, 0x22, 0x59, 0x38,
+	0x4b, 0x24, 0xd5, 0x49, 0x22, 0x59, 0x54, 0x44, 0x62, 0xfc, 0xa9, 0x06, 0x7a, 0xb6, 0xe1, 0x57,
+	0x9b, 0x2b, 0x7f, 0x25, 0x97, 0x2b, 0xbf, 0x35, 0xc5, 0x6a, 0xd2, 0x15, 0x8a, 0x54, 0xe2, 0x3e,
+	0xac, 0xf1, 0xd7, 0x0b, 0x09, 0x94, 0x7d, 0x6c, 0x49, 0x19, 0x7f, 0xae, 0xc1, 0xfa, 0x08, 0xa9,
+	0xcf, 0x8a, 0x07, 0xe5, 0xf9, 0x78, 0xd0, 0x81, 0x75, 0xf1, 0x70, 0xe1, 0x13, 0xab, 0xab, 0xe1,
+	0xc2, 0xea, 0x41, 0x34, 0x08, 0xe8, 0x5c, 0x6f, 0x4d, 0x94, 0x6b, 0xd8, 0xd2, 0xe8, 0x35, 0x6c,
+	0x3f, 0xf4, 0x3d, 0x67, 0x28, 0xb7, 0x29, 0x5b, 0xc6, 0xf7, 0x79, 0xbd, 0x2d, 0xa6, 0x01, 0xd7,
+	0xe7, 0x3d, 0xea, 0x78, 0xf8, 0x10, 0x73, 0xc4, 0xbf, 0x6a, 0x63, 0xfe, 0x15, 0xdd, 0xbc, 0xef,
+	0x39, 0x1e, 0x15, 0xa7, 0x8d, 0xba, 0x99, 0xb6, 0xf9, 0x5e, 0x9e, 0x53, 0x9a, 0xbc, 0x5c, 0xc2,
+	0xdf, 0x7c, 0xf6, 0x88, 0xda, 0x2c, 0x4d, 0xfe, 0x64, 0xcb, 0xf8, 0x0b, 0x0d, 0xd6, 0xf2, 0x9b,
+	0xfc, 0xac, 0xce, 0x85, 0xe5, 0x29, 0xe7, 0xc2, 0x11, 0xf6, 0x48, 0x51, 0xff, 0x81, 0x06, 0xab,
+	0xa2, 0x58, 0xfa, 0xa9, 0xbe, 0x11, 0x19, 0x79, 0x44, 0x51, 0x9e, 0xf8, 0x88, 0xa2, 0xa2, 0x3c,
+	0xa2, 0xd8, 0x84, 0x1a, 0x7e, 0x7d, 0xc1, 0x06, 0xbd, 0xc4, 0xe9, 0x27, 0x6d, 0xe3, 0x87, 0x25,
+	0xd0, 0xef, 0x7a, 0x7e, 0xbe, 0x6e, 0x9c, 0x10, 0xd1, 0x14, 0x22, 0xb7, 0x93, 0x34, 0x55, 0xb0,
+	0xf5, 0xed, 0x09, 0xa9, 0x53, 0x42, 0x29, 0x97, 0xab, 0xce, 0xf4, 0x19, 0x42, 0xc1, 0x43, 0xa8,
+	0x4a, 0xe1, 0x57, 0x45, 0xa3, 0xd7, 0xb9, 0x0b, 0xe3, 0xd7, 0xb9, 0xf9, 0x0f, 0x07, 0xaa, 0x05,
+	0x1f, 0x0e, 0xd0, 0x97, 0x7d, 0x71, 0x97, 0xa0, 0xd6, 0x44, 0x13, 0x20, 0x9e, 0x27, 0xb3, 0x0a,
+	0xbe, 0x52, 0x0f, 0x95, 0x15, 0x7c, 0x8e, 0x60, 0xfc, 0xad, 0x06, 0x24, 0x2f, 0x62, 0x64, 0xde,
+	0x99, 0xe6, 0xc1, 0xb9, 0xc1, 0xd9, 0x4f, 0x5d, 0xeb, 0xc4, 0xf3, 0x29, 0x4b, 0x3f, 0x5b, 0x10,
+	0x40, 0xce, 0x43, 0xfc, 0x62, 0x68, 0x10, 0x44, 0xd4, 0x09, 0x23, 0x37, 0xc5, 0x13, 0x5c, 0x5b,
+	0xce, 0xe0, 0x02, 0x75, 0x07, 0x8b, 0xb2, 0xc7, 0x3e, 0x4d, 0xbf, 0x9c, 0xbb, 0x7c, 0x86, 0x70,
+	0xc4, 0x4a, 0xcd, 0x74, 0x98, 0xf1, 0x97, 0x1a, 0xac, 0x8d, 0x6c, 0xe5, 0x95, 0x5a, 0xda, 0xd7,
+	0x72, 0x51, 0xe5, 0x73, 0xc5, 0x27, 0x90, 0x31, 0x56, 0x4b, 0x53, 0xfb, 0xb1, 0x06, 0xab, 0xfb,
+	0x2f, 0xfb, 0x61, 0x14, 0x7f, 0xf6, 0xa6, 0xf6, 0x26, 0x34, 0xed, 0xc8, 0x39, 0xf5, 0x5e, 0x50,
+	0x4b, 0x79, 0x44, 0xd8, 0x90, 0x30, 0x2c, 0x61, 0x9c, 0x5d, 0xa7, 0xf8, 0x6d, 0x0d, 0x56, 0x3b,
+	0xbd, 0x79, 0x37, 0x34, 0x3a, 0x75, 0x69, 0x7c, 0xea, 0x8f, 0xb3, 0x25, 0xe3, 0x47, 0x1a, 0xac,
+	0x8a, 0x75, 0xec, 0x08, 0x52, 0xb3, 0xea, 0xf9, 0x0c, 0x0b, 0x6a, 0xc3, 0x62, 0xf2, 0xc9, 0x83,
+	0x28, 0x15, 0x26, 0x4d, 0xee, 0x0d, 0xf8, 0x25, 0x2f, 0x7f, 0x6a, 0xaf, 0x7e, 0x94, 0x54, 0x37,
+	0x5b, 0x12, 0x9c, 0x7c, 0xe6, 0xc0, 0x53, 0x24, 0xb4, 0x0e, 0xe1, 0x06, 0x44, 0x23, 0xad, 0x33,
+	0x56, 0x95, 0x3a, 0xe3, 0x5f, 0x69, 0xb0, 0x3e, 0xba, 0x91, 0x57, 0xaa, 0xe5, 0x5f, 0xcf, 0x69,
+	0xf9, 0xb4, 0x13, 0x47, 0x8e, 0xd3, 0x52, 0xcd, 0x7f, 0xab, 0x04, 0x2b, 0xbb, 0x61, 0xff, 0x67,
+	0x20, 0x9e, 0x6c, 0x40, 0x55, 0x94, 0xfe, 0xa5, 0x7a, 0xcb, 0x16, 0xbf, 0x8b, 0x16, 0xbf, 0x2c,
+	0x95, 0xa6, 0x50, 0x70, 0x5d, 0xf4, 0xdc, 0xc9, 0x28, 0x5f, 0x82, 0x86, 0xc4, 0xc6, 0x09, 0xe4,
+	0x7b, 0x40, 0x01, 0x3a, 0x18, 0x0d, 0x5b, 0xb5, 0x91, 0xb0, 0xf5, 0x9f, 0x25, 0xd0, 0x55, 0x66,
+	0xcc, 0xa6, 0x91, 0xd9, 0xc2, 0x4b, 0x33, 0x2c, 0xbc, 0x3c, 0xdb, 0xc2, 0x2b, 0x63, 0x0b, 0x57,
+	0xb4, 0x7a, 0xe1, 0x4c, 0xad, 0xae, 0x16, 0x6a, 0x35, 0x3f, 0xc3, 0x87, 0x7d, 0x2f, 0x75, 0xfd,
+	0x22, 0x40, 0x35, 0x04, 0x4c, 0xb8, 0x7d, 0xfe, 0xda, 0x40, 0xd2, 0x12, 0x38, 0x22, 0x42, 0x35,
+	0x25, 0x50, 0x20, 0x5d, 0x02, 0x39, 0x46, 0x7d, 0x92, 0x02, 0x02, 0x74, 0x28, 0xbf, 0x08, 0x4a,
+	0xae, 0x57, 0x25, 0x19, 0xf9, 0x1e, 0x25, 0x81, 0x22, 0x1d, 0xe3, 0xcf, 0x34, 0x20, 0x39, 0x7e,
+	0xbf, 0x52, 0xc3, 0xf9, 0x6a, 0xce, 0x70, 0x26, 0x7d, 0xdc, 0xdb, 0x2f, 0x0a, 0x0e, 0x7f, 0xa8,
+	0xc1, 0xf2, 0xd1, 0x69, 0x14, 0xc6, 0xb1, 0x4f, 0x0f, 0xa2, 0x90, 0x6f, 0xb1, 0xf0, 0x44, 0xbe,
+	0x86, 0xe9, 0x4d, 0x94, 0x68, 0x86, 0x68, 0xf0, 0xa5, 0xd0, 0x20, 0x79, 0x43, 0xce, 0x7f, 0xf2,
+	0x7a, 0x96, 0x28, 0x9d, 0xf6, 0x69, 0x24, 0xbf, 0x43, 0x90, 0x97, 0x19, 0x2d, 0x84, 0x1f, 0xd0,
+	0x48, 0x7c, 0x87, 0xc0, 0xdf, 0x5a, 0x48, 0x56, 0xe4, 0x90, 0xc5, 0x37, 0x0b, 0x2b, 0x49, 0x57,
+	0x8a, 0x6f, 0xfc, 0xb1, 0x06, 0xad, 0x64, 0xa5, 0xbb, 0x61, 0x70, 0xe2, 0x75, 0x0b, 0x27, 0xd3,
+	0xe6, 0x99, 0xac, 0x34, 0x61, 0x32, 0xf2, 0x6d, 0xcc, 0x19, 0x92, 0xb4, 0x82, 0xe7, 0x0c, 0xc5,
+	0x09, 0xdd, 0x08, 0xeb, 0xcc, 0x74, 0x94, 0x71, 0x0b, 0xc8, 0x3d, 0x1a, 0x27, 0xfd, 0x33, 0xb9,
+	0x23, 0xe3, 0x05, 0xac, 0x3f, 0xed, 0xbb, 0x76, 0x4c, 0xe7, 0x1a, 0x46, 0xbe, 0x05, 0xb5, 0x58,
+	0x0e, 0xc0, 0x1d, 0x4d, 0x3a, 0x73, 0xe5, 0xb9, 0x67, 0xa6, 0x83, 0x78, 0x69, 0xb1, 0x99, 0x74,
+	0xe2, 0xa7, 0x4c, 0x5f, 0x83, 0xaa, 0x83, 0x48, 0x6d, 0x6d, 0x76, 0x7a, 0x72, 0x08, 0x37, 0x19,
+	0xdb, 0x89, 0x31, 0xac, 0x09, 0x66, 0x24, 0xdf, 0xde, 0x0a, 0x68, 0xa2, 0x65, 0x45, 0xc2, 0x2b,
+	0xcf, 0x23, 0xbc, 0xca, 0x24, 0x4d, 0xf9, 0x81, 0x06, 0x7a, 0xc6, 0xc1, 0x57, 0x6b, 0x8a, 0xef,
+	0xe6, 0x4c, 0xf1, 0xcd, 0xa9, 0x7c, 0xcb, 0x3e, 0x04, 0xb8, 0xf6, 0xeb, 0xd0, 0x54, 0xc9, 0x93,
+	0x06, 0x2c, 0x1e, 0x0e, 0x1c, 0x87, 0x32, 0xa6, 0x9f, 0x23, 0xcb, 0xd0, 0x78, 0x1c, 0xc6, 0xd6,
+	0xe1, 0xa0, 0xcf, 0x73, 0x1e, 0x5d, 0x23, 0x2b, 0xb0, 0xf4, 0x38, 0xb4, 0x0e, 0x68, 0xd4, 0xf3,
+	0x30, 0x23, 0xd2, 0x4b, 0xa4, 0x06, 0x95, 0xbb, 0xb6, 0xe7, 0xeb, 0x65, 0xb2, 0x06, 0xcb, 0x58,
+	0x51, 0xa7, 0x31, 0x8d, 0xac, 0xfd, 0x28, 0x0a, 0x23, 0xfd, 0x77, 0xcb, 0xe4, 0x22, 0xb4, 0xa5,
+	0x32, 0x59, 0x4f, 0x8e, 0x7f, 0x85, 0x3a, 0xb1, 0xc5, 0x49, 0xde, 0x0d, 0x07, 0x81, 0xab, 0xff,
+	0x5e, 0xf9, 0xda, 0xef, 0xa4, 0x49, 0x4c, 0xae, 0x98, 0x47, 0x08, 0xb4, 0xee, 0xec, 0xec, 0x3e,
+	0x78, 0x7a, 0x60, 0x75, 0x1e, 0x77, 0x8e, 0x3a, 0x3b, 0x0f, 0xf5, 0x73, 0x64, 0x0d, 0x74, 0x09,
+	0xdb, 0xff, 0x60, 0x7f, 0xf7, 0xe9, 0x51, 0xe7, 0xf1, 0x3d, 0x5d, 0x53, 0x30, 0x0f, 0x9f, 0xee,
+	0xee, 0xee, 0x1f, 0x1e, 0xea, 0x25, 0xbe, 0x70, 0x09, 0xbb, 0xbb, 0xd3, 0x79, 0xa8, 0x97, 0x15,
+	0xa4, 0xa3, 0xce, 0xa3, 0xfd, 0x27, 0x4f, 0x8f, 0xf4, 0x8a, 0x42, 0x6e, 0x77, 0xe7, 0xf1, 0xee,
+	0xfe, 0xc3, 0x87, 0xfb, 0x7b, 0xfa, 0xc2, 0x35, 0x9a, 0x3e, 0x64, 0xc8, 0x2f, 0xa8, 0x01, 0x8b,
+	0xd9, 0x4a, 0x96, 0xa0, 0xae, 0x2e, 0x81, 0x33, 0x2d, 0x9d, 0x9b, 0x33, 0x44, 0x4c, 0xda, 0x80,
+	0xc5, 0x6c, 0xb6, 0x25, 0xa8, 0xab, 0xd3, 0x7c, 0xc0, 0xc3, 0xe4, 0xc8, 0x7f, 0x1c, 0x00, 0x54,
+	0x0f, 0xe3, 0x28, 0x0c, 0xba, 0xfa, 0x39, 0x24, 0x29, 0xb2, 0x4e, 0x41, 0xff, 0x0e, 0x67, 0x18,
+	0x75, 0xf5, 0x12, 0x69, 0x01, 0xec, 0xbf, 0xa0, 0x01, 0x3f, 0xed, 0xf8, 0x43, 0xbd, 0xcc, 0xdb,
+	0xbb, 0x03, 0x16, 0x87, 0x3d, 0xef, 0x23, 0xea, 0xea, 0x95, 0x6b, 0x3f, 0xd1, 0xa0, 0x96, 0xdc,
+	0x2b, 0xf0, 0xc5, 0x3c, 0x0e, 0x03, 0xaa, 0x9f, 0xe3, 0xbf, 0xee, 0x84, 0xa1, 0xaf, 0x6b, 0xfc,
+	0x57, 0x27, 0x88, 0xdf, 0xd7, 0x4b, 0xa4, 0x0e, 0x0b, 0x9d, 0x20, 0xfe, 0xd2, 0x7b, 0x7a, 0x59,
+	0xfe, 0xfc, 0xf2, 0x2d, 0xbd, 0x22, 0x7f, 0xbe, 0xf7, 0x8e, 0xbe, 0xc0, 0x7f, 0xde, 0xf5, 0x43,
+	0x3b, 0xd6, 0x81, 0x2f, 0x6e, 0x0f, 0xef, 0xb2, 0xf4, 0x86, 0x5c, 0xa8, 0x17, 0x74, 0xf5, 0x35,
+	0xbe, 0xb6, 0x67, 0x76, 0xb4, 0x7b, 0x6a, 0x47, 0xfa, 0x3a, 0xc7, 0xdf, 0x89, 0x22, 0x7b, 0xa8,
+	0x6f, 0xf0, 0x59, 0xbe, 0xc3, 0xc2, 0x40, 0x3f, 0x4f, 0x74, 0x68, 0xde, 0xf1, 0x02, 0x3b, 0x1a,
+	0x3e, 0xa3, 0x4e, 0x1c, 0x46, 0xba, 0xcb, 0xc5, 0x83, 0x64, 0x25, 0x80, 0x72, 0xbd, 0x42, 0xc0,
+	0x97, 0xde, 0x93, 0xa0, 0x13, 0x94, 0x58, 0x1e, 0xd6, 0x25, 0xeb, 0xb0, 0x72, 0xd8, 0xb7, 0x23,
+	0x46, 0xd5, 0xd1, 0xa7, 0xd7, 0x9e, 0x01, 0x64, 0xd7, 0x30, 0x7c, 0x3a, 0x6c, 0x89, 0xfa, 0xa3,
+	0xab, 0x9f, 0x43, 0xea, 0x29, 0x84, 0xaf, 0x5a, 0x4b, 0x41, 0x7b, 0x51, 0xd8, 0xef, 0x73, 0x50,
+	0x29, 0x1d, 0x87, 0x20, 0xea, 0xea, 0xe5, 0x6b, 0x1e, 0x2c, 0x8f, 0x9c, 0x9b, 0xf9, 0x6e, 0xef,
+	0x76, 0x1e, 0xee, 0x5b, 0x4f, 0x1e, 0xe8, 0xe7, 0x70, 0x04, 0x6f, 0x3c, 0xea, 0x1c, 0x1e, 0x0a,
+	0x45, 0x68, 0x01, 0x20, 0x64, 0xff, 0x83, 0x23, 0x73, 0x47, 0x2f, 0xf1, 0x4d, 0x60, 0xfb, 0xc8,
+	0x7c, 0xfa, 0x78, 0x77, 0xe7, 0x68, 0x7f, 0x4f, 0x2f, 0x93, 0x55, 0x58, 0x4e, 0x46, 0x3d, 0xda,
+	0x39, 0xda, 0xbd, 0xbf, 0xbf, 0xa7, 0x57, 0x6e, 0xfd, 0xef, 0x32, 0xac, 0x3e, 0x42, 0x8b, 0x95,
+	0xf5, 0x29, 0x1a, 0xbd, 0xf0, 0x1c, 0x4a, 0x1c, 0x68, 0xaa, 0xdf, 0x66, 0x92, 0xe2, 0xdc, 0xb4,
+	0xe0, 0xf3, 0xcd, 0xcd, 0xcf, 0x9d, 0xf5, 0x21, 0x90, 0xb4, 0x7a, 0xe3, 0x1c, 0xf9, 0x65, 0xa8,
+	0xa7, 0x5f, 0x7a, 0x91, 0xe2, 0x20, 0x3e, 0xfa, 0x25, 0xd8, 0x3c, 0xe4, 0x8f, 0xa1, 0xa1, 0x7c,
+	0x1e, 0x45, 0x8a, 0x47, 0x8e, 0x7f, 0x9e, 0xb5, 0xb9, 0x7d, 0x36, 0x62, 0x3a, 0x07, 0x85, 0xa6,
+	0xfa, 0xe5, 0xd1, 0x04, 0x3e, 0x15, 0x7c, 0xf2, 0xb4, 0x79, 0x75, 0x06, 0xcc, 0x74, 0x9a, 0x53,
+	0x58, 0xca, 0xbd, 0x37, 0x20, 0x57, 0x67, 0xfe, 0x4c, 0x67, 0xf3, 0xda, 0x2c, 0xa8, 0xe9, 0x4c,
+	0x5d, 0x80, 0xec, 0x21, 0x02, 0xf9, 0xfc, 0x24, 0xa1, 0x14, 0xbc, 0x54, 0x98, 0x73, 0xa2, 0x03,
+	0x58, 0xc0, 0xeb, 0x2f, 0x52, 0x1c, 0x32, 0xd4, 0xab, 0xb2, 0x4d, 0x63, 0x1a, 0x4a, 0x4a, 0xd1,
+	0x81, 0xa6, 0xfa, 0x91, 0x2a, 0x99, 0xf8, 0x82, 0x76, 0xf4, 0x3b, 0xd6, 0x79, 0x94, 0x8a, 0x1b,
+	0x86, 0xf2, 0x85, 0xe8, 0x24, 0xc3, 0x18, 0xff, 0x88, 0x74, 0x9e, 0x49, 0x4e, 0x61, 0x29, 0xf7,
+	0x59, 0xe8, 0x04, 0x71, 0x17, 0x7d, 0x3a, 0x3a, 0xa7, 0x14, 0x28, 0xb4, 0xf2, 0xf7, 0x25, 0xe4,
+	0xda, 0x14, 0x4b, 0x1f, 0xa9, 0x52, 0x6f, 0x5e, 0x9e, 0x7e, 0x33, 0x93, 0xdb, 0x50, 0xae, 0x40,
+	0x3f, 0x61, 0x43, 0x45, 0xf7, 0x01, 0x9b, 0xd7, 0x66, 0x41, 0x55, 0x37, 0x94, 0xaf, 0xa8, 0x4f,
+	0xd8, 0x50, 0x61, 0xd9, 0x7d, 0xf6, 0x0d, 0x51, 0x68, 0xaa, 0x85, 0xe8, 0x09, 0x6a, 0x50, 0x50,
+	0x90, 0xdf, 0xbc, 0x3a, 0x03, 0xa6, 0x3a, 0x8d, 0x5a, 0xe5, 0x9a, 0x30, 0x4d, 0x41, 0x59, 0x79,
+	0xf3, 0xea, 0x0c, 0x98, 0xe9, 0x34, 0x27, 0xd0, 0x54, 0xeb, 0x65, 0x13, 0xa6, 0x29, 0x28, 0xa9,
+	0x4d, 0x10, 0x4e, 0x61, 0x51, 0x45, 0xcc, 0xd3, 0xe9, 0x9d, 0x39, 0x4f, 0xa7, 0xf7, 0x49, 0xe7,
+	0xb1, 0x00, 0xb2, 0xd3, 0x1f, 0xb9, 0x72, 0xe6, 0xf1, 0x70, 0x9a, 0x81, 0x8e, 0x1f, 0x72, 0x71,
+	0x82, 0x86, 0x72, 0xd6, 0x99, 0x10, 0x5a, 0xc6, 0x4f, 0x43, 0x13, 0xf4, 0x6b, 0x34, 0x75, 0x17,
+	0x6a, 0x9c, 0x3f, 0x18, 0x4d, 0x50, 0xe3, 0xc2, 0xd3, 0xd3, 0xcc, 0xd3, 0xdc, 0xf9, 0xea, 0x77,
+	0xbf, 0xd2, 0xf5, 0xe2, 0xd3, 0xc1, 0xf1, 0x75, 0x27, 0xec, 0xdd, 0xf8, 0xc8, 0xf3, 0x7d, 0xef,
+	0xa3, 0x98, 0x3a, 0xa7, 0x37, 0xc4, 0xf8, 0x2f, 0x8a, 0x91, 0x37, 0x9c, 0x30, 0x92, 0x7f, 0x07,
+	0x77, 0x43, 0x40, 0xfa, 0xc7, 0xc7, 0x55, 0x6c, 0x7f, 0xf9, 0xff, 0x06, 0x00, 0xf2, 0x45, 0xb8,
+	0xfc, 0x51, 0x4e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3134,73 +6413,216 @@ type MilvusBackupServiceClient interface {
 	GetRestore(ctx context.Context, in *GetRestoreStateRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
 	// Check connections
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// Resume a failed or interrupted backup from its checkpoint
+	ResumeBackup(ctx context.Context, in *ResumeBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error)
+	// Cancel a running backup
+	CancelBackup(ctx context.Context, in *CancelBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error)
+	// Cancel a running restore
+	CancelRestore(ctx context.Context, in *CancelRestoreRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	// Create a backup schedule run by the server
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	// List backup schedules with their run history
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// Delete a backup schedule created by CreateSchedule
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	// Prune the backups by the retention policies
+	PruneBackups(ctx context.Context, in *PruneBackupsRequest, opts ...grpc.CallOption) (*PruneBackupsResponse, error)
+	// Verify the files of a backup against its checksum manifest and segment meta
+	VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error)
+	// Export a backup into a single tar archive
+	ExportBackup(ctx context.Context, in *ExportBackupRequest, opts ...grpc.CallOption) (*BackupArchiveResponse, error)
+	// Import a backup from an archive created by ExportBackup
+	ImportBackup(ctx context.Context, in *ImportBackupRequest, opts ...grpc.CallOption) (*BackupArchiveResponse, error)
+	// Copy a backup into another bucket, path or storage
+	CopyBackup(ctx context.Context, in *CopyBackupRequest, opts ...grpc.CallOption) (*CopyBackupResponse, error)
+	// Get the throttle of the data copies
+	GetThrottle(ctx context.Context, in *GetThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error)
+	// Update the throttle of the data copies, the update is not persisted
+	UpdateThrottle(ctx context.Context, in *UpdateThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error)
+}
+
+type milvusBackupServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMilvusBackupServiceClient(cc *grpc.ClientConn) MilvusBackupServiceClient {
+	return &milvusBackupServiceClient{cc}
+}
+
+func (c *milvusBackupServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CreateBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
+	out := new(DeleteBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/DeleteBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/RestoreBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) GetRestore(ctx context.Context, in *GetRestoreStateRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/Check", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) ResumeBackup(ctx context.Context, in *ResumeBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ResumeBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) CancelBackup(ctx context.Context, in *CancelBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CancelBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type milvusBackupServiceClient struct {
-	cc *grpc.ClientConn
+func (c *milvusBackupServiceClient) CancelRestore(ctx context.Context, in *CancelRestoreRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CancelRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewMilvusBackupServiceClient(cc *grpc.ClientConn) MilvusBackupServiceClient {
-	return &milvusBackupServiceClient{cc}
+func (c *milvusBackupServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *milvusBackupServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
-	out := new(BackupInfoResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CreateBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListSchedules", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
-	out := new(BackupInfoResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/DeleteSchedule", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
-	out := new(ListBackupsResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListBackups", in, out, opts...)
+func (c *milvusBackupServiceClient) PruneBackups(ctx context.Context, in *PruneBackupsRequest, opts ...grpc.CallOption) (*PruneBackupsResponse, error) {
+	out := new(PruneBackupsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/PruneBackups", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
-	out := new(DeleteBackupResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/DeleteBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error) {
+	out := new(VerifyBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/VerifyBackup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
-	out := new(RestoreBackupResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/RestoreBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) ExportBackup(ctx context.Context, in *ExportBackupRequest, opts ...grpc.CallOption) (*BackupArchiveResponse, error) {
+	out := new(BackupArchiveResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ExportBackup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) GetRestore(ctx context.Context, in *GetRestoreStateRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
-	out := new(RestoreBackupResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetRestore", in, out, opts...)
+func (c *milvusBackupServiceClient) ImportBackup(ctx context.Context, in *ImportBackupRequest, opts ...grpc.CallOption) (*BackupArchiveResponse, error) {
+	out := new(BackupArchiveResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ImportBackup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
-	out := new(CheckResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/Check", in, out, opts...)
+func (c *milvusBackupServiceClient) CopyBackup(ctx context.Context, in *CopyBackupRequest, opts ...grpc.CallOption) (*CopyBackupResponse, error) {
+	out := new(CopyBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CopyBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) GetThrottle(ctx context.Context, in *GetThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error) {
+	out := new(ThrottleResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetThrottle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) UpdateThrottle(ctx context.Context, in *UpdateThrottleRequest, opts ...grpc.CallOption) (*ThrottleResponse, error) {
+	out := new(ThrottleResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/UpdateThrottle", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3223,6 +6645,32 @@ type MilvusBackupServiceServer interface {
 	GetRestore(context.Context, *GetRestoreStateRequest) (*RestoreBackupResponse, error)
 	// Check connections
 	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// Resume a failed or interrupted backup from its checkpoint
+	ResumeBackup(context.Context, *ResumeBackupRequest) (*BackupInfoResponse, error)
+	// Cancel a running backup
+	CancelBackup(context.Context, *CancelBackupRequest) (*BackupInfoResponse, error)
+	// Cancel a running restore
+	CancelRestore(context.Context, *CancelRestoreRequest) (*RestoreBackupResponse, error)
+	// Create a backup schedule run by the server
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*ScheduleResponse, error)
+	// List backup schedules with their run history
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// Delete a backup schedule created by CreateSchedule
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*ScheduleResponse, error)
+	// Prune the backups by the retention policies
+	PruneBackups(context.Context, *PruneBackupsRequest) (*PruneBackupsResponse, error)
+	// Verify the files of a backup against its checksum manifest and segment meta
+	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
+	// Export a backup into a single tar archive
+	ExportBackup(context.Context, *ExportBackupRequest) (*BackupArchiveResponse, error)
+	// Import a backup from an archive created by ExportBackup
+	ImportBackup(context.Context, *ImportBackupRequest) (*BackupArchiveResponse, error)
+	// Copy a backup into another bucket, path or storage
+	CopyBackup(context.Context, *CopyBackupRequest) (*CopyBackupResponse, error)
+	// Get the throttle of the data copies
+	GetThrottle(context.Context, *GetThrottleRequest) (*ThrottleResponse, error)
+	// Update the throttle of the data copies, the update is not persisted
+	UpdateThrottle(context.Context, *UpdateThrottleRequest) (*ThrottleResponse, error)
 }
 
 // UnimplementedMilvusBackupServiceServer can be embedded to have forward compatible implementations.
@@ -3250,6 +6698,45 @@ func (*UnimplementedMilvusBackupServiceServer) GetRestore(ctx context.Context, r
 func (*UnimplementedMilvusBackupServiceServer) Check(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (*UnimplementedMilvusBackupServiceServer) ResumeBackup(ctx context.Context, req *ResumeBackupRequest) (*BackupInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) CancelBackup(ctx context.Context, req *CancelBackupRequest) (*BackupInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) CancelRestore(ctx context.Context, req *CancelRestoreRequest) (*RestoreBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRestore not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) CreateSchedule(ctx context.Context, req *CreateScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ListSchedules(ctx context.Context, req *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) DeleteSchedule(ctx context.Context, req *DeleteScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) PruneBackups(ctx context.Context, req *PruneBackupsRequest) (*PruneBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneBackups not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) VerifyBackup(ctx context.Context, req *VerifyBackupRequest) (*VerifyBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ExportBackup(ctx context.Context, req *ExportBackupRequest) (*BackupArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ImportBackup(ctx context.Context, req *ImportBackupRequest) (*BackupArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) CopyBackup(ctx context.Context, req *CopyBackupRequest) (*CopyBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) GetThrottle(ctx context.Context, req *GetThrottleRequest) (*ThrottleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThrottle not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) UpdateThrottle(ctx context.Context, req *UpdateThrottleRequest) (*ThrottleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateThrottle not implemented")
+}
 
 func RegisterMilvusBackupServiceServer(s *grpc.Server, srv MilvusBackupServiceServer) {
 	s.RegisterService(&_MilvusBackupService_serviceDesc, srv)
@@ -3381,6 +6868,240 @@ func _MilvusBackupService_Check_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusBackupService_ResumeBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ResumeBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ResumeBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ResumeBackup(ctx, req.(*ResumeBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_CancelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CancelBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CancelBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CancelBackup(ctx, req.(*CancelBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_CancelRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CancelRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CancelRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CancelRestore(ctx, req.(*CancelRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/DeleteSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_PruneBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).PruneBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/PruneBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).PruneBackups(ctx, req.(*PruneBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_VerifyBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).VerifyBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/VerifyBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).VerifyBackup(ctx, req.(*VerifyBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_ExportBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ExportBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ExportBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ExportBackup(ctx, req.(*ExportBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_ImportBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ImportBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ImportBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ImportBackup(ctx, req.(*ImportBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_CopyBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CopyBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CopyBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CopyBackup(ctx, req.(*CopyBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_GetThrottle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThrottleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).GetThrottle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/GetThrottle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).GetThrottle(ctx, req.(*GetThrottleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_UpdateThrottle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateThrottleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).UpdateThrottle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/UpdateThrottle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).UpdateThrottle(ctx, req.(*UpdateThrottleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MilvusBackupService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.backup.MilvusBackupService",
 	HandlerType: (*MilvusBackupServiceServer)(nil),
@@ -3413,6 +7134,58 @@ var _MilvusBackupService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _MilvusBackupService_Check_Handler,
 		},
+		{
+			MethodName: "ResumeBackup",
+			Handler:    _MilvusBackupService_ResumeBackup_Handler,
+		},
+		{
+			MethodName: "CancelBackup",
+			Handler:    _MilvusBackupService_CancelBackup_Handler,
+		},
+		{
+			MethodName: "CancelRestore",
+			Handler:    _MilvusBackupService_CancelRestore_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _MilvusBackupService_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _MilvusBackupService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _MilvusBackupService_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "PruneBackups",
+			Handler:    _MilvusBackupService_PruneBackups_Handler,
+		},
+		{
+			MethodName: "VerifyBackup",
+			Handler:    _MilvusBackupService_VerifyBackup_Handler,
+		},
+		{
+			MethodName: "ExportBackup",
+			Handler:    _MilvusBackupService_ExportBackup_Handler,
+		},
+		{
+			MethodName: "ImportBackup",
+			Handler:    _MilvusBackupService_ImportBackup_Handler,
+		},
+		{
+			MethodName: "CopyBackup",
+			Handler:    _MilvusBackupService_CopyBackup_Handler,
+		},
+		{
+			MethodName: "GetThrottle",
+			Handler:    _MilvusBackupService_GetThrottle_Handler,
+		},
+		{
+			MethodName: "UpdateThrottle",
+			Handler:    _MilvusBackupService_UpdateThrottle_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "backup.proto",
@@ -132,6 +132,17 @@ func (mcm *AzureChunkManager) Write(ctx context.Context, bucketName string, file
 	return nil
 }
 
+// WriteFrom writes the data read from reader to azure storage, it is uploaded in blocks.
+func (mcm *AzureChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	err := mcm.putObject(ctx, bucketName, filePath, reader, size)
+	if err != nil {
+		log.Warn("failed to put object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Int64("size", size), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 // MultiWrite saves multiple objects, the path is the key of @kvs.
 // The object value is the value of @kvs.
 func (mcm *AzureChunkManager) MultiWrite(ctx context.Context, bucketName string, kvs map[string][]byte) error {
@@ -226,6 +237,30 @@ func (mcm *AzureChunkManager) ReadWithPrefix(ctx context.Context, bucketName str
 	return objectsKeys, objectsValues, nil
 }
 
+// ReadAt reads specific position data of azure storage if exists.
+func (mcm *AzureChunkManager) ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+	if length == 0 {
+		return []byte{}, nil
+	}
+
+	object, err := mcm.getObject(ctx, bucketName, filePath, off, length)
+	if err != nil {
+		log.Warn("failed to get object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	defer object.Close()
+
+	data, err := Read(object, length)
+	if err != nil {
+		log.Warn("failed to read object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
 // Remove deletes an object with @key.
 func (mcm *AzureChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
 	err := mcm.removeObject(ctx, bucketName, filePath)
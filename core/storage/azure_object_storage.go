@@ -147,7 +147,7 @@ func (aos *AzureObjectStorage) getClient(ctx context.Context, bucketName string)
 
 func (aos *AzureObjectStorage) GetObject(ctx context.Context, bucketName, objectName string, offset int64, size int64) (FileReader, error) {
 	opts := azblob.DownloadStreamOptions{}
-	if offset > 0 {
+	if offset > 0 || size > 0 {
 		opts.Range = azblob.HTTPRange{
 			Offset: offset,
 			Count:  size,
@@ -2,15 +2,48 @@ package storage
 
 import (
 	"context"
+	"fmt"
+
+	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/internal/log"
 )
 
+// NewChunkManager creates the chunk manager of the milvus storage, it serves the backup storage as well unless crossStorage is enabled
 func NewChunkManager(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+	return NewChunkManagerOfRole(ctx, params, MilvusStorage)
+}
+
+// NewChunkManagerOfRole creates the chunk manager of the storage of the role,
+// the backup storage only has a chunk manager of its own if crossStorage is enabled
+func NewChunkManagerOfRole(ctx context.Context, params paramtable.BackupParams, role StorageRole) (ChunkManager, error) {
+	chunkManager, err := newChunkManagerWithParams(ctx, params, role)
+	if err != nil || len(params.BackupCfg.Faults) == 0 {
+		return chunkManager, err
+	}
+	rules := make([]FaultRule, 0, len(params.BackupCfg.Faults))
+	for _, fault := range params.BackupCfg.Faults {
+		log.Warn("inject faults into storage", zap.Any("fault", fault))
+		rules = append(rules, FaultRule(fault))
+	}
+	faultChunkManager, err := NewFaultInjectingChunkManager(chunkManager, rules)
+	if err != nil {
+		return nil, err
+	}
+	return faultChunkManager, nil
+}
+
+func newChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams, role StorageRole) (ChunkManager, error) {
+	if params.MinioCfg.CrossStorage {
+		return newCrossStorageChunkManagerWithParams(ctx, params, role)
+	}
 	engine := params.MinioCfg.StorageType
 	switch engine {
 	case paramtable.Local:
 		return newLocalChunkManagerWithParams(ctx, params)
+	case paramtable.Memory:
+		return NewMemoryChunkManager(ctx, newDefaultConfig())
 	case paramtable.CloudProviderAzure:
 		return newAzureChunkManagerWithParams(ctx, params)
 	default:
@@ -65,3 +98,78 @@ func newLocalChunkManagerWithParams(ctx context.Context, params paramtable.Backu
 
 	return NewLocalChunkManager(ctx, c)
 }
+
+// NewCopyTargetChunkManager creates the chunk manager of a storage to copy backups to
+func NewCopyTargetChunkManager(ctx context.Context, target paramtable.CopyTargetConfig) (ChunkManager, error) {
+	c := newDefaultConfig()
+	c.address = target.Address + ":" + target.Port
+	c.accessKeyID = target.AccessKeyID
+	c.secretAccessKeyID = target.SecretAccessKey
+	c.useSSL = target.UseSSL
+	c.bucketName = target.BucketName
+	c.rootPath = target.RootPath
+	c.storageType = target.StorageType
+	c.useIAM = target.UseIAM
+	c.iamEndpoint = target.IAMEndpoint
+	c.createBucket = true
+	// azure chunk manager serves the backup account as well, both are the target one here
+	c.backupAccessKeyID = target.AccessKeyID
+	c.backupSecretAccessKeyID = target.SecretAccessKey
+	c.backupBucketName = target.BucketName
+	c.backupRootPath = target.RootPath
+	return newChunkManagerWithConfig(ctx, c)
+}
+
+// newChunkManagerWithConfig creates the chunk manager of a single storage
+func newChunkManagerWithConfig(ctx context.Context, c *config) (ChunkManager, error) {
+	switch c.storageType {
+	case paramtable.Local:
+		return NewLocalChunkManager(ctx, c)
+	case paramtable.Memory:
+		return NewMemoryChunkManager(ctx, c)
+	case paramtable.CloudProviderAzure:
+		return NewAzureChunkManager(ctx, c)
+	default:
+		return newMinioChunkManagerWithConfig(ctx, c)
+	}
+}
+
+// newCrossStorageChunkManagerWithParams creates the chunk manager of the storage of the role when crossStorage is enabled
+func newCrossStorageChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams, role StorageRole) (ChunkManager, error) {
+	c := newDefaultConfig()
+	if role == BackupStorage {
+		c.address = params.MinioCfg.BackupAddress + ":" + params.MinioCfg.BackupPort
+		c.accessKeyID = params.MinioCfg.BackupAccessKeyID
+		c.secretAccessKeyID = params.MinioCfg.BackupSecretAccessKey
+		c.useSSL = params.MinioCfg.BackupUseSSL
+		c.bucketName = params.MinioCfg.BackupBucketName
+		c.rootPath = params.MinioCfg.BackupRootPath
+		c.storageType = params.MinioCfg.BackupStorageType
+		c.useIAM = params.MinioCfg.BackupUseIAM
+		c.iamEndpoint = params.MinioCfg.BackupIAMEndpoint
+		c.createBucket = true
+	} else {
+		c.address = params.MinioCfg.Address + ":" + params.MinioCfg.Port
+		c.accessKeyID = params.MinioCfg.AccessKeyID
+		c.secretAccessKeyID = params.MinioCfg.SecretAccessKey
+		c.useSSL = params.MinioCfg.UseSSL
+		c.bucketName = params.MinioCfg.BucketName
+		c.rootPath = params.MinioCfg.RootPath
+		c.storageType = params.MinioCfg.StorageType
+		c.useIAM = params.MinioCfg.UseIAM
+		c.iamEndpoint = params.MinioCfg.IAMEndpoint
+		// the bucket of milvus should already exist
+		c.createBucket = false
+	}
+	// azure chunk manager serves the backup account as well, both are the account of the role here
+	c.backupAccessKeyID = c.accessKeyID
+	c.backupSecretAccessKeyID = c.secretAccessKeyID
+	c.backupBucketName = c.bucketName
+	c.backupRootPath = c.rootPath
+
+	chunkManager, err := newChunkManagerWithConfig(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create %s storage client: %w", role, err)
+	}
+	return chunkManager, nil
+}
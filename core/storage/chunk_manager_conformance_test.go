@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChunkManagerConformance runs the same cases against the chunk managers, so that they behave the same to the backup.
+// The minio case runs only if MINIO_ADDRESS is set, with MINIO_ACCESS_KEY and MINIO_SECRET_KEY defaulted to minioadmin.
+func TestChunkManagerConformance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("memory", func(t *testing.T) {
+		cm, err := NewMemoryChunkManager(ctx, newDefaultConfig())
+		require.NoError(t, err)
+		testChunkManagerConformance(t, cm, "a-bucket", "conformance")
+	})
+
+	t.Run("local", func(t *testing.T) {
+		cm, err := NewLocalChunkManager(ctx, &config{rootPath: t.TempDir()})
+		require.NoError(t, err)
+		testChunkManagerConformance(t, cm, "", t.TempDir())
+	})
+
+	t.Run("minio", func(t *testing.T) {
+		address := os.Getenv("MINIO_ADDRESS")
+		if address == "" {
+			t.Skip("MINIO_ADDRESS is not set")
+		}
+		accessKey, secretKey := os.Getenv("MINIO_ACCESS_KEY"), os.Getenv("MINIO_SECRET_KEY")
+		if accessKey == "" {
+			accessKey, secretKey = "minioadmin", "minioadmin"
+		}
+		bucketName := "conformance"
+		cm, err := NewMinioChunkManager(ctx,
+			Address(address),
+			AccessKeyID(accessKey),
+			SecretAccessKeyID(secretKey),
+			BucketName(bucketName),
+			CreateBucket(true),
+		)
+		require.NoError(t, err)
+		rootPath := fmt.Sprintf("conformance-%d", time.Now().UnixNano())
+		defer cm.RemoveWithPrefix(ctx, bucketName, rootPath+"/")
+		testChunkManagerConformance(t, cm, bucketName, rootPath)
+	})
+}
+
+func testChunkManagerConformance(t *testing.T, cm ChunkManager, bucketName string, rootPath string) {
+	ctx := context.Background()
+	files := map[string]string{
+		"a/1":     "file a/1",
+		"a/2":     "file a/2",
+		"a/b/1":   "file a/b/1",
+		"a/b/c/1": "file a/b/c/1",
+		"ab":      "file ab",
+		"empty":   "",
+	}
+	for name, content := range files {
+		require.NoError(t, cm.Write(ctx, bucketName, rootPath+"/"+name, []byte(content)))
+	}
+	keysOf := func(names ...string) []string {
+		keys := make([]string, 0, len(names))
+		for _, name := range names {
+			keys = append(keys, rootPath+"/"+name)
+		}
+		return keys
+	}
+	list := func(prefix string, recursive bool) map[string]int64 {
+		keys, sizes, err := cm.ListWithPrefix(ctx, bucketName, prefix, recursive)
+		require.NoError(t, err)
+		require.Equal(t, len(keys), len(sizes))
+		listed := make(map[string]int64, len(keys))
+		for i, key := range keys {
+			listed[key] = sizes[i]
+		}
+		return listed
+	}
+	listKeys := func(prefix string, recursive bool) []string {
+		keys := make([]string, 0)
+		for key := range list(prefix, recursive) {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	t.Run("read", func(t *testing.T) {
+		data, err := cm.Read(ctx, bucketName, rootPath+"/a/b/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "file a/b/1", string(data))
+
+		data, err = cm.Read(ctx, bucketName, rootPath+"/empty")
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+
+		_, err = cm.Read(ctx, bucketName, rootPath+"/not_exist")
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+
+		size, err := cm.Size(ctx, bucketName, rootPath+"/a/1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len("file a/1")), size)
+		_, err = cm.Size(ctx, bucketName, rootPath+"/not_exist")
+		assert.Error(t, err)
+
+		reader, err := cm.Reader(ctx, bucketName, rootPath+"/a/2")
+		assert.NoError(t, err)
+		data, err = io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "file a/2", string(data))
+		assert.NoError(t, reader.Close())
+
+		// the error of a missing file is returned by Reader or by reading it
+		reader, err = cm.Reader(ctx, bucketName, rootPath+"/not_exist")
+		if err == nil {
+			_, err = io.ReadAll(reader)
+			reader.Close()
+		}
+		assert.Error(t, err)
+
+		data, err = cm.ReadAt(ctx, bucketName, rootPath+"/a/b/c/1", 5, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "a/b", string(data))
+		_, err = cm.ReadAt(ctx, bucketName, rootPath+"/a/1", -1, 3)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("exist", func(t *testing.T) {
+		for _, name := range []string{"a/1", "empty", "a", "a/b"} {
+			exist, err := cm.Exist(ctx, bucketName, rootPath+"/"+name)
+			assert.NoError(t, err)
+			assert.True(t, exist, name)
+		}
+		exist, err := cm.Exist(ctx, bucketName, rootPath+"/not_exist")
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		p, err := cm.Path(ctx, bucketName, rootPath+"/a/1")
+		assert.NoError(t, err)
+		assert.Equal(t, rootPath+"/a/1", p)
+		_, err = cm.Path(ctx, bucketName, rootPath+"/not_exist")
+		assert.Error(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		// recursive lists the files only
+		assert.Equal(t, keysOf("a/1", "a/2", "a/b/1", "a/b/c/1", "ab", "empty"), listKeys(rootPath+"/", true))
+		assert.Equal(t, keysOf("a/1", "a/2", "a/b/1", "a/b/c/1"), listKeys(rootPath+"/a/", true))
+		// a prefix is not a directory
+		assert.Equal(t, keysOf("a/1", "a/2", "a/b/1", "a/b/c/1", "ab"), listKeys(rootPath+"/a", true))
+		assert.Equal(t, int64(len("file a/b/c/1")), list(rootPath+"/a/b/c/", true)[rootPath+"/a/b/c/1"])
+
+		// non recursive lists the directories ended by "/" with size 0
+		listed := list(rootPath+"/a/", false)
+		assert.Equal(t, map[string]int64{
+			rootPath + "/a/1":  int64(len("file a/1")),
+			rootPath + "/a/2":  int64(len("file a/2")),
+			rootPath + "/a/b/": 0,
+		}, listed)
+		assert.Equal(t, keysOf("a/", "ab", "empty"), listKeys(rootPath+"/", false))
+		assert.Equal(t, keysOf("a/", "ab"), listKeys(rootPath+"/a", false))
+
+		assert.Empty(t, listKeys(rootPath+"/not_exist/", true))
+		assert.Empty(t, listKeys(rootPath+"/not_exist/", false))
+	})
+
+	t.Run("write from", func(t *testing.T) {
+		content := []byte("0123456789")
+		filePath := rootPath + "/write_from/known"
+		assert.NoError(t, cm.WriteFrom(ctx, bucketName, filePath, bytes.NewReader(content), int64(len(content))))
+		data, err := cm.Read(ctx, bucketName, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, data)
+
+		filePath = rootPath + "/write_from/unknown"
+		assert.NoError(t, cm.WriteFrom(ctx, bucketName, filePath, bytes.NewReader(content), -1))
+		data, err = cm.Read(ctx, bucketName, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, data)
+
+		// overwrite
+		assert.NoError(t, cm.Write(ctx, bucketName, filePath, []byte("new")))
+		data, err = cm.Read(ctx, bucketName, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		assert.NoError(t, cm.Copy(ctx, bucketName, bucketName, rootPath+"/a/", rootPath+"/copy/"))
+		assert.Equal(t, keysOf("copy/1", "copy/2", "copy/b/1", "copy/b/c/1"), listKeys(rootPath+"/copy/", true))
+		data, err := cm.Read(ctx, bucketName, rootPath+"/copy/b/c/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "file a/b/c/1", string(data))
+
+		assert.NoError(t, cm.Copy(ctx, bucketName, bucketName, rootPath+"/ab", rootPath+"/copied/ab"))
+		data, err = cm.Read(ctx, bucketName, rootPath+"/copied/ab")
+		assert.NoError(t, err)
+		assert.Equal(t, "file ab", string(data))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.NoError(t, cm.Remove(ctx, bucketName, rootPath+"/copy/1"))
+		exist, err := cm.Exist(ctx, bucketName, rootPath+"/copy/1")
+		assert.NoError(t, err)
+		assert.False(t, exist)
+		// removing a missing file is not an error
+		assert.NoError(t, cm.Remove(ctx, bucketName, rootPath+"/copy/1"))
+
+		assert.NoError(t, cm.RemoveWithPrefix(ctx, bucketName, rootPath+"/copy/"))
+		assert.Empty(t, listKeys(rootPath+"/copy/", true))
+		exist, err = cm.Exist(ctx, bucketName, rootPath+"/copy/b")
+		assert.NoError(t, err)
+		assert.False(t, exist)
+		// other files are kept
+		assert.Equal(t, keysOf("a/1", "a/2", "a/b/1", "a/b/c/1"), listKeys(rootPath+"/a/", true))
+	})
+}
+
+func TestMemoryChunkManagerBuckets(t *testing.T) {
+	ctx := context.Background()
+	mem, err := NewMemoryChunkManager(ctx, newDefaultConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, mem.Write(ctx, "milvus", "files/1", []byte("1")))
+	exist, err := mem.Exist(ctx, "backup", "files/1")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	assert.NoError(t, mem.Copy(ctx, "milvus", "backup", "files/", "copied/"))
+	data, err := mem.Read(ctx, "backup", "copied/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(data))
+	assert.Error(t, mem.Copy(ctx, "milvus", "backup", "not_exist/", "copied/"))
+
+	// the written content is copied, modifying it afterwards doesn't change the object
+	content := []byte("2")
+	assert.NoError(t, mem.Write(ctx, "milvus", "files/2", content))
+	content[0] = 'x'
+	data, err = mem.Read(ctx, "milvus", "files/2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", string(data))
+
+	assert.Error(t, mem.RemoveWithPrefix(ctx, "milvus", ""))
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestNewChunkManagerOfRole(t *testing.T) {
+	ctx := context.Background()
+	var params paramtable.BackupParams
+	params.MinioCfg.StorageType = paramtable.Memory
+	params.MinioCfg.BackupStorageType = paramtable.Local
+	params.MinioCfg.BackupRootPath = t.TempDir()
+
+	// both roles are served by the milvus storage
+	milvus, err := NewChunkManagerOfRole(ctx, params, MilvusStorage)
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryChunkManager{}, milvus)
+	backup, err := NewChunkManagerOfRole(ctx, params, BackupStorage)
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryChunkManager{}, backup)
+
+	params.MinioCfg.CrossStorage = true
+	milvus, err = NewChunkManager(ctx, params)
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryChunkManager{}, milvus)
+	backup, err = NewChunkManagerOfRole(ctx, params, BackupStorage)
+	require.NoError(t, err)
+	assert.IsType(t, &LocalChunkManager{}, backup)
+}
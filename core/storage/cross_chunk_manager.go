@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// StorageRole is the role of a storage. Milvus keeps its data in the milvus storage and backups are kept in the backup storage.
+// Both roles are served by the same storage unless crossStorage is enabled, which puts the backup storage on its own provider
+// or account. Callers pick the chunk manager by the role of the data rather than by the bucket name, so the buckets of the
+// two storages are able to have the same name, and buckets given by requests are kept in the storage of their role.
+type StorageRole int
+
+const (
+	MilvusStorage StorageRole = iota
+	BackupStorage
+)
+
+func (role StorageRole) String() string {
+	switch role {
+	case MilvusStorage:
+		return "milvus"
+	case BackupStorage:
+		return "backup"
+	default:
+		return fmt.Sprintf("StorageRole(%d)", int(role))
+	}
+}
+
+// CopyBetweenStorages copies the objects under fromPath to toPath by streaming them from the source storage into the target storage,
+// it is used when the server side copy is not possible between the storages
+func CopyBetweenStorages(ctx context.Context, from ChunkManager, fromBucketName string, fromPath string, to ChunkManager, toBucketName string, toPath string) error {
+	objectKeys, sizes, err := from.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		log.Warn("listWithPrefix error", zap.String("bucket", fromBucketName), zap.String("prefix", fromPath), zap.Error(err))
+		return err
+	}
+	for i, objectKey := range objectKeys {
+		dstObjectKey := strings.Replace(objectKey, fromPath, toPath, 1)
+		err := CopyObjectBetweenStorages(ctx, from, fromBucketName, objectKey, sizes[i], to, toBucketName, dstObjectKey)
+		if err != nil {
+			log.Error("copyObject error", zap.String("srcObjectKey", objectKey), zap.String("dstObjectKey", dstObjectKey), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// CopyObjectBetweenStorages copies a single object of the size by streaming it from the source storage into the target storage
+func CopyObjectBetweenStorages(ctx context.Context, from ChunkManager, fromBucketName string, fromKey string, size int64, to ChunkManager, toBucketName string, toKey string) error {
+	reader, err := from.Reader(ctx, fromBucketName, fromKey)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return to.WriteFrom(ctx, toBucketName, toKey, reader, size)
+}
@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// ErrInjectedFault is the error returned by the faults injected by FaultInjectingChunkManager
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultRule injects faults into the calls of the operations on the paths matching the pattern.
+// Calls matching the rule pass through until Skip of them have passed, then Times of them are injected, 0 means no limit.
+// Each fault of an injected call happens at its rate, so a rate of 1 injects the fault deterministically.
+type FaultRule struct {
+	Name string
+	// names of the ChunkManager methods, such as Copy and Exist, empty means all of them
+	Operations []string
+	// regular expression matched against the path, the fromPath of Copy and the prefix of ListWithPrefix, empty matches all
+	PathPattern string
+	// delay before the call
+	Latency time.Duration
+	// rate of failing the call with ErrInjectedFault
+	ErrorRate float64
+	// rate of writing the first half of the content then failing the call, only for Write and WriteFrom
+	PartialWriteRate float64
+	// rate of treating the object as missing, only for Path, Size, Exist, Read, Reader, ReadAt, ListWithPrefix and Copy,
+	// ListWithPrefix drops the listed objects matching the pattern
+	MissingRate float64
+	Skip        int
+	Times       int
+}
+
+type faultRuleState struct {
+	rule     FaultRule
+	pattern  *regexp.Regexp
+	matched  int
+	injected int
+}
+
+// fault is the faults decided for a call
+type fault struct {
+	latency time.Duration
+	err     bool
+	partial bool
+	missing bool
+	// patterns of the rules injecting missing objects, nil matches all
+	missingPatterns []*regexp.Regexp
+}
+
+// FaultInjectingChunkManager injects latency, errors, partial writes and missing objects into a chunk manager by the rules,
+// to test how backup and restore handle the failures of storage.
+type FaultInjectingChunkManager struct {
+	ChunkManager
+	mu    sync.Mutex
+	rules []*faultRuleState
+	// fixed seed, so that a sequential test sees the same faults on every run
+	rand *rand.Rand
+}
+
+var _ ChunkManager = (*FaultInjectingChunkManager)(nil)
+
+func NewFaultInjectingChunkManager(chunkManager ChunkManager, rules []FaultRule) (*FaultInjectingChunkManager, error) {
+	states := make([]*faultRuleState, 0, len(rules))
+	for _, rule := range rules {
+		state := &faultRuleState{rule: rule}
+		if rule.PathPattern != "" {
+			pattern, err := regexp.Compile(rule.PathPattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path pattern of fault rule %s: %w", rule.Name, err)
+			}
+			state.pattern = pattern
+		}
+		states = append(states, state)
+	}
+	return &FaultInjectingChunkManager{
+		ChunkManager: chunkManager,
+		rules:        states,
+		rand:         rand.New(rand.NewSource(1)),
+	}, nil
+}
+
+func (s *faultRuleState) match(operation string, filePath string) bool {
+	if len(s.rule.Operations) > 0 {
+		found := false
+		for _, op := range s.rule.Operations {
+			if op == operation {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return s.pattern == nil || s.pattern.MatchString(filePath)
+}
+
+// decide returns the faults of a call by the rules matching it
+func (fcm *FaultInjectingChunkManager) decide(operation string, filePath string) fault {
+	fcm.mu.Lock()
+	defer fcm.mu.Unlock()
+	var f fault
+	for _, state := range fcm.rules {
+		if !state.match(operation, filePath) {
+			continue
+		}
+		state.matched++
+		if state.matched <= state.rule.Skip || (state.rule.Times > 0 && state.injected >= state.rule.Times) {
+			continue
+		}
+		state.injected++
+		f.latency += state.rule.Latency
+		f.err = f.err || fcm.rand.Float64() < state.rule.ErrorRate
+		f.partial = f.partial || fcm.rand.Float64() < state.rule.PartialWriteRate
+		if fcm.rand.Float64() < state.rule.MissingRate {
+			f.missing = true
+			f.missingPatterns = append(f.missingPatterns, state.pattern)
+		}
+	}
+	if f.err || f.partial || f.missing {
+		log.Warn("inject fault",
+			zap.String("operation", operation),
+			zap.String("path", filePath),
+			zap.Bool("error", f.err),
+			zap.Bool("partialWrite", f.partial),
+			zap.Bool("missing", f.missing))
+	}
+	return f
+}
+
+// before decides the faults of a call, waits for the latency and returns the injected error
+func (fcm *FaultInjectingChunkManager) before(ctx context.Context, operation string, filePath string) (fault, error) {
+	f := fcm.decide(operation, filePath)
+	if f.latency > 0 {
+		select {
+		case <-ctx.Done():
+			return f, ctx.Err()
+		case <-time.After(f.latency):
+		}
+	}
+	if f.err {
+		return f, fmt.Errorf("%w: %s %s", ErrInjectedFault, operation, filePath)
+	}
+	return f, nil
+}
+
+func (fcm *FaultInjectingChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	f, err := fcm.before(ctx, "Path", filePath)
+	if err != nil {
+		return "", err
+	}
+	if f.missing {
+		return "", WrapErrNoSuchKey(filePath)
+	}
+	return fcm.ChunkManager.Path(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	f, err := fcm.before(ctx, "Size", filePath)
+	if err != nil {
+		return 0, err
+	}
+	if f.missing {
+		return 0, WrapErrNoSuchKey(filePath)
+	}
+	return fcm.ChunkManager.Size(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	f, err := fcm.before(ctx, "Write", filePath)
+	if err != nil {
+		return err
+	}
+	if f.partial {
+		if err := fcm.ChunkManager.Write(ctx, bucketName, filePath, content[:len(content)/2]); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: partial write %s", ErrInjectedFault, filePath)
+	}
+	return fcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+}
+
+func (fcm *FaultInjectingChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	f, err := fcm.before(ctx, "WriteFrom", filePath)
+	if err != nil {
+		return err
+	}
+	if f.partial {
+		var buffer bytes.Buffer
+		if _, err := io.Copy(&buffer, reader); err != nil {
+			return err
+		}
+		if err := fcm.ChunkManager.Write(ctx, bucketName, filePath, buffer.Bytes()[:buffer.Len()/2]); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: partial write %s", ErrInjectedFault, filePath)
+	}
+	return fcm.ChunkManager.WriteFrom(ctx, bucketName, filePath, reader, size)
+}
+
+func (fcm *FaultInjectingChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	f, err := fcm.before(ctx, "Exist", filePath)
+	if err != nil {
+		return false, err
+	}
+	if f.missing {
+		return false, nil
+	}
+	return fcm.ChunkManager.Exist(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	f, err := fcm.before(ctx, "Read", filePath)
+	if err != nil {
+		return nil, err
+	}
+	if f.missing {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return fcm.ChunkManager.Read(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error) {
+	f, err := fcm.before(ctx, "Reader", filePath)
+	if err != nil {
+		return nil, err
+	}
+	if f.missing {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return fcm.ChunkManager.Reader(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) ([]byte, error) {
+	f, err := fcm.before(ctx, "ReadAt", filePath)
+	if err != nil {
+		return nil, err
+	}
+	if f.missing {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return fcm.ChunkManager.ReadAt(ctx, bucketName, filePath, off, length)
+}
+
+func (fcm *FaultInjectingChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	f, err := fcm.before(ctx, "ListWithPrefix", prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys, sizes, err := fcm.ChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+	if err != nil || !f.missing {
+		return keys, sizes, err
+	}
+	listedKeys := make([]string, 0, len(keys))
+	listedSizes := make([]int64, 0, len(sizes))
+	for i, key := range keys {
+		missing := false
+		for _, pattern := range f.missingPatterns {
+			if pattern == nil || pattern.MatchString(key) {
+				missing = true
+				break
+			}
+		}
+		if !missing {
+			listedKeys = append(listedKeys, key)
+			listedSizes = append(listedSizes, sizes[i])
+		}
+	}
+	return listedKeys, listedSizes, nil
+}
+
+func (fcm *FaultInjectingChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	if _, err := fcm.before(ctx, "Remove", filePath); err != nil {
+		return err
+	}
+	return fcm.ChunkManager.Remove(ctx, bucketName, filePath)
+}
+
+func (fcm *FaultInjectingChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	if _, err := fcm.before(ctx, "RemoveWithPrefix", prefix); err != nil {
+		return err
+	}
+	return fcm.ChunkManager.RemoveWithPrefix(ctx, bucketName, prefix)
+}
+
+func (fcm *FaultInjectingChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	f, err := fcm.before(ctx, "Copy", fromPath)
+	if err != nil {
+		return err
+	}
+	if f.missing {
+		return WrapErrNoSuchKey(fromPath)
+	}
+	return fcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+}
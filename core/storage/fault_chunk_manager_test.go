@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFaultTestChunkManager(t *testing.T, rules ...FaultRule) (*MemoryChunkManager, *FaultInjectingChunkManager) {
+	mem, err := NewMemoryChunkManager(context.Background(), newDefaultConfig())
+	require.NoError(t, err)
+	fcm, err := NewFaultInjectingChunkManager(mem, rules)
+	require.NoError(t, err)
+	return mem, fcm
+}
+
+func TestFaultInjectingChunkManagerError(t *testing.T) {
+	ctx := context.Background()
+	_, fcm := newFaultTestChunkManager(t, FaultRule{
+		Name:        "meta",
+		Operations:  []string{"Write"},
+		PathPattern: `meta/.*\.json$`,
+		ErrorRate:   1,
+		Skip:        1,
+		Times:       2,
+	})
+
+	// the first matching call is skipped, the next two fail, then the calls pass again
+	assert.NoError(t, fcm.Write(ctx, "bucket", "meta/backup.json", []byte("1")))
+	assert.ErrorIs(t, fcm.Write(ctx, "bucket", "meta/backup.json", []byte("2")), ErrInjectedFault)
+	assert.ErrorIs(t, fcm.Write(ctx, "bucket", "meta/backup.json", []byte("3")), ErrInjectedFault)
+	assert.NoError(t, fcm.Write(ctx, "bucket", "meta/backup.json", []byte("4")))
+	data, err := fcm.Read(ctx, "bucket", "meta/backup.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", string(data))
+
+	// other operations and paths are not matched
+	assert.NoError(t, fcm.Write(ctx, "bucket", "meta/backup.txt", []byte("1")))
+	exist, err := fcm.Exist(ctx, "bucket", "meta/backup.json")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	_, err = NewFaultInjectingChunkManager(fcm, []FaultRule{{Name: "invalid", PathPattern: "("}})
+	assert.Error(t, err)
+}
+
+func TestFaultInjectingChunkManagerPartialWrite(t *testing.T) {
+	ctx := context.Background()
+	mem, fcm := newFaultTestChunkManager(t, FaultRule{Name: "partial", PartialWriteRate: 1, Times: 2})
+
+	content := []byte("0123456789")
+	assert.ErrorIs(t, fcm.Write(ctx, "bucket", "file", content), ErrInjectedFault)
+	data, err := mem.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, "01234", string(data))
+
+	assert.ErrorIs(t, fcm.WriteFrom(ctx, "bucket", "from", bytes.NewReader(content), int64(len(content))), ErrInjectedFault)
+	data, err = mem.Read(ctx, "bucket", "from")
+	assert.NoError(t, err)
+	assert.Equal(t, "01234", string(data))
+
+	// a retry overwrites the partial content
+	assert.NoError(t, fcm.Write(ctx, "bucket", "file", content))
+	data, err = mem.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestFaultInjectingChunkManagerMissing(t *testing.T) {
+	ctx := context.Background()
+	mem, fcm := newFaultTestChunkManager(t, FaultRule{Name: "missing", PathPattern: `/2$`, MissingRate: 1})
+	for _, name := range []string{"files/1", "files/2"} {
+		require.NoError(t, mem.Write(ctx, "bucket", name, []byte(name)))
+	}
+
+	exist, err := fcm.Exist(ctx, "bucket", "files/2")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	_, err = fcm.Read(ctx, "bucket", "files/2")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	_, err = fcm.Size(ctx, "bucket", "files/2")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	assert.ErrorIs(t, fcm.Copy(ctx, "bucket", "bucket", "files/2", "copied/2"), ErrNoSuchKey)
+
+	data, err := fcm.Read(ctx, "bucket", "files/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "files/1", string(data))
+
+	// the prefix doesn't match the pattern, the listed objects matching it are dropped
+	_, fcm = newFaultTestChunkManager(t, FaultRule{Name: "missing", Operations: []string{"ListWithPrefix"}, MissingRate: 1})
+	fcm.ChunkManager = mem
+	keys, sizes, err := fcm.ListWithPrefix(ctx, "bucket", "files/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Empty(t, sizes)
+}
+
+func TestFaultInjectingChunkManagerLatency(t *testing.T) {
+	_, fcm := newFaultTestChunkManager(t, FaultRule{Name: "slow", Operations: []string{"Exist"}, Latency: 50 * time.Millisecond})
+
+	start := time.Now()
+	_, err := fcm.Exist(context.Background(), "bucket", "file")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = fcm.Exist(ctx, "bucket", "file")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
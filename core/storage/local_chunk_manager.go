@@ -86,6 +86,36 @@ func (lcm *LocalChunkManager) Write(ctx context.Context, bucketName string, file
 	return WriteFile(filePath, content, os.ModePerm)
 }
 
+// WriteFrom writes the data read from reader to local storage.
+func (lcm *LocalChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	dir := path.Dir(filePath)
+	exist, err := lcm.Exist(ctx, bucketName, dir)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		err := os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			return WrapErrFileNotFound(filePath)
+		}
+	}
+
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return WrapErrFileNotFound(filePath)
+	}
+	defer file.Close()
+	if size < 0 {
+		_, err = io.Copy(file, reader)
+	} else {
+		_, err = io.CopyN(file, reader, size)
+	}
+	if err != nil {
+		return fmt.Errorf("fail to write file %s: %w", filePath, err)
+	}
+	return file.Close()
+}
+
 // Exist checks whether chunk is saved to local storage.
 func (lcm *LocalChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
 	_, err := os.Stat(filePath)
@@ -103,6 +133,36 @@ func (lcm *LocalChunkManager) Read(ctx context.Context, bucketName string, fileP
 	return ReadFile(filePath)
 }
 
+// Reader returns a reader of the local file if exists.
+func (lcm *LocalChunkManager) Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, WrapErrFileNotFound(filePath)
+	} else if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// ReadAt reads specific position data of the local file if exists.
+func (lcm *LocalChunkManager) ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, WrapErrFileNotFound(filePath)
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(io.NewSectionReader(file, off, length), length)
+}
+
+// ListWithPrefix lists the files with the prefix. Non recursive listing returns the directories ended by "/" with size 0,
+// which is relied on by the callers listing the field dirs of a segment or the backups under the root path.
 func (lcm *LocalChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
 	var filePaths []string
 	var sizes []int64
@@ -131,13 +191,19 @@ func (lcm *LocalChunkManager) ListWithPrefix(ctx context.Context, bucketName str
 	if err != nil {
 		return nil, nil, err
 	}
-	filePaths = append(filePaths, globPaths...)
-	for _, filePath := range filePaths {
-		size, err := lcm.Size(ctx, bucketName, filePath)
+	for _, filePath := range globPaths {
+		fi, err := os.Stat(filePath)
 		if err != nil {
-			return filePaths, nil, err
+			return filePaths, nil, WrapErrFileNotFound(filePath)
+		}
+		// directories are listed as the common prefixes of minio, ended by "/" with size 0
+		if fi.IsDir() {
+			filePaths = append(filePaths, filePath+"/")
+			sizes = append(sizes, 0)
+			continue
 		}
-		sizes = append(sizes, size)
+		filePaths = append(filePaths, filePath)
+		sizes = append(sizes, fi.Size())
 	}
 
 	return filePaths, sizes, nil
@@ -161,6 +227,8 @@ func (lcm *LocalChunkManager) Remove(ctx context.Context, bucketName string, fil
 	return err
 }
 
+// RemoveWithPrefix removes the files with the prefix and the directories left empty by it,
+// so that a removed backup is not listed as an empty directory by ListWithPrefix.
 func (lcm *LocalChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
 	// If the prefix is empty string, the ListWithPrefix() will return all files under current process work folder,
 	// MultiRemove() will delete all these files. This is a danger behavior, empty prefix is not allowed.
@@ -175,7 +243,34 @@ func (lcm *LocalChunkManager) RemoveWithPrefix(ctx context.Context, bucketName s
 		return err
 	}
 
-	return lcm.MultiRemove(ctx, bucketName, filePaths)
+	if err := lcm.MultiRemove(ctx, bucketName, filePaths); err != nil {
+		return err
+	}
+	return removeEmptyDirs(prefix)
+}
+
+// removeEmptyDirs removes the directories with the prefix left empty, as directories don't exist without files in object storages
+func removeEmptyDirs(prefix string) error {
+	var dirs []string
+	err := filepath.Walk(filepath.Dir(prefix), func(filePath string, f os.FileInfo, err error) error {
+		if err == nil && f.IsDir() && strings.HasPrefix(filePath+"/", prefix) {
+			dirs = append(dirs, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// the children are walked after their parents, remove them first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (lcm *LocalChunkManager) MultiRemove(ctx context.Context, bucketName string, filePaths []string) error {
@@ -197,6 +292,9 @@ func (lcm *LocalChunkManager) Copy(ctx context.Context, fromBucketName string, t
 	if sourceFileStat.IsDir() {
 		return CopyDir(fromPath, toPath)
 	} else {
+		if err := os.MkdirAll(path.Dir(toPath), os.ModePerm); err != nil {
+			return err
+		}
 		return CopyFile(fromPath, toPath)
 	}
 }
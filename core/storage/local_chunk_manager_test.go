@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalChunkManagerStreaming(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lcm, err := NewLocalChunkManager(ctx, &config{rootPath: dir})
+	assert.NoError(t, err)
+
+	content := []byte("0123456789abcdefghij")
+	filePath := path.Join(dir, "a", "b", "file")
+	err = lcm.WriteFrom(ctx, "", filePath, bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	data, err := lcm.Read(ctx, "", filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	// unknown size
+	unknownPath := path.Join(dir, "a", "unknown")
+	err = lcm.WriteFrom(ctx, "", unknownPath, bytes.NewReader(content), -1)
+	assert.NoError(t, err)
+	size, err := lcm.Size(ctx, "", unknownPath)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+
+	// the reader is shorter than the size
+	err = lcm.WriteFrom(ctx, "", path.Join(dir, "short"), bytes.NewReader(content), int64(len(content)+1))
+	assert.Error(t, err)
+
+	reader, err := lcm.Reader(ctx, "", filePath)
+	assert.NoError(t, err)
+	data, err = io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.NoError(t, reader.Close())
+
+	_, err = lcm.Reader(ctx, "", path.Join(dir, "not_exist"))
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	data, err = lcm.ReadAt(ctx, "", filePath, 5, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, content[5:10], data)
+
+	// read to the end
+	data, err = lcm.ReadAt(ctx, "", filePath, 15, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, content[15:], data)
+
+	data, err = lcm.ReadAt(ctx, "", filePath, 30, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = lcm.ReadAt(ctx, "", filePath, -1, 10)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = lcm.ReadAt(ctx, "", path.Join(dir, "not_exist"), 0, 10)
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
+
+func TestCopyBetweenStorages(t *testing.T) {
+	ctx := context.Background()
+	fromDir := t.TempDir()
+	toDir := t.TempDir()
+	from, err := NewLocalChunkManager(ctx, &config{rootPath: fromDir})
+	assert.NoError(t, err)
+	to, err := NewLocalChunkManager(ctx, &config{rootPath: toDir})
+	assert.NoError(t, err)
+
+	files := map[string][]byte{
+		"binlog/1/1": []byte("first"),
+		"binlog/1/2": []byte("second"),
+		"binlog/2/1": bytes.Repeat([]byte("third"), 1024),
+	}
+	for key, content := range files {
+		assert.NoError(t, from.Write(ctx, "", path.Join(fromDir, key), content))
+	}
+
+	err = CopyBetweenStorages(ctx, from, "milvus", path.Join(fromDir, "binlog"), to, "backup", path.Join(toDir, "backup", "binlog"))
+	assert.NoError(t, err)
+	for key, content := range files {
+		data, err := to.Read(ctx, "backup", path.Join(toDir, "backup", key))
+		assert.NoError(t, err)
+		assert.Equal(t, content, data)
+	}
+}
+
+func TestLocalChunkManagerRemoveWithPrefix(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lcm, err := NewLocalChunkManager(ctx, &config{rootPath: dir})
+	assert.NoError(t, err)
+	for _, name := range []string{"backup/b1/meta/backup_meta.json", "backup/b1/binlogs/1/2", "backup/b2/meta/backup_meta.json"} {
+		assert.NoError(t, lcm.Write(ctx, "", path.Join(dir, name), []byte("content")))
+	}
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "backup", "empty"), os.ModePerm))
+
+	// the removed backup is not listed, while the other backup and the directories out of the prefix are kept
+	assert.NoError(t, lcm.RemoveWithPrefix(ctx, "", path.Join(dir, "backup", "b1")+"/"))
+	keys, sizes, err := lcm.ListWithPrefix(ctx, "", path.Join(dir, "backup")+"/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{path.Join(dir, "backup", "b2") + "/", path.Join(dir, "backup", "empty") + "/"}, keys)
+	assert.Equal(t, []int64{0, 0}, sizes)
+
+	// the root of the prefix is removed once it is empty
+	assert.NoError(t, lcm.RemoveWithPrefix(ctx, "", path.Join(dir, "backup")+"/"))
+	_, err = os.Stat(path.Join(dir, "backup"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(dir)
+	assert.NoError(t, err)
+}
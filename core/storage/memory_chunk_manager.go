@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// MemoryChunkManager keeps objects in memory, keyed by bucket and object key. It follows the semantics of minio:
+// buckets are created on first write, keys are flat and a directory is only a common prefix of keys ended by "/".
+// It is used by tests and dry runs, the objects are lost when the process exits.
+type MemoryChunkManager struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+var _ ChunkManager = (*MemoryChunkManager)(nil)
+
+// NewMemoryChunkManager creates an empty in-memory chunk manager
+func NewMemoryChunkManager(ctx context.Context, c *config) (*MemoryChunkManager, error) {
+	return &MemoryChunkManager{
+		buckets: make(map[string]map[string][]byte),
+	}, nil
+}
+
+// get returns the content of the object, ErrNoSuchKey if it doesn't exist. mu should be held.
+func (mem *MemoryChunkManager) get(bucketName string, filePath string) ([]byte, error) {
+	content, ok := mem.buckets[bucketName][filePath]
+	if !ok {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return content, nil
+}
+
+// put stores the object, the content should not be modified afterwards. mu should be held.
+func (mem *MemoryChunkManager) put(bucketName string, filePath string, content []byte) {
+	bucket, ok := mem.buckets[bucketName]
+	if !ok {
+		bucket = make(map[string][]byte)
+		mem.buckets[bucketName] = bucket
+	}
+	bucket[filePath] = content
+}
+
+// sortedKeys returns the keys of the bucket with the prefix in lexical order. mu should be held.
+func (mem *MemoryChunkManager) sortedKeys(bucketName string, prefix string) []string {
+	keys := make([]string, 0)
+	for key := range mem.buckets[bucketName] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (mem *MemoryChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	exist, err := mem.Exist(ctx, bucketName, filePath)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", WrapErrNoSuchKey(filePath)
+	}
+	return filePath, nil
+}
+
+func (mem *MemoryChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	content, err := mem.get(bucketName, filePath)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(content)), nil
+}
+
+func (mem *MemoryChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	data := make([]byte, len(content))
+	copy(data, content)
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	mem.put(bucketName, filePath, data)
+	return nil
+}
+
+func (mem *MemoryChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	var buffer bytes.Buffer
+	var err error
+	if size < 0 {
+		_, err = io.Copy(&buffer, reader)
+	} else {
+		_, err = io.CopyN(&buffer, reader, size)
+	}
+	if err != nil {
+		return fmt.Errorf("fail to write file %s: %w", filePath, err)
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	mem.put(bucketName, filePath, buffer.Bytes())
+	return nil
+}
+
+// Exist returns true if @filePath is an object or a directory of objects
+func (mem *MemoryChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	if _, ok := mem.buckets[bucketName][filePath]; ok {
+		return true, nil
+	}
+	dir := filePath
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	for key := range mem.buckets[bucketName] {
+		if strings.HasPrefix(key, dir) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (mem *MemoryChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	content, err := mem.get(bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, len(content))
+	copy(data, content)
+	return data, nil
+}
+
+func (mem *MemoryChunkManager) Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error) {
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	content, err := mem.get(bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	// the content is never modified in place, it is safe to read it without the lock
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (mem *MemoryChunkManager) ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	content, err := mem.get(bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if off >= int64(len(content)) {
+		return []byte{}, nil
+	}
+	end := off + length
+	if end > int64(len(content)) {
+		end = int64(len(content))
+	}
+	data := make([]byte, end-off)
+	copy(data, content[off:end])
+	return data, nil
+}
+
+// ListWithPrefix lists the objects with the prefix in lexical order. Without recursive, the keys containing "/" after
+// the prefix are folded into their directories, which are listed as the common prefixes ended by "/" with size 0.
+func (mem *MemoryChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	mem.mu.RLock()
+	defer mem.mu.RUnlock()
+	var objectKeys []string
+	var sizes []int64
+	dirs := make(map[string]bool)
+	for _, key := range mem.sortedKeys(bucketName, prefix) {
+		if !recursive {
+			if index := strings.Index(key[len(prefix):], "/"); index >= 0 {
+				dir := key[:len(prefix)+index+1]
+				if !dirs[dir] {
+					dirs[dir] = true
+					objectKeys = append(objectKeys, dir)
+					sizes = append(sizes, 0)
+				}
+				continue
+			}
+		}
+		objectKeys = append(objectKeys, key)
+		sizes = append(sizes, int64(len(mem.buckets[bucketName][key])))
+	}
+	return objectKeys, sizes, nil
+}
+
+func (mem *MemoryChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	delete(mem.buckets[bucketName], filePath)
+	return nil
+}
+
+func (mem *MemoryChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	// same as LocalChunkManager, removing all objects of a bucket by an empty prefix is not allowed
+	if len(prefix) == 0 {
+		errMsg := "empty prefix is not allowed for ChunkManager remove operation"
+		log.Warn(errMsg)
+		return errors.New(errMsg)
+	}
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	for _, key := range mem.sortedKeys(bucketName, prefix) {
+		delete(mem.buckets[bucketName], key)
+	}
+	return nil
+}
+
+// Copy copies the objects with the prefix @fromPath, the prefix is replaced by @toPath in the keys of the copies.
+// It fails if no object has the prefix as LocalChunkManager does.
+func (mem *MemoryChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	keys := mem.sortedKeys(fromBucketName, fromPath)
+	if len(keys) == 0 {
+		return WrapErrNoSuchKey(fromPath)
+	}
+	for _, key := range keys {
+		mem.put(toBucketName, strings.Replace(key, fromPath, toPath, 1), mem.buckets[fromBucketName][key])
+	}
+	return nil
+}
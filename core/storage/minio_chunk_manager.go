@@ -213,6 +213,28 @@ func (mcm *MinioChunkManager) Write(ctx context.Context, bucketName string, file
 	return nil
 }
 
+// unknownSizePartSize is the part size to upload the data of unknown size, minio client buffers a part in memory for each upload.
+// It limits the object to 10000 parts, that is 160 GiB.
+const unknownSizePartSize = 16 << 20
+
+// WriteFrom writes the data read from reader to minio storage,
+// minio client uploads it by multipart upload if the size is unknown or larger than a part.
+// The data of unknown size is uploaded in parts of unknownSizePartSize, it is put as a single object if it is less than a part.
+func (mcm *MinioChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	opts := minio.PutObjectOptions{}
+	if size < 0 {
+		// otherwise minio client buffers parts sized for the max object of 5 TiB, which is 512 MiB each
+		opts.PartSize = unknownSizePartSize
+	}
+	_, err := mcm.Client.PutObject(ctx, bucketName, filePath, reader, size, opts)
+	if err != nil {
+		log.Warn("failed to put object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Int64("size", size), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 // MultiWrite saves multiple objects, the path is the key of @kvs.
 // The object value is the value of @kvs.
 func (mcm *MinioChunkManager) MultiWrite(ctx context.Context, bucketName string, kvs map[string][]byte) error {
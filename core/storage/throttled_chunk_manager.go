@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Throttle limits the bytes and requests per second of the storage traffic, shared by the chunk managers throttled by it.
+// The limits are able to be changed at runtime.
+type Throttle struct {
+	mu                sync.Mutex
+	bytesPerSecond    int64
+	requestsPerSecond int
+	bytes             *rate.Limiter
+	requests          *rate.Limiter
+}
+
+// NewThrottle creates a throttle with the limits, 0 means no limit
+func NewThrottle(bytesPerSecond int64, requestsPerSecond int) *Throttle {
+	return &Throttle{
+		bytesPerSecond:    bytesPerSecond,
+		requestsPerSecond: requestsPerSecond,
+		bytes:             newLimiter(bytesPerSecond),
+		requests:          newLimiter(int64(requestsPerSecond)),
+	}
+}
+
+// newLimiter creates a limiter allowing a burst of one second, 0 means no limit
+func newLimiter(perSecond int64) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), int(perSecond))
+}
+
+// SetLimits changes the limits, 0 means no limit. The waits already started are not affected.
+func (t *Throttle) SetLimits(bytesPerSecond int64, requestsPerSecond int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if bytesPerSecond != t.bytesPerSecond {
+		t.bytesPerSecond = bytesPerSecond
+		t.bytes = newLimiter(bytesPerSecond)
+	}
+	if requestsPerSecond != t.requestsPerSecond {
+		t.requestsPerSecond = requestsPerSecond
+		t.requests = newLimiter(int64(requestsPerSecond))
+	}
+}
+
+// Limits returns the current limits
+func (t *Throttle) Limits() (int64, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.bytesPerSecond, t.requestsPerSecond
+}
+
+// WaitBytes blocks until n bytes are allowed to be transferred
+func (t *Throttle) WaitBytes(ctx context.Context, n int64) error {
+	t.mu.Lock()
+	limiter := t.bytes
+	t.mu.Unlock()
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+	for n > 0 {
+		// n may exceed the burst of the limiter, wait for it piece by piece
+		piece := n
+		if piece > int64(limiter.Burst()) {
+			piece = int64(limiter.Burst())
+		}
+		if err := limiter.WaitN(ctx, int(piece)); err != nil {
+			return err
+		}
+		n -= piece
+	}
+	return nil
+}
+
+// WaitRequest blocks until a request is allowed to be sent
+func (t *Throttle) WaitRequest(ctx context.Context) error {
+	t.mu.Lock()
+	limiter := t.requests
+	t.mu.Unlock()
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+// ThrottledChunkManager limits the traffic of a chunk manager by the throttle.
+// Every call is counted as a request, the bytes of Read, Write and Copy are counted as they are transferred,
+// the bytes copied by Copy are counted before the copy as they may be copied by the storage server.
+type ThrottledChunkManager struct {
+	ChunkManager
+	throttle *Throttle
+}
+
+var _ ChunkManager = (*ThrottledChunkManager)(nil)
+
+func NewThrottledChunkManager(chunkManager ChunkManager, throttle *Throttle) *ThrottledChunkManager {
+	return &ThrottledChunkManager{
+		ChunkManager: chunkManager,
+		throttle:     throttle,
+	}
+}
+
+func (tcm *ThrottledChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return "", err
+	}
+	return tcm.ChunkManager.Path(ctx, bucketName, filePath)
+}
+
+func (tcm *ThrottledChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return 0, err
+	}
+	return tcm.ChunkManager.Size(ctx, bucketName, filePath)
+}
+
+func (tcm *ThrottledChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return err
+	}
+	if err := tcm.throttle.WaitBytes(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return tcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+}
+
+func (tcm *ThrottledChunkManager) WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return err
+	}
+	return tcm.ChunkManager.WriteFrom(ctx, bucketName, filePath, &throttledReader{ctx: ctx, reader: reader, throttle: tcm.throttle}, size)
+}
+
+func (tcm *ThrottledChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return false, err
+	}
+	return tcm.ChunkManager.Exist(ctx, bucketName, filePath)
+}
+
+func (tcm *ThrottledChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return nil, err
+	}
+	data, err := tcm.ChunkManager.Read(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := tcm.throttle.WaitBytes(ctx, int64(len(data))); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (tcm *ThrottledChunkManager) Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return nil, err
+	}
+	reader, err := tcm.ChunkManager.Reader(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &throttledReader{ctx: ctx, reader: reader, closer: reader, throttle: tcm.throttle}, nil
+}
+
+func (tcm *ThrottledChunkManager) ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) ([]byte, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return nil, err
+	}
+	data, err := tcm.ChunkManager.ReadAt(ctx, bucketName, filePath, off, length)
+	if err != nil {
+		return nil, err
+	}
+	if err := tcm.throttle.WaitBytes(ctx, int64(len(data))); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (tcm *ThrottledChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return nil, nil, err
+	}
+	return tcm.ChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+}
+
+func (tcm *ThrottledChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return err
+	}
+	return tcm.ChunkManager.Remove(ctx, bucketName, filePath)
+}
+
+func (tcm *ThrottledChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	if err := tcm.throttle.WaitRequest(ctx); err != nil {
+		return err
+	}
+	return tcm.ChunkManager.RemoveWithPrefix(ctx, bucketName, prefix)
+}
+
+// Copy waits for a request of each object under fromPath and their total size before copying them
+func (tcm *ThrottledChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	objectKeys, sizes, err := tcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for i := range objectKeys {
+		if err := tcm.throttle.WaitRequest(ctx); err != nil {
+			return err
+		}
+		total += sizes[i]
+	}
+	if err := tcm.throttle.WaitBytes(ctx, total); err != nil {
+		return err
+	}
+	return tcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+}
+
+// throttledReader waits for the bytes read from the reader
+type throttledReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	closer   io.Closer
+	throttle *Throttle
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.throttle.WaitBytes(r.ctx, int64(n)); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+func (r *throttledReader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottledChunkManager(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lcm, err := NewLocalChunkManager(ctx, &config{rootPath: dir})
+	assert.NoError(t, err)
+	throttle := NewThrottle(1000, 0)
+	tcm := NewThrottledChunkManager(lcm, throttle)
+
+	// the burst of one second is allowed at once
+	content := bytes.Repeat([]byte("a"), 1000)
+	filePath := path.Join(dir, "file")
+	assert.NoError(t, tcm.Write(ctx, "", filePath, content))
+
+	// the bytes beyond the burst wait for the limit
+	start := time.Now()
+	reader, err := tcm.Reader(ctx, "", filePath)
+	assert.NoError(t, err)
+	data, err := io.ReadAll(io.LimitReader(reader, 500))
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, content[:500], data)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// requests beyond the limit are rejected by a context ending before they are allowed
+	throttle.SetLimits(1000, 2)
+	for i := 0; i < 2; i++ {
+		_, err = tcm.Exist(ctx, "", filePath)
+		assert.NoError(t, err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = tcm.Exist(timeoutCtx, "", filePath)
+	assert.Error(t, err)
+	err = tcm.Copy(timeoutCtx, "", "", filePath, path.Join(dir, "copy"))
+	assert.Error(t, err)
+
+	// no limit after the limits are removed
+	throttle.SetLimits(0, 0)
+	bytesPerSecond, requestsPerSecond := throttle.Limits()
+	assert.Equal(t, int64(0), bytesPerSecond)
+	assert.Equal(t, 0, requestsPerSecond)
+	timeoutCtx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, tcm.Copy(timeoutCtx, "", "", filePath, path.Join(dir, "copy")))
+	data, err = tcm.Read(timeoutCtx, "", path.Join(dir, "copy"))
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+}
@@ -23,6 +23,10 @@ type ChunkManager interface {
 	Size(ctx context.Context, bucketName string, filePath string) (int64, error)
 	// Write writes @content to @filePath.
 	Write(ctx context.Context, bucketName string, filePath string, content []byte) error
+	// WriteFrom writes the content read from @reader to @filePath, @size is the length of the content or -1 if unknown.
+	// The content is streamed into the storage, only the memory storage keeps it in memory as a whole. Object storages buffer
+	// a part of the content at a time, minio buffers a part of 16 MiB at most for the content of unknown size.
+	WriteFrom(ctx context.Context, bucketName string, filePath string, reader io.Reader, size int64) error
 	// MultiWrite writes multi @content to @filePath.
 	//MultiWrite(ctx context.Context, bucketName string, contents map[string][]byte) error
 	// Exist returns true if @filePath exists.
@@ -30,23 +34,25 @@ type ChunkManager interface {
 	// Read reads @filePath and returns content.
 	Read(ctx context.Context, bucketName string, filePath string) ([]byte, error)
 	// Reader return a reader for @filePath
-	//Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error)
+	Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error)
 	// MultiRead reads @filePath and returns content.
 	//MultiRead(ctx context.Context, bucketName string, filePaths []string) ([][]byte, error)
+	// ListWithPrefix returns the files with @prefix and their sizes. Recursive lists the files only,
+	// otherwise the directories under @prefix are listed as well, ended by "/" with size 0 like the common prefixes of minio.
 	ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error)
 	// ReadWithPrefix reads files with same @prefix and returns contents.
 	//ReadWithPrefix(ctx context.Context, bucketName string, prefix string) ([]string, [][]byte, error)
 	// Not use
 	//Mmap(ctx context.Context, bucketName string, filePath string) (*mmap.ReaderAt, error)
 	// ReadAt reads @filePath by offset @off, content stored in @p, return @n as the number of bytes read.
-	// if all bytes are read, @err is io.EOF.
+	// if @off or @length is negative, @err is io.EOF.
 	// return other error if read failed.
-	//ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) (p []byte, err error)
+	ReadAt(ctx context.Context, bucketName string, filePath string, off int64, length int64) (p []byte, err error)
 	// Remove delete @filePath.
 	Remove(ctx context.Context, bucketName string, filePath string) error
 	// MultiRemove delete @filePaths.
 	//MultiRemove(ctx context.Context, bucketName string, filePaths []string) error
-	// RemoveWithPrefix remove files with same @prefix.
+	// RemoveWithPrefix remove files with same @prefix, the directories left empty are removed as well.
 	RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error
 	// Move move files from fromPath into toPath recursively
 	Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error
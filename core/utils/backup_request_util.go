@@ -55,3 +55,8 @@ func WrapDBCollections(dbCollections string) *structpb.Value {
 		},
 	}
 }
+
+// GetScheduleDBCollections returns the db_collections of the schedule, parsed the same way as the backups it creates
+func GetScheduleDBCollections(schedule *backuppb.BackupSchedule) string {
+	return GetCreateDBCollections(&backuppb.CreateBackupRequest{DbCollections: schedule.GetDbCollections()})
+}
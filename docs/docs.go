@@ -23,6 +23,128 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/cancel_backup": {
+            "delete": {
+                "description": "Cancel a running backup with the given name, the partial backup is removed if cleanup is true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Cancel backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "backup_name",
+                        "name": "backup_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "cleanup",
+                        "name": "cleanup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.BackupInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cancel_restore": {
+            "delete": {
+                "description": "Cancel a running restore task with the given id, the target collections created by it are dropped if cleanup is true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restore"
+                ],
+                "summary": "Cancel restore interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "cleanup",
+                        "name": "cleanup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.RestoreBackupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/copy": {
+            "post": {
+                "description": "Copy a backup with the backups referenced by it into another bucket, path or storage, and verify the copied files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Copy backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "CopyBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.CopyBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.CopyBackupResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/create": {
             "post": {
                 "description": "Create a backup with the given name and collections",
@@ -98,6 +220,46 @@ const docTemplate = `{
                 }
             }
         },
+        "/export": {
+            "post": {
+                "description": "Export a backup with the backups referenced by it into a single tar archive on the local disk of the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Export backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ExportBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ExportBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.BackupArchiveResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/get_backup": {
             "get": {
                 "description": "Get the backup with the given name or id",
@@ -175,6 +337,46 @@ const docTemplate = `{
                 }
             }
         },
+        "/import": {
+            "post": {
+                "description": "Import a backup from an archive created by the export interface on the local disk of the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Import backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ImportBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ImportBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.BackupArchiveResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/list": {
             "get": {
                 "description": "List all backups in current storage",
@@ -210,9 +412,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/prune": {
+            "post": {
+                "description": "Delete the backups not kept by the retention policies, only evaluate the policies if dry_run is true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Prune backups interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PruneBackupsRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.PruneBackupsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.PruneBackupsResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/restore": {
             "post": {
-                "description": "Submit a request to restore the data from backup",
+                "description": "Submit a request to restore the data from backup, only return the plan of the restore if dry_run is true, verify the restored collections if verify is true, load the collections loaded at backup time if restore_load_state is true, recreate the aliases if restore_aliases is true",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,330 +491,1374 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "backuppb.BackupInfo": {
-            "type": "object",
-            "properties": {
-                "backup_timestamp": {
-                    "description": "backup timestamp",
-                    "type": "integer"
-                },
-                "collection_backups": {
-                    "description": "array of collection backup",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.CollectionBackupInfo"
-                    }
-                },
-                "end_time": {
-                    "type": "integer"
-                },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "milvus_version": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "progress": {
-                    "type": "integer"
-                },
-                "size": {
-                    "type": "integer"
-                },
-                "start_time": {
-                    "type": "integer"
-                },
-                "state_code": {
-                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
-                }
-            }
         },
-        "backuppb.BackupInfoResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "response code. 0 means success. others are fail",
-                    "allOf": [
-                        {
+        "/resume": {
+            "post": {
+                "description": "Resume a failed or interrupted backup from its checkpoint, only the segments not backuped yet will be copied",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Resume backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ResumeBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ResumeBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.BackupInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule": {
+            "post": {
+                "description": "Create a backup schedule run by the server, backups are created by the cron expression of the schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create schedule interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "CreateScheduleRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ScheduleResponse"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "List the backup schedules with their last and next run time and run history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "List schedules interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ListSchedulesResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a backup schedule created by API, backups created by it are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Delete schedule interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ScheduleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/throttle": {
+            "get": {
+                "description": "Get the throttle config of the data copies and the limits in effect",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Throttle"
+                ],
+                "summary": "Get throttle interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ThrottleResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Replace the throttle config of the data copies at runtime, it takes effect on the files copied afterwards and is not persisted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Throttle"
+                ],
+                "summary": "Update throttle interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpdateThrottleRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.UpdateThrottleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ThrottleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify": {
+            "post": {
+                "description": "Check the files of a backup against its checksum manifest and segment meta, report the missing, extra, truncated or mismatched files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Verify backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "VerifyBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.VerifyBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.VerifyBackupResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "backuppb.BackupArchiveResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
+                },
+                "data": {
+                    "$ref": "#/definitions/backuppb.BackupArchiveResult"
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.BackupArchiveResult": {
+            "type": "object",
+            "properties": {
+                "archive_path": {
+                    "type": "string"
+                },
+                "backup_name": {
+                    "description": "name of the exported or imported backup",
+                    "type": "string"
+                },
+                "backups": {
+                    "description": "backups in the archive, the backup itself and the backups referenced by its segments",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "files": {
+                    "description": "number of files in the archive, including the blobs of deduplicated backups",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "total size of the files in bytes, before the archive is compressed",
+                    "type": "integer"
+                },
+                "skipped_backups": {
+                    "description": "referenced backups already existing, not imported again",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                }
+            }
+        },
+        "backuppb.BackupInfo": {
+            "type": "object",
+            "properties": {
+                "backup_timestamp": {
+                    "description": "backup timestamp",
+                    "type": "integer"
+                },
+                "binlog_compression": {
+                    "description": "compression codec of the binlogs copied into the backup, empty if they are not compressed",
+                    "type": "string"
+                },
+                "collection_backups": {
+                    "description": "array of collection backup",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CollectionBackupInfo"
+                    }
+                },
+                "encryption": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.EncryptionInfo"
+                        }
+                    ],
+                    "description": "encryption of the backup data and meta, empty if the backup is not encrypted"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meta_compression": {
+                    "description": "compression codec of the meta files, empty if they are not compressed",
+                    "type": "string"
+                },
+                "milvus_version": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_backup": {
+                    "description": "name of the parent backup if this is an incremental backup",
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
+                }
+            }
+        },
+        "backuppb.BackupInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "data": {
+                    "description": "backup info entity",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.BackupInfo"
+                        }
+                    ]
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.BackupSchedule": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "description": "cron expression: minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly, @yearly",
+                    "type": "string"
+                },
+                "db_collections": {
+                    "description": "database and collections to backup, same as db_collections of CreateBackupRequest, empty to backup all",
+                    "type": "string"
+                },
+                "force": {
+                    "description": "force backup skip flush",
+                    "type": "boolean"
+                },
+                "history": {
+                    "description": "latest runs of the schedule, the latest first",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.ScheduleRun"
+                    },
+                    "type": "array"
+                },
+                "last_run_time": {
+                    "description": "unix time in seconds of the last run, 0 if it never runs",
+                    "type": "integer"
+                },
+                "meta_only": {
+                    "description": "only backup meta, including collection schema and index info",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "unique name of the schedule",
+                    "type": "string"
+                },
+                "name_template": {
+                    "description": "template of the backup name, supports {schedule}, {time} and {timestamp}",
+                    "type": "string"
+                },
+                "next_run_time": {
+                    "description": "unix time in seconds of the next run, 0 if it never runs again",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "where the schedule is defined: config or api",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.BackupTaskStateCode": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5
+            ],
+            "x-enum-varnames": [
+                "BackupTaskStateCode_BACKUP_INITIAL",
+                "BackupTaskStateCode_BACKUP_EXECUTING",
+                "BackupTaskStateCode_BACKUP_SUCCESS",
+                "BackupTaskStateCode_BACKUP_FAIL",
+                "BackupTaskStateCode_BACKUP_TIMEOUT",
+                "BackupTaskStateCode_BACKUP_CANCELLED"
+            ]
+        },
+        "backuppb.Binlog": {
+            "type": "object",
+            "properties": {
+                "compression": {
+                    "description": "compression codec of the binlog copied into backup, empty if it is not compressed",
+                    "type": "string"
+                },
+                "entries_num": {
+                    "type": "integer"
+                },
+                "log_path": {
+                    "type": "string"
+                },
+                "log_size": {
+                    "type": "integer"
+                },
+                "timestamp_from": {
+                    "type": "integer"
+                },
+                "timestamp_to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.CollectionBackupInfo": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "aliases of the collection at backup time",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "backup_physical_timestamp": {
+                    "description": "physical unix time of backup",
+                    "type": "integer"
+                },
+                "backup_timestamp": {
+                    "description": "logical time of backup, used for restore",
+                    "type": "integer"
+                },
+                "channel_checkpoints": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "collection_id": {
+                    "type": "integer"
+                },
+                "collection_name": {
+                    "type": "string"
+                },
+                "consistency_level": {
+                    "$ref": "#/definitions/backuppb.ConsistencyLevel"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "has_index": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index_infos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.IndexInfo"
+                    }
+                },
+                "l0_segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
+                    }
+                },
+                "load_state": {
+                    "type": "string"
+                },
+                "partition_backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.PartitionBackupInfo"
+                    }
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "schema": {
+                    "$ref": "#/definitions/backuppb.CollectionSchema"
+                },
+                "shards_num": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
+                }
+            }
+        },
+        "backuppb.CollectionSchema": {
+            "type": "object",
+            "properties": {
+                "autoID": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enable_dynamic_field": {
+                    "type": "boolean"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FieldSchema"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.ConsistencyLevel": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "ConsistencyLevel_Strong",
+                "ConsistencyLevel_Session",
+                "ConsistencyLevel_Bounded",
+                "ConsistencyLevel_Eventually",
+                "ConsistencyLevel_Customized"
+            ]
+        },
+        "backuppb.CopyBackupRequest": {
+            "type": "object",
+            "properties": {
+                "backup_name": {
+                    "description": "name of the backup to copy",
+                    "type": "string"
+                },
+                "bucket_name": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
+                "checksum": {
+                    "description": "read the copied files to compare their checksums in the verification, otherwise only the existence and sizes are compared",
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "name of the copy target in config, copy within the backup storage if not set",
+                    "type": "string"
+                },
+                "target_bucket_name": {
+                    "description": "if target_bucket_name and target_path is set. will override the bucket/path of the target.",
+                    "type": "string"
+                },
+                "target_path": {
+                    "description": "if target_bucket_name and target_path is set. will override the bucket/path of the target.",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.CopyBackupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
+                },
+                "data": {
+                    "$ref": "#/definitions/backuppb.CopyBackupResult"
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.CopyBackupResult": {
+            "type": "object",
+            "properties": {
+                "backup_name": {
+                    "type": "string"
+                },
+                "backups": {
+                    "description": "backups copied, the backups referenced by the segments are copied before the backup",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "copied_files": {
+                    "description": "number of files copied",
+                    "type": "integer"
+                },
+                "copied_size": {
+                    "description": "total size of the copied files in bytes",
+                    "type": "integer"
+                },
+                "skipped_backups": {
+                    "description": "referenced backups already existing in the target, not copied again",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "skipped_files": {
+                    "description": "number of files already existing in the target with the same size, not copied again",
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "target_bucket_name": {
+                    "type": "string"
+                },
+                "target_path": {
+                    "type": "string"
+                },
+                "verified_files": {
+                    "description": "number of files checked by the verification",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.CreateBackupRequest": {
+            "type": "object",
+            "properties": {
+                "async": {
+                    "description": "async or not",
+                    "type": "boolean"
+                },
+                "backup_name": {
+                    "description": "backup name, will generate one if not set",
+                    "type": "string"
+                },
+                "collection_names": {
+                    "description": "collection names to backup, empty to backup all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "db_collections": {
+                    "description": "database and collections to backup. A json string. To support database. 2023.7.7\npartitions are selected by an object of collections to partitions, for example: {\"db1\":{\"collection1\":[\"p1\",\"p2\"]}}",
+                    "type": "string"
+                },
+                "force": {
+                    "description": "force backup skip flush, Should make sure data has been stored into disk when using it",
+                    "type": "boolean"
+                },
+                "gc_pause_address": {
+                    "description": "gc pause API address",
+                    "type": "string"
+                },
+                "gc_pause_enable": {
+                    "description": "if true, stop GC to avoid the data compacted and GCed during backup, use it when the data to backup is very large.",
+                    "type": "boolean"
+                },
+                "gc_pause_seconds": {
+                    "description": "gc pause seconds, set it larger than the time cost of backup",
+                    "type": "integer"
+                },
+                "meta_only": {
+                    "description": "only backup meta, including collection schema and index info",
+                    "type": "boolean"
+                },
+                "parent_backup": {
+                    "description": "if set, create an incremental backup based on the given backup, only new or changed segments will be copied",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.CreateScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "description": "cron expression: minute hour day-of-month month day-of-week, or @hourly, @daily, @weekly, @monthly, @yearly",
+                    "type": "string"
+                },
+                "db_collections": {
+                    "description": "database and collections to backup, same as db_collections of CreateBackupRequest, empty to backup all",
+                    "type": "string"
+                },
+                "force": {
+                    "description": "force backup skip flush",
+                    "type": "boolean"
+                },
+                "meta_only": {
+                    "description": "only backup meta, including collection schema and index info",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "unique name of the schedule",
+                    "type": "string"
+                },
+                "name_template": {
+                    "description": "template of the backup name, supports {schedule}, {time} and {timestamp}, default is {schedule}_{time}",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.DataType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5,
+                10,
+                11,
+                20,
+                21,
+                22,
+                23,
+                100,
+                101,
+                102,
+                103,
+                104
+            ],
+            "x-enum-varnames": [
+                "DataType_None",
+                "DataType_Bool",
+                "DataType_Int8",
+                "DataType_Int16",
+                "DataType_Int32",
+                "DataType_Int64",
+                "DataType_Float",
+                "DataType_Double",
+                "DataType_String",
+                "DataType_VarChar",
+                "DataType_Array",
+                "DataType_Json",
+                "DataType_BinaryVector",
+                "DataType_FloatVector",
+                "DataType_Float16Vector",
+                "DataType_BFloat16Vector",
+                "DataType_SparseFloatVector"
+            ]
+        },
+        "backuppb.DeleteBackupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
                             "$ref": "#/definitions/backuppb.ResponseCode"
                         }
                     ]
                 },
-                "data": {
-                    "description": "backup info entity",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backuppb.BackupInfo"
-                        }
-                    ]
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.EncryptionInfo": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "master_key_id": {
+                    "description": "identifier of the master key which wraps the data key",
+                    "type": "string"
+                },
+                "wrapped_data_key": {
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
+                }
+            }
+        },
+        "backuppb.ExportBackupRequest": {
+            "type": "object",
+            "properties": {
+                "archive_path": {
+                    "description": "path of the archive file to write, relative to the archive dir of the server",
+                    "type": "string"
+                },
+                "backup_name": {
+                    "description": "name of the backup to export",
+                    "type": "string"
+                },
+                "bucket_name": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
+                "compression": {
+                    "description": "compression codec of the archive, support none, gzip, zstd. default none",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.FieldBinlog": {
+            "type": "object",
+            "properties": {
+                "binlogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.Binlog"
+                    }
+                },
+                "fieldID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.FieldSchema": {
+            "type": "object",
+            "properties": {
+                "autoID": {
+                    "type": "boolean"
+                },
+                "data_type": {
+                    "$ref": "#/definitions/backuppb.DataType"
+                },
+                "default_value": {
+                    "$ref": "#/definitions/backuppb.ValueField"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "element_type": {
+                    "$ref": "#/definitions/backuppb.DataType"
+                },
+                "fieldID": {
+                    "type": "integer"
+                },
+                "index_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
+                },
+                "is_dynamic": {
+                    "type": "boolean"
+                },
+                "is_partition_key": {
+                    "type": "boolean"
+                },
+                "is_primary_key": {
+                    "type": "boolean"
                 },
-                "msg": {
-                    "description": "error msg if fail",
+                "name": {
                     "type": "string"
                 },
-                "requestId": {
-                    "description": "uuid of the request to response",
-                    "type": "string"
+                "state": {
+                    "$ref": "#/definitions/backuppb.FieldState"
+                },
+                "type_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
                 }
             }
         },
-        "backuppb.BackupTaskStateCode": {
+        "backuppb.FieldState": {
             "type": "integer",
             "enum": [
                 0,
                 1,
                 2,
-                3,
-                4
+                3
             ],
             "x-enum-varnames": [
-                "BackupTaskStateCode_BACKUP_INITIAL",
-                "BackupTaskStateCode_BACKUP_EXECUTING",
-                "BackupTaskStateCode_BACKUP_SUCCESS",
-                "BackupTaskStateCode_BACKUP_FAIL",
-                "BackupTaskStateCode_BACKUP_TIMEOUT"
+                "FieldState_FieldCreated",
+                "FieldState_FieldCreating",
+                "FieldState_FieldDropping",
+                "FieldState_FieldDropped"
             ]
         },
-        "backuppb.Binlog": {
+        "backuppb.FileVerifyResult": {
             "type": "object",
             "properties": {
-                "entries_num": {
+                "actual_size": {
+                    "description": "size found in backup, -1 if the file is missing",
                     "type": "integer"
                 },
-                "log_path": {
+                "collection_id": {
+                    "type": "integer"
+                },
+                "collection_name": {
                     "type": "string"
                 },
-                "log_size": {
+                "expected_size": {
+                    "description": "recorded size, -1 if it is unknown",
                     "type": "integer"
                 },
-                "timestamp_from": {
+                "partition_id": {
                     "type": "integer"
                 },
-                "timestamp_to": {
+                "path": {
+                    "description": "path of the file in backup bucket",
+                    "type": "string"
+                },
+                "segment_id": {
                     "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/backuppb.FileVerifyState"
                 }
             }
         },
-        "backuppb.CollectionBackupInfo": {
+        "backuppb.FileVerifyState": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "FileVerifyState_FILE_OK",
+                "FileVerifyState_FILE_MISSING",
+                "FileVerifyState_FILE_EXTRA",
+                "FileVerifyState_FILE_TRUNCATED",
+                "FileVerifyState_FILE_MISMATCHED"
+            ]
+        },
+        "backuppb.ImportBackupRequest": {
             "type": "object",
             "properties": {
-                "backup_physical_timestamp": {
-                    "description": "physical unix time of backup",
-                    "type": "integer"
-                },
-                "backup_timestamp": {
-                    "description": "logical time of backup, used for restore",
-                    "type": "integer"
-                },
-                "channel_checkpoints": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "collection_id": {
-                    "type": "integer"
+                "archive_path": {
+                    "description": "path of the archive file to read, relative to the archive dir of the server",
+                    "type": "string"
                 },
-                "collection_name": {
+                "bucket_name": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
                     "type": "string"
                 },
-                "consistency_level": {
-                    "$ref": "#/definitions/backuppb.ConsistencyLevel"
+                "path": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
                 },
-                "db_name": {
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.IndexInfo": {
+            "type": "object",
+            "properties": {
+                "field_name": {
                     "type": "string"
                 },
-                "end_time": {
-                    "type": "integer"
+                "index_name": {
+                    "type": "string"
                 },
-                "errorMessage": {
+                "index_type": {
                     "type": "string"
                 },
-                "has_index": {
-                    "type": "boolean"
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "backuppb.KeyValuePair": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
                 },
-                "id": {
+                "value": {
                     "type": "string"
+                }
+            }
+        },
+        "backuppb.ListBackupsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
                 },
-                "index_infos": {
+                "data": {
+                    "description": "backup info entities",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.IndexInfo"
+                        "$ref": "#/definitions/backuppb.BackupInfo"
                     }
                 },
-                "l0_segments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
-                    }
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
                 },
-                "load_state": {
+                "requestId": {
+                    "description": "uuid of the request to response",
                     "type": "string"
+                }
+            }
+        },
+        "backuppb.ListSchedulesResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
                 },
-                "partition_backups": {
-                    "type": "array",
+                "data": {
+                    "description": "schedule entities",
                     "items": {
-                        "$ref": "#/definitions/backuppb.PartitionBackupInfo"
-                    }
+                        "$ref": "#/definitions/backuppb.BackupSchedule"
+                    },
+                    "type": "array"
                 },
-                "progress": {
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.PartitionBackupInfo": {
+            "type": "object",
+            "properties": {
+                "collection_id": {
                     "type": "integer"
                 },
-                "schema": {
-                    "$ref": "#/definitions/backuppb.CollectionSchema"
+                "load_state": {
+                    "type": "string"
                 },
-                "shards_num": {
+                "partition_id": {
                     "type": "integer"
                 },
-                "size": {
-                    "type": "integer"
+                "partition_name": {
+                    "type": "string"
                 },
-                "start_time": {
-                    "type": "integer"
+                "segment_backups": {
+                    "description": "array of segment backup",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
+                    }
                 },
-                "state_code": {
-                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "backuppb.CollectionSchema": {
+        "backuppb.PruneBackupsRequest": {
             "type": "object",
             "properties": {
-                "autoID": {
+                "dry_run": {
+                    "description": "only evaluate the retention policies without deleting any backup",
                     "type": "boolean"
                 },
-                "description": {
+                "policy": {
+                    "description": "only apply the retention policy with the name if set",
                     "type": "string"
                 },
-                "enable_dynamic_field": {
-                    "type": "boolean"
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.PruneBackupsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
                 },
-                "fields": {
-                    "type": "array",
+                "data": {
+                    "description": "decisions of the backups selected by the retention policies",
                     "items": {
-                        "$ref": "#/definitions/backuppb.FieldSchema"
-                    }
+                        "$ref": "#/definitions/backuppb.RetentionDecision"
+                    },
+                    "type": "array"
                 },
-                "name": {
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
                     "type": "string"
                 }
             }
         },
-        "backuppb.ConsistencyLevel": {
+        "backuppb.ResponseCode": {
             "type": "integer",
             "enum": [
                 0,
                 1,
                 2,
                 3,
-                4
+                400,
+                404
             ],
             "x-enum-varnames": [
-                "ConsistencyLevel_Strong",
-                "ConsistencyLevel_Session",
-                "ConsistencyLevel_Bounded",
-                "ConsistencyLevel_Eventually",
-                "ConsistencyLevel_Customized"
+                "ResponseCode_Success",
+                "ResponseCode_Not_Support",
+                "ResponseCode_No_Permission",
+                "ResponseCode_Fail",
+                "ResponseCode_Parameter_Error",
+                "ResponseCode_Request_Object_Not_Found"
             ]
         },
-        "backuppb.CreateBackupRequest": {
+        "backuppb.RestoreBackupRequest": {
             "type": "object",
             "properties": {
                 "async": {
-                    "description": "async or not",
+                    "description": "execute asynchronously or not",
                     "type": "boolean"
                 },
                 "backup_name": {
-                    "description": "backup name, will generate one if not set",
+                    "description": "backup name to restore",
+                    "type": "string"
+                },
+                "bucket_name": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
                     "type": "string"
                 },
                 "collection_names": {
-                    "description": "collection names to backup, empty to backup all",
+                    "description": "collections to restore",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "collection_renames": {
+                    "description": "2, give a map to rename the collections, if not given, use the original name.\ncollection_renames has higher priority than collection_suffix",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "collection_suffix": {
+                    "description": "Support two ways to rename the collections while recover\n1, set a suffix",
+                    "type": "string"
+                },
                 "db_collections": {
-                    "description": "database and collections to backup. A json string. To support database. 2023.7.7",
+                    "description": "database and collections to restore. A json string. for example: {\"db1\":[\"collection1\"],\"db2\":[\"collection2\",\"collection3\"]}\npartitions are selected by an object of collections to partitions, for example: {\"db1\":{\"collection1\":[\"p1\",\"p2\"]}}",
                     "type": "string"
                 },
-                "force": {
-                    "description": "force backup skip flush, Should make sure data has been stored into disk when using it",
+                "dropExistCollection": {
+                    "description": "if true, drop existing target collection before create",
                     "type": "boolean"
                 },
-                "gc_pause_address": {
-                    "description": "gc pause API address",
-                    "type": "string"
+                "dropExistIndex": {
+                    "description": "if true, drop existing index of target collection before create",
+                    "type": "boolean"
                 },
-                "gc_pause_enable": {
-                    "description": "if true, stop GC to avoid the data compacted and GCed during backup, use it when the data to backup is very large.",
+                "dry_run": {
+                    "description": "only validate the request and return the plan of the restore, nothing is created, dropped or copied",
                     "type": "boolean"
                 },
-                "gc_pause_seconds": {
-                    "description": "gc pause seconds, set it larger than the time cost of backup",
+                "id": {
+                    "type": "string"
+                },
+                "load_replica_number": {
+                    "description": "replica number to load the collections, 0 means the default of milvus",
                     "type": "integer"
                 },
-                "meta_only": {
-                    "description": "only backup meta, including collection schema and index info",
+                "metaOnly": {
+                    "description": "if true only restore meta, not restore data",
                     "type": "boolean"
                 },
+                "path": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
                 "requestId": {
                     "description": "uuid of request, will generate one if not set",
                     "type": "string"
+                },
+                "restoreIndex": {
+                    "description": "if true restore index info",
+                    "type": "boolean"
+                },
+                "restore_aliases": {
+                    "description": "recreate the aliases of the collections, the aliases are renamed by collection_renames and collection_suffix as collections",
+                    "type": "boolean"
+                },
+                "restore_load_state": {
+                    "description": "load the collections and partitions which were loaded at backup time, after the data is restored and the indexes are built",
+                    "type": "boolean"
+                },
+                "restore_to_time": {
+                    "description": "restore the data to the time in RFC3339 format, used if restore_to_timestamp is not set",
+                    "type": "string"
+                },
+                "restore_to_timestamp": {
+                    "description": "restore the data to the timestamp, should not be later than the backup timestamp",
+                    "type": "integer"
+                },
+                "resume_restore_id": {
+                    "description": "id of a failed or interrupted restore task to resume, only the groups and l0 segments not finished will be restored",
+                    "type": "string"
+                },
+                "skipCreateCollection": {
+                    "description": "if true, will skip collection, use when collection exist, restore index or data",
+                    "type": "boolean"
+                },
+                "strict_verify": {
+                    "description": "if true a mismatch found by the verification fails the restore of the collection",
+                    "type": "boolean"
+                },
+                "swap_aliases": {
+                    "description": "move the aliases which exist on other collections onto the restored collections, implies restore_aliases.\ncollection_suffix is not applied to the aliases, so that the original aliases are moved",
+                    "type": "boolean"
+                },
+                "useAutoIndex": {
+                    "description": "if true use autoindex when restore vector index",
+                    "type": "boolean"
+                },
+                "verify": {
+                    "description": "verify the row counts and sample the primary keys of the restored collections after bulk insert",
+                    "type": "boolean"
                 }
             }
         },
-        "backuppb.DataType": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4,
-                5,
-                10,
-                11,
-                20,
-                21,
-                22,
-                23,
-                100,
-                101,
-                102,
-                103,
-                104
-            ],
-            "x-enum-varnames": [
-                "DataType_None",
-                "DataType_Bool",
-                "DataType_Int8",
-                "DataType_Int16",
-                "DataType_Int32",
-                "DataType_Int64",
-                "DataType_Float",
-                "DataType_Double",
-                "DataType_String",
-                "DataType_VarChar",
-                "DataType_Array",
-                "DataType_Json",
-                "DataType_BinaryVector",
-                "DataType_FloatVector",
-                "DataType_Float16Vector",
-                "DataType_BFloat16Vector",
-                "DataType_SparseFloatVector"
-            ]
-        },
-        "backuppb.DeleteBackupResponse": {
+        "backuppb.RestoreBackupResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -583,292 +1869,533 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "data": {
+                    "description": "restore task info entity",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.RestoreBackupTask"
+                        }
+                    ]
+                },
                 "msg": {
                     "description": "error msg if fail",
                     "type": "string"
                 },
+                "plan": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.RestorePlan"
+                        }
+                    ],
+                    "description": "plan of the restore if dry_run"
+                },
                 "requestId": {
                     "description": "uuid of the request to response",
                     "type": "string"
                 }
             }
         },
-        "backuppb.FieldBinlog": {
+        "backuppb.RestoreBackupTask": {
             "type": "object",
             "properties": {
-                "binlogs": {
+                "collection_restore_tasks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.Binlog"
+                        "$ref": "#/definitions/backuppb.RestoreCollectionTask"
                     }
                 },
-                "fieldID": {
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "restored_size": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
+                },
+                "to_restore_size": {
                     "type": "integer"
                 }
             }
         },
-        "backuppb.FieldSchema": {
+        "backuppb.RestoreCollectionPlan": {
             "type": "object",
             "properties": {
-                "autoID": {
+                "aliases": {
+                    "description": "aliases to create for the target collection",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "create": {
+                    "description": "whether the target collection would be created",
                     "type": "boolean"
                 },
-                "data_type": {
-                    "$ref": "#/definitions/backuppb.DataType"
+                "drop": {
+                    "description": "whether the existing target collection would be dropped by dropExistCollection",
+                    "type": "boolean"
                 },
-                "default_value": {
-                    "$ref": "#/definitions/backuppb.ValueField"
+                "drop_index": {
+                    "description": "whether the indexes of the existing target collection would be dropped by dropExistIndex",
+                    "type": "boolean"
+                },
+                "exist": {
+                    "description": "whether the target collection exists before the restore",
+                    "type": "boolean"
+                },
+                "indexes": {
+                    "description": "indexes to create if restoreIndex, vector indexes are replaced by autoindex if useAutoIndex",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.IndexInfo"
+                    },
+                    "type": "array"
+                },
+                "l0_segment_ids": {
+                    "description": "l0 segments of the collection to bulk insert",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
+                },
+                "load": {
+                    "description": "whether the collection would be loaded by restore_load_state",
+                    "type": "boolean"
+                },
+                "load_partitions": {
+                    "description": "partitions to load, empty means the whole collection",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "partitions": {
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RestorePartitionPlan"
+                    },
+                    "type": "array"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "source_collection_name": {
+                    "type": "string"
+                },
+                "source_db_name": {
+                    "type": "string"
+                },
+                "target_collection_name": {
+                    "type": "string"
+                },
+                "target_db_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.RestoreCollectionTask": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "aliases to create for the restored collection, after the data is restored, loaded and verified",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "coll_backup": {
+                    "$ref": "#/definitions/backuppb.CollectionBackupInfo"
+                },
+                "dropExistCollection": {
+                    "description": "if true drop the collections",
+                    "type": "boolean"
+                },
+                "dropExistIndex": {
+                    "description": "if true drop index info",
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "finished_l0_segment_ids": {
+                    "description": "collection level l0 segments which have been bulk inserted",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "load_partitions": {
+                    "description": "loaded partitions, empty means the whole collection is loaded",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "load_progress": {
+                    "description": "progress of loading the restored collection, from 0 to 100",
+                    "type": "integer"
+                },
+                "load_replica_number": {
+                    "type": "integer"
+                },
+                "metaOnly": {
+                    "description": "if true only restore meta",
+                    "type": "boolean"
+                },
+                "partition_restore_tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RestorePartitionTask"
+                    }
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "restoreIndex": {
+                    "description": "if true restore index info",
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
+                "restore_load_state": {
+                    "description": "load the collection or partitions as the load state of the backup",
+                    "type": "boolean"
                 },
-                "element_type": {
-                    "$ref": "#/definitions/backuppb.DataType"
+                "restore_to_timestamp": {
+                    "description": "restore the data to the timestamp, 0 means the backup timestamp",
+                    "type": "integer"
                 },
-                "fieldID": {
+                "restored_size": {
                     "type": "integer"
                 },
-                "index_params": {
+                "selected_partitions": {
+                    "description": "partitions selected by db_collections, empty means all of them.\nl0 segments of the collection are bulk inserted into each selected partition rather than into the whole collection",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                        "type": "string"
                     }
                 },
-                "is_dynamic": {
+                "skipCreateCollection": {
+                    "description": "if true will skip create collections",
                     "type": "boolean"
                 },
-                "is_partition_key": {
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
+                },
+                "strict_verify": {
+                    "description": "if true a verification mismatch fails the task",
                     "type": "boolean"
                 },
-                "is_primary_key": {
+                "swap_aliases": {
+                    "description": "move the aliases which exist on other collections onto the restored collection",
                     "type": "boolean"
                 },
-                "name": {
+                "target_collection_name": {
                     "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/backuppb.FieldState"
+                "target_db_name": {
+                    "type": "string"
                 },
-                "type_params": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.KeyValuePair"
-                    }
+                "to_restore_size": {
+                    "type": "integer"
+                },
+                "useAutoIndex": {
+                    "description": "if true use autoindex when restore vector index",
+                    "type": "boolean"
+                },
+                "verify": {
+                    "description": "verify the restored collection after bulk insert",
+                    "type": "boolean"
+                },
+                "verify_result": {
+                    "$ref": "#/definitions/backuppb.RestoreVerifyResult"
                 }
             }
         },
-        "backuppb.FieldState": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3
-            ],
-            "x-enum-varnames": [
-                "FieldState_FieldCreated",
-                "FieldState_FieldCreating",
-                "FieldState_FieldDropping",
-                "FieldState_FieldDropped"
-            ]
-        },
-        "backuppb.IndexInfo": {
+        "backuppb.RestoreGroupPlan": {
             "type": "object",
             "properties": {
-                "field_name": {
-                    "type": "string"
-                },
-                "index_name": {
-                    "type": "string"
+                "group_id": {
+                    "description": "0 for the backups without segment groups",
+                    "type": "integer"
                 },
-                "index_type": {
-                    "type": "string"
+                "segment_ids": {
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
                 },
-                "params": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "backuppb.KeyValuePair": {
+        "backuppb.RestorePartitionPlan": {
             "type": "object",
             "properties": {
-                "key": {
-                    "type": "string"
+                "create": {
+                    "description": "whether the partition would be created",
+                    "type": "boolean"
                 },
-                "value": {
+                "groups": {
+                    "description": "segment groups to bulk insert",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RestoreGroupPlan"
+                    },
+                    "type": "array"
+                },
+                "l0_segment_ids": {
+                    "description": "l0 segments of the partition to bulk insert",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
+                },
+                "partition_name": {
                     "type": "string"
+                },
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "backuppb.ListBackupsResponse": {
+        "backuppb.RestorePartitionTask": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "response code. 0 means success. others are fail",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backuppb.ResponseCode"
-                        }
-                    ]
+                "end_time": {
+                    "type": "integer"
                 },
-                "data": {
-                    "description": "backup info entities",
-                    "type": "array",
+                "errorMessage": {
+                    "type": "string"
+                },
+                "finished_group_ids": {
+                    "description": "segment groups which have been bulk inserted",
                     "items": {
-                        "$ref": "#/definitions/backuppb.BackupInfo"
-                    }
+                        "type": "integer"
+                    },
+                    "type": "array"
                 },
-                "msg": {
-                    "description": "error msg if fail",
+                "finished_l0_segment_ids": {
+                    "description": "l0 segments of the partition which have been bulk inserted",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "type": "array"
+                },
+                "id": {
                     "type": "string"
                 },
-                "requestId": {
-                    "description": "uuid of the request to response",
+                "part_backup": {
+                    "$ref": "#/definitions/backuppb.PartitionBackupInfo"
+                },
+                "partition_id": {
+                    "type": "integer"
+                },
+                "partition_name": {
                     "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
                 }
             }
         },
-        "backuppb.PartitionBackupInfo": {
+        "backuppb.RestorePartitionVerifyResult": {
             "type": "object",
             "properties": {
-                "collection_id": {
+                "actual_rows": {
+                    "description": "rows counted in the restored partition, -1 if the collection is not loaded",
                     "type": "integer"
                 },
-                "load_state": {
-                    "type": "string"
+                "max_rows": {
+                    "type": "integer"
                 },
-                "partition_id": {
+                "min_rows": {
+                    "description": "the rows restored should be within [min_rows, max_rows] by the num_of_rows of the restored segments,\nrows deleted by the restored deltalogs and rows of the binlogs across the restore timestamp are uncertain",
+                    "type": "integer"
+                },
+                "mismatched_keys": {
+                    "description": "sampled primary keys which are not found or found more than once",
                     "type": "integer"
                 },
                 "partition_name": {
                     "type": "string"
                 },
-                "segment_backups": {
-                    "description": "array of segment backup",
-                    "type": "array",
+                "sampled_keys": {
+                    "description": "primary keys sampled from the insert binlogs of the backup and queried back from the restored partition",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.RestorePlan": {
+            "type": "object",
+            "properties": {
+                "collections": {
                     "items": {
-                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
-                    }
+                        "$ref": "#/definitions/backuppb.RestoreCollectionPlan"
+                    },
+                    "type": "array"
+                },
+                "conflicts": {
+                    "description": "conflicts which fail the restore, such as the target collection exists",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "create_databases": {
+                    "description": "databases to create",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
                 },
                 "size": {
+                    "description": "total size of the data to restore",
                     "type": "integer"
                 }
             }
         },
-        "backuppb.ResponseCode": {
+        "backuppb.RestoreTaskStateCode": {
             "type": "integer",
             "enum": [
                 0,
                 1,
                 2,
                 3,
-                400,
-                404
+                4,
+                5
             ],
             "x-enum-varnames": [
-                "ResponseCode_Success",
-                "ResponseCode_Not_Support",
-                "ResponseCode_No_Permission",
-                "ResponseCode_Fail",
-                "ResponseCode_Parameter_Error",
-                "ResponseCode_Request_Object_Not_Found"
+                "RestoreTaskStateCode_INITIAL",
+                "RestoreTaskStateCode_EXECUTING",
+                "RestoreTaskStateCode_SUCCESS",
+                "RestoreTaskStateCode_FAIL",
+                "RestoreTaskStateCode_TIMEOUT",
+                "RestoreTaskStateCode_CANCELLED"
             ]
         },
-        "backuppb.RestoreBackupRequest": {
+        "backuppb.RestoreVerifyResult": {
             "type": "object",
             "properties": {
-                "async": {
-                    "description": "execute asynchronously or not",
-                    "type": "boolean"
-                },
-                "backup_name": {
-                    "description": "backup name to restore",
-                    "type": "string"
-                },
-                "bucket_name": {
-                    "description": "if bucket_name and path is set. will override bucket/path in config.",
-                    "type": "string"
+                "collection_rows": {
+                    "description": "rows of the collection statistics, checked instead of the partitions if the collection is not loaded, -1 if not counted",
+                    "type": "integer"
                 },
-                "collection_names": {
-                    "description": "collections to restore",
-                    "type": "array",
+                "mismatches": {
                     "items": {
                         "type": "string"
-                    }
-                },
-                "collection_renames": {
-                    "description": "2, give a map to rename the collections, if not given, use the original name.\ncollection_renames has higher priority than collection_suffix",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "collection_suffix": {
-                    "description": "Support two ways to rename the collections while recover\n1, set a suffix",
-                    "type": "string"
+                    },
+                    "type": "array"
                 },
-                "db_collections": {
-                    "description": "database and collections to restore. A json string. for example: {\"db1\":[\"collection1\"],\"db2\":[\"collection2\",\"collection3\"]}",
-                    "type": "string"
+                "partitions": {
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RestorePartitionVerifyResult"
+                    },
+                    "type": "array"
                 },
-                "dropExistCollection": {
-                    "description": "if true, drop existing target collection before create",
+                "passed": {
                     "type": "boolean"
                 },
-                "dropExistIndex": {
-                    "description": "if true, drop existing index of target collection before create",
+                "verified": {
+                    "description": "false if the restored rows are not checked, as the existing collection restored into is not loaded",
+                    "type": "boolean"
+                }
+            }
+        },
+        "backuppb.ResumeBackupRequest": {
+            "type": "object",
+            "properties": {
+                "async": {
+                    "description": "async or not",
                     "type": "boolean"
                 },
-                "id": {
+                "backup_name": {
+                    "description": "name of the backup to resume",
                     "type": "string"
                 },
-                "metaOnly": {
-                    "description": "if true only restore meta, not restore data",
+                "gc_pause_address": {
+                    "description": "gc pause API address",
+                    "type": "string"
+                },
+                "gc_pause_enable": {
+                    "description": "if true, stop GC to avoid the data compacted and GCed during backup, use it when the data to backup is very large.",
                     "type": "boolean"
                 },
-                "path": {
-                    "description": "if bucket_name and path is set. will override bucket/path in config.",
-                    "type": "string"
+                "gc_pause_seconds": {
+                    "description": "gc pause seconds, set it larger than the time cost of backup",
+                    "type": "integer"
                 },
                 "requestId": {
                     "description": "uuid of request, will generate one if not set",
                     "type": "string"
+                }
+            }
+        },
+        "backuppb.RetentionDecision": {
+            "type": "object",
+            "properties": {
+                "backup_name": {
+                    "type": "string"
                 },
-                "restoreIndex": {
-                    "description": "if true restore index info",
+                "keep": {
+                    "description": "whether the backup is kept",
                     "type": "boolean"
                 },
-                "skipCreateCollection": {
-                    "description": "if true, will skip collection, use when collection exist, restore index or data",
-                    "type": "boolean"
+                "policies": {
+                    "description": "names of the retention policies selecting the backup",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
                 },
-                "useAutoIndex": {
-                    "description": "if true use autoindex when restore vector index",
-                    "type": "boolean"
+                "reason": {
+                    "description": "why the backup is kept or pruned",
+                    "type": "string"
                 }
             }
         },
-        "backuppb.RestoreBackupResponse": {
+        "backuppb.ScheduleResponse": {
             "type": "object",
             "properties": {
                 "code": {
-                    "description": "response code. 0 means success. others are fail",
                     "allOf": [
                         {
                             "$ref": "#/definitions/backuppb.ResponseCode"
                         }
-                    ]
+                    ],
+                    "description": "response code. 0 means success. others are fail"
                 },
                 "data": {
-                    "description": "restore task info entity",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/backuppb.RestoreBackupTask"
+                            "$ref": "#/definitions/backuppb.BackupSchedule"
                         }
-                    ]
+                    ],
+                    "description": "schedule entity"
                 },
                 "msg": {
                     "description": "error msg if fail",
@@ -880,205 +2407,263 @@ const docTemplate = `{
                 }
             }
         },
-        "backuppb.RestoreBackupTask": {
+        "backuppb.ScheduleRun": {
             "type": "object",
             "properties": {
-                "collection_restore_tasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.RestoreCollectionTask"
-                    }
+                "backup_name": {
+                    "description": "name of the backup created by the run",
+                    "type": "string"
                 },
                 "end_time": {
+                    "description": "unix time in seconds when the run ended, 0 if it is still running",
                     "type": "integer"
                 },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "id": {
+                "msg": {
+                    "description": "error msg if the run fails or is skipped",
                     "type": "string"
                 },
-                "progress": {
-                    "type": "integer"
-                },
-                "restored_size": {
-                    "type": "integer"
-                },
                 "start_time": {
+                    "description": "unix time in seconds when the run started",
                     "type": "integer"
                 },
                 "state_code": {
-                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
-                },
-                "to_restore_size": {
-                    "type": "integer"
+                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
                 }
             }
         },
-        "backuppb.RestoreCollectionTask": {
+        "backuppb.SegmentBackupInfo": {
             "type": "object",
             "properties": {
-                "coll_backup": {
-                    "$ref": "#/definitions/backuppb.CollectionBackupInfo"
-                },
-                "dropExistCollection": {
-                    "description": "if true drop the collections",
+                "backuped": {
                     "type": "boolean"
                 },
-                "dropExistIndex": {
-                    "description": "if true drop index info",
-                    "type": "boolean"
+                "binlogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FieldBinlog"
+                    }
                 },
-                "end_time": {
+                "collection_id": {
                     "type": "integer"
                 },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "metaOnly": {
-                    "description": "if true only restore meta",
-                    "type": "boolean"
-                },
-                "partition_restore_tasks": {
+                "deltalogs": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.RestorePartitionTask"
+                        "$ref": "#/definitions/backuppb.FieldBinlog"
                     }
                 },
-                "progress": {
+                "group_id": {
+                    "description": "separate segments into multi groups by size,\nsegments in one group will be copied into one directory during backup\nand will bulkinsert in one call during restore",
                     "type": "integer"
                 },
-                "restoreIndex": {
-                    "description": "if true restore index info",
+                "is_l0": {
                     "type": "boolean"
                 },
-                "restored_size": {
+                "num_of_rows": {
                     "type": "integer"
                 },
-                "skipCreateCollection": {
-                    "description": "if true will skip create collections",
-                    "type": "boolean"
-                },
-                "start_time": {
+                "partition_id": {
                     "type": "integer"
                 },
-                "state_code": {
-                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
-                },
-                "target_collection_name": {
+                "ref_backup": {
+                    "description": "name of the backup which physically holds the binlogs of this segment,\nempty means the binlogs are stored in the current backup. Set for the unchanged segments of an incremental backup",
                     "type": "string"
                 },
-                "target_db_name": {
-                    "type": "string"
+                "segment_id": {
+                    "type": "integer"
                 },
-                "to_restore_size": {
+                "size": {
                     "type": "integer"
                 },
-                "useAutoIndex": {
-                    "description": "if true use autoindex when restore vector index",
-                    "type": "boolean"
+                "statslogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FieldBinlog"
+                    }
                 }
             }
         },
-        "backuppb.RestorePartitionTask": {
+        "backuppb.ThrottleConfig": {
             "type": "object",
             "properties": {
-                "end_time": {
+                "bytes_per_second": {
+                    "description": "limit of the bytes copied per second when no profile is active, 0 means no limit",
                     "type": "integer"
                 },
-                "errorMessage": {
-                    "type": "string"
+                "profiles": {
+                    "description": "the first active profile in order takes effect",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.ThrottleProfile"
+                    },
+                    "type": "array"
                 },
-                "id": {
+                "requests_per_second": {
+                    "description": "limit of the storage requests per second when no profile is active, 0 means no limit",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.ThrottleInfo": {
+            "type": "object",
+            "properties": {
+                "active_profile": {
+                    "description": "name of the active profile, empty if no profile is active",
                     "type": "string"
                 },
-                "part_backup": {
-                    "$ref": "#/definitions/backuppb.PartitionBackupInfo"
+                "bytes_per_second": {
+                    "description": "limits in effect",
+                    "type": "integer"
                 },
-                "progress": {
+                "config": {
+                    "$ref": "#/definitions/backuppb.ThrottleConfig"
+                },
+                "requests_per_second": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.ThrottleProfile": {
+            "type": "object",
+            "properties": {
+                "bytes_per_second": {
+                    "description": "0 means no limit",
                     "type": "integer"
                 },
-                "start_time": {
+                "end": {
+                    "description": "\"HH:MM\" in the local time of the server, earlier than start if the profile crosses midnight",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "requests_per_second": {
+                    "description": "0 means no limit",
                     "type": "integer"
                 },
-                "state_code": {
-                    "$ref": "#/definitions/backuppb.RestoreTaskStateCode"
+                "start": {
+                    "description": "\"HH:MM\" in the local time of the server",
+                    "type": "string"
                 }
             }
         },
-        "backuppb.RestoreTaskStateCode": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "RestoreTaskStateCode_INITIAL",
-                "RestoreTaskStateCode_EXECUTING",
-                "RestoreTaskStateCode_SUCCESS",
-                "RestoreTaskStateCode_FAIL",
-                "RestoreTaskStateCode_TIMEOUT"
-            ]
-        },
-        "backuppb.SegmentBackupInfo": {
+        "backuppb.ThrottleResponse": {
             "type": "object",
             "properties": {
-                "backuped": {
-                    "type": "boolean"
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
                 },
-                "binlogs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.FieldBinlog"
-                    }
+                "data": {
+                    "$ref": "#/definitions/backuppb.ThrottleInfo"
                 },
-                "collection_id": {
-                    "type": "integer"
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
                 },
-                "deltalogs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.FieldBinlog"
-                    }
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.UpdateThrottleRequest": {
+            "type": "object",
+            "properties": {
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
                 },
-                "group_id": {
-                    "description": "separate segments into multi groups by size,\nsegments in one group will be copied into one directory during backup\nand will bulkinsert in one call during restore",
-                    "type": "integer"
+                "throttle": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ThrottleConfig"
+                        }
+                    ],
+                    "description": "replaces the current throttle config"
+                }
+            }
+        },
+        "backuppb.ValueField": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Types that are valid to be assigned to Data:\n\n\t*ValueField_BoolData\n\t*ValueField_IntData\n\t*ValueField_LongData\n\t*ValueField_FloatData\n\t*ValueField_DoubleData\n\t*ValueField_StringData\n\t*ValueField_BytesData"
+                }
+            }
+        },
+        "backuppb.VerifyBackupRequest": {
+            "type": "object",
+            "properties": {
+                "backup_name": {
+                    "description": "name of the backup to verify",
+                    "type": "string"
                 },
-                "is_l0": {
+                "bucket_name": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
+                },
+                "checksum": {
+                    "description": "read the files to compare their checksums, otherwise only the existence and sizes are compared",
                     "type": "boolean"
                 },
-                "num_of_rows": {
-                    "type": "integer"
+                "path": {
+                    "description": "if bucket_name and path is set. will override bucket/path in config.",
+                    "type": "string"
                 },
-                "partition_id": {
-                    "type": "integer"
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.VerifyBackupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ],
+                    "description": "response code. 0 means success. others are fail"
                 },
-                "segment_id": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/backuppb.VerifyBackupResult"
                 },
-                "size": {
-                    "type": "integer"
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
                 },
-                "statslogs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.FieldBinlog"
-                    }
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
                 }
             }
         },
-        "backuppb.ValueField": {
+        "backuppb.VerifyBackupResult": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Types that are valid to be assigned to Data:\n\n\t*ValueField_BoolData\n\t*ValueField_IntData\n\t*ValueField_LongData\n\t*ValueField_FloatData\n\t*ValueField_DoubleData\n\t*ValueField_StringData\n\t*ValueField_BytesData"
+                "backup_name": {
+                    "type": "string"
+                },
+                "checked_files": {
+                    "description": "number of files checked",
+                    "type": "integer"
+                },
+                "problems": {
+                    "description": "files failing the verification",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FileVerifyResult"
+                    },
+                    "type": "array"
+                },
+                "unrecorded_files": {
+                    "description": "number of files without recorded checksum, only their existence is checked",
+                    "type": "integer"
                 }
             }
         }
@@ -12,6 +12,9 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// waitJobsInterval is the interval to check the status of the jobs waited by WaitJobs
+const waitJobsInterval = 10 * time.Millisecond
+
 // WorkerPool a pool that can control the total amount and rate of concurrency
 type WorkerPool struct {
 	job    chan JobWithId
@@ -98,7 +101,11 @@ func (p *WorkerPool) SubmitWithId(job Job) int64 {
 	return jobId
 }
 
-func (p *WorkerPool) WaitJobs(jobIds []int64) error {
+// WaitJobs waits until the jobs are done and returns the first error of them,
+// or returns the error of ctx once it is done, the jobs are left running in that case
+func (p *WorkerPool) WaitJobs(ctx context.Context, jobIds []int64) error {
+	ticker := time.NewTicker(waitJobsInterval)
+	defer ticker.Stop()
 	for {
 		var done = true
 		var err error = nil
@@ -121,6 +128,11 @@ func (p *WorkerPool) WaitJobs(jobIds []int64) error {
 		if done {
 			return nil
 		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
 }
 
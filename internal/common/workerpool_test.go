@@ -69,10 +69,28 @@ func TestWaitJobs(t *testing.T) {
 	}
 
 	//time.Sleep(15 * time.Second)
-	err = wp.WaitJobs(jobs)
+	err = wp.WaitJobs(context.Background(), jobs)
 
 	assert.NoError(t, err)
 	duration := time.Now().Unix() - start
 	assert.True(t, duration >= 8)
 	//wp.Done()
 }
+
+func TestWaitJobsCancel(t *testing.T) {
+	wp, err := NewWorkerPool(context.Background(), 1, 0)
+	assert.Nil(t, err)
+
+	wp.Start()
+	release := make(chan struct{})
+	defer close(release)
+	id := wp.SubmitWithId(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = wp.WaitJobs(ctx, []int64{id})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,180 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filekv
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/internal/kv"
+	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
+)
+
+const tmpFileSuffix = ".tmp"
+
+// FileKV implements BaseKV interface and persists the data into a local directory, one file per key.
+// Data is served from memory, a change only rewrites the files of the changed keys.
+type FileKV struct {
+	// mu serializes the changes and the file writes
+	mu  sync.Mutex
+	dir string
+	mem *memkv.MemoryKV
+}
+
+var _ kv.BaseKV = (*FileKV)(nil)
+
+// NewFileKV returns a FileKV persisted to the directory @dir, data already in the directory will be loaded.
+func NewFileKV(dir string) (*FileKV, error) {
+	fileKV := &FileKV{
+		dir: dir,
+		mem: memkv.NewMemoryKV(),
+	}
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	kvs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// temporary files are left by crashes during writing
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), tmpFileSuffix) {
+			continue
+		}
+		key, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			continue
+		}
+		bytes, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		kvs[key] = string(bytes)
+	}
+	err = fileKV.mem.MultiSave(kvs)
+	if err != nil {
+		return nil, err
+	}
+	return fileKV, nil
+}
+
+// keyPath returns the file of the key, the key is escaped so that every key is a single file in the directory
+func (kv *FileKV) keyPath(key string) string {
+	return filepath.Join(kv.dir, url.PathEscape(key))
+}
+
+// writeKey writes the value into a temporary file and renames it to the file of the key,
+// so the file is always complete even if the process crashes during writing.
+func (kv *FileKV) writeKey(key, value string) error {
+	path := kv.keyPath(key)
+	tmpPath := path + tmpFileSuffix
+	err := os.WriteFile(tmpPath, []byte(value), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (kv *FileKV) removeKeys(keys []string) error {
+	for _, key := range keys {
+		err := os.Remove(kv.keyPath(key))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load loads an object with @key.
+func (kv *FileKV) Load(key string) (string, error) {
+	return kv.mem.Load(key)
+}
+
+// MultiLoad loads objects with multi @keys.
+func (kv *FileKV) MultiLoad(keys []string) ([]string, error) {
+	return kv.mem.MultiLoad(keys)
+}
+
+// LoadWithPrefix returns all keys & values with given prefix.
+func (kv *FileKV) LoadWithPrefix(key string) ([]string, []string, error) {
+	return kv.mem.LoadWithPrefix(key)
+}
+
+// Save object with @key. Object value is @value.
+func (kv *FileKV) Save(key, value string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if err := kv.writeKey(key, value); err != nil {
+		return err
+	}
+	return kv.mem.Save(key, value)
+}
+
+// MultiSave saves given key-value pairs in FileKV, keys are written one by one.
+func (kv *FileKV) MultiSave(kvs map[string]string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	for key, value := range kvs {
+		if err := kv.writeKey(key, value); err != nil {
+			return err
+		}
+	}
+	return kv.mem.MultiSave(kvs)
+}
+
+// Remove deletes an object with @key.
+func (kv *FileKV) Remove(key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if err := kv.removeKeys([]string{key}); err != nil {
+		return err
+	}
+	return kv.mem.Remove(key)
+}
+
+// MultiRemove removes given @keys in FileKV.
+func (kv *FileKV) MultiRemove(keys []string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if err := kv.removeKeys(keys); err != nil {
+		return err
+	}
+	return kv.mem.MultiRemove(keys)
+}
+
+// RemoveWithPrefix remove key of given prefix in FileKV.
+func (kv *FileKV) RemoveWithPrefix(key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	keys, _, err := kv.mem.LoadWithPrefix(key)
+	if err != nil {
+		return err
+	}
+	if err := kv.removeKeys(keys); err != nil {
+		return err
+	}
+	return kv.mem.RemoveWithPrefix(key)
+}
+
+// Close dummy close, data has been written on every change
+func (kv *FileKV) Close() {
+}
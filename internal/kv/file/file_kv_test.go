@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filekv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileKV(t *testing.T) {
+	dir, err := os.MkdirTemp("", "file_kv_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "kv")
+
+	fileKV, err := NewFileKV(path)
+	assert.NoError(t, err)
+	assert.NoError(t, fileKV.Save("backup/1", "a"))
+	assert.NoError(t, fileKV.MultiSave(map[string]string{"backup/2": "b", "restore/1": "c"}))
+	assert.NoError(t, fileKV.Remove("backup/2"))
+
+	value, err := fileKV.Load("backup/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", value)
+	_, err = fileKV.Load("backup/2")
+	assert.Error(t, err)
+
+	// every key is a file of the directory
+	entries, err := os.ReadDir(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	bytes, err := os.ReadFile(filepath.Join(path, "backup%2F1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(bytes))
+	// a temporary file left by a crash is ignored
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "backup%2F3.tmp"), []byte("c"), 0600))
+
+	// reopen, data should be loaded from file
+	reopened, err := NewFileKV(path)
+	assert.NoError(t, err)
+	keys, values, err := reopened.LoadWithPrefix("backup/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/1"}, keys)
+	assert.Equal(t, []string{"a"}, values)
+
+	assert.NoError(t, reopened.RemoveWithPrefix("restore/"))
+	reopened, err = NewFileKV(path)
+	assert.NoError(t, err)
+	keys, _, err = reopened.LoadWithPrefix("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/1"}, keys)
+}
+
+func TestFileKVNotDirectory(t *testing.T) {
+	dir, err := os.MkdirTemp("", "file_kv_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "kv.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+
+	_, err = NewFileKV(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+// Package binlog reads the values of milvus insert binlogs, such as the primary keys sampled to verify a restore.
+//
+// A binlog starts with the magic number and a descriptor event, followed by the insert events.
+// Every event has a header of timestamp, type code, event length and the position of the next event,
+// the payload of an insert event is a parquet file of a single column after its start and end timestamps.
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	magicNumber int32 = 0xfffabc
+	// timestamp int64, type code int8, event length int32, next position int32
+	eventHeaderSize = 17
+	// start timestamp and end timestamp of the insert event
+	insertEventDataSize = 16
+
+	descriptorEventType = 0
+	insertEventType     = 1
+)
+
+// ReadValues reads the values of an insert binlog, int64 for the integer fields and string for the varchar fields.
+// Nulls are skipped, reading stops after limit values if limit is positive.
+func ReadValues(data []byte, limit int) ([]interface{}, error) {
+	if len(data) < 4 || int32(binary.LittleEndian.Uint32(data)) != magicNumber {
+		return nil, fmt.Errorf("not a milvus binlog")
+	}
+	values := make([]interface{}, 0)
+	pos := 4
+	for pos < len(data) {
+		if pos+eventHeaderSize > len(data) {
+			return nil, fmt.Errorf("unexpected end of binlog event header at %d", pos)
+		}
+		typeCode := int8(data[pos+8])
+		eventLength := int(int32(binary.LittleEndian.Uint32(data[pos+9:])))
+		nextPosition := int(int32(binary.LittleEndian.Uint32(data[pos+13:])))
+		if eventLength < eventHeaderSize || pos+eventLength > len(data) || nextPosition <= pos {
+			return nil, fmt.Errorf("invalid binlog event at %d", pos)
+		}
+		switch typeCode {
+		case descriptorEventType:
+		case insertEventType:
+			if eventLength < eventHeaderSize+insertEventDataSize {
+				return nil, fmt.Errorf("invalid binlog insert event at %d", pos)
+			}
+			payload := data[pos+eventHeaderSize+insertEventDataSize : pos+eventLength]
+			remaining := 0
+			if limit > 0 {
+				remaining = limit - len(values)
+			}
+			eventValues, err := readParquetColumn(payload, remaining)
+			if err != nil {
+				return nil, fmt.Errorf("fail to read binlog insert event at %d: %w", pos, err)
+			}
+			values = append(values, eventValues...)
+			if limit > 0 && len(values) >= limit {
+				return values, nil
+			}
+		default:
+			return nil, fmt.Errorf("unsupported binlog event type %d, only insert binlogs are supported", typeCode)
+		}
+		pos = nextPosition
+	}
+	return values, nil
+}
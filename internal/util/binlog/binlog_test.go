@@ -0,0 +1,117 @@
+package binlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zilliztech/milvus-backup/internal/util/binlog/binlogtest"
+)
+
+func TestReadValues(t *testing.T) {
+	ints := []interface{}{int64(3), int64(-1), int64(1 << 40), int64(3), int64(7), int64(8), int64(9), int64(10), int64(11)}
+	strs := []interface{}{"a", nil, "bb", "", "a", "ccc"}
+	for _, c := range []struct {
+		name   string
+		column binlogtest.Column
+	}{
+		{"plain", binlogtest.Column{Values: ints}},
+		{"dictionary zstd", binlogtest.Column{Values: ints, Dictionary: true, Codec: binlogtest.CodecZstd}},
+		{"dictionary snappy v2", binlogtest.Column{Values: ints, Dictionary: true, Codec: binlogtest.CodecSnappy, V2: true}},
+		{"optional string gzip", binlogtest.Column{Values: strs, Optional: true, Codec: binlogtest.CodecGzip}},
+		{"optional string dictionary v2", binlogtest.Column{Values: strs, Optional: true, Dictionary: true, V2: true}},
+		{"optional string zstd v2", binlogtest.Column{Values: strs, Optional: true, Codec: binlogtest.CodecZstd, V2: true}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			expected := make([]interface{}, 0)
+			for _, value := range c.column.Values {
+				if value != nil {
+					expected = append(expected, value)
+				}
+			}
+			payload := binlogtest.WriteParquet(t, c.column)
+			values, err := readParquetColumn(payload, 0)
+			require.NoError(t, err)
+			assert.Equal(t, expected, values)
+
+			// the values of the events are concatenated
+			data := binlogtest.WriteInsertBinlog(payload, payload)
+			values, err = ReadValues(data, 0)
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]interface{}{}, expected...), expected...), values)
+
+			values, err = ReadValues(data, len(expected)+2)
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]interface{}{}, expected...), expected[:2]...), values)
+		})
+	}
+}
+
+// TestReadMilvusBinlogs reads the binlogs of testdata written by the arrow parquet writer the way milvus writes them,
+// see testdata/gen.go
+func TestReadMilvusBinlogs(t *testing.T) {
+	pk := func(i int) interface{} { return int64(449196313400000000 + i*7) }
+	varchar := func(i int) interface{} { return fmt.Sprintf("pk_%06d", i) }
+	long := func(i int) interface{} { return fmt.Sprintf("pk_%06d", i) + strings.Repeat("_", 1000) }
+	for _, c := range []struct {
+		file  string
+		count int
+		value func(i int) interface{}
+		// every third value is null
+		nullable bool
+	}{
+		{"int64.binlog", 1000, pk, false},
+		{"varchar.binlog", 1000, varchar, false},
+		{"int64_nullable.binlog", 1000, pk, true},
+		{"varchar_nullable.binlog", 1000, varchar, true},
+		// a dictionary encoded data page followed by a plain one after the dictionary is over 1MB
+		{"varchar_large.binlog", 3000, long, false},
+	} {
+		t.Run(c.file, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + c.file)
+			require.NoError(t, err)
+			expected := make([]interface{}, 0, c.count)
+			for i := 0; i < c.count; i++ {
+				if !c.nullable || i%3 != 0 {
+					expected = append(expected, c.value(i))
+				}
+			}
+			values, err := ReadValues(data, 0)
+			require.NoError(t, err)
+			assert.Equal(t, expected, values)
+
+			values, err = ReadValues(data, 10)
+			require.NoError(t, err)
+			assert.Equal(t, expected[:10], values)
+		})
+	}
+}
+
+func TestReadValuesInvalid(t *testing.T) {
+	_, err := ReadValues([]byte("not a binlog"), 0)
+	assert.Error(t, err)
+
+	payload := binlogtest.WriteParquet(t, binlogtest.Column{Values: []interface{}{int64(1), int64(2)}})
+	data := binlogtest.WriteInsertBinlog(payload)
+	_, err = ReadValues(data[:len(data)-10], 0)
+	assert.Error(t, err)
+
+	// a delete event is not of an insert binlog
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, binlogtest.MagicNumber)
+	binlogtest.WriteEvent(&buf, 2, make([]byte, insertEventDataSize))
+	_, err = ReadValues(buf.Bytes(), 0)
+	assert.Error(t, err)
+
+	// a corrupted payload
+	corrupted := append([]byte{}, data...)
+	copy(corrupted[len(corrupted)-4:], "PAR0")
+	_, err = ReadValues(corrupted, 0)
+	assert.Error(t, err)
+}
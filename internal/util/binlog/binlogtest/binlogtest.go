@@ -0,0 +1,376 @@
+// Package binlogtest writes the milvus insert binlogs of the parquet payloads for tests.
+package binlogtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"math/bits"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+// compression codecs of parquet
+const (
+	CodecUncompressed = 0
+	CodecSnappy       = 1
+	CodecGzip         = 2
+	CodecZstd         = 6
+)
+
+// MagicNumber starts a binlog
+const MagicNumber int32 = 0xfffabc
+
+const (
+	eventHeaderSize     = 17
+	insertEventDataSize = 16
+
+	descriptorEventType = 0
+	insertEventType     = 1
+
+	thriftStop      = 0
+	thriftTrue      = 1
+	thriftFalse     = 2
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftStructure = 12
+
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	dataPage       = 0
+	dictionaryPage = 2
+	dataPageV2     = 3
+
+	plainEncoding         = 0
+	rleDictionaryEncoding = 8
+
+	optionalRepetition = 1
+)
+
+// thriftField is a field of a struct encoded by writeThriftStruct, values are int32, int64, bool, string, []byte,
+// []interface{} of the same type or []thriftField as a nested struct
+type thriftField struct {
+	id    int16
+	value interface{}
+}
+
+func thriftTypeOf(value interface{}) byte {
+	switch v := value.(type) {
+	case int32:
+		return thriftI32
+	case int64:
+		return thriftI64
+	case bool:
+		if v {
+			return thriftTrue
+		}
+		return thriftFalse
+	case string, []byte:
+		return thriftBinary
+	case []interface{}:
+		return thriftList
+	case []thriftField:
+		return thriftStructure
+	}
+	panic("unsupported thrift value")
+}
+
+func writeVarint(buf *bytes.Buffer, value uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], value)])
+}
+
+func writeThriftValue(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case int32:
+		writeVarint(buf, uint64((int64(v)<<1)^(int64(v)>>63)))
+	case int64:
+		writeVarint(buf, uint64((v<<1)^(v>>63)))
+	case bool:
+		if v {
+			buf.WriteByte(thriftTrue)
+		} else {
+			buf.WriteByte(thriftFalse)
+		}
+	case string:
+		writeVarint(buf, uint64(len(v)))
+		buf.WriteString(v)
+	case []byte:
+		writeVarint(buf, uint64(len(v)))
+		buf.Write(v)
+	case []interface{}:
+		elementType := byte(thriftI32)
+		if len(v) > 0 {
+			elementType = thriftTypeOf(v[0])
+			if elementType == thriftFalse {
+				elementType = thriftTrue
+			}
+		}
+		if len(v) < 15 {
+			buf.WriteByte(byte(len(v))<<4 | elementType)
+		} else {
+			buf.WriteByte(0xf0 | elementType)
+			writeVarint(buf, uint64(len(v)))
+		}
+		for _, element := range v {
+			writeThriftValue(buf, element)
+		}
+	case []thriftField:
+		writeThriftStruct(buf, v)
+	}
+}
+
+func writeThriftStruct(buf *bytes.Buffer, fields []thriftField) {
+	var lastID int16
+	for _, field := range fields {
+		fieldType := thriftTypeOf(field.value)
+		if delta := field.id - lastID; delta > 0 && delta <= 15 {
+			buf.WriteByte(byte(delta)<<4 | fieldType)
+		} else {
+			buf.WriteByte(fieldType)
+			writeVarint(buf, uint64((int64(field.id)<<1)^(int64(field.id)>>63)))
+		}
+		lastID = field.id
+		if fieldType != thriftTrue && fieldType != thriftFalse {
+			writeThriftValue(buf, field.value)
+		}
+	}
+	buf.WriteByte(thriftStop)
+}
+
+func compress(t *testing.T, codec int32, data []byte) []byte {
+	switch codec {
+	case CodecSnappy:
+		return snappy.Encode(nil, data)
+	case CodecGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, err := writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return buf.Bytes()
+	case CodecZstd:
+		encoder, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		defer encoder.Close()
+		return encoder.EncodeAll(data, nil)
+	}
+	return data
+}
+
+// writeBitPacked encodes the values by a bit packed run of the hybrid encoding
+func writeBitPacked(buf *bytes.Buffer, values []uint64, bitWidth int) {
+	groups := (len(values) + 7) / 8
+	writeVarint(buf, uint64(groups)<<1|1)
+	packed := make([]byte, groups*bitWidth)
+	for i, value := range values {
+		for b := 0; b < bitWidth; b++ {
+			if value>>b&1 == 1 {
+				bit := i*bitWidth + b
+				packed[bit/8] |= 1 << (bit % 8)
+			}
+		}
+	}
+	buf.Write(packed)
+}
+
+// Column is a parquet column to write
+type Column struct {
+	// int64 or string values, nil is null
+	Values     []interface{}
+	Optional   bool
+	Dictionary bool
+	// compression codec of parquet, such as CodecZstd
+	Codec int32
+	// write the values in a data page v2, otherwise in a data page v1
+	V2 bool
+}
+
+func writePlain(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case int64:
+		binary.Write(buf, binary.LittleEndian, v)
+	case string:
+		binary.Write(buf, binary.LittleEndian, uint32(len(v)))
+		buf.WriteString(v)
+	}
+}
+
+func writePage(buf *bytes.Buffer, header []thriftField, data []byte) {
+	writeThriftStruct(buf, header)
+	buf.Write(data)
+}
+
+// WriteParquet writes the values into a parquet file of a single column in a row group
+func WriteParquet(t *testing.T, column Column) []byte {
+	valueType := int32(parquetInt64)
+	numNulls := 0
+	for _, value := range column.Values {
+		if value == nil {
+			numNulls++
+		} else if _, ok := value.(string); ok {
+			valueType = parquetByteArray
+		}
+	}
+
+	// definition levels, each value by a rle run
+	var levels bytes.Buffer
+	for _, value := range column.Values {
+		writeVarint(&levels, 1<<1)
+		if value == nil {
+			levels.WriteByte(0)
+		} else {
+			levels.WriteByte(1)
+		}
+	}
+
+	var values, dictionary bytes.Buffer
+	encoding := int32(plainEncoding)
+	indexes := make(map[interface{}]uint64)
+	if column.Dictionary {
+		encoding = rleDictionaryEncoding
+		keys := make([]uint64, 0)
+		for _, value := range column.Values {
+			if value == nil {
+				continue
+			}
+			if _, ok := indexes[value]; !ok {
+				indexes[value] = uint64(len(indexes))
+				writePlain(&dictionary, value)
+			}
+			keys = append(keys, indexes[value])
+		}
+		bitWidth := bits.Len64(uint64(len(indexes) - 1))
+		values.WriteByte(byte(bitWidth))
+		writeBitPacked(&values, keys, bitWidth)
+	} else {
+		for _, value := range column.Values {
+			if value != nil {
+				writePlain(&values, value)
+			}
+		}
+	}
+
+	file := bytes.NewBufferString("PAR1")
+	var dictionaryOffset int64
+	if column.Dictionary {
+		dictionaryOffset = int64(file.Len())
+		data := compress(t, column.Codec, dictionary.Bytes())
+		writePage(file, []thriftField{
+			{1, int32(dictionaryPage)},
+			{2, int32(dictionary.Len())},
+			{3, int32(len(data))},
+			{7, []thriftField{{1, int32(len(indexes))}, {2, int32(plainEncoding)}}},
+		}, data)
+	}
+	dataOffset := int64(file.Len())
+	if column.V2 {
+		var levelBytes []byte
+		if column.Optional {
+			levelBytes = levels.Bytes()
+		}
+		data := append(append([]byte{}, levelBytes...), compress(t, column.Codec, values.Bytes())...)
+		writePage(file, []thriftField{
+			{1, int32(dataPageV2)},
+			{2, int32(len(levelBytes) + values.Len())},
+			{3, int32(len(data))},
+			{8, []thriftField{
+				{1, int32(len(column.Values))},
+				{2, int32(numNulls)},
+				{3, int32(len(column.Values))},
+				{4, encoding},
+				{5, int32(len(levelBytes))},
+				{6, int32(0)},
+				{7, column.Codec != CodecUncompressed},
+			}},
+		}, data)
+	} else {
+		var page bytes.Buffer
+		if column.Optional {
+			binary.Write(&page, binary.LittleEndian, uint32(levels.Len()))
+			page.Write(levels.Bytes())
+		}
+		page.Write(values.Bytes())
+		data := compress(t, column.Codec, page.Bytes())
+		writePage(file, []thriftField{
+			{1, int32(dataPage)},
+			{2, int32(page.Len())},
+			{3, int32(len(data))},
+			{5, []thriftField{{1, int32(len(column.Values))}, {2, encoding}, {3, int32(3)}, {4, int32(3)}}},
+		}, data)
+	}
+	chunkSize := int64(file.Len()) - 4
+
+	repetition := int32(0)
+	if column.Optional {
+		repetition = optionalRepetition
+	}
+	columnMeta := []thriftField{
+		{1, valueType},
+		{2, []interface{}{encoding}},
+		{3, []interface{}{"val"}},
+		{4, column.Codec},
+		{5, int64(len(column.Values))},
+		{6, chunkSize},
+		{7, chunkSize},
+		{9, dataOffset},
+	}
+	if column.Dictionary {
+		columnMeta = append(columnMeta, thriftField{11, dictionaryOffset})
+	}
+	var meta bytes.Buffer
+	writeThriftStruct(&meta, []thriftField{
+		{1, int32(1)},
+		{2, []interface{}{
+			[]thriftField{{4, "schema"}, {5, int32(1)}},
+			[]thriftField{{1, valueType}, {3, repetition}, {4, "val"}},
+		}},
+		{3, int64(len(column.Values))},
+		{4, []interface{}{
+			[]thriftField{
+				{1, []interface{}{[]thriftField{{2, int64(4)}, {3, columnMeta}}}},
+				{2, chunkSize},
+				{3, int64(len(column.Values))},
+			},
+		}},
+		// key value meta is skipped
+		{5, []interface{}{[]thriftField{{1, "ARROW:schema"}, {2, "..."}}}},
+	})
+	file.Write(meta.Bytes())
+	binary.Write(file, binary.LittleEndian, uint32(meta.Len()))
+	file.WriteString("PAR1")
+	return file.Bytes()
+}
+
+// WriteEvent appends an event of the type code to the binlog
+func WriteEvent(buf *bytes.Buffer, typeCode int8, data []byte) {
+	length := eventHeaderSize + len(data)
+	nextPosition := buf.Len() + length
+	binary.Write(buf, binary.LittleEndian, int64(1000))
+	binary.Write(buf, binary.LittleEndian, typeCode)
+	binary.Write(buf, binary.LittleEndian, int32(length))
+	binary.Write(buf, binary.LittleEndian, int32(nextPosition))
+	buf.Write(data)
+}
+
+// WriteInsertBinlog writes the parquet payloads into an insert binlog of an event for each
+func WriteInsertBinlog(payloads ...[]byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, MagicNumber)
+	// collection, partition, segment, field, start and end timestamps, payload type, post header lengths and extras
+	descriptor := make([]byte, 52)
+	descriptor = append(descriptor, bytes.Repeat([]byte{17}, 8)...)
+	descriptor = append(descriptor, 2, 0, 0, 0, '{', '}')
+	WriteEvent(&buf, descriptorEventType, descriptor)
+	for _, payload := range payloads {
+		data := make([]byte, insertEventDataSize)
+		WriteEvent(&buf, insertEventType, append(data, payload...))
+	}
+	return buf.Bytes()
+}
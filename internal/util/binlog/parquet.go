@@ -0,0 +1,377 @@
+package binlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+var parquetMagic = []byte("PAR1")
+
+// physical types of parquet
+const (
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetByteArray = 6
+)
+
+// page types of parquet
+const (
+	dataPage       = 0
+	dictionaryPage = 2
+	dataPageV2     = 3
+)
+
+// encodings of parquet
+const (
+	plainEncoding           = 0
+	plainDictionaryEncoding = 2
+	rleDictionaryEncoding   = 8
+)
+
+// compression codecs of parquet
+const (
+	uncompressed = 0
+	snappyCodec  = 1
+	gzipCodec    = 2
+	zstdCodec    = 6
+)
+
+// optional repetition of a parquet column
+const optionalRepetition = 1
+
+// readParquetColumn reads the values of the first column of a flat parquet file, such as the payload of a binlog event.
+// Only the int32, int64 and byte array columns encoded plainly or by dictionary are supported, byte arrays are read as strings.
+// Nulls are skipped, reading stops after limit values if limit is positive.
+func readParquetColumn(data []byte, limit int) ([]interface{}, error) {
+	if len(data) < 12 || !bytes.Equal(data[:4], parquetMagic) || !bytes.Equal(data[len(data)-4:], parquetMagic) {
+		return nil, fmt.Errorf("not a parquet file")
+	}
+	metaLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if metaLength > len(data)-12 {
+		return nil, fmt.Errorf("invalid parquet meta length %d", metaLength)
+	}
+	decoder := &thriftDecoder{data: data[len(data)-8-metaLength : len(data)-8]}
+	fileMeta, err := decoder.readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("fail to read parquet meta: %w", err)
+	}
+	// the root of the schema is followed by the columns
+	schema := fileMeta.list(2)
+	if len(schema) < 2 {
+		return nil, fmt.Errorf("parquet file has no column")
+	}
+	column, _ := schema[1].(thriftStruct)
+	repetition, _ := column.int(3)
+	optional := repetition == optionalRepetition
+
+	values := make([]interface{}, 0)
+	for _, v := range fileMeta.list(4) {
+		rowGroup, _ := v.(thriftStruct)
+		columns := rowGroup.list(1)
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("parquet row group has no column")
+		}
+		columnChunk, _ := columns[0].(thriftStruct)
+		columnMeta, ok := columnChunk.structure(3)
+		if !ok {
+			return nil, fmt.Errorf("parquet column chunk has no meta")
+		}
+		reader, err := newColumnChunkReader(data, columnMeta, optional)
+		if err != nil {
+			return nil, err
+		}
+		values, err = reader.read(values, limit)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(values) >= limit {
+			return values[:limit], nil
+		}
+	}
+	return values, nil
+}
+
+// columnChunkReader reads the pages of a column chunk
+type columnChunkReader struct {
+	chunk      []byte
+	valueType  int64
+	codec      int64
+	optional   bool
+	dictionary []interface{}
+}
+
+func newColumnChunkReader(data []byte, columnMeta thriftStruct, optional bool) (*columnChunkReader, error) {
+	valueType, _ := columnMeta.int(1)
+	if valueType != parquetInt32 && valueType != parquetInt64 && valueType != parquetByteArray {
+		return nil, fmt.Errorf("unsupported parquet type %d", valueType)
+	}
+	codec, _ := columnMeta.int(4)
+	size, _ := columnMeta.int(7)
+	start, _ := columnMeta.int(9)
+	// the dictionary page is ahead of the data pages
+	if dictionaryOffset, ok := columnMeta.int(11); ok && dictionaryOffset > 0 && dictionaryOffset < start {
+		start = dictionaryOffset
+	}
+	if start < 0 || size < 0 || start+size > int64(len(data)) {
+		return nil, fmt.Errorf("parquet column chunk [%d, %d) exceeds the file", start, start+size)
+	}
+	return &columnChunkReader{
+		chunk:     data[start : start+size],
+		valueType: valueType,
+		codec:     codec,
+		optional:  optional,
+	}, nil
+}
+
+func (r *columnChunkReader) read(values []interface{}, limit int) ([]interface{}, error) {
+	pos := 0
+	for pos < len(r.chunk) && (limit <= 0 || len(values) < limit) {
+		decoder := &thriftDecoder{data: r.chunk[pos:]}
+		header, err := decoder.readStruct()
+		if err != nil {
+			return nil, fmt.Errorf("fail to read parquet page header: %w", err)
+		}
+		pos += decoder.pos
+		pageType, _ := header.int(1)
+		uncompressedSize, _ := header.int(2)
+		compressedSize, _ := header.int(3)
+		if compressedSize < 0 || pos+int(compressedSize) > len(r.chunk) {
+			return nil, fmt.Errorf("parquet page of %d bytes exceeds the column chunk", compressedSize)
+		}
+		page := r.chunk[pos : pos+int(compressedSize)]
+		pos += int(compressedSize)
+
+		switch pageType {
+		case dictionaryPage:
+			dictionaryHeader, _ := header.structure(7)
+			numValues, _ := dictionaryHeader.int(1)
+			page, err = decompress(r.codec, page, uncompressedSize)
+			if err != nil {
+				return nil, err
+			}
+			r.dictionary, err = r.readPlain(page, int(numValues))
+			if err != nil {
+				return nil, err
+			}
+		case dataPage:
+			dataHeader, _ := header.structure(5)
+			numValues, _ := dataHeader.int(1)
+			encoding, _ := dataHeader.int(2)
+			page, err = decompress(r.codec, page, uncompressedSize)
+			if err != nil {
+				return nil, err
+			}
+			nonNulls := int(numValues)
+			if r.optional {
+				// definition levels prefixed by their length
+				if len(page) < 4 {
+					return nil, fmt.Errorf("invalid parquet definition levels")
+				}
+				levelsLength := int(binary.LittleEndian.Uint32(page))
+				if 4+levelsLength > len(page) {
+					return nil, fmt.Errorf("invalid parquet definition levels")
+				}
+				nonNulls, err = countNonNulls(page[4:4+levelsLength], int(numValues))
+				if err != nil {
+					return nil, err
+				}
+				page = page[4+levelsLength:]
+			}
+			values, err = r.readValues(values, page, encoding, nonNulls)
+			if err != nil {
+				return nil, err
+			}
+		case dataPageV2:
+			dataHeader, _ := header.structure(8)
+			numValues, _ := dataHeader.int(1)
+			numNulls, _ := dataHeader.int(2)
+			encoding, _ := dataHeader.int(4)
+			defLength, _ := dataHeader.int(5)
+			repLength, _ := dataHeader.int(6)
+			levelsLength := int(defLength + repLength)
+			if levelsLength < 0 || levelsLength > len(page) {
+				return nil, fmt.Errorf("invalid parquet levels of %d bytes", levelsLength)
+			}
+			// the levels are not compressed
+			page = page[levelsLength:]
+			if compressed, ok := dataHeader[7].(bool); !ok || compressed {
+				page, err = decompress(r.codec, page, uncompressedSize-int64(levelsLength))
+				if err != nil {
+					return nil, err
+				}
+			}
+			values, err = r.readValues(values, page, encoding, int(numValues-numNulls))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+func (r *columnChunkReader) readValues(values []interface{}, page []byte, encoding int64, count int) ([]interface{}, error) {
+	switch encoding {
+	case plainEncoding:
+		plain, err := r.readPlain(page, count)
+		if err != nil {
+			return nil, err
+		}
+		return append(values, plain...), nil
+	case plainDictionaryEncoding, rleDictionaryEncoding:
+		if len(page) < 1 {
+			return nil, fmt.Errorf("invalid parquet dictionary indices")
+		}
+		indices, err := readHybrid(page[1:], int(page[0]), count)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indices {
+			if int(index) >= len(r.dictionary) {
+				return nil, fmt.Errorf("parquet dictionary index %d out of %d values", index, len(r.dictionary))
+			}
+			values = append(values, r.dictionary[index])
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported parquet encoding %d", encoding)
+	}
+}
+
+// readPlain reads the plainly encoded values
+func (r *columnChunkReader) readPlain(data []byte, count int) ([]interface{}, error) {
+	values := make([]interface{}, 0, count)
+	pos := 0
+	for i := 0; i < count; i++ {
+		switch r.valueType {
+		case parquetInt32:
+			if pos+4 > len(data) {
+				return nil, fmt.Errorf("unexpected end of parquet values")
+			}
+			values = append(values, int64(int32(binary.LittleEndian.Uint32(data[pos:]))))
+			pos += 4
+		case parquetInt64:
+			if pos+8 > len(data) {
+				return nil, fmt.Errorf("unexpected end of parquet values")
+			}
+			values = append(values, int64(binary.LittleEndian.Uint64(data[pos:])))
+			pos += 8
+		case parquetByteArray:
+			if pos+4 > len(data) {
+				return nil, fmt.Errorf("unexpected end of parquet values")
+			}
+			length := int(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+			if length < 0 || pos+length > len(data) {
+				return nil, fmt.Errorf("unexpected end of parquet values")
+			}
+			values = append(values, string(data[pos:pos+length]))
+			pos += length
+		}
+	}
+	return values, nil
+}
+
+// countNonNulls counts the values defined by the definition levels of an optional flat column
+func countNonNulls(levels []byte, count int) (int, error) {
+	defined, err := readHybrid(levels, 1, count)
+	if err != nil {
+		return 0, err
+	}
+	nonNulls := 0
+	for _, level := range defined {
+		if level == 1 {
+			nonNulls++
+		}
+	}
+	return nonNulls, nil
+}
+
+// readHybrid reads count values of the rle and bit packed hybrid encoding
+func readHybrid(data []byte, bitWidth int, count int) ([]uint64, error) {
+	if bitWidth < 0 || bitWidth > 64 {
+		return nil, fmt.Errorf("invalid parquet bit width %d", bitWidth)
+	}
+	values := make([]uint64, 0, count)
+	pos := 0
+	for len(values) < count {
+		header, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid parquet rle header")
+		}
+		pos += n
+		if header&1 == 0 {
+			// rle run of a value
+			runLength := int(header >> 1)
+			valueBytes := (bitWidth + 7) / 8
+			if pos+valueBytes > len(data) {
+				return nil, fmt.Errorf("unexpected end of parquet rle run")
+			}
+			var value uint64
+			for i := 0; i < valueBytes; i++ {
+				value |= uint64(data[pos+i]) << (8 * i)
+			}
+			pos += valueBytes
+			for i := 0; i < runLength && len(values) < count; i++ {
+				values = append(values, value)
+			}
+			continue
+		}
+		// bit packed groups of 8 values, from the least significant bit
+		groups := int(header >> 1)
+		end := pos + groups*bitWidth
+		if end > len(data) {
+			return nil, fmt.Errorf("unexpected end of parquet bit packed run")
+		}
+		mask := uint64(1)<<bitWidth - 1
+		if bitWidth == 64 {
+			mask = ^uint64(0)
+		}
+		for i := 0; i < groups*8 && len(values) < count; i++ {
+			bit := i * bitWidth
+			var value uint64
+			for read := 0; read < bitWidth; {
+				b := data[pos+(bit+read)/8] >> ((bit + read) % 8)
+				available := 8 - (bit+read)%8
+				value |= uint64(b) << read
+				read += available
+			}
+			values = append(values, value&mask)
+		}
+		pos = end
+	}
+	return values, nil
+}
+
+func decompress(codec int64, data []byte, uncompressedSize int64) ([]byte, error) {
+	switch codec {
+	case uncompressed:
+		return data, nil
+	case snappyCodec:
+		return snappy.Decode(nil, data)
+	case gzipCodec:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case zstdCodec:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		if uncompressedSize < 0 {
+			uncompressedSize = 0
+		}
+		return decoder.DecodeAll(data, make([]byte, 0, uncompressedSize))
+	default:
+		return nil, fmt.Errorf("unsupported parquet compression codec %d", codec)
+	}
+}
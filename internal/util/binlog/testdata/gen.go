@@ -0,0 +1,150 @@
+//go:build ignore
+
+// gen writes the insert binlogs of testdata the way milvus 2.4 writes them, the parquet payloads by the arrow writer
+// and the writer properties of milvus internal/storage/payload_writer.go, the events by internal/storage/binlog_writer.go.
+// It is run in a module requiring github.com/apache/arrow/go/v12 v12.0.1, the version milvus 2.4 depends on:
+//
+//	go run gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+const (
+	magicNumber int32 = 0xfffabc
+
+	descriptorEventType int8 = 0
+	insertEventType     int8 = 1
+
+	// schemapb.DataType
+	dataTypeInt64   int32 = 5
+	dataTypeVarChar int32 = 21
+
+	timestamp uint64 = 449196313400000000
+)
+
+// writePayload is NativePayloadWriter.FinishPayloadWriter of milvus
+func writePayload(arr arrow.Array, nullable bool) []byte {
+	field := arrow.Field{Name: "val", Type: arr.DataType(), Nullable: nullable}
+	schema := arrow.NewSchema([]arrow.Field{field}, nil)
+	column := arrow.NewColumnFromArr(field, arr)
+	defer column.Release()
+	table := array.NewTable(schema, []arrow.Column{column}, int64(column.Len()))
+	defer table.Release()
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithCompressionLevel(3),
+	)
+	var buf bytes.Buffer
+	if err := pqarrow.WriteTable(table, &buf, 1024*1024*1024, props, pqarrow.DefaultWriterProps()); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func writeHeader(buf *bytes.Buffer, typeCode int8, eventLength, nextPosition int32) {
+	binary.Write(buf, binary.LittleEndian, timestamp)
+	binary.Write(buf, binary.LittleEndian, typeCode)
+	binary.Write(buf, binary.LittleEndian, eventLength)
+	binary.Write(buf, binary.LittleEndian, nextPosition)
+}
+
+// writeBinlog is InsertBinlogWriter.Finish of milvus, a descriptor event followed by an insert event per payload
+func writeBinlog(dataType int32, originalSize int, payloads ...[]byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, magicNumber)
+
+	extras, err := json.Marshal(map[string]interface{}{"original_size": fmt.Sprintf("%v", originalSize)})
+	if err != nil {
+		panic(err)
+	}
+	var descriptor bytes.Buffer
+	// collection, partition, segment and field ids, start and end timestamps, payload data type
+	for _, id := range []int64{449196313400000001, 449196313400000002, 449196313400000003, 100} {
+		binary.Write(&descriptor, binary.LittleEndian, id)
+	}
+	binary.Write(&descriptor, binary.LittleEndian, timestamp)
+	binary.Write(&descriptor, binary.LittleEndian, timestamp+1)
+	binary.Write(&descriptor, binary.LittleEndian, dataType)
+	binary.Write(&descriptor, binary.LittleEndian, int32(len(extras)))
+	descriptor.Write(extras)
+	// post header lengths of the event types
+	descriptor.Write([]byte{52, 16, 16, 16, 16, 16, 16, 16})
+	eventLength := int32(17 + descriptor.Len())
+	writeHeader(&buf, descriptorEventType, eventLength, 4+eventLength)
+	descriptor.WriteTo(&buf)
+
+	for _, payload := range payloads {
+		eventLength := int32(17 + 16 + len(payload))
+		writeHeader(&buf, insertEventType, eventLength, int32(buf.Len())+eventLength)
+		binary.Write(&buf, binary.LittleEndian, timestamp)
+		binary.Write(&buf, binary.LittleEndian, timestamp+1)
+		buf.Write(payload)
+	}
+	return buf.Bytes()
+}
+
+func int64Array(n int, value func(i int) int64, valid func(i int) bool) arrow.Array {
+	builder := array.NewInt64Builder(memory.DefaultAllocator)
+	defer builder.Release()
+	for i := 0; i < n; i++ {
+		if valid(i) {
+			builder.Append(value(i))
+		} else {
+			builder.AppendNull()
+		}
+	}
+	return builder.NewArray()
+}
+
+func stringArray(n int, value func(i int) string, valid func(i int) bool) arrow.Array {
+	builder := array.NewStringBuilder(memory.DefaultAllocator)
+	defer builder.Release()
+	for i := 0; i < n; i++ {
+		if valid(i) {
+			builder.Append(value(i))
+		} else {
+			builder.AppendNull()
+		}
+	}
+	return builder.NewArray()
+}
+
+func write(name string, data []byte) {
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	all := func(i int) bool { return true }
+	everyThird := func(i int) bool { return i%3 != 0 }
+	pk := func(i int) int64 { return 449196313400000000 + int64(i)*7 }
+	varchar := func(i int) string { return fmt.Sprintf("pk_%06d", i) }
+
+	// the int64 primary keys of a small segment, dictionary encoded
+	write("int64.binlog", writeBinlog(dataTypeInt64, 1000*8, writePayload(int64Array(1000, pk, all), false)))
+	// the varchar primary keys, two insert events
+	write("varchar.binlog", writeBinlog(dataTypeVarChar, 2*500*9,
+		writePayload(stringArray(500, varchar, all), false),
+		writePayload(stringArray(500, func(i int) string { return varchar(500 + i) }, all), false)))
+	// a nullable field of milvus 2.5
+	write("int64_nullable.binlog", writeBinlog(dataTypeInt64, 1000*8, writePayload(int64Array(1000, pk, everyThird), true)))
+	write("varchar_nullable.binlog", writeBinlog(dataTypeVarChar, 1000*9, writePayload(stringArray(1000, varchar, everyThird), true)))
+	// the dictionary page over 1MB falls back to plain encoding, in several data pages
+	long := func(i int) string { return varchar(i) + strings.Repeat("_", 1000) }
+	write("varchar_large.binlog", writeBinlog(dataTypeVarChar, 3000*1009, writePayload(stringArray(3000, long, all), false)))
+}
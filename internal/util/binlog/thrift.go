@@ -0,0 +1,192 @@
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// types of the thrift compact protocol
+const (
+	thriftStop      = 0
+	thriftTrue      = 1
+	thriftFalse     = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStructure = 12
+)
+
+// thriftStruct is a decoded thrift struct keyed by field id. Integers are decoded as int64, binaries as []byte,
+// lists and sets as []interface{}, nested structs as thriftStruct, maps are skipped as parquet meta doesn't need them
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) int(id int16) (int64, bool) {
+	value, ok := s[id].(int64)
+	return value, ok
+}
+
+func (s thriftStruct) structure(id int16) (thriftStruct, bool) {
+	value, ok := s[id].(thriftStruct)
+	return value, ok
+}
+
+func (s thriftStruct) list(id int16) []interface{} {
+	value, _ := s[id].([]interface{})
+	return value
+}
+
+// thriftDecoder decodes the thrift compact protocol which parquet meta is encoded by
+type thriftDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *thriftDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, fmt.Errorf("unexpected end of thrift data")
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) readVarint() (uint64, error) {
+	value, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid thrift varint at %d", d.pos)
+	}
+	d.pos += n
+	return value, nil
+}
+
+func (d *thriftDecoder) readZigzag() (int64, error) {
+	value, err := d.readVarint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(value>>1) ^ -int64(value&1), nil
+}
+
+func (d *thriftDecoder) readBinary() ([]byte, error) {
+	length, err := d.readVarint()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("thrift binary of %d bytes exceeds the data", length)
+	}
+	value := d.data[d.pos : d.pos+int(length)]
+	d.pos += int(length)
+	return value, nil
+}
+
+// readStruct decodes a struct until its stop field
+func (d *thriftDecoder) readStruct() (thriftStruct, error) {
+	s := make(thriftStruct)
+	var lastID int16
+	for {
+		header, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		fieldType := header & 0x0f
+		if fieldType == thriftStop {
+			return s, nil
+		}
+		if delta := header >> 4; delta != 0 {
+			lastID += int16(delta)
+		} else {
+			id, err := d.readZigzag()
+			if err != nil {
+				return nil, err
+			}
+			lastID = int16(id)
+		}
+		var value interface{}
+		if fieldType == thriftTrue || fieldType == thriftFalse {
+			// booleans of fields are encoded in the field type
+			value = fieldType == thriftTrue
+		} else {
+			value, err = d.readValue(fieldType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		s[lastID] = value
+	}
+}
+
+func (d *thriftDecoder) readValue(valueType byte) (interface{}, error) {
+	switch valueType {
+	case thriftTrue, thriftFalse:
+		// booleans of lists take a byte each
+		b, err := d.readByte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := d.readByte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return d.readZigzag()
+	case thriftDouble:
+		if d.pos+8 > len(d.data) {
+			return nil, fmt.Errorf("unexpected end of thrift data")
+		}
+		value := math.Float64frombits(binary.LittleEndian.Uint64(d.data[d.pos:]))
+		d.pos += 8
+		return value, nil
+	case thriftBinary:
+		return d.readBinary()
+	case thriftList, thriftSet:
+		header, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = d.readVarint(); err != nil {
+				return nil, err
+			}
+		}
+		if size > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("thrift list of %d elements exceeds the data", size)
+		}
+		values := make([]interface{}, 0, size)
+		for i := uint64(0); i < size; i++ {
+			value, err := d.readValue(header & 0x0f)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case thriftMap:
+		size, err := d.readVarint()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+		types, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < size; i++ {
+			if _, err := d.readValue(types >> 4); err != nil {
+				return nil, err
+			}
+			if _, err := d.readValue(types & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case thriftStructure:
+		return d.readStruct()
+	default:
+		return nil, fmt.Errorf("unknown thrift type %d", valueType)
+	}
+}
@@ -0,0 +1,137 @@
+// Package compression compresses backup files by gzip or zstd.
+//
+// Compressed files start with the magic number of their codec, so that they are distinguished from
+// the uncompressed ones written by old versions, json meta files and milvus binlogs.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// None means the file is not compressed
+	None = ""
+	Gzip = "gzip"
+	Zstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Validate checks whether the codec is supported, "none" is accepted as None
+func Validate(codec string) (string, error) {
+	switch codec {
+	case None, "none":
+		return None, nil
+	case Gzip, Zstd:
+		return codec, nil
+	default:
+		return None, fmt.Errorf("unsupported compression codec: %s, should be one of none, gzip, zstd", codec)
+	}
+}
+
+// Detect returns the codec of the data by its magic number, None if it is not compressed
+func Detect(data []byte) string {
+	if bytes.HasPrefix(data, zstdMagic) {
+		return Zstd
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		return Gzip
+	}
+	return None
+}
+
+// Compress compresses the whole data by the codec
+func Compress(codec string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(codec, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses the whole data by the codec
+func Decompress(codec string, data []byte) ([]byte, error) {
+	reader, err := NewReader(codec, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// DecompressAuto decompresses the data by the codec detected from it, the data is returned as is if it is not compressed
+func DecompressAuto(data []byte) ([]byte, error) {
+	codec := Detect(data)
+	if codec == None {
+		return data, nil
+	}
+	return Decompress(codec, data)
+}
+
+// NewWriter returns a writer compressing the data written to it by the codec into w, close it to flush the data
+func NewWriter(codec string, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		// lots of files are compressed concurrently, each of them by a single goroutine
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+	}
+}
+
+// NewReader returns a reader of the data of src decompressed by the codec, src is returned as is if codec is None
+func NewReader(codec string, src io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case None:
+		return io.NopCloser(src), nil
+	case Gzip:
+		return gzip.NewReader(src)
+	case Zstd:
+		decoder, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+	}
+}
+
+// NewCompressReader returns a reader of the data of src compressed by the codec, src is returned as is if codec is None.
+// The data is compressed by a goroutine on the fly, close the reader to stop it if the reader is not read to the end.
+func NewCompressReader(codec string, src io.Reader) (io.ReadCloser, error) {
+	if codec == None {
+		return io.NopCloser(src), nil
+	}
+	pr, pw := io.Pipe()
+	writer, err := NewWriter(codec, pw)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		_, err := io.Copy(writer, src)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
@@ -0,0 +1,85 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"collection_name":"test","partition_id":1}`), 10000)
+	for _, codec := range []string{Gzip, Zstd} {
+		compressed, err := Compress(codec, data)
+		assert.NoError(t, err)
+		assert.Less(t, len(compressed), len(data))
+		assert.Equal(t, codec, Detect(compressed))
+
+		decompressed, err := Decompress(codec, compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+		decompressed, err = DecompressAuto(compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+
+		// streaming
+		reader, err := NewCompressReader(codec, bytes.NewReader(data))
+		assert.NoError(t, err)
+		decompressReader, err := NewReader(codec, reader)
+		assert.NoError(t, err)
+		decompressed, err = io.ReadAll(decompressReader)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+		assert.NoError(t, decompressReader.Close())
+		assert.NoError(t, reader.Close())
+	}
+
+	// not compressed
+	assert.Equal(t, None, Detect(data))
+	decompressed, err := DecompressAuto(data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+	reader, err := NewCompressReader(None, bytes.NewReader(data))
+	assert.NoError(t, err)
+	read, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	_, err = Compress("lz4", data)
+	assert.Error(t, err)
+	_, err = Decompress(Gzip, data)
+	assert.Error(t, err)
+}
+
+func TestCompressReaderError(t *testing.T) {
+	srcErr := errors.New("read error")
+	reader, err := NewCompressReader(Zstd, io.MultiReader(bytes.NewReader([]byte("data")), &errReader{err: srcErr}))
+	assert.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	assert.ErrorIs(t, err, srcErr)
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestValidate(t *testing.T) {
+	for _, codec := range []string{"", "none"} {
+		validated, err := Validate(codec)
+		assert.NoError(t, err)
+		assert.Equal(t, None, validated)
+	}
+	for _, codec := range []string{Gzip, Zstd} {
+		validated, err := Validate(codec)
+		assert.NoError(t, err)
+		assert.Equal(t, codec, validated)
+	}
+	_, err := Validate("lz4")
+	assert.Error(t, err)
+}
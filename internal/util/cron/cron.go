@@ -0,0 +1,212 @@
+// Package cron parses the standard 5-field cron expressions and computes their next activation times.
+//
+// An expression consists of minute, hour, day of month, month and day of week fields separated by spaces.
+// Each field supports `*`, single values, ranges `a-b`, steps `*/n` or `a-b/n` and lists `a,b,c`.
+// Months and days of week also accept their 3-letter names, both 0 and 7 mean Sunday.
+// As in the standard cron, if both day of month and day of week are restricted, a day matching either of them is activated.
+// The descriptors @yearly (@annually), @monthly, @weekly, @daily (@midnight) and @hourly are supported as well.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// whether day of month or day of week is not restricted
+	domStar bool
+	dowStar bool
+}
+
+type bounds struct {
+	min   uint
+	max   uint
+	names map[string]uint
+}
+
+var (
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as Sunday and folded into 0
+	dowBounds = bounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// searchYears limits how far Next looks ahead, expressions like `0 0 30 2 *` never activate
+const searchYears = 5
+
+// Parse parses the cron expression
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := descriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized cron descriptor: %s", spec)
+		}
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression should have 5 fields, got %d: %s", len(fields), spec)
+	}
+
+	schedule := &Schedule{}
+	var err error
+	if schedule.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("illegal minute field of cron expression %s: %w", spec, err)
+	}
+	if schedule.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("illegal hour field of cron expression %s: %w", spec, err)
+	}
+	if schedule.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("illegal day of month field of cron expression %s: %w", spec, err)
+	}
+	if schedule.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("illegal month field of cron expression %s: %w", spec, err)
+	}
+	if schedule.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("illegal day of week field of cron expression %s: %w", spec, err)
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	schedule.domStar = strings.HasPrefix(fields[2], "*")
+	schedule.dowStar = strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// parseField parses a comma separated field into a bitset of the activated values
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+// parsePart parses a single value, range or step of a field
+func parsePart(part string, b bounds) (uint64, error) {
+	rangePart, step := part, uint(1)
+	if index := strings.Index(part, "/"); index >= 0 {
+		rangePart = part[:index]
+		stepValue, err := strconv.ParseUint(part[index+1:], 10, 32)
+		if err != nil || stepValue == 0 {
+			return 0, fmt.Errorf("illegal step: %s", part)
+		}
+		step = uint(stepValue)
+	}
+
+	var start, end uint
+	switch {
+	case rangePart == "*":
+		start, end = b.min, b.max
+	case strings.Contains(rangePart, "-"):
+		splits := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if start, err = parseValue(splits[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(splits[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("illegal range: %s", rangePart)
+		}
+	default:
+		value, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// a/n means from a to the max value
+		if step > 1 {
+			end = b.max
+		}
+	}
+
+	var bits uint64
+	for value := start; value <= end; value += step {
+		bits |= 1 << value
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (uint, error) {
+	if named, ok := b.names[strings.ToLower(value)]; ok {
+		return named, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("illegal value: %s", value)
+	}
+	if uint(parsed) < b.min || uint(parsed) > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", parsed, b.min, b.max)
+	}
+	return uint(parsed), nil
+}
+
+// Next returns the first activation time later than t in the location of t,
+// the zero time is returned if the schedule never activates
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	yearLimit := t.Year() + searchYears
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParse(t *testing.T, spec string) *Schedule {
+	schedule, err := Parse(spec)
+	assert.NoError(t, err)
+	return schedule
+}
+
+func TestParseIllegal(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2024-03-01 is a Friday
+	now := time.Date(2024, 3, 1, 10, 30, 15, 0, time.UTC)
+	cases := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 1, 10, 45, 0, 0, time.UTC)},
+		{"30 * * * *", time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * mon", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		// day of month or day of week
+		{"0 0 15 * sat", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC).AddDate(4, 0, 0)},
+		{"@hourly", time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.next, mustParse(t, c.spec).Next(now), c.spec)
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	schedule := mustParse(t, "0 0 30 2 *")
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
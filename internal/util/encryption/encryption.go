@@ -0,0 +1,299 @@
+// Package encryption implements the envelope encryption of backup data.
+//
+// Every backup is encrypted by its own random data key, the data key is stored along with the backup
+// after being wrapped by a master key which is kept out of the storage, in a local key file or an environment variable.
+// Both keys are AES-256 keys, data is encrypted by AES-GCM.
+//
+// Objects are encrypted in chunks so that they are able to be streamed without being loaded into memory.
+// An encrypted object consists of a header, the magic and a random nonce prefix, followed by the sealed chunks.
+// Every chunk but the last one holds ChunkSize bytes of plaintext, the last one holds the remainder, which may be empty.
+// The nonce of a chunk is the nonce prefix followed by the chunk index, and the last chunk is authenticated as the last one,
+// so that reordered, truncated or extended objects fail to decrypt.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// Algorithm is the name of the encryption algorithm recorded in backup meta
+	Algorithm = "AES-256-GCM"
+	// KeySize is the size of master keys and data keys in bytes
+	KeySize = 32
+	// ChunkSize is the size of plaintext sealed in a chunk
+	ChunkSize = 64 * 1024
+
+	magic           = "MBKENC01"
+	noncePrefixSize = 8
+	headerSize      = len(magic) + noncePrefixSize
+	tagSize         = 16
+	sealedChunkSize = ChunkSize + tagSize
+)
+
+var (
+	ErrInvalidKey       = errors.New("invalid encryption key")
+	ErrInvalidData      = errors.New("invalid encrypted data")
+	ErrMasterKeyMissing = errors.New("master key is not configured")
+)
+
+// MasterKey wraps and unwraps the data keys of backups
+type MasterKey struct {
+	id  string
+	key []byte
+}
+
+// NewMasterKey creates a master key from raw key bytes, its id is derived from the key
+func NewMasterKey(key []byte) (*MasterKey, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: master key should be %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	sum := sha256.Sum256(key)
+	return &MasterKey{
+		id:  hex.EncodeToString(sum[:8]),
+		key: key,
+	}, nil
+}
+
+// LoadMasterKey loads the base64 encoded master key from the key file, or from the environment variable if key file is empty.
+// It returns ErrMasterKeyMissing if neither of them is set.
+func LoadMasterKey(keyFile, keyEnv string) (*MasterKey, error) {
+	var encoded string
+	if keyFile != "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read master key file %s: %w", keyFile, err)
+		}
+		encoded = string(content)
+	} else if keyEnv != "" {
+		encoded = os.Getenv(keyEnv)
+	}
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, ErrMasterKeyMissing
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: master key should be base64 encoded: %s", ErrInvalidKey, err.Error())
+	}
+	return NewMasterKey(key)
+}
+
+// ID returns the identifier of the master key, which is recorded in backup meta to find the key to decrypt
+func (m *MasterKey) ID() string {
+	return m.id
+}
+
+// NewDataKey generates a random data key, returns it together with the data key wrapped by the master key
+func (m *MasterKey) NewDataKey() ([]byte, []byte, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := Encrypt(m.key, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataKey, wrapped, nil
+}
+
+// UnwrapDataKey decrypts the data key wrapped by the master key
+func (m *MasterKey) UnwrapDataKey(wrapped []byte) ([]byte, error) {
+	dataKey, err := Decrypt(m.key, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("fail to unwrap data key by master key %s: %w", m.id, err)
+	}
+	if len(dataKey) != KeySize {
+		return nil, fmt.Errorf("%w: unwrapped data key should be %d bytes", ErrInvalidKey, KeySize)
+	}
+	return dataKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: key should be %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedSize returns the size of the encrypted object of the plaintext size
+func EncryptedSize(size int64) int64 {
+	return int64(headerSize) + size + (size/ChunkSize+1)*tagSize
+}
+
+// DecryptedSize returns the plaintext size of the encrypted object size
+func DecryptedSize(size int64) (int64, error) {
+	sealedSize := size - int64(headerSize)
+	lastChunkSize := sealedSize % sealedChunkSize
+	if sealedSize < tagSize || lastChunkSize < tagSize {
+		return 0, fmt.Errorf("%w: size %d is not a valid encrypted size", ErrInvalidData, size)
+	}
+	chunks := sealedSize/sealedChunkSize + 1
+	return sealedSize - chunks*tagSize, nil
+}
+
+// Encrypt encrypts the whole data by the key
+func Encrypt(key []byte, data []byte) ([]byte, error) {
+	reader, err := NewEncryptReader(key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// Decrypt decrypts the whole data encrypted by the key
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	reader, err := NewDecryptReader(key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func chunkNonce(prefix []byte, index uint32) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], index)
+	return nonce
+}
+
+// chunkAdditionalData authenticates whether the chunk is the last one
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+type encryptReader struct {
+	gcm    cipher.AEAD
+	src    io.Reader
+	prefix []byte
+	index  uint32
+	plain  []byte
+	sealed []byte
+	// sealed data not read yet
+	buf  []byte
+	done bool
+}
+
+// NewEncryptReader returns a reader of the data of src encrypted by the key
+func NewEncryptReader(key []byte, src io.Reader) (io.Reader, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, prefix...)
+	return &encryptReader{
+		gcm:    gcm,
+		src:    src,
+		prefix: prefix,
+		plain:  make([]byte, ChunkSize),
+		sealed: make([]byte, 0, sealedChunkSize),
+		buf:    header,
+	}, nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.plain)
+		last := false
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			last = true
+		} else if err != nil {
+			return 0, err
+		}
+		r.buf = r.gcm.Seal(r.sealed[:0], chunkNonce(r.prefix, r.index), r.plain[:n], chunkAdditionalData(last))
+		r.index++
+		r.done = last
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+type decryptReader struct {
+	gcm    cipher.AEAD
+	src    io.Reader
+	prefix []byte
+	index  uint32
+	sealed []byte
+	// decrypted data not read yet
+	buf  []byte
+	done bool
+}
+
+// NewDecryptReader returns a reader of the data of src decrypted by the key
+func NewDecryptReader(key []byte, src io.Reader) (io.Reader, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%w: header is truncated", ErrInvalidData)
+		}
+		return nil, err
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, fmt.Errorf("%w: unknown header", ErrInvalidData)
+	}
+	return &decryptReader{
+		gcm:    gcm,
+		src:    src,
+		prefix: header[len(magic):],
+		sealed: make([]byte, sealedChunkSize),
+	}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(r.src, r.sealed)
+		last := false
+		if err == io.EOF {
+			return 0, fmt.Errorf("%w: data is truncated", ErrInvalidData)
+		} else if err == io.ErrUnexpectedEOF {
+			last = true
+		} else if err != nil {
+			return 0, err
+		}
+		plain, err := r.gcm.Open(r.sealed[:0], chunkNonce(r.prefix, r.index), r.sealed[:n], chunkAdditionalData(last))
+		if err != nil {
+			return 0, fmt.Errorf("%w: chunk %d: %s", ErrInvalidData, r.index, err.Error())
+		}
+		r.buf = plain
+		r.index++
+		r.done = last
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,121 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomBytes(t *testing.T, size int) []byte {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := randomBytes(t, KeySize)
+	for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 100} {
+		data := randomBytes(t, size)
+		encrypted, err := Encrypt(key, data)
+		assert.NoError(t, err)
+		assert.Equal(t, EncryptedSize(int64(size)), int64(len(encrypted)))
+		decryptedSize, err := DecryptedSize(int64(len(encrypted)))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(size), decryptedSize)
+
+		decrypted, err := Decrypt(key, encrypted)
+		assert.NoError(t, err)
+		assert.True(t, bytes.Equal(data, decrypted))
+	}
+
+	// streaming by small reads
+	data := randomBytes(t, 2*ChunkSize+10)
+	reader, err := NewEncryptReader(key, bytes.NewReader(data))
+	assert.NoError(t, err)
+	decryptReader, err := NewDecryptReader(key, io.LimitReader(reader, EncryptedSize(int64(len(data)))))
+	assert.NoError(t, err)
+	var decrypted bytes.Buffer
+	buf := make([]byte, 1000)
+	_, err = io.CopyBuffer(&decrypted, struct{ io.Reader }{decryptReader}, buf)
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(data, decrypted.Bytes()))
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	key := randomBytes(t, KeySize)
+	data := randomBytes(t, 2*ChunkSize)
+	encrypted, err := Encrypt(key, data)
+	assert.NoError(t, err)
+
+	_, err = Decrypt(randomBytes(t, KeySize), encrypted)
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = Decrypt(key, tampered)
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	// truncated at the chunk boundary
+	_, err = Decrypt(key, encrypted[:headerSize+sealedChunkSize])
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	_, err = Decrypt(key, encrypted[:headerSize-1])
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	_, err = Decrypt(key, data)
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	for _, size := range []int64{0, int64(headerSize + tagSize - 1), int64(headerSize + sealedChunkSize + 1)} {
+		_, err = DecryptedSize(size)
+		assert.ErrorIs(t, err, ErrInvalidData)
+	}
+
+	_, err = Encrypt(key[:16], data)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestMasterKey(t *testing.T) {
+	key := randomBytes(t, KeySize)
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(encoded+"\n"), 0600))
+	fromFile, err := LoadMasterKey(keyFile, "")
+	assert.NoError(t, err)
+
+	t.Setenv("TEST_BACKUP_MASTER_KEY", encoded)
+	fromEnv, err := LoadMasterKey("", "TEST_BACKUP_MASTER_KEY")
+	assert.NoError(t, err)
+	assert.Equal(t, fromFile.ID(), fromEnv.ID())
+
+	dataKey, wrapped, err := fromFile.NewDataKey()
+	assert.NoError(t, err)
+	assert.Len(t, dataKey, KeySize)
+	unwrapped, err := fromEnv.UnwrapDataKey(wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	other, err := NewMasterKey(randomBytes(t, KeySize))
+	assert.NoError(t, err)
+	assert.NotEqual(t, fromFile.ID(), other.ID())
+	_, err = other.UnwrapDataKey(wrapped)
+	assert.Error(t, err)
+
+	_, err = LoadMasterKey("", "")
+	assert.ErrorIs(t, err, ErrMasterKeyMissing)
+	_, err = LoadMasterKey("", "TEST_BACKUP_MASTER_KEY_NOT_SET")
+	assert.ErrorIs(t, err, ErrMasterKeyMissing)
+
+	t.Setenv("TEST_BACKUP_MASTER_KEY", "not base64")
+	_, err = LoadMasterKey("", "TEST_BACKUP_MASTER_KEY")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = NewMasterKey(key[:16])
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}